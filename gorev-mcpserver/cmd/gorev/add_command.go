@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/gorev"
+	"github.com/msenol/gorev/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var addDryRun bool
+
+// createAddCommand creates the quick-add CLI command
+func createAddCommand() *cobra.Command {
+	addCmd := &cobra.Command{
+		Use:   "add <text>",
+		Short: i18n.T("cli.add"),
+		Long:  i18n.T("cli.addDescription"),
+		Example: `  # Tags, priority, project, due date and estimate in one line
+  gorev add "Fix login timeout #auth #bug !yuksek @BackendAPI due:friday est:3h"
+
+  # Subtask due next Friday, in Turkish
+  gorev add "Testleri yaz ^<parent-id> gelecek cuma"
+
+  # Show how a line is read without creating the task
+  gorev add --dry-run "Release notes - summarize changes in 3 days"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAdd(strings.Join(args, " "))
+		},
+	}
+
+	addCmd.Flags().BoolVar(&addDryRun, "dry-run", false, "Only show how the text is parsed, without creating the task")
+
+	return addCmd
+}
+
+// runAdd creates a task from quick-add syntax in the current database
+func runAdd(text string) error {
+	veriYonetici, err := createVeriYonetici()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer func() { _ = veriYonetici.Kapat() }()
+
+	ctx := context.Background()
+	isYonetici := gorev.YeniIsYonetici(veriYonetici)
+
+	quickAdd, err := isYonetici.HizliEkleAyristir(ctx, text)
+	if err != nil {
+		return err
+	}
+	excludeID := ""
+	if addDryRun {
+		fmt.Printf("🔍 %s\n", quickAdd.Title)
+	} else {
+		task, err := isYonetici.HizliGorevEkle(ctx, quickAdd)
+		if err != nil {
+			return err
+		}
+		excludeID = task.ID
+		fmt.Printf("✅ %s  %s\n", task.ID, task.Title)
+	}
+
+	if quickAdd.Description != "" {
+		fmt.Printf("   description: %s\n", quickAdd.Description)
+	}
+	if quickAdd.Priority != "" {
+		fmt.Printf("   priority:    %s\n", quickAdd.Priority)
+	}
+	if quickAdd.Project != "" {
+		fmt.Printf("   project:     %s\n", quickAdd.Project)
+	}
+	if quickAdd.ParentID != "" {
+		fmt.Printf("   parent:      %s\n", quickAdd.ParentID)
+	}
+	if quickAdd.DueDate != nil {
+		fmt.Printf("   due:         %s\n", quickAdd.DueDate.Format(constants.DateFormatISO))
+	}
+	if len(quickAdd.Tags) > 0 {
+		fmt.Printf("   tags:        %s\n", strings.Join(quickAdd.Tags, ", "))
+	}
+	if quickAdd.EstimatedHours > 0 {
+		fmt.Printf("   estimate:    %gh\n", quickAdd.EstimatedHours)
+	}
+
+	// Duplicate warnings are best effort and never fail the command
+	if duplicates, err := isYonetici.OlasiKopyalar(ctx, quickAdd.Title, quickAdd.Description, excludeID); err == nil && len(duplicates) > 0 {
+		fmt.Println("⚠️  possible duplicates:")
+		for _, duplicate := range duplicates {
+			fmt.Printf("   %s  %s (%.0f%%)\n", duplicate.Task.ID, duplicate.Task.Title, duplicate.Score*100)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/gorev"
+	"github.com/msenol/gorev/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var (
+	archiveDays    int
+	archiveSetDays int
+	archiveRestore string
+	archiveList    bool
+)
+
+// createArchiveCommand creates the archive CLI command
+func createArchiveCommand() *cobra.Command {
+	archiveCmd := &cobra.Command{
+		Use:   "archive",
+		Short: i18n.T("cli.archive"),
+		Long:  i18n.T("cli.archiveDescription"),
+		Example: `  # Archive tasks completed more than 90 days ago from now on, and right away
+  gorev archive --set-days 90
+  gorev archive
+
+  # Archive tasks completed more than 30 days ago once, without changing the setting
+  gorev archive --days 30
+
+  # List archived tasks and restore one of them
+  gorev archive --list
+  gorev archive --restore <task-id>`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runArchive(cmd.Flags().Changed("set-days"))
+		},
+	}
+
+	archiveCmd.Flags().IntVar(&archiveDays, "days", 0, "Archive tasks completed more than this many days ago (default: workspace setting)")
+	archiveCmd.Flags().IntVar(&archiveSetDays, "set-days", 0, "Store the retention of the workspace in days (0 disables scheduled archiving)")
+	archiveCmd.Flags().StringVar(&archiveRestore, "restore", "", "Restore an archived task with its archived parents and subtasks")
+	archiveCmd.Flags().BoolVar(&archiveList, "list", false, "List archived tasks instead of archiving")
+
+	return archiveCmd
+}
+
+// runArchive archives, lists or restores tasks in the current database
+func runArchive(setDays bool) error {
+	veriYonetici, err := createVeriYonetici()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer func() { _ = veriYonetici.Kapat() }()
+
+	ctx := context.Background()
+	isYonetici := gorev.YeniIsYonetici(veriYonetici)
+
+	switch {
+	case archiveList:
+		tasks, err := isYonetici.ArsivGorevleriListele(ctx, 0)
+		if err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			fmt.Printf("%s  %s  %s\n", task.ArchivedAt.Format(constants.DateFormatISO), task.ID, task.Title)
+		}
+		fmt.Printf("%d archived tasks\n", len(tasks))
+		return nil
+
+	case archiveRestore != "":
+		result, err := isYonetici.ArsivdenGeriYukle(ctx, archiveRestore)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("♻️  Restored %d tasks (%d tag links, %d dependencies, %d AI interactions, %d file paths)\n",
+			result.Tasks, result.Tags, result.Links, result.Interactions, result.FilePaths)
+		return nil
+	}
+
+	if setDays {
+		if _, err := isYonetici.ArsivAyarlariKaydet(ctx, archiveSetDays); err != nil {
+			return err
+		}
+		if archiveSetDays == 0 {
+			fmt.Println("🗃️  Scheduled archiving disabled")
+			return nil
+		}
+		fmt.Printf("🗃️  Completed tasks are archived %d days after completion\n", archiveSetDays)
+	}
+
+	result, err := isYonetici.GorevleriArsivle(ctx, archiveDays)
+	if err != nil {
+		return err
+	}
+	if result.RetentionDays == 0 {
+		fmt.Println("Archiving is disabled for this workspace; use --days or --set-days")
+		return nil
+	}
+	fmt.Printf("🗃️  Archived %d tasks completed before %s (%d tag links, %d dependencies, %d AI interactions, %d file paths)\n",
+		result.Tasks, result.Cutoff.Format(constants.DateFormatISO), result.Tags, result.Links, result.Interactions, result.FilePaths)
+	return nil
+}
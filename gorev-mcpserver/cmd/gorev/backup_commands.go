@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/msenol/gorev/internal/config"
+	"github.com/msenol/gorev/internal/gorev"
+	"github.com/msenol/gorev/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupDBPath      string
+	backupCentralized bool
+	backupDir         string
+	backupKeepDaily   int
+	backupKeepWeekly  int
+	backupList        bool
+)
+
+// createBackupCommand creates the backup CLI command
+func createBackupCommand() *cobra.Command {
+	backupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: i18n.T("cli.backup"),
+		Long:  i18n.T("cli.backupDescription"),
+		Example: `  # Back up the workspace database (.gorev/gorev.db) into .gorev/backups
+  gorev backup
+
+  # Back up the centralized database, keeping 14 daily and 8 weekly copies
+  gorev backup --centralized --keep-daily 14 --keep-weekly 8
+
+  # List existing backups
+  gorev backup --list`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBackup()
+		},
+	}
+
+	addBackupTargetFlags(backupCmd)
+	backupCmd.Flags().IntVar(&backupKeepDaily, "keep-daily", 7, "Number of daily backups to keep (0 with --keep-weekly 0 keeps all)")
+	backupCmd.Flags().IntVar(&backupKeepWeekly, "keep-weekly", 4, "Number of weekly backups to keep")
+	backupCmd.Flags().BoolVar(&backupList, "list", false, "List existing backups instead of creating one")
+
+	return backupCmd
+}
+
+// createRestoreCommand creates the restore CLI command
+func createRestoreCommand() *cobra.Command {
+	restoreCmd := &cobra.Command{
+		Use:   "restore [backup-file]",
+		Short: i18n.T("cli.restore"),
+		Long:  i18n.T("cli.restoreDescription"),
+		Example: `  # Restore the newest backup of the workspace database
+  gorev restore
+
+  # Restore a specific backup of the centralized database
+  gorev restore /data/backups/centralized-20250301-020000.db --centralized`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			backupFile := ""
+			if len(args) > 0 {
+				backupFile = args[0]
+			}
+			return runRestore(backupFile)
+		},
+	}
+
+	addBackupTargetFlags(restoreCmd)
+
+	return restoreCmd
+}
+
+// addBackupTargetFlags adds the flags selecting the database and backup directory
+func addBackupTargetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&backupDBPath, "db-path", "", "Database file (default: workspace database, or GOREV_DB_PATH)")
+	cmd.Flags().BoolVar(&backupCentralized, "centralized", false, "Use the centralized database (GOREV_DB_PATH or /data/gorev.db)")
+	cmd.Flags().StringVar(&backupDir, "dir", "", "Backup directory (default: backups/ next to the database)")
+}
+
+// backupTarget resolves the database path, backup directory and backup file label.
+// The labels match the ones used by the daemon's scheduled backups, so both share one rotation.
+func backupTarget() (dbPath, dir, label string) {
+	dbPath, label = resolveTargetDatabase(backupDBPath, backupCentralized)
+
+	dir = backupDir
+	if dir == "" {
+		dir = filepath.Join(filepath.Dir(dbPath), "backups")
+	}
+	return dbPath, dir, label
+}
+
+// resolveTargetDatabase returns the database selected by --db-path or --centralized, falling
+// back to the workspace database, together with the label used for its backup files
+func resolveTargetDatabase(dbPath string, centralized bool) (string, string) {
+	switch {
+	case dbPath != "":
+		return dbPath, strings.TrimSuffix(filepath.Base(dbPath), filepath.Ext(dbPath))
+	case centralized:
+		dbPath = config.DefaultConfig().CentralizedDBPath
+		if dbPath == "" {
+			dbPath = "/data/gorev.db"
+		}
+		return dbPath, "centralized"
+	default:
+		dbPath = getDatabasePath()
+		return dbPath, strings.TrimSuffix(filepath.Base(dbPath), filepath.Ext(dbPath))
+	}
+}
+
+// runBackup creates a verified backup and applies the rotation policy
+func runBackup() error {
+	dbPath, dir, label := backupTarget()
+
+	if backupList {
+		backups, err := gorev.ListBackups(dir, label)
+		if err != nil {
+			return err
+		}
+		for _, backup := range backups {
+			fmt.Printf("%s  %8d bytes  %s\n", backup.CreatedAt.Format(time.DateTime), backup.Size, backup.Path)
+		}
+		return nil
+	}
+
+	info, err := gorev.BackupDatabase(context.Background(), dbPath, filepath.Join(dir, gorev.BackupFileName(label, time.Now())))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Backup written and verified: %s (%d bytes)\n", info.Path, info.Size)
+
+	removed, err := gorev.RotateBackups(dir, label, gorev.BackupRotation{Daily: backupKeepDaily, Weekly: backupKeepWeekly})
+	if err != nil {
+		return err
+	}
+	for _, path := range removed {
+		fmt.Printf("   🗑️  Rotated out: %s\n", path)
+	}
+	return nil
+}
+
+// runRestore restores a backup (the newest one when none is given) over the database,
+// after saving the current database as a pre-restore backup
+func runRestore(backupFile string) error {
+	ctx := context.Background()
+	dbPath, dir, label := backupTarget()
+
+	if backupFile == "" {
+		backups, err := gorev.ListBackups(dir, label)
+		if err != nil {
+			return err
+		}
+		if len(backups) == 0 {
+			return fmt.Errorf(i18n.T("error.noBackupFound", map[string]interface{}{"Dir": dir}))
+		}
+		backupFile = backups[0].Path
+	}
+
+	if err := gorev.VerifyBackup(ctx, backupFile); err != nil {
+		return err
+	}
+	migrationsFS, err := getEmbeddedMigrationsFS()
+	if err != nil {
+		return fmt.Errorf("failed to get embedded migrations: %w", err)
+	}
+
+	// Keep the current database unless there is none yet
+	if _, err := os.Stat(dbPath); err == nil {
+		safety, err := gorev.BackupDatabase(ctx, dbPath, filepath.Join(dir, gorev.BackupFileName(label+"-prerestore", time.Now())))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("💾 Current database saved as %s\n", safety.Path)
+	}
+
+	if err := gorev.RestoreDatabase(ctx, backupFile, dbPath, migrationsFS); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Restored %s from %s\n", dbPath, backupFile)
+	return nil
+}
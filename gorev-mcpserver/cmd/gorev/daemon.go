@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -21,6 +22,11 @@ func createDaemonCommand() *cobra.Command {
 	var detach bool
 	var serverMode string
 	var dbPath string
+	var backupInterval time.Duration
+	var backupDir string
+	var backupKeepDaily int
+	var backupKeepWeekly int
+	var archiveInterval time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "daemon",
@@ -49,6 +55,9 @@ Server Modes:
   # Start daemon on custom port
   gorev daemon --port 5083
 
+  # Back up all workspace databases every 24h, keeping 7 daily and 4 weekly copies
+  gorev daemon --backup-interval 24h --backup-keep-daily 7 --backup-keep-weekly 4
+
   # Check daemon status
   curl http://localhost:5082/api/health`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -62,10 +71,25 @@ Server Modes:
 			}
 			cfg.Port = daemonPort
 			cfg.AllowLocalPaths = cfg.Mode == config.ModeLocal
+			if cmd.Flags().Changed("backup-interval") {
+				cfg.BackupInterval = backupInterval
+			}
+			if backupDir != "" {
+				cfg.BackupDir = backupDir
+			}
+			if cmd.Flags().Changed("backup-keep-daily") {
+				cfg.BackupKeepDaily = backupKeepDaily
+			}
+			if cmd.Flags().Changed("backup-keep-weekly") {
+				cfg.BackupKeepWeekly = backupKeepWeekly
+			}
+			if cmd.Flags().Changed("archive-interval") {
+				cfg.ArchiveInterval = archiveInterval
+			}
 			config.SetGlobalConfig(cfg)
 
 			if detach {
-				return runDetachedDaemon(daemonPort, serverMode, dbPath)
+				return runDetachedDaemon(daemonPort, serverMode, dbPath, cfg)
 			}
 			return runDaemon(daemonPort)
 		},
@@ -75,6 +99,11 @@ Server Modes:
 	cmd.Flags().BoolVar(&detach, "detach", false, "Run as background process (daemon)")
 	cmd.Flags().StringVar(&serverMode, "mode", "", "Server mode: local (default) or centralized")
 	cmd.Flags().StringVar(&dbPath, "db-path", "", "Database path (for centralized mode)")
+	cmd.Flags().DurationVar(&backupInterval, "backup-interval", 0, "Back up workspace databases at this interval, e.g. 24h (0 disables)")
+	cmd.Flags().StringVar(&backupDir, "backup-dir", "", "Directory for scheduled backups (default: backups/ next to each database)")
+	cmd.Flags().IntVar(&backupKeepDaily, "backup-keep-daily", 7, "Number of daily backups to keep")
+	cmd.Flags().IntVar(&backupKeepWeekly, "backup-keep-weekly", 4, "Number of weekly backups to keep")
+	cmd.Flags().DurationVar(&archiveInterval, "archive-interval", 24*time.Hour, "Apply the archive retention of each workspace at this interval (0 disables)")
 
 	return cmd
 }
@@ -115,6 +144,14 @@ func runDaemon(port string) error {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
+	// Scheduled backups stop together with the daemon
+	backupCtx, stopBackups := context.WithCancel(context.Background())
+	defer stopBackups()
+	if cfg.BackupInterval > 0 {
+		go apiServer.RunScheduledBackups(backupCtx, api.BackupScheduleFromConfig(cfg))
+	}
+	go apiServer.RunScheduledArchive(backupCtx, cfg.ArchiveInterval)
+
 	// Start server in background
 	errChan := make(chan error, 1)
 	go func() {
@@ -130,6 +167,9 @@ func runDaemon(port string) error {
 	} else {
 		log.Printf("💾 Database: Per-workspace (.gorev/gorev.db)")
 	}
+	if cfg.BackupInterval > 0 {
+		log.Printf("🗄️  Backups: every %s (keep %d daily, %d weekly)", cfg.BackupInterval, cfg.BackupKeepDaily, cfg.BackupKeepWeekly)
+	}
 	log.Printf("📱 Web UI: http://localhost:%s", port)
 	log.Printf("🔧 API: http://localhost:%s/api/v1", port)
 	log.Printf("🔌 WebSocket: ws://localhost:%s/ws (future)", port)
@@ -157,7 +197,7 @@ func runDaemon(port string) error {
 	return nil
 }
 
-func runDetachedDaemon(port, mode, dbPath string) error {
+func runDetachedDaemon(port, mode, dbPath string, cfg *config.ServerConfig) error {
 	// Get current executable path
 	exePath, err := os.Executable()
 	if err != nil {
@@ -172,6 +212,15 @@ func runDetachedDaemon(port, mode, dbPath string) error {
 	if dbPath != "" {
 		args = append(args, "--db-path", dbPath)
 	}
+	if cfg.BackupInterval > 0 {
+		args = append(args, "--backup-interval", cfg.BackupInterval.String(),
+			"--backup-keep-daily", strconv.Itoa(cfg.BackupKeepDaily),
+			"--backup-keep-weekly", strconv.Itoa(cfg.BackupKeepWeekly))
+		if cfg.BackupDir != "" {
+			args = append(args, "--backup-dir", cfg.BackupDir)
+		}
+	}
+	args = append(args, "--archive-interval", cfg.ArchiveInterval.String())
 
 	// Fork process and run in background
 	cmd := exec.Command(exePath, args...)
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/msenol/gorev/internal/gorev"
+	"github.com/msenol/gorev/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat           string
+	exportOutput           string
+	exportProjects         []string
+	exportIncludeCompleted bool
+	exportIncludeArchived  bool
+
+	importFormat             string
+	importConflictResolution string
+	importPreserveIDs        bool
+	importDryRun             bool
+	importChunkSize          int
+)
+
+// createExportCommand creates the export CLI command
+func createExportCommand() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: i18n.T("cli.export"),
+		Long:  i18n.T("cli.exportDescription"),
+		Example: `  # Export everything as Gorev JSON
+  gorev export --output backup.json
+
+  # Export open tasks for Taskwarrior, then load them with "task import"
+  gorev export --format taskwarrior --include-completed=false --output tasks.json
+
+  # Export a project as todo.txt
+  gorev export --format todotxt --project <project-id> --output todo.txt
+
+  # Stream a large database as NDJSON (one record per line)
+  gorev export --format ndjson --output backup.ndjson`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport()
+		},
+	}
+
+	exportCmd.Flags().StringVar(&exportFormat, "format", "json", "Export format (json, ndjson, csv, ics, taskwarrior, todotxt)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file path")
+	exportCmd.Flags().StringSliceVar(&exportProjects, "project", nil, "Only export the given project IDs")
+	exportCmd.Flags().BoolVar(&exportIncludeCompleted, "include-completed", true, "Include completed tasks")
+	exportCmd.Flags().BoolVar(&exportIncludeArchived, "include-archived", true, "Include archived tasks")
+	_ = exportCmd.MarkFlagRequired("output")
+
+	return exportCmd
+}
+
+// createImportCommand creates the import CLI command
+func createImportCommand() *cobra.Command {
+	importCmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: i18n.T("cli.import"),
+		Long:  i18n.T("cli.importDescription"),
+		Example: `  # Import "task export" output from Taskwarrior
+  task export > tasks.json && gorev import tasks.json --format taskwarrior
+
+  # Preview a todo.txt import without changing anything
+  gorev import ~/todo.txt --dry-run
+
+  # Re-import a file exported from Gorev, overwriting changed tasks
+  gorev import backup.json --preserve-ids --conflict overwrite
+
+  # Import a streamed export, committing 1000 tasks per transaction
+  gorev import backup.ndjson --preserve-ids --chunk-size 1000`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(args[0])
+		},
+	}
+
+	importCmd.Flags().StringVar(&importFormat, "format", "", "Import format ("+strings.Join(gorev.SupportedImportFormats(), ", ")+"); detected from the file extension when empty")
+	importCmd.Flags().StringVar(&importConflictResolution, "conflict", "skip", "Conflict resolution (skip, overwrite)")
+	importCmd.Flags().BoolVar(&importPreserveIDs, "preserve-ids", false, "Keep the IDs from the file")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Only report conflicts, don't change anything")
+	importCmd.Flags().IntVar(&importChunkSize, "chunk-size", gorev.DefaultImportChunkSize, "Tasks or links committed per transaction (ndjson only)")
+
+	return importCmd
+}
+
+// runExport exports tasks of the current database to a file
+func runExport() error {
+	veriYonetici, err := createVeriYonetici()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer func() { _ = veriYonetici.Kapat() }()
+
+	ctx := context.Background()
+	isYonetici := gorev.YeniIsYonetici(veriYonetici)
+	options := gorev.ExportOptions{
+		Format:              exportFormat,
+		OutputPath:          exportOutput,
+		ProjectFilter:       exportProjects,
+		IncludeCompleted:    exportIncludeCompleted,
+		IncludeArchived:     exportIncludeArchived,
+		IncludeDependencies: true,
+		IncludeMetadata:     true,
+	}
+
+	// NDJSON is streamed from the database without loading everything into memory
+	if exportFormat == "ndjson" {
+		stats, err := isYonetici.StreamExportToFile(ctx, options, printStreamProgress)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Exported %d tasks and %d projects to %s (%s)\n", stats.Tasks, stats.Projects, exportOutput, exportFormat)
+		return nil
+	}
+
+	exportData, err := isYonetici.ExportData(ctx, options)
+	if err != nil {
+		return err
+	}
+	if err := isYonetici.SaveExportToFile(ctx, exportData, options); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Exported %d tasks and %d projects to %s (%s)\n",
+		len(exportData.Tasks), len(exportData.Projects), exportOutput, exportFormat)
+	return nil
+}
+
+// runImport imports a file into the current database
+func runImport(filePath string) error {
+	veriYonetici, err := createVeriYonetici()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer func() { _ = veriYonetici.Kapat() }()
+
+	isYonetici := gorev.YeniIsYonetici(veriYonetici)
+	result, err := isYonetici.ImportData(context.Background(), gorev.ImportOptions{
+		FilePath:           filePath,
+		Format:             importFormat,
+		ImportMode:         "merge",
+		ConflictResolution: importConflictResolution,
+		PreserveIDs:        importPreserveIDs,
+		DryRun:             importDryRun,
+		ChunkSize:          importChunkSize,
+		Progress:           printStreamProgress,
+	})
+	if err != nil {
+		return err
+	}
+
+	if importDryRun {
+		fmt.Printf("🔍 %s\n", i18n.T("import.dryRunResults"))
+	} else {
+		fmt.Printf("✅ %s\n", i18n.T("import.success"))
+		fmt.Printf("   %s: %d\n", i18n.T("import.importedTasks"), result.ImportedTasks)
+		fmt.Printf("   %s: %d\n", i18n.T("import.importedProjects"), result.ImportedProjects)
+		fmt.Printf("   %s: %d\n", i18n.T("import.importedTags"), result.ImportedTags)
+	}
+
+	if len(result.Conflicts) > 0 {
+		fmt.Printf("\n⚠️  %s (%d)\n", i18n.T("import.conflicts"), len(result.Conflicts))
+		for _, conflict := range result.Conflicts {
+			fmt.Printf("   - %s: %s\n", conflict.Type, conflictTitle(conflict.Incoming))
+		}
+	}
+	for _, warning := range result.Warnings {
+		fmt.Printf("   ⚠️  %s\n", warning)
+	}
+	for _, errMsg := range result.Errors {
+		fmt.Printf("   ❌ %s\n", errMsg)
+	}
+
+	return nil
+}
+
+// conflictTitle returns a readable name for a conflicting task or project
+func conflictTitle(item interface{}) string {
+	switch v := item.(type) {
+	case *gorev.Gorev:
+		return fmt.Sprintf("%s (%s)", v.Title, v.ID)
+	case *gorev.Proje:
+		return fmt.Sprintf("%s (%s)", v.Name, v.ID)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// printStreamProgress prints the progress of a streaming export or import on stderr
+func printStreamProgress(progress gorev.StreamProgress) {
+	fmt.Fprintf(os.Stderr, "\r   %d records (%d tasks, %d links)", progress.Records, progress.Tasks, progress.Links)
+	if progress.Done {
+		fmt.Fprintln(os.Stderr)
+	}
+}
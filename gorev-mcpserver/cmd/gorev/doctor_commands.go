@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/msenol/gorev/internal/gorev"
+	"github.com/msenol/gorev/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorDBPath      string
+	doctorCentralized bool
+	doctorFix         bool
+	doctorVacuum      bool
+	doctorJSON        bool
+)
+
+// createDoctorCommand creates the doctor CLI command
+func createDoctorCommand() *cobra.Command {
+	doctorCmd := &cobra.Command{
+		Use:   "doctor",
+		Short: i18n.T("cli.doctor"),
+	}
+
+	doctorDBCmd := &cobra.Command{
+		Use:   "db",
+		Short: i18n.T("cli.doctorDB"),
+		Long:  i18n.T("cli.doctorDBDescription"),
+		Example: `  # Report dangling references in the workspace database
+  gorev doctor db
+
+  # Repair them (a backup is written first) and compact the database
+  gorev doctor db --fix --vacuum
+
+  # Check the centralized database and print the report as JSON
+  gorev doctor db --centralized --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctorDB()
+		},
+	}
+	doctorDBCmd.Flags().StringVar(&doctorDBPath, "db-path", "", "Database file (default: workspace database, or GOREV_DB_PATH)")
+	doctorDBCmd.Flags().BoolVar(&doctorCentralized, "centralized", false, "Use the centralized database (GOREV_DB_PATH or /data/gorev.db)")
+	doctorDBCmd.Flags().BoolVar(&doctorFix, "fix", false, "Repair the detected issues")
+	doctorDBCmd.Flags().BoolVar(&doctorVacuum, "vacuum", false, "Run VACUUM after the checks")
+	doctorDBCmd.Flags().BoolVar(&doctorJSON, "json", false, "Print the report as JSON")
+
+	doctorCmd.AddCommand(doctorDBCmd)
+	return doctorCmd
+}
+
+// runDoctorDB checks the target database without migrating it, so that
+// databases which fail to open in the server can still be inspected
+func runDoctorDB() error {
+	ctx := context.Background()
+	dbPath, label := resolveTargetDatabase(doctorDBPath, doctorCentralized)
+	if _, err := os.Stat(dbPath); err != nil {
+		return fmt.Errorf(i18n.T("error.fileNotFound", map[string]interface{}{"Path": dbPath}))
+	}
+
+	if doctorFix {
+		backupPath := filepath.Join(filepath.Dir(dbPath), "backups", gorev.BackupFileName(label+"-predoctor", time.Now()))
+		if _, err := gorev.BackupDatabase(ctx, dbPath, backupPath); err != nil {
+			return err
+		}
+		if !doctorJSON {
+			fmt.Printf("💾 Backup written: %s\n", backupPath)
+		}
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.dbOpenFailed", map[string]interface{}{"Error": err}))
+	}
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("PRAGMA busy_timeout=10000"); err != nil {
+		return fmt.Errorf(i18n.T("error.busyTimeoutFailed", map[string]interface{}{"Error": err}))
+	}
+
+	report, err := gorev.DatabaseDoctor(ctx, db, gorev.DoctorOptions{Fix: doctorFix, Vacuum: doctorVacuum})
+	if err != nil {
+		return err
+	}
+
+	if doctorJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	printDoctorReport(dbPath, report)
+	if !report.Healthy() {
+		// Non-zero exit status for scripts and CI
+		return fmt.Errorf(i18n.T("error.doctorUnhealthy", map[string]interface{}{"Path": dbPath}))
+	}
+	return nil
+}
+
+// printDoctorReport prints the checks of a doctor run
+func printDoctorReport(dbPath string, report *gorev.DoctorReport) {
+	fmt.Printf("Database: %s\n\n", dbPath)
+
+	if len(report.IntegrityProblems) == 0 {
+		fmt.Println("✅ integrity_check: ok")
+	} else {
+		fmt.Printf("❌ integrity_check: %s\n", strings.Join(report.IntegrityProblems, "; "))
+	}
+
+	for _, issue := range report.Issues {
+		switch {
+		case issue.Count == 0:
+			fmt.Printf("✅ %s\n", issue.Check)
+		case issue.Fixed:
+			fmt.Printf("🔧 %s: %d fixed\n", issue.Check, issue.Count)
+		default:
+			fmt.Printf("❌ %s: %d found (e.g. %s)\n", issue.Check, issue.Count, strings.Join(issue.Samples, ", "))
+		}
+	}
+	for _, check := range report.Skipped {
+		fmt.Printf("⏭️  %s: skipped, table missing\n", check)
+	}
+
+	fmt.Println()
+	if report.Vacuumed {
+		fmt.Println("ANALYZE and VACUUM done")
+	} else {
+		fmt.Println("ANALYZE done")
+	}
+	if !report.Healthy() && len(report.IntegrityProblems) == 0 {
+		fmt.Println("Run 'gorev doctor db --fix' to repair the issues above")
+	}
+}
@@ -30,6 +30,7 @@ var (
 	noAPIFlag      bool
 	serverModeFlag string
 	dbPathFlag     string
+	ephemeralFlag  bool
 )
 
 // getMigrationsPath returns the correct path to migrations folder
@@ -39,6 +40,52 @@ func getMigrationsPath() string {
 	return "embedded://migrations"
 }
 
+// veriKatmaniOlustur opens the data layer of the server: in-memory storage with
+// --ephemeral, otherwise the SQLite database behind the lookup cache. The returned
+// migrationsPath is empty in ephemeral mode.
+func veriKatmaniOlustur(cfg *config.ServerConfig) (gorev.VeriYoneticiInterface, func(), string, error) {
+	if ephemeralFlag {
+		if cfg.Mode == config.ModeCentralized {
+			return nil, nil, "", errors.New(i18n.T("error.ephemeralCentralized"))
+		}
+		log.Printf("🧪 Ephemeral mode: data is kept in memory and lost on exit")
+		veriYonetici := gorev.NewMemoryVeriYonetici()
+		return veriYonetici, func() { _ = veriYonetici.Kapat() }, "", nil
+	}
+
+	// Veritabanını başlat
+	dbPath := getDatabasePath()
+	log.Printf("Using database: %s", dbPath)
+
+	// Use embedded migrations if available, fallback to filesystem
+	migrationsPath := getMigrationsPath()
+	var veriYonetici *gorev.VeriYonetici
+	var err error
+
+	if migrationsPath == "embedded://migrations" {
+		// Use embedded migrations
+		migrationsFS, fsErr := getEmbeddedMigrationsFS()
+		if fsErr != nil {
+			return nil, nil, "", errors.New(i18n.T("error.dataManagerInit", map[string]interface{}{"Error": fsErr}))
+		}
+		veriYonetici, err = gorev.YeniVeriYoneticiWithEmbeddedMigrations(dbPath, migrationsFS)
+	} else {
+		// Fallback to filesystem migrations
+		veriYonetici, err = gorev.YeniVeriYonetici(dbPath, migrationsPath)
+	}
+
+	if err != nil {
+		return nil, nil, "", errors.New(i18n.T("error.dataManagerInit", map[string]interface{}{"Error": err}))
+	}
+
+	var veriKatmani gorev.VeriYoneticiInterface = veriYonetici
+	if cfg.CacheSize > 0 {
+		// Hierarchy printing and dependency info look up the same tasks repeatedly
+		veriKatmani = gorev.NewCachedVeriYonetici(veriYonetici, gorev.CacheOptions{MaxEntries: cfg.CacheSize, TTL: cfg.CacheTTL})
+	}
+	return veriKatmani, func() { _ = veriYonetici.Kapat() }, migrationsPath, nil
+}
+
 // getDatabasePath returns the correct path to database file
 func getDatabasePath() string {
 	// First priority: GOREV_DB_PATH environment variable (can be set by any MCP client)
@@ -302,6 +349,7 @@ func main() {
 	serveCmd.PersistentFlags().BoolVar(&noAPIFlag, "no-api", false, "Disable API server (MCP only)")
 	serveCmd.PersistentFlags().StringVar(&serverModeFlag, "mode", "", "Server mode: local (default) or centralized")
 	serveCmd.PersistentFlags().StringVar(&dbPathFlag, "db-path", "", "Database path (for centralized mode)")
+	serveCmd.PersistentFlags().BoolVar(&ephemeralFlag, "ephemeral", false, "Keep all data in memory; nothing is written to disk and everything is lost on exit")
 
 	versionCmd := &cobra.Command{
 		Use:   "version",
@@ -376,6 +424,7 @@ func main() {
 	}
 
 	templateCmd.AddCommand(templateListCmd, templateShowCmd, templateInitCmd, templateAliasesCmd)
+	templateCmd.AddCommand(createProjectTemplateCommands()...)
 
 	// MCP test commands
 	mcpCmd := createMCPCommand()
@@ -394,10 +443,30 @@ func main() {
 	// Seed test data command
 	seedCmd := createSeedCommand()
 
+	// Export/import commands
+	exportCmd := createExportCommand()
+	importCmd := createImportCommand()
+
+	// Backup/restore commands
+	backupCmd := createBackupCommand()
+	restoreCmd := createRestoreCommand()
+
+	// Schema migration command
+	migrateCmd := createMigrateCommand()
+
+	// Database doctor command
+	doctorCmd := createDoctorCommand()
+
+	// Archive of old completed tasks
+	archiveCmd := createArchiveCommand()
+
+	// Quick-add of tasks from one line
+	addCmd := createAddCommand()
+
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", i18n.T("flags.language"))
 
-	rootCmd.AddCommand(serveCmd, versionCmd, initCmd, templateCmd, mcpCmd, ideCmd, daemonCmd, daemonStopCmd, daemonStatusCmd, mcpProxyCmd, seedCmd)
+	rootCmd.AddCommand(serveCmd, versionCmd, initCmd, templateCmd, mcpCmd, ideCmd, daemonCmd, daemonStopCmd, daemonStatusCmd, mcpProxyCmd, seedCmd, exportCmd, importCmd, backupCmd, restoreCmd, migrateCmd, doctorCmd, archiveCmd, addCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Hata: %v\n", err)
@@ -406,47 +475,32 @@ func main() {
 }
 
 func runServer() error {
-	// Veritabanını başlat
-	dbPath := getDatabasePath()
-	log.Printf("Using database: %s", dbPath)
-
-	// Use embedded migrations if available, fallback to filesystem
-	migrationsPath := getMigrationsPath()
-	var veriYonetici *gorev.VeriYonetici
-	var err error
-
-	if migrationsPath == "embedded://migrations" {
-		// Use embedded migrations
-		migrationsFS, fsErr := getEmbeddedMigrationsFS()
-		if fsErr != nil {
-			return errors.New(i18n.T("error.dataManagerInit", map[string]interface{}{"Error": fsErr}))
-		}
-		veriYonetici, err = gorev.YeniVeriYoneticiWithEmbeddedMigrations(dbPath, migrationsFS)
-	} else {
-		// Fallback to filesystem migrations
-		veriYonetici, err = gorev.YeniVeriYonetici(dbPath, migrationsPath)
-	}
-
+	cfg := config.GetGlobalConfig()
+	veriKatmani, kapat, migrationsPath, err := veriKatmaniOlustur(cfg)
 	if err != nil {
-		return errors.New(i18n.T("error.dataManagerInit", map[string]interface{}{"Error": err}))
+		return err
 	}
-	defer func() { _ = veriYonetici.Kapat() }()
+	defer kapat()
 
 	// İş mantığı servisini oluştur
 	// Centralized modda: default workspace_id ile IsYonetici oluştur
 	var isYonetici *gorev.IsYonetici
-	cfg := config.GetGlobalConfig()
 	if cfg.Mode == config.ModeCentralized {
 		defaultWorkspaceID := os.Getenv("GOREV_WORKSPACE_ID")
 		if defaultWorkspaceID == "" {
 			defaultWorkspaceID = "default"
 		}
 		log.Printf("📦 Centralized mode: using workspace_id=%s", defaultWorkspaceID)
-		isYonetici = gorev.YeniIsYoneticiWithWorkspaceID(veriYonetici, defaultWorkspaceID)
+		isYonetici = gorev.YeniIsYoneticiWithWorkspaceID(veriKatmani, defaultWorkspaceID)
 	} else {
-		isYonetici = gorev.YeniIsYonetici(veriYonetici)
+		isYonetici = gorev.YeniIsYonetici(veriKatmani)
 	}
 
+	// Completed tasks are archived by the retention of the workspace (gorev archive --set-days)
+	archiveCtx, stopArchive := context.WithCancel(context.Background())
+	defer stopArchive()
+	go isYonetici.ZamanlanmisArsivle(archiveCtx, cfg.ArchiveInterval)
+
 	// IDE extension durumunu kontrol et (background'da)
 	if !debugFlag {
 		go checkAndPromptIDEExtensions()
@@ -460,6 +514,7 @@ func runServer() error {
 	var apiServer *api.APIServer
 	if !noAPIFlag {
 		apiServer = api.NewAPIServer(apiPortFlag, isYonetici)
+		apiServer.SetEphemeral(ephemeralFlag)
 
 		// Set migrations FS for workspace manager
 		if migrationsPath == "embedded://migrations" {
@@ -109,9 +109,10 @@ func createMCPCallCommand() *cobra.Command {
 	var jsonOutput bool
 
 	cmd := &cobra.Command{
-		Use:   "call <tool> [param=value...]",
-		Short: i18n.T("cli.call"),
-		Args:  cobra.MinimumNArgs(1),
+		Use:               "call <tool> [param=value...]",
+		Short:             i18n.T("cli.call"),
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeMCPCallArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			toolName := args[0]
 			params := make(map[string]interface{})
@@ -222,6 +223,62 @@ func createMCPCallCommand() *cobra.Command {
 	return cmd
 }
 
+// mcpParamCompletionKinds maps tool parameters to the kind of value they take
+var mcpParamCompletionKinds = map[string]string{
+	constants.ParamID:          constants.CompletionKindTask,
+	constants.ParamTaskID:      constants.CompletionKindTask,
+	constants.ParamGorevID:     constants.CompletionKindTask,
+	constants.ParamParentID:    constants.CompletionKindTask,
+	constants.ParamNewParentID: constants.CompletionKindTask,
+	constants.ParamSourceID:    constants.CompletionKindTask,
+	constants.ParamTargetID:    constants.CompletionKindTask,
+	constants.ParamProjeID:     constants.CompletionKindProject,
+	constants.ParamTag:         constants.CompletionKindTag,
+	constants.ParamTags:        constants.CompletionKindTag,
+	constants.ParamTemplateID:  constants.CompletionKindTemplate,
+}
+
+// completeMCPCallArgs completes tool names and the values of ID, tag, project and
+// template parameters of "gorev mcp call"
+func completeMCPCallArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		var names []string
+		for _, tool := range mcp.ListTools() {
+			if strings.HasPrefix(tool.Name, toComplete) {
+				names = append(names, tool.Name+"\t"+tool.Description)
+			}
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	key, prefix, ok := strings.Cut(toComplete, "=")
+	kind, known := mcpParamCompletionKinds[key]
+	if !ok || !known {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	veriYonetici, err := gorev.YeniVeriYonetici(getDatabasePath(), getMigrationsPath())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	defer func() { _ = veriYonetici.Kapat() }()
+
+	completions, err := gorev.YeniIsYonetici(veriYonetici).OtomatikTamamla(cmd.Context(), gorev.CompletionOptions{
+		Query: prefix,
+		Kind:  kind,
+		Limit: gorev.MaxCompletionLimit,
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	values := make([]string, 0, len(completions))
+	for _, completion := range completions {
+		values = append(values, key+"="+completion.Value+"\t"+completion.Label)
+	}
+	return values, cobra.ShellCompDirectiveNoFileComp
+}
+
 // Shortcut commands for common operations
 
 func createMCPListTasksCommand() *cobra.Command {
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/msenol/gorev/internal/gorev"
+	"github.com/msenol/gorev/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateDBPath      string
+	migrateCentralized bool
+)
+
+// createMigrateCommand creates the migrate CLI command with its status, up, down and force subcommands
+func createMigrateCommand() *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: i18n.T("cli.migrate"),
+		Long:  i18n.T("cli.migrateDescription"),
+		Example: `  # Show applied and pending migrations of the workspace database
+  gorev migrate status
+
+  # Apply the next pending migration of the centralized database
+  gorev migrate up 1 --centralized
+
+  # Roll back the last two migrations
+  gorev migrate down 2
+
+  # Mark a database whose migration 13 failed half-way as cleanly migrated to 12
+  gorev migrate force 12`,
+	}
+	migrateCmd.PersistentFlags().StringVar(&migrateDBPath, "db-path", "", "Database file (default: workspace database, or GOREV_DB_PATH)")
+	migrateCmd.PersistentFlags().BoolVar(&migrateCentralized, "centralized", false, "Use the centralized database (GOREV_DB_PATH or /data/gorev.db)")
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: i18n.T("cli.migrateStatus"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withMigrator(false, func(ctx context.Context, dbPath string, m *gorev.Migrator) error {
+				status, err := m.Status(ctx)
+				if err != nil {
+					return err
+				}
+				printSchemaStatus(dbPath, status)
+				return nil
+			})
+		},
+	}
+
+	upCmd := &cobra.Command{
+		Use:   "up [N]",
+		Short: i18n.T("cli.migrateUp"),
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := migrationCountArg(args)
+			if err != nil {
+				return err
+			}
+			return withMigrator(true, func(ctx context.Context, dbPath string, m *gorev.Migrator) error {
+				if err := m.RepairState(); err != nil {
+					return err
+				}
+				applied, err := m.Up(ctx, n)
+				for _, version := range applied {
+					fmt.Printf("⬆️  Applied migration %d\n", version)
+				}
+				if err != nil {
+					return err
+				}
+				if len(applied) == 0 {
+					fmt.Println("✅ Database is up to date")
+				}
+				return nil
+			})
+		},
+	}
+
+	downCmd := &cobra.Command{
+		Use:   "down [N]",
+		Short: i18n.T("cli.migrateDown"),
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := migrationCountArg(args)
+			if err != nil {
+				return err
+			}
+			return withMigrator(false, func(ctx context.Context, dbPath string, m *gorev.Migrator) error {
+				reverted, err := m.Down(ctx, n)
+				for _, version := range reverted {
+					fmt.Printf("⬇️  Reverted migration %d\n", version)
+				}
+				return err
+			})
+		},
+	}
+
+	forceCmd := &cobra.Command{
+		Use:   "force <version>",
+		Short: i18n.T("cli.migrateForce"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf(i18n.T("error.migrationUnknownVersion", map[string]interface{}{"Version": args[0]}))
+			}
+			return withMigrator(false, func(ctx context.Context, dbPath string, m *gorev.Migrator) error {
+				if err := m.Force(ctx, version); err != nil {
+					return err
+				}
+				fmt.Printf("✅ Schema version of %s set to %d\n", dbPath, version)
+				return nil
+			})
+		},
+	}
+
+	migrateCmd.AddCommand(statusCmd, upCmd, downCmd, forceCmd)
+	return migrateCmd
+}
+
+// migrationCountArg parses the optional [N] argument of up and down; 0 means "default"
+func migrationCountArg(args []string) (int, error) {
+	if len(args) == 0 {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf(i18n.T("error.invalidMigrationCount", map[string]interface{}{"Value": args[0]}))
+	}
+	return n, nil
+}
+
+// withMigrator opens the target database without migrating it and runs fn with a migrator
+// for the migrations embedded in the binary. Only create may create a missing database.
+func withMigrator(create bool, fn func(ctx context.Context, dbPath string, m *gorev.Migrator) error) error {
+	dbPath, _ := resolveTargetDatabase(migrateDBPath, migrateCentralized)
+	if _, err := os.Stat(dbPath); err != nil && !create {
+		return fmt.Errorf(i18n.T("error.fileNotFound", map[string]interface{}{"Path": dbPath}))
+	}
+
+	migrationsFS, err := getEmbeddedMigrationsFS()
+	if err != nil {
+		return fmt.Errorf("failed to get embedded migrations: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.dbOpenFailed", map[string]interface{}{"Error": err}))
+	}
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("PRAGMA busy_timeout=10000"); err != nil {
+		return fmt.Errorf(i18n.T("error.busyTimeoutFailed", map[string]interface{}{"Error": err}))
+	}
+
+	migrator, err := gorev.NewMigrator(db, migrationsFS)
+	if err != nil {
+		return err
+	}
+	return fn(context.Background(), dbPath, migrator)
+}
+
+// printSchemaStatus prints the migration table of a database
+func printSchemaStatus(dbPath string, status *gorev.SchemaStatus) {
+	fmt.Printf("Database: %s\n", dbPath)
+	fmt.Printf("Version:  %d (binary supports up to %d)\n", status.Current, status.Latest)
+	if status.Dirty {
+		fmt.Printf("State:    ❌ dirty at version %d - fix the schema, then run 'gorev migrate force <version>'\n", status.DirtyAt)
+	} else {
+		fmt.Println("State:    clean")
+	}
+	fmt.Println()
+
+	for _, m := range status.Migrations {
+		state := "pending"
+		switch {
+		case m.Dirty:
+			state = "dirty"
+		case m.Applied && !m.Known:
+			state = "applied (unknown to this binary)"
+		case m.Applied:
+			state = "applied"
+		}
+		fmt.Printf("  %06d  %-32s %s\n", m.Version, m.Name, state)
+	}
+
+	pending := len(status.Pending())
+	unknown := status.Unknown()
+	fmt.Println()
+	fmt.Printf("%d pending migration(s)\n", pending)
+	if len(unknown) > 0 {
+		fmt.Printf("⚠️  %s\n", i18n.T("error.schemaTooNew", map[string]interface{}{"Version": status.Current, "Latest": status.Latest}))
+	}
+}
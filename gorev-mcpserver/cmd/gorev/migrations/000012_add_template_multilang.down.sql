@@ -13,10 +13,10 @@ DROP INDEX IF EXISTS idx_template_language_active;
 -- 2. Remove language support columns
 -- ========================================
 ALTER TABLE gorev_templateleri
-DROP COLUMN IF EXISTS base_template_id;
+DROP COLUMN base_template_id;
 
 ALTER TABLE gorev_templateleri
-DROP COLUMN IF EXISTS language_code;
+DROP COLUMN language_code;
 
 -- ========================================
 -- 3. Restore old UNIQUE constraint on alias
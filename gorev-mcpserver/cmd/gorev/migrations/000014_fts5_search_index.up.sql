@@ -0,0 +1,135 @@
+-- Replace the plain gorevler_search table and the rowid-less FTS table of 000011
+-- with an FTS5 index over title, description, tags and project name.
+-- The porter tokenizer matches word stems ("deployments" finds "Deployment"),
+-- remove_diacritics lets "gorev" match "görev".
+
+-- Old sync triggers and tables
+DROP TRIGGER IF EXISTS gorevler_ai;
+DROP TRIGGER IF EXISTS gorevler_ad;
+DROP TRIGGER IF EXISTS gorevler_au;
+DROP TABLE IF EXISTS gorevler_fts;
+DROP INDEX IF EXISTS idx_gorevler_search_baslik;
+DROP INDEX IF EXISTS idx_gorevler_search_text;
+DROP INDEX IF EXISTS idx_gorevler_search_combined;
+DROP TABLE IF EXISTS gorevler_search;
+
+-- Stable FTS rowid per task: gorevler has a TEXT primary key, and its implicit rowid
+-- may change on VACUUM. Triggers find index rows through this table by rowid
+-- instead of scanning the UNINDEXED task_id column.
+CREATE TABLE gorevler_fts_docs (
+    doc_id INTEGER PRIMARY KEY,
+    task_id TEXT NOT NULL UNIQUE
+);
+
+CREATE VIRTUAL TABLE gorevler_fts USING fts5(
+    task_id UNINDEXED,
+    title,
+    description,
+    tags,
+    project_name,
+    tokenize = 'porter unicode61 remove_diacritics 2'
+);
+
+-- Backfill existing tasks
+INSERT INTO gorevler_fts_docs(task_id) SELECT id FROM gorevler;
+
+INSERT INTO gorevler_fts(rowid, task_id, title, description, tags, project_name)
+SELECT
+    d.doc_id,
+    g.id,
+    g.title,
+    COALESCE(g.description, ''),
+    COALESCE((
+        SELECT GROUP_CONCAT(e.name, ' ')
+        FROM gorev_etiketleri ge
+        JOIN etiketler e ON ge.tag_id = e.id
+        WHERE ge.task_id = g.id
+    ), ''),
+    COALESCE((SELECT p.name FROM projeler p WHERE p.id = g.project_id), '')
+FROM gorevler g
+JOIN gorevler_fts_docs d ON d.task_id = g.id;
+
+-- Tasks
+CREATE TRIGGER gorevler_fts_ai AFTER INSERT ON gorevler BEGIN
+    INSERT OR IGNORE INTO gorevler_fts_docs(task_id) VALUES (new.id);
+    INSERT INTO gorevler_fts(rowid, task_id, title, description, tags, project_name)
+    VALUES (
+        (SELECT doc_id FROM gorevler_fts_docs WHERE task_id = new.id),
+        new.id,
+        new.title,
+        COALESCE(new.description, ''),
+        COALESCE((
+            SELECT GROUP_CONCAT(e.name, ' ')
+            FROM gorev_etiketleri ge
+            JOIN etiketler e ON ge.tag_id = e.id
+            WHERE ge.task_id = new.id
+        ), ''),
+        COALESCE((SELECT p.name FROM projeler p WHERE p.id = new.project_id), '')
+    );
+END;
+
+CREATE TRIGGER gorevler_fts_ad AFTER DELETE ON gorevler BEGIN
+    DELETE FROM gorevler_fts WHERE rowid = (SELECT doc_id FROM gorevler_fts_docs WHERE task_id = old.id);
+    DELETE FROM gorevler_fts_docs WHERE task_id = old.id;
+END;
+
+-- Status and priority changes do not touch the index
+CREATE TRIGGER gorevler_fts_au AFTER UPDATE OF id, title, description, project_id ON gorevler BEGIN
+    UPDATE gorevler_fts
+    SET
+        task_id = new.id,
+        title = new.title,
+        description = COALESCE(new.description, ''),
+        project_name = COALESCE((SELECT p.name FROM projeler p WHERE p.id = new.project_id), '')
+    WHERE rowid = (SELECT doc_id FROM gorevler_fts_docs WHERE task_id = old.id);
+    UPDATE gorevler_fts_docs SET task_id = new.id WHERE task_id = old.id AND new.id != old.id;
+END;
+
+-- Tags
+CREATE TRIGGER gorev_etiketleri_fts_ai AFTER INSERT ON gorev_etiketleri BEGIN
+    UPDATE gorevler_fts
+    SET tags = COALESCE((
+        SELECT GROUP_CONCAT(e.name, ' ')
+        FROM gorev_etiketleri ge
+        JOIN etiketler e ON ge.tag_id = e.id
+        WHERE ge.task_id = new.task_id
+    ), '')
+    WHERE rowid = (SELECT doc_id FROM gorevler_fts_docs WHERE task_id = new.task_id);
+END;
+
+CREATE TRIGGER gorev_etiketleri_fts_ad AFTER DELETE ON gorev_etiketleri BEGIN
+    UPDATE gorevler_fts
+    SET tags = COALESCE((
+        SELECT GROUP_CONCAT(e.name, ' ')
+        FROM gorev_etiketleri ge
+        JOIN etiketler e ON ge.tag_id = e.id
+        WHERE ge.task_id = old.task_id
+    ), '')
+    WHERE rowid = (SELECT doc_id FROM gorevler_fts_docs WHERE task_id = old.task_id);
+END;
+
+CREATE TRIGGER etiketler_fts_au AFTER UPDATE OF name ON etiketler BEGIN
+    UPDATE gorevler_fts
+    SET tags = COALESCE((
+        SELECT GROUP_CONCAT(e.name, ' ')
+        FROM gorev_etiketleri ge
+        JOIN etiketler e ON ge.tag_id = e.id
+        WHERE ge.task_id = gorevler_fts.task_id
+    ), '')
+    WHERE rowid IN (
+        SELECT d.doc_id FROM gorevler_fts_docs d
+        JOIN gorev_etiketleri ge ON ge.task_id = d.task_id
+        WHERE ge.tag_id = new.id
+    );
+END;
+
+-- Project renames
+CREATE TRIGGER projeler_fts_au AFTER UPDATE OF name ON projeler BEGIN
+    UPDATE gorevler_fts
+    SET project_name = new.name
+    WHERE rowid IN (
+        SELECT d.doc_id FROM gorevler_fts_docs d
+        JOIN gorevler g ON g.id = d.task_id
+        WHERE g.project_id = new.id
+    );
+END;
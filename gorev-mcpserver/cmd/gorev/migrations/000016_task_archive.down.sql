@@ -0,0 +1,14 @@
+-- Rollback: archived tasks are lost, restore them with the archive tool first
+
+DROP TABLE IF EXISTS arsiv_ayarlari;
+DROP INDEX IF EXISTS idx_task_file_paths_arsiv_task;
+DROP TABLE IF EXISTS task_file_paths_arsiv;
+DROP INDEX IF EXISTS idx_ai_interactions_arsiv_task;
+DROP TABLE IF EXISTS ai_interactions_arsiv;
+DROP INDEX IF EXISTS idx_baglantilar_arsiv_target;
+DROP INDEX IF EXISTS idx_baglantilar_arsiv_source;
+DROP TABLE IF EXISTS baglantilar_arsiv;
+DROP TABLE IF EXISTS gorev_etiketleri_arsiv;
+DROP INDEX IF EXISTS idx_gorevler_arsiv_workspace;
+DROP INDEX IF EXISTS idx_gorevler_arsiv_parent;
+DROP TABLE IF EXISTS gorevler_arsiv;
@@ -0,0 +1,6 @@
+-- Indexes in the default order of task lists, newest first with ties broken by id,
+-- so a page of root tasks walks the index and stops at its LIMIT instead of sorting
+-- the whole table. The second one serves the workspace filter of centralized mode.
+
+CREATE INDEX idx_gorev_created ON gorevler(created_at, id);
+CREATE INDEX idx_gorev_workspace_created ON gorevler(workspace_id, created_at, id);
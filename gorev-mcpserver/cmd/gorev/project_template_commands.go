@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/msenol/gorev/internal/gorev"
+	"github.com/msenol/gorev/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var (
+	templateApplyProject  string
+	templateApplyName     string
+	templateApplyStart    string
+	templateApplyValues   []string
+	templateImportReplace bool
+)
+
+// createProjectTemplateCommands creates the project template subcommands of gorev template
+func createProjectTemplateCommands() []*cobra.Command {
+	applyCmd := &cobra.Command{
+		Use:   "apply <project-template>",
+		Short: i18n.T("cli.templateApply"),
+		Long:  i18n.T("cli.templateApplyDescription"),
+		Example: `  # Create the project "Release 2.4" with all release tasks due relative to Monday
+  gorev template apply release --set version=2.4 --start 2026-11-02
+
+  # Add the tasks to an existing project instead
+  gorev template apply release --project <project-id> --set version=2.4`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTemplateApply(args[0])
+		},
+	}
+	applyCmd.Flags().StringVar(&templateApplyProject, "project", "", "Add the tasks to this project instead of creating one")
+	applyCmd.Flags().StringVar(&templateApplyName, "name", "", "Name of the created project (default: the template name)")
+	applyCmd.Flags().StringVar(&templateApplyStart, "start", "", "Start date the due offsets count from, YYYY-MM-DD (default: today)")
+	applyCmd.Flags().StringArrayVar(&templateApplyValues, "set", nil, "Template field value as name=value (repeatable)")
+
+	importCmd := &cobra.Command{
+		Use:   "import-project <file.json>",
+		Short: i18n.T("cli.templateImport"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTemplateImport(args[0])
+		},
+	}
+	importCmd.Flags().BoolVar(&templateImportReplace, "replace", false, "Replace a project template with the same alias")
+
+	projectsCmd := &cobra.Command{
+		Use:   "projects",
+		Short: i18n.T("cli.templateProjects"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTemplateProjects()
+		},
+	}
+
+	return []*cobra.Command{applyCmd, importCmd, projectsCmd}
+}
+
+// runTemplateApply instantiates a project template in the current database
+func runTemplateApply(idOrAlias string) error {
+	values := make(map[string]string, len(templateApplyValues))
+	for _, value := range templateApplyValues {
+		name, v, ok := strings.Cut(value, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid --set %q, expected name=value", value)
+		}
+		values[strings.TrimSpace(name)] = v
+	}
+
+	veriYonetici, err := createVeriYonetici()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer func() { _ = veriYonetici.Kapat() }()
+
+	isYonetici := gorev.YeniIsYonetici(veriYonetici)
+	result, err := isYonetici.ProjeTemplateUygula(context.Background(), idOrAlias, gorev.ProjeTemplateSecenekleri{
+		ProjectID:   templateApplyProject,
+		ProjectName: templateApplyName,
+		StartDate:   templateApplyStart,
+		Values:      values,
+	})
+	if err != nil {
+		return err
+	}
+
+	verb := "Added to"
+	if result.ProjectCreated {
+		verb = "Created"
+	}
+	fmt.Printf("🧩 %s project %s (%s): %d tasks, %d dependencies, %d tag links\n",
+		verb, result.Project.Name, result.Project.ID, result.Tasks, result.Links, result.Tags)
+	return nil
+}
+
+// runTemplateImport stores the project template of a JSON file
+func runTemplateImport(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	template := &gorev.ProjeTemplate{}
+	if err := json.Unmarshal(data, template); err != nil {
+		return fmt.Errorf(i18n.T("error.projectTemplateInvalidJSON", map[string]interface{}{"Error": err}))
+	}
+
+	veriYonetici, err := createVeriYonetici()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer func() { _ = veriYonetici.Kapat() }()
+
+	ctx := context.Background()
+	isYonetici := gorev.YeniIsYonetici(veriYonetici)
+	if templateImportReplace && template.Alias != "" {
+		if existing, err := isYonetici.ProjeTemplateGetir(ctx, template.Alias); err == nil {
+			if err := isYonetici.ProjeTemplateSil(ctx, existing.ID); err != nil {
+				return err
+			}
+		}
+	}
+	if err := isYonetici.ProjeTemplateKaydet(ctx, template); err != nil {
+		return err
+	}
+	fmt.Printf("🧩 Saved project template %s (%s)\n", template.Name, template.ID)
+	return nil
+}
+
+// runTemplateProjects lists the stored project templates
+func runTemplateProjects() error {
+	veriYonetici, err := createVeriYonetici()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer func() { _ = veriYonetici.Kapat() }()
+
+	templates, err := gorev.YeniIsYonetici(veriYonetici).ProjeTemplateListele(context.Background())
+	if err != nil {
+		return err
+	}
+	for _, template := range templates {
+		alias := ""
+		if template.Alias != "" {
+			alias = " [" + template.Alias + "]"
+		}
+		fmt.Printf("%s  %s%s  %s\n", template.ID, template.Name, alias, template.Definition)
+	}
+	fmt.Printf("%d project templates\n", len(templates))
+	return nil
+}
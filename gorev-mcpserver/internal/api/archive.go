@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ArchiveWorkspaces applies the archive retention of every registered workspace.
+// Workspaces without a retention setting keep their tasks, and failures of single
+// workspaces are logged so that one broken database does not stop the others.
+func (wm *WorkspaceManager) ArchiveWorkspaces(ctx context.Context) int {
+	archived := 0
+	for _, workspace := range wm.ListWorkspaces() {
+		if workspace.VeriYonetici == nil || workspace.IsYonetici == nil {
+			continue // Ephemeral workspaces have no archive
+		}
+		result, err := workspace.IsYonetici.GorevleriArsivle(ctx, 0)
+		if err != nil {
+			log.Printf("⚠️  Scheduled archive of %s failed: %v", workspace.Name, err)
+			continue
+		}
+		if result.Tasks > 0 {
+			log.Printf("🗃️  %s: archived %d tasks", workspace.Name, result.Tasks)
+		}
+		archived += result.Tasks
+	}
+	return archived
+}
+
+// RunScheduledArchive runs ArchiveWorkspaces every interval until ctx is cancelled
+func (wm *WorkspaceManager) RunScheduledArchive(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			wm.ArchiveWorkspaces(ctx)
+		}
+	}
+}
+
+// RunScheduledArchive runs the archive policy of the server's workspaces every interval until ctx is cancelled
+func (s *APIServer) RunScheduledArchive(ctx context.Context, interval time.Duration) {
+	s.workspaceManager.RunScheduledArchive(ctx, interval)
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/msenol/gorev/internal/config"
+	"github.com/msenol/gorev/internal/gorev"
+)
+
+// BackupSchedule configures the daemon's periodic database backups
+type BackupSchedule struct {
+	Interval time.Duration        // Time between backups; zero disables scheduling
+	Dir      string               // Backup directory; empty keeps backups next to each database in backups/
+	Rotation gorev.BackupRotation // Retention policy applied after every backup
+}
+
+// BackupScheduleFromConfig builds the backup schedule from the server configuration
+func BackupScheduleFromConfig(cfg *config.ServerConfig) BackupSchedule {
+	return BackupSchedule{
+		Interval: cfg.BackupInterval,
+		Dir:      cfg.BackupDir,
+		Rotation: gorev.BackupRotation{Daily: cfg.BackupKeepDaily, Weekly: cfg.BackupKeepWeekly},
+	}
+}
+
+// BackupWorkspaces backs up the centralized database, or every registered workspace
+// database in local mode, and applies the rotation policy. Failures of single
+// workspaces are collected so that one broken database does not stop the others.
+func (wm *WorkspaceManager) BackupWorkspaces(ctx context.Context, schedule BackupSchedule) ([]*gorev.BackupInfo, error) {
+	now := time.Now()
+	backups := []*gorev.BackupInfo{}
+	var errs []error
+
+	if config.IsCentralizedMode() {
+		dbPath := config.GetGlobalConfig().CentralizedDBPath
+		if dbPath == "" {
+			dbPath = "/data/gorev.db"
+		}
+		dir := schedule.Dir
+		if dir == "" {
+			dir = filepath.Join(filepath.Dir(dbPath), "backups")
+		}
+		label := "centralized"
+		dest := filepath.Join(dir, gorev.BackupFileName(label, now))
+
+		var info *gorev.BackupInfo
+		var err error
+		if wm.centralizedDB != nil {
+			info, err = wm.centralizedDB.Yedekle(ctx, dest)
+		} else if _, statErr := os.Stat(dbPath); statErr == nil {
+			info, err = gorev.BackupDatabase(ctx, dbPath, dest)
+		} else {
+			return backups, nil // Nothing has been written yet
+		}
+		if err != nil {
+			return backups, err
+		}
+		backups = append(backups, info)
+		if _, err := gorev.RotateBackups(dir, label, schedule.Rotation); err != nil {
+			return backups, err
+		}
+		return backups, nil
+	}
+
+	for _, workspace := range wm.ListWorkspaces() {
+		if workspace.VeriYonetici == nil {
+			continue
+		}
+		// Per-workspace subdirectories keep the rotation of different workspaces apart
+		dir := filepath.Join(filepath.Dir(workspace.DatabasePath), "backups")
+		if schedule.Dir != "" {
+			dir = filepath.Join(schedule.Dir, workspace.ID)
+		}
+		label := "gorev"
+
+		info, err := workspace.VeriYonetici.Yedekle(ctx, filepath.Join(dir, gorev.BackupFileName(label, now)))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", workspace.Name, err))
+			continue
+		}
+		backups = append(backups, info)
+		if _, err := gorev.RotateBackups(dir, label, schedule.Rotation); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", workspace.Name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return backups, fmt.Errorf("%d workspace backups failed: %v", len(errs), errs)
+	}
+	return backups, nil
+}
+
+// RunScheduledBackups runs BackupWorkspaces every schedule.Interval until ctx is cancelled
+func (wm *WorkspaceManager) RunScheduledBackups(ctx context.Context, schedule BackupSchedule) {
+	if schedule.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(schedule.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			backups, err := wm.BackupWorkspaces(ctx, schedule)
+			for _, backup := range backups {
+				log.Printf("💾 Backup written: %s (%d bytes)", backup.Path, backup.Size)
+			}
+			if err != nil {
+				log.Printf("⚠️  Scheduled backup failed: %v", err)
+			}
+		}
+	}
+}
+
+// RunScheduledBackups runs periodic backups of the server's workspace databases until ctx is cancelled
+func (s *APIServer) RunScheduledBackups(ctx context.Context, schedule BackupSchedule) {
+	s.workspaceManager.RunScheduledBackups(ctx, schedule)
+}
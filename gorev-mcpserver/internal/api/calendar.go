@@ -0,0 +1,111 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/msenol/gorev/internal/config"
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/gorev"
+)
+
+// getCalendarFeed serves tasks with due dates as a subscribable iCalendar feed
+// Query params: token (required), workspace, project, component (vtodo|vevent|both), include_completed
+func (s *APIServer) getCalendarFeed(c *fiber.Ctx) error {
+	expectedToken := config.GetGlobalConfig().CalendarFeedToken
+	if expectedToken == "" {
+		return fiber.NewError(fiber.StatusNotFound, "calendar feed is disabled (set GOREV_CALENDAR_TOKEN to enable)")
+	}
+
+	// Accept the token as query param (calendar apps) or bearer token (scripts)
+	token := c.Query("token")
+	if token == "" {
+		token = strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(expectedToken)) != 1 {
+		return fiber.NewError(fiber.StatusUnauthorized, "invalid calendar token")
+	}
+
+	// Calendar apps cannot send workspace headers, so resolve the workspace from the query
+	iy := s.getIsYoneticiFromContext(c)
+	calendarName := "Gorev"
+	if workspaceID := c.Query("workspace"); workspaceID != "" {
+		wsCtx, err := s.workspaceManager.GetWorkspaceContext(workspaceID)
+		if err != nil {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("workspace not found: %s", workspaceID))
+		}
+		iy = wsCtx.IsYonetici
+		calendarName = "Gorev - " + wsCtx.Name
+	}
+	if iy == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "no workspace available for calendar feed")
+	}
+
+	ctx := s.getContextFromRequest(c)
+	gorevler, err := iy.GorevListele(ctx, map[string]interface{}{})
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("failed to list tasks: %v", err))
+	}
+
+	projectID := c.Query("project")
+	includeCompleted := c.QueryBool("include_completed", true)
+
+	tasks := make([]*gorev.Gorev, 0, len(gorevler))
+	for _, task := range gorevler {
+		if task.DueDate == nil {
+			continue
+		}
+		if projectID != "" && task.ProjeID != projectID {
+			continue
+		}
+		if !includeCompleted && task.Status == constants.TaskStatusCompleted {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+
+	if projectID != "" {
+		if proje, err := iy.ProjeGetir(ctx, projectID); err == nil && proje != nil {
+			calendarName = calendarName + " / " + proje.Name
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := gorev.WriteICal(&buf, tasks, gorev.ICalOptions{
+		Component:    c.Query("component", gorev.ICalComponentBoth),
+		CalendarName: calendarName,
+	}); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	// ETag lets subscribers poll cheaply; it changes whenever a task in the feed changes
+	etag := calendarETag(tasks, c.Query("component"))
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	if c.Get(fiber.HeaderIfNoneMatch) == etag {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="gorev.ics"`)
+	return c.Send(buf.Bytes())
+}
+
+// calendarETag derives a weak ETag from the fields of the feed's tasks that end up in the calendar
+func calendarETag(tasks []*gorev.Gorev, component string) string {
+	h := sha256.New()
+	h.Write([]byte(component))
+	for _, task := range tasks {
+		fmt.Fprintf(h, "|%s|%s|%s|%s|%s|%d|%d",
+			task.ID, task.Title, task.Description, task.Status, task.Priority,
+			task.DueDate.UnixNano(), task.UpdatedAt.UnixNano())
+		for _, tag := range task.Tags {
+			fmt.Fprintf(h, "|%s", tag.Name)
+		}
+	}
+	return fmt.Sprintf(`W/"%x"`, h.Sum(nil)[:16])
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/config"
+	"github.com/msenol/gorev/internal/gorev"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCalendarFeed tests the token protected iCalendar feed
+func TestCalendarFeed(t *testing.T) {
+	server, cleanup := setupBasicTestServer(t)
+	defer cleanup()
+
+	cfg := config.DefaultConfig()
+	cfg.CalendarFeedToken = "secret-token"
+	config.SetGlobalConfig(cfg)
+	defer config.SetGlobalConfig(nil)
+
+	ctx := context.Background()
+	proje, err := server.isYonetici.ProjeOlustur(ctx, "Calendar Project", "")
+	require.NoError(t, err)
+	_, err = server.isYonetici.GorevOlustur(ctx, "Due task", "", "yuksek", proje.ID, time.Now().AddDate(0, 0, 2).Format("2006-01-02"), nil)
+	require.NoError(t, err)
+	_, err = server.isYonetici.GorevOlustur(ctx, "Undated task", "", "orta", proje.ID, "", nil)
+	require.NoError(t, err)
+
+	t.Run("missing token", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/calendar.ics", nil)
+		resp, err := server.app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, 401, resp.StatusCode)
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/calendar.ics?token=secret-token&project="+proje.ID, nil)
+		resp, err := server.app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Content-Type"), "text/calendar")
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Contains(t, string(body), "SUMMARY:Due task")
+		assert.NotContains(t, string(body), "Undated task")
+
+		// Unchanged feed answers conditional requests with 304
+		etag := resp.Header.Get("ETag")
+		require.NotEmpty(t, etag)
+		req = httptest.NewRequest("GET", "/api/v1/calendar.ics?token=secret-token&project="+proje.ID, nil)
+		req.Header.Set("If-None-Match", etag)
+		resp, err = server.app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, 304, resp.StatusCode)
+	})
+
+	t.Run("unknown workspace", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/calendar.ics?token=secret-token&workspace=missing", nil)
+		resp, err := server.app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+	})
+
+	t.Run("disabled without token", func(t *testing.T) {
+		config.SetGlobalConfig(&config.ServerConfig{Mode: config.ModeLocal})
+		req := httptest.NewRequest("GET", "/api/v1/calendar.ics?token=secret-token", nil)
+		resp, err := server.app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+	})
+}
+
+// TestCalendarETagChangesWithTasks verifies that task edits change the feed ETag
+func TestCalendarETagChangesWithTasks(t *testing.T) {
+	due := time.Now()
+	task := &gorev.Gorev{ID: "1", Title: "A", DueDate: &due}
+	before := calendarETag([]*gorev.Gorev{task}, "")
+	task.Title = "B"
+	assert.NotEqual(t, before, calendarETag([]*gorev.Gorev{task}, ""))
+}
@@ -15,6 +15,7 @@ type ExportRequest struct {
 	IncludeDependencies bool     `json:"include_dependencies"`
 	IncludeTemplates    bool     `json:"include_templates"`
 	IncludeAIContext    bool     `json:"include_ai_context"`
+	IncludeArchived     *bool    `json:"include_archived"` // Default: true
 	ProjectFilter       []string `json:"project_filter"`
 }
 
@@ -26,6 +27,9 @@ type ImportRequest struct {
 	DryRun             bool              `json:"dry_run"`
 	PreserveIDs        bool              `json:"preserve_ids"`
 	ProjectMapping     map[string]string `json:"project_mapping"`
+	Format             string            `json:"format"`
+	ColumnMapping      map[string]string `json:"column_mapping"`
+	ChunkSize          int               `json:"chunk_size"`
 }
 
 // exportData handles data export requests
@@ -55,6 +59,10 @@ func (s *APIServer) exportData(c *fiber.Ctx) error {
 		"include_ai_context":   req.IncludeAIContext,
 	}
 
+	if req.IncludeArchived != nil {
+		params["include_archived"] = *req.IncludeArchived
+	}
+
 	if len(req.ProjectFilter) > 0 {
 		// Convert []string to []interface{} for MCP handler
 		projectFilterInterface := make([]interface{}, len(req.ProjectFilter))
@@ -131,7 +139,16 @@ func (s *APIServer) importData(c *fiber.Ctx) error {
 	}
 
 	if len(req.ProjectMapping) > 0 {
-		params["project_mapping"] = req.ProjectMapping
+		params["project_mapping"] = stringMapParam(req.ProjectMapping)
+	}
+	if req.Format != "" {
+		params["format"] = req.Format
+	}
+	if len(req.ColumnMapping) > 0 {
+		params["column_mapping"] = stringMapParam(req.ColumnMapping)
+	}
+	if req.ChunkSize > 0 {
+		params["chunk_size"] = float64(req.ChunkSize)
 	}
 
 	// Call MCP handler through server's handlers field
@@ -169,3 +186,12 @@ func (s *APIServer) importData(c *fiber.Ctx) error {
 		"message": message,
 	})
 }
+
+// stringMapParam converts a string map into the map[string]interface{} shape MCP handlers expect
+func stringMapParam(m map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
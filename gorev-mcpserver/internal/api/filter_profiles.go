@@ -0,0 +1,250 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/msenol/gorev/internal/gorev"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// FilterProfileRequest represents the create/update filter profile payload; fields
+// left out of an update keep their stored values
+type FilterProfileRequest struct {
+	Name        *string              `json:"name"`
+	Description *string              `json:"description"`
+	Filters     *gorev.SearchFilters `json:"filters"`
+	SearchQuery *string              `json:"search_query"`
+}
+
+// apply copies the fields given in the request to profile
+func (req *FilterProfileRequest) apply(profile *gorev.FilterProfile) {
+	if req.Name != nil {
+		profile.Name = *req.Name
+	}
+	if req.Description != nil {
+		profile.Description = *req.Description
+	}
+	if req.Filters != nil {
+		profile.Filters = *req.Filters
+	}
+	if req.SearchQuery != nil {
+		profile.SearchQuery = *req.SearchQuery
+	}
+}
+
+// filterProfileManager returns the filter profile manager of the request's workspace
+func (s *APIServer) filterProfileManager(c *fiber.Ctx) (*gorev.FilterProfileManager, error) {
+	vy := s.getIsYoneticiFromContext(c).VeriYonetici()
+	db, err := vy.GetReadDB()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("database access failed: %v", err))
+	}
+	if db == nil {
+		return nil, fiber.NewError(fiber.StatusNotImplemented, i18n.T("error.memoryBackendSQLUnavailable", map[string]interface{}{"Operation": "filter profiles"}))
+	}
+	return gorev.NewFilterProfileManager(vy, db), nil
+}
+
+// getFilterProfileByParam loads the profile named by the :id route parameter
+func (s *APIServer) getFilterProfileByParam(c *fiber.Ctx, fpm *gorev.FilterProfileManager) (int, *gorev.FilterProfile, error) {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return 0, nil, fiber.NewError(fiber.StatusBadRequest, "Filter profile ID must be a positive number")
+	}
+
+	profile, err := fpm.GetFilterProfile(id)
+	if err != nil {
+		return 0, nil, fiber.NewError(fiber.StatusNotFound, err.Error())
+	}
+	return id, profile, nil
+}
+
+// getFilterProfiles lists the filter profiles of the workspace
+// Query params: q (name or description substring), defaults_only
+func (s *APIServer) getFilterProfiles(c *fiber.Ctx) error {
+	fpm, err := s.filterProfileManager(c)
+	if err != nil {
+		return err
+	}
+
+	var profiles []*gorev.FilterProfile
+	if q := c.Query("q"); q != "" {
+		profiles, err = fpm.SearchFilterProfiles(q)
+	} else {
+		profiles, err = fpm.ListFilterProfiles(c.QueryBool("defaults_only", false))
+	}
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("failed to list filter profiles: %v", err))
+	}
+	if profiles == nil {
+		profiles = []*gorev.FilterProfile{}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    profiles,
+		"total":   len(profiles),
+	})
+}
+
+// getMostUsedFilterProfiles lists the most used filter profiles
+// Query params: limit (default 5)
+func (s *APIServer) getMostUsedFilterProfiles(c *fiber.Ctx) error {
+	fpm, err := s.filterProfileManager(c)
+	if err != nil {
+		return err
+	}
+
+	profiles, err := fpm.GetMostUsedProfiles(c.QueryInt("limit", 5))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("failed to list most used filter profiles: %v", err))
+	}
+	if profiles == nil {
+		profiles = []*gorev.FilterProfile{}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    profiles,
+		"total":   len(profiles),
+	})
+}
+
+// getFilterProfile retrieves a filter profile by ID
+func (s *APIServer) getFilterProfile(c *fiber.Ctx) error {
+	fpm, err := s.filterProfileManager(c)
+	if err != nil {
+		return err
+	}
+
+	_, profile, err := s.getFilterProfileByParam(c, fpm)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    profile,
+	})
+}
+
+// createFilterProfile saves a new filter profile; its filters and search query must
+// form a valid query
+func (s *APIServer) createFilterProfile(c *fiber.Ctx) error {
+	var req FilterProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+	}
+	if req.Name == nil || *req.Name == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "name is required")
+	}
+
+	fpm, err := s.filterProfileManager(c)
+	if err != nil {
+		return err
+	}
+
+	profile := &gorev.FilterProfile{}
+	req.apply(profile)
+	if err := fpm.SaveFilterProfile(profile); err != nil {
+		return queryError(c, fiber.StatusBadRequest, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    profile,
+		"message": "Filter profile created successfully",
+	})
+}
+
+// updateFilterProfile changes the fields given in the request body
+func (s *APIServer) updateFilterProfile(c *fiber.Ctx) error {
+	var req FilterProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+	}
+
+	fpm, err := s.filterProfileManager(c)
+	if err != nil {
+		return err
+	}
+
+	id, profile, err := s.getFilterProfileByParam(c, fpm)
+	if err != nil {
+		return err
+	}
+
+	req.apply(profile)
+	if err := fpm.SaveFilterProfile(profile); err != nil {
+		return queryError(c, fiber.StatusBadRequest, err)
+	}
+
+	// Return updated profile
+	profile, err = fpm.GetFilterProfile(id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("failed to get updated filter profile %d: %v", id, err))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    profile,
+		"message": "Filter profile updated successfully",
+	})
+}
+
+// deleteFilterProfile deletes a filter profile; default profiles cannot be deleted
+func (s *APIServer) deleteFilterProfile(c *fiber.Ctx) error {
+	fpm, err := s.filterProfileManager(c)
+	if err != nil {
+		return err
+	}
+
+	id, _, err := s.getFilterProfileByParam(c, fpm)
+	if err != nil {
+		return err
+	}
+
+	if err := fpm.DeleteFilterProfile(id); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Filter profile deleted successfully",
+	})
+}
+
+// useFilterProfile counts a use of the profile, for clients that run its query
+// themselves through /search
+func (s *APIServer) useFilterProfile(c *fiber.Ctx) error {
+	fpm, err := s.filterProfileManager(c)
+	if err != nil {
+		return err
+	}
+
+	id, _, err := s.getFilterProfileByParam(c, fpm)
+	if err != nil {
+		return err
+	}
+
+	if err := fpm.MarkProfileUsed(id); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("failed to mark filter profile %d used: %v", id, err))
+	}
+
+	profile, err := fpm.GetFilterProfile(id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("failed to get filter profile %d: %v", id, err))
+	}
+
+	query, err := profile.Query()
+	if err != nil {
+		return queryError(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    profile,
+		"query":   query.Input,
+	})
+}
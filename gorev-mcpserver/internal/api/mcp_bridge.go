@@ -247,6 +247,74 @@ func (s *APIServer) dispatchMCPTool(handlers *mcp.Handlers, toolName string, par
 			}
 		}
 
+	// Database integrity check; with fix=true broken references are repaired
+	case "gorev_doctor":
+		result, err = handlers.GorevDoctor(params)
+		if err == nil {
+			if fix, _ := params["fix"].(bool); fix {
+				wsCtx.EventEmitter.EmitWorkspaceSync(wsCtx.ID)
+			}
+		}
+
+	// Archive of old completed tasks (run|restore|list|settings)
+	case "gorev_arsiv":
+		result, err = handlers.GorevArsiv(params)
+		if err == nil {
+			action, _ := params["action"].(string)
+			if action == "run" || action == "restore" {
+				wsCtx.EventEmitter.EmitWorkspaceSync(wsCtx.ID)
+			}
+		}
+
+	// Type-ahead completion (read-only)
+	case "gorev_complete":
+		result, err = handlers.GorevComplete(params)
+
+	// One-line task creation; dry_run only shows the parsed fields
+	case "gorev_quick_add":
+		result, err = handlers.GorevQuickAdd(params)
+		if err == nil {
+			if dryRun, _ := params["dry_run"].(bool); !dryRun {
+				if taskID := extractTaskIDFromResult(result); taskID != "" {
+					wsCtx.EventEmitter.EmitTaskCreated(wsCtx.ID, taskID, params)
+				} else {
+					wsCtx.EventEmitter.EmitWorkspaceSync(wsCtx.ID)
+				}
+			}
+		}
+
+	// Similar tasks by TF-IDF (read-only)
+	case "gorev_similar":
+		result, err = handlers.GorevSimilar(params)
+
+	// Duplicate merge; the source is cancelled and its subtasks move to the target
+	case "gorev_merge":
+		result, err = handlers.GorevMerge(params)
+		if err == nil {
+			if dryRun, _ := params["dry_run"].(bool); !dryRun {
+				wsCtx.EventEmitter.EmitWorkspaceSync(wsCtx.ID)
+			}
+		}
+
+	// Deep clone of a task tree or a whole project
+	case "gorev_clone":
+		result, err = handlers.GorevClone(params)
+		if err == nil {
+			wsCtx.EventEmitter.EmitWorkspaceSync(wsCtx.ID)
+		}
+
+	// Project templates (list|show|create|delete|apply)
+	case "gorev_project_template":
+		result, err = handlers.GorevProjectTemplate(params)
+		if err == nil {
+			switch action, _ := params["action"].(string); action {
+			case "apply":
+				wsCtx.EventEmitter.EmitWorkspaceSync(wsCtx.ID)
+			case "create", "delete":
+				wsCtx.EventEmitter.EmitTemplateChanged(wsCtx.ID)
+			}
+		}
+
 	// MCP Protocol methods
 	case "initialize":
 		// Return proper MCP initialize response
@@ -263,7 +331,7 @@ func (s *APIServer) dispatchMCPTool(handlers *mcp.Handlers, toolName string, par
 		err = nil
 
 	case "tools/list":
-		// Return list of 24 optimized MCP tools (reduced from 45)
+		// Return list of 32 optimized MCP tools (reduced from 45)
 		tools := []map[string]interface{}{
 			// === CORE TOOLS (11) ===
 			// Task CRUD
@@ -293,12 +361,20 @@ func (s *APIServer) dispatchMCPTool(handlers *mcp.Handlers, toolName string, par
 			{"name": "gorev_context", "description": "AI context (unified: set_active|get_active|recent|summary)", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"action": map[string]interface{}{"type": "string", "enum": []string{"set_active", "get_active", "recent", "summary"}}, "task_id": map[string]interface{}{"type": "string"}}, "required": []string{"action"}}},
 			{"name": "gorev_search", "description": "Search tasks (unified: nlp|advanced|history)", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"mode": map[string]interface{}{"type": "string", "enum": []string{"nlp", "advanced", "history"}}, "query": map[string]interface{}{"type": "string"}}, "required": []string{"mode"}}},
 
-			// === SPECIAL TOOLS (5) ===
+			// === SPECIAL TOOLS (13) ===
 			{"name": "ozet_goster", "description": "Show workspace summary", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}},
 			{"name": "gorev_export", "description": "Export tasks", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"format": map[string]interface{}{"type": "string"}}}},
 			{"name": "gorev_import", "description": "Import tasks", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"data": map[string]interface{}{"type": "object"}}, "required": []string{"data"}}},
 			{"name": "gorev_suggestions", "description": "Get AI task suggestions", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"context": map[string]interface{}{"type": "string"}}}},
 			{"name": "gorev_intelligent_create", "description": "AI-powered task creation", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"title": map[string]interface{}{"type": "string", "description": "Task title"}, "description": map[string]interface{}{"type": "string", "description": "Task description"}, "auto_split": map[string]interface{}{"type": "boolean", "description": "Auto-split into subtasks"}, "estimate_time": map[string]interface{}{"type": "boolean", "description": "Estimate task duration"}, "smart_priority": map[string]interface{}{"type": "boolean", "description": "AI-suggested priority"}, "suggest_template": map[string]interface{}{"type": "boolean", "description": "Suggest matching template"}, "project_id": map[string]interface{}{"type": "string", "description": "Project ID"}}, "required": []string{"title"}}},
+			{"name": "gorev_doctor", "description": "Check database integrity and optionally repair it", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"fix": map[string]interface{}{"type": "boolean", "description": "Repair the problems found"}, "vacuum": map[string]interface{}{"type": "boolean", "description": "Compact the database after repairing"}}}},
+			{"name": "gorev_arsiv", "description": "Archive old completed tasks (unified: run|restore|list|settings)", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"action": map[string]interface{}{"type": "string", "enum": []string{"run", "restore", "list", "settings"}}, "task_id": map[string]interface{}{"type": "string", "description": "Task to restore"}, "retention_days": map[string]interface{}{"type": "number"}, "limit": map[string]interface{}{"type": "number"}}, "required": []string{"action"}}},
+			{"name": "gorev_complete", "description": "Complete task, tag, project and template names as you type", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"query": map[string]interface{}{"type": "string"}, "kind": map[string]interface{}{"type": "string", "enum": []string{"all", "task", "tag", "project", "template"}}, "limit": map[string]interface{}{"type": "number"}}}},
+			{"name": "gorev_quick_add", "description": "Create a task from one line like 'Fix login #bug !yuksek due:friday'", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"text": map[string]interface{}{"type": "string"}, "dry_run": map[string]interface{}{"type": "boolean", "description": "Only show the parsed fields"}}, "required": []string{"text"}}},
+			{"name": "gorev_similar", "description": "Find tasks similar to a task or a text", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"task_id": map[string]interface{}{"type": "string"}, "text": map[string]interface{}{"type": "string"}, "limit": map[string]interface{}{"type": "number"}, "min_score": map[string]interface{}{"type": "number"}, "active_only": map[string]interface{}{"type": "boolean"}}}},
+			{"name": "gorev_merge", "description": "Merge a duplicate task into another", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"source_id": map[string]interface{}{"type": "string", "description": "Duplicate task, cancelled after the merge"}, "target_id": map[string]interface{}{"type": "string", "description": "Task that is kept"}, "dry_run": map[string]interface{}{"type": "boolean", "description": "Only preview the merge"}}, "required": []string{"source_id", "target_id"}}},
+			{"name": "gorev_clone", "description": "Deep-clone a task with its subtasks, or a whole project", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"task_id": map[string]interface{}{"type": "string"}, "project_id": map[string]interface{}{"type": "string"}, "target_project_id": map[string]interface{}{"type": "string"}, "project_name": map[string]interface{}{"type": "string"}, "reset_status": map[string]interface{}{"type": "boolean"}, "shift_days": map[string]interface{}{"type": "number"}, "shift_months": map[string]interface{}{"type": "number"}, "include_files": map[string]interface{}{"type": "boolean"}}}},
+			{"name": "gorev_project_template", "description": "Project templates that create whole task trees (unified: list|show|create|delete|apply)", "inputSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"action": map[string]interface{}{"type": "string", "enum": []string{"list", "show", "create", "delete", "apply"}}, "template_id": map[string]interface{}{"type": "string"}, "template": map[string]interface{}{"type": "object"}, "project_id": map[string]interface{}{"type": "string"}, "project_name": map[string]interface{}{"type": "string"}, "start_date": map[string]interface{}{"type": "string"}, "values": map[string]interface{}{"type": "object"}}, "required": []string{"action"}}},
 		}
 		result = map[string]interface{}{
 			"tools": tools,
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/msenol/gorev/internal/gorev"
+	"github.com/msenol/gorev/internal/i18n"
+	"github.com/msenol/gorev/internal/mcp"
+	ws "github.com/msenol/gorev/internal/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMCPBridgeCoversRegisteredTools checks that the bridge, which the proxy sends every
+// call to, lists and dispatches every tool the MCP server registers
+func TestMCPBridgeCoversRegisteredTools(t *testing.T) {
+	vy, err := gorev.YeniVeriYonetici(":memory:", "file://../../internal/veri/migrations")
+	require.NoError(t, err)
+	defer vy.Kapat()
+	iy := gorev.YeniIsYonetici(vy)
+
+	sunucu, err := mcp.YeniMCPSunucu(iy)
+	require.NoError(t, err)
+	response, err := json.Marshal(sunucu.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)))
+	require.NoError(t, err)
+	var registered struct {
+		Result struct {
+			Tools []struct {
+				Name string `json:"name"`
+			} `json:"tools"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(response, &registered))
+	require.NotEmpty(t, registered.Result.Tools)
+
+	server := &APIServer{}
+	handlers := mcp.YeniHandlers(iy)
+	wsCtx := &WorkspaceContext{ID: "bridge-test", IsYonetici: iy, EventEmitter: ws.NewNoOpEventEmitter()}
+	result, err := server.dispatchMCPTool(handlers, "tools/list", nil, wsCtx)
+	require.NoError(t, err)
+	listed := make(map[string]bool)
+	for _, tool := range result.(map[string]interface{})["tools"].([]map[string]interface{}) {
+		listed[tool["name"].(string)] = true
+	}
+
+	// The bridge serves the file watch tools as the unified gorev_file_watch
+	unified := map[string]bool{"gorev_file_watch_add": true, "gorev_file_watch_remove": true, "gorev_file_watch_list": true, "gorev_file_watch_stats": true}
+	for _, tool := range registered.Result.Tools {
+		if unified[tool.Name] {
+			continue
+		}
+		assert.True(t, listed[tool.Name], "%s is missing from the bridge tools/list", tool.Name)
+		if _, err := server.dispatchMCPTool(handlers, tool.Name, map[string]interface{}{}, wsCtx); err != nil {
+			unknown := i18n.T("error.unknownTool", map[string]interface{}{"Tool": tool.Name})
+			assert.NotEqual(t, unknown, err.Error(), "%s is not dispatched by the bridge", tool.Name)
+		}
+	}
+}
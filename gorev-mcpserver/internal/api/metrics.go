@@ -0,0 +1,67 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/msenol/gorev/internal/gorev"
+)
+
+// WriteQueueStats returns the write queue metrics of every open database, keyed by
+// workspace ID. Workspaces sharing the centralized database are reported once as "centralized".
+func (wm *WorkspaceManager) WriteQueueStats() map[string]gorev.WriteQueueStats {
+	wm.mu.RLock()
+	defer wm.mu.RUnlock()
+
+	stats := make(map[string]gorev.WriteQueueStats)
+	if wm.centralizedDB != nil {
+		stats["centralized"] = wm.centralizedDB.WriteQueueStats()
+	}
+	for id, workspace := range wm.workspaces {
+		if workspace.VeriYonetici == nil || workspace.VeriYonetici == wm.centralizedDB {
+			continue
+		}
+		stats[id] = workspace.VeriYonetici.WriteQueueStats()
+	}
+	return stats
+}
+
+// CacheStats returns the lookup cache metrics of every open database, keyed like WriteQueueStats
+func (wm *WorkspaceManager) CacheStats() map[string]gorev.CacheStats {
+	wm.mu.RLock()
+	defer wm.mu.RUnlock()
+
+	stats := make(map[string]gorev.CacheStats)
+	if cache, ok := wm.centralizedData.(*gorev.CachedVeriYonetici); ok {
+		stats["centralized"] = cache.CacheStats()
+	}
+	for id, workspace := range wm.workspaces {
+		if workspace.IsYonetici == nil || workspace.VeriYonetici == wm.centralizedDB {
+			continue
+		}
+		if cache, ok := workspace.IsYonetici.VeriYonetici().(*gorev.CachedVeriYonetici); ok {
+			stats[id] = cache.CacheStats()
+		}
+	}
+	return stats
+}
+
+// getDaemonMetricsHandler reports queue depth and wait times of the database writers
+// and hit rates of the lookup caches
+func (s *APIServer) getDaemonMetricsHandler(c *fiber.Ctx) error {
+	writeQueues := s.workspaceManager.WriteQueueStats()
+	caches := s.workspaceManager.CacheStats()
+	if s.isYonetici != nil {
+		data := s.isYonetici.VeriYonetici()
+		if reporter, ok := data.(interface{ WriteQueueStats() gorev.WriteQueueStats }); ok {
+			writeQueues["default"] = reporter.WriteQueueStats()
+		}
+		if cache, ok := data.(*gorev.CachedVeriYonetici); ok {
+			caches["default"] = cache.CacheStats()
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success":      true,
+		"write_queues": writeQueues,
+		"caches":       caches,
+	})
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/msenol/gorev/internal/gorev"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// projectTemplateManager returns the business logic of the request's workspace if its
+// storage can hold project templates
+func (s *APIServer) projectTemplateManager(c *fiber.Ctx) (*gorev.IsYonetici, error) {
+	iy := s.getIsYoneticiFromContext(c)
+	db, err := iy.VeriYonetici().GetReadDB()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("database access failed: %v", err))
+	}
+	if db == nil {
+		return nil, fiber.NewError(fiber.StatusNotImplemented, i18n.T("error.memoryBackendSQLUnavailable", map[string]interface{}{"Operation": "project templates"}))
+	}
+	return iy, nil
+}
+
+// getProjectTemplateByParam loads the project template named by the :id route
+// parameter, which may also be its alias
+func (s *APIServer) getProjectTemplateByParam(c *fiber.Ctx, iy *gorev.IsYonetici) (*gorev.ProjeTemplate, error) {
+	template, err := iy.ProjeTemplateGetir(s.getContextFromRequest(c), c.Params("id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, err.Error())
+	}
+	return template, nil
+}
+
+// getProjectTemplates lists the project templates by name
+func (s *APIServer) getProjectTemplates(c *fiber.Ctx) error {
+	iy, err := s.projectTemplateManager(c)
+	if err != nil {
+		return err
+	}
+
+	templates, err := iy.ProjeTemplateListele(s.getContextFromRequest(c))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("failed to list project templates: %v", err))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    templates,
+		"total":   len(templates),
+	})
+}
+
+// getProjectTemplate retrieves a project template by ID or alias
+func (s *APIServer) getProjectTemplate(c *fiber.Ctx) error {
+	iy, err := s.projectTemplateManager(c)
+	if err != nil {
+		return err
+	}
+
+	template, err := s.getProjectTemplateByParam(c, iy)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    template,
+	})
+}
+
+// createProjectTemplate saves a new project template; the body is the template itself
+func (s *APIServer) createProjectTemplate(c *fiber.Ctx) error {
+	var template gorev.ProjeTemplate
+	if err := c.BodyParser(&template); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+	}
+
+	iy, err := s.projectTemplateManager(c)
+	if err != nil {
+		return err
+	}
+
+	if err := iy.ProjeTemplateKaydet(s.getContextFromRequest(c), &template); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    template,
+		"message": "Project template created successfully",
+	})
+}
+
+// deleteProjectTemplate deletes a project template; projects created from it stay
+func (s *APIServer) deleteProjectTemplate(c *fiber.Ctx) error {
+	iy, err := s.projectTemplateManager(c)
+	if err != nil {
+		return err
+	}
+
+	template, err := s.getProjectTemplateByParam(c, iy)
+	if err != nil {
+		return err
+	}
+	if err := iy.ProjeTemplateSil(s.getContextFromRequest(c), template.ID); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("failed to delete project template %s: %v", template.ID, err))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Project template deleted successfully",
+	})
+}
+
+// applyProjectTemplate creates the task tree of a project template in a new project,
+// or in body.project_id, in one transaction
+func (s *APIServer) applyProjectTemplate(c *fiber.Ctx) error {
+	var options gorev.ProjeTemplateSecenekleri
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&options); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		}
+	}
+
+	iy, err := s.projectTemplateManager(c)
+	if err != nil {
+		return err
+	}
+
+	template, err := s.getProjectTemplateByParam(c, iy)
+	if err != nil {
+		return err
+	}
+	result, err := iy.ProjeTemplateUygula(s.getContextFromRequest(c), template.ID, options)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+		"message": "Project template applied successfully",
+	})
+}
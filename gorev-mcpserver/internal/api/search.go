@@ -0,0 +1,113 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/gorev"
+)
+
+// searchTasks runs a search with highlights and facet counts
+// Query params: q (query language or free text), limit, sort_by, sort_direction,
+// fuzzy, fuzzy_threshold, include_completed, include_archived
+func (s *APIServer) searchTasks(c *fiber.Ctx) error {
+	iy := s.getIsYoneticiFromContext(c)
+	db, err := iy.VeriYonetici().GetReadDB()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("database access failed: %v", err))
+	}
+
+	options := gorev.SearchOptions{
+		Query:            c.Query("q"),
+		Filters:          make(map[string]interface{}),
+		UseFuzzySearch:   c.QueryBool("fuzzy", true),
+		FuzzyThreshold:   c.QueryFloat("fuzzy_threshold", 0.6),
+		MaxResults:       c.QueryInt("limit", 50),
+		SortBy:           c.Query("sort_by", "relevance"),
+		SortDirection:    c.Query("sort_direction", "desc"),
+		IncludeCompleted: c.QueryBool("include_completed", false),
+		IncludeArchived:  c.QueryBool("include_archived", false),
+	}
+
+	response, err := gorev.NewSearchEngine(iy.VeriYonetici(), db).Search(options)
+	if err != nil {
+		return queryError(c, fiber.StatusInternalServerError, fmt.Errorf("search failed: %w", err))
+	}
+
+	return c.JSON(fiber.Map{
+		"success":       true,
+		"data":          response.Results,
+		"total":         response.TotalCount,
+		"facets":        response.Facets,
+		"suggestions":   response.Suggestions,
+		"used_fuzzy":    response.UsedFuzzy,
+		"query_time_ms": response.QueryTime.Milliseconds(),
+	})
+}
+
+// getSearchHistory returns the most recent searches of the workspace
+// Query params: limit (default 20)
+func (s *APIServer) getSearchHistory(c *fiber.Ctx) error {
+	fpm, err := s.filterProfileManager(c)
+	if err != nil {
+		return err
+	}
+
+	history, err := fpm.GetSearchHistory(c.QueryInt("limit", 20))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("failed to get search history: %v", err))
+	}
+	if history == nil {
+		history = []*gorev.SearchHistoryEntry{}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    history,
+		"total":   len(history),
+	})
+}
+
+// getCompletions returns type-ahead completions from the workspace's in-memory index
+// Query params: q, kind (task, tag, project, template or all), limit
+func (s *APIServer) getCompletions(c *fiber.Ctx) error {
+	iy := s.getIsYoneticiFromContext(c)
+	ctx := s.getContextFromRequest(c)
+
+	kind := c.Query("kind")
+	if kind != "" && !constants.IsValidCompletionKind(kind) {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("invalid kind '%s' (valid: %s)", kind, strings.Join(constants.ValidCompletionKinds, ", ")))
+	}
+
+	completions, err := iy.OtomatikTamamla(ctx, gorev.CompletionOptions{
+		Query: c.Query("q"),
+		Kind:  kind,
+		Limit: c.QueryInt("limit", gorev.DefaultCompletionLimit),
+	})
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("completion failed: %v", err))
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    completions,
+		"total":   len(completions),
+	})
+}
+
+// queryError answers query syntax errors with 400 and the error position, other
+// errors with status
+func queryError(c *fiber.Ctx, status int, err error) error {
+	var syntaxErr *gorev.QuerySyntaxError
+	if errors.As(err, &syntaxErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":    true,
+			"message":  syntaxErr.Error(),
+			"position": syntaxErr.Position,
+		})
+	}
+	return fiber.NewError(status, err.Error())
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/msenol/gorev/internal/gorev"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSearchEndpoint tests highlights, facet counts and query errors of /api/v1/search
+func TestSearchEndpoint(t *testing.T) {
+	server, cleanup := setupBasicTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	proje, err := server.isYonetici.ProjeOlustur(ctx, "Search Project", "")
+	require.NoError(t, err)
+	for title, description := range map[string]string{
+		"Login page redesign": "Users report that login fails",
+		"Login timeout":       "Sessions expire after login",
+		"Logout button":       "Move it to the menu",
+	} {
+		_, err := server.isYonetici.GorevOlustur(ctx, title, description, "yuksek", proje.ID, "", nil)
+		require.NoError(t, err)
+	}
+	_, err = server.isYonetici.GorevOlustur(ctx, "Unrelated", "", "dusuk", "", "", nil)
+	require.NoError(t, err)
+
+	search := func(query string) (int, map[string]json.RawMessage) {
+		req := httptest.NewRequest("GET", "/api/v1/search?"+query, nil)
+		resp, err := server.app.Test(req)
+		require.NoError(t, err)
+		var body map[string]json.RawMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body
+	}
+
+	t.Run("highlights and facets", func(t *testing.T) {
+		status, body := search("q=" + url.QueryEscape("login priority:yuksek") + "&limit=1")
+		require.Equal(t, 200, status)
+
+		var results []gorev.SearchResult
+		require.NoError(t, json.Unmarshal(body["data"], &results))
+		require.Len(t, results, 1)
+		assert.Contains(t, results[0].Highlights["baslik"], "<mark>Login</mark>")
+		assert.Contains(t, results[0].Highlights["aciklama"], "<mark>login</mark>")
+
+		// Facets count every match, not only the returned page
+		var total int
+		require.NoError(t, json.Unmarshal(body["total"], &total))
+		assert.Equal(t, 2, total)
+		var facets gorev.SearchFacets
+		require.NoError(t, json.Unmarshal(body["facets"], &facets))
+		require.Len(t, facets.Project, 1)
+		assert.Equal(t, "Search Project", facets.Project[0].Label)
+		assert.Equal(t, 2, facets.Project[0].Count)
+		require.Len(t, facets.Due, 1)
+		assert.Equal(t, gorev.DueBucketNone, facets.Due[0].Value)
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		status, body := search("q=" + url.QueryEscape("status:done OR"))
+		assert.Equal(t, 400, status)
+		assert.JSONEq(t, "13", string(body["position"]))
+	})
+}
+
+// TestFilterProfileEndpoints tests filter profile CRUD, usage counts and search history
+func TestFilterProfileEndpoints(t *testing.T) {
+	server, cleanup := setupBasicTestServer(t)
+	defer cleanup()
+
+	request := func(method, path, body string) (int, map[string]json.RawMessage) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := server.app.Test(req)
+		require.NoError(t, err)
+		var decoded map[string]json.RawMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+		return resp.StatusCode, decoded
+	}
+	profileOf := func(body map[string]json.RawMessage) gorev.FilterProfile {
+		var profile gorev.FilterProfile
+		require.NoError(t, json.Unmarshal(body["data"], &profile))
+		return profile
+	}
+
+	status, body := request("POST", "/api/v1/filter-profiles",
+		`{"name":"urgent","search_query":"-tag:blocked","filters":{"priority":["yuksek"]}}`)
+	require.Equal(t, 201, status)
+	created := profileOf(body)
+	require.NotEmpty(t, created.ID)
+	path := "/api/v1/filter-profiles/" + created.ID
+
+	t.Run("invalid query", func(t *testing.T) {
+		status, body := request("POST", "/api/v1/filter-profiles", `{"name":"broken","search_query":"tag:a OR"}`)
+		assert.Equal(t, 400, status)
+		assert.JSONEq(t, "7", string(body["position"]))
+
+		status, _ = request("POST", "/api/v1/filter-profiles", `{"search_query":"tag:a"}`)
+		assert.Equal(t, 400, status)
+	})
+
+	t.Run("update keeps omitted fields", func(t *testing.T) {
+		status, body := request("PUT", path, `{"description":"High priority work"}`)
+		require.Equal(t, 200, status)
+		updated := profileOf(body)
+		assert.Equal(t, "urgent", updated.Name)
+		assert.Equal(t, "High priority work", updated.Description)
+		assert.Equal(t, []string{"yuksek"}, updated.Filters.Priority)
+
+		status, _ = request("PUT", "/api/v1/filter-profiles/999999", `{"description":"x"}`)
+		assert.Equal(t, 404, status)
+	})
+
+	t.Run("use and most used", func(t *testing.T) {
+		status, body := request("POST", path+"/use", "")
+		require.Equal(t, 200, status)
+		assert.Equal(t, 1, profileOf(body).UseCount)
+		assert.JSONEq(t, `"priority:yuksek (-tag:blocked)"`, string(body["query"]))
+
+		status, body = request("GET", "/api/v1/filter-profiles/most-used?limit=1", "")
+		require.Equal(t, 200, status)
+		var profiles []gorev.FilterProfile
+		require.NoError(t, json.Unmarshal(body["data"], &profiles))
+		require.Len(t, profiles, 1)
+		assert.Equal(t, created.ID, profiles[0].ID)
+	})
+
+	t.Run("list and search", func(t *testing.T) {
+		status, body := request("GET", "/api/v1/filter-profiles?q=priority+work", "")
+		require.Equal(t, 200, status)
+		assert.JSONEq(t, "1", string(body["total"]))
+
+		status, body = request("GET", "/api/v1/filter-profiles?q=nothing-like-this", "")
+		require.Equal(t, 200, status)
+		assert.JSONEq(t, "[]", string(body["data"]))
+	})
+
+	t.Run("search history", func(t *testing.T) {
+		status, _ := request("GET", "/api/v1/search?q=deployment", "")
+		require.Equal(t, 200, status)
+
+		status, body := request("GET", "/api/v1/search/history?limit=1", "")
+		require.Equal(t, 200, status)
+		var history []gorev.SearchHistoryEntry
+		require.NoError(t, json.Unmarshal(body["data"], &history))
+		require.Len(t, history, 1)
+		assert.Equal(t, "deployment", history[0].Query)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		status, _ := request("DELETE", path, "")
+		require.Equal(t, 200, status)
+		status, _ = request("GET", path, "")
+		assert.Equal(t, 404, status)
+		status, _ = request("GET", "/api/v1/filter-profiles/abc", "")
+		assert.Equal(t, 400, status)
+	})
+}
+
+// TestCompleteEndpoint tests /api/v1/complete
+func TestCompleteEndpoint(t *testing.T) {
+	server, cleanup := setupBasicTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	proje, err := server.isYonetici.ProjeOlustur(ctx, "Completion Project", "")
+	require.NoError(t, err)
+	gorev1, err := server.isYonetici.GorevOlustur(ctx, "Sürüm notlarını yaz", "", "orta", proje.ID, "", []string{"release"})
+	require.NoError(t, err)
+
+	complete := func(query string) (int, []gorev.Completion) {
+		req := httptest.NewRequest("GET", "/api/v1/complete?"+query, nil)
+		resp, err := server.app.Test(req)
+		require.NoError(t, err)
+		var body struct {
+			Data []gorev.Completion `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body.Data
+	}
+
+	status, completions := complete("q=surum&kind=task")
+	require.Equal(t, 200, status)
+	require.Len(t, completions, 1)
+	assert.Equal(t, gorev1.ID, completions[0].Value)
+
+	status, completions = complete("q=rel&kind=tag")
+	require.Equal(t, 200, status)
+	require.Len(t, completions, 1)
+	assert.Equal(t, "release", completions[0].Value)
+	assert.Equal(t, 1, completions[0].Count)
+
+	status, completions = complete("q=" + url.QueryEscape("Completion P") + "&kind=project")
+	require.Equal(t, 200, status)
+	require.Len(t, completions, 1)
+	assert.Equal(t, proje.ID, completions[0].Value)
+
+	req := httptest.NewRequest("GET", "/api/v1/complete?kind=person", nil)
+	resp, err := server.app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
@@ -35,6 +35,11 @@ func (s *APIServer) SetMigrationsFS(migrationsFS fs.FS) {
 	s.workspaceManager.SetMigrationsFS(migrationsFS)
 }
 
+// SetEphemeral makes registered workspaces use in-memory storage (gorev serve --ephemeral)
+func (s *APIServer) SetEphemeral(ephemeral bool) {
+	s.workspaceManager.SetEphemeral(ephemeral)
+}
+
 // NewAPIServer creates a new API server instance
 func NewAPIServer(port string, isYonetici *gorev.IsYonetici) *APIServer {
 	if port == "" {
@@ -143,21 +148,47 @@ func (s *APIServer) setupRoutes() {
 	api.Get("/tasks", s.getTasks)
 	api.Post("/tasks", s.createTask)
 	api.Get("/tasks/:id", s.getTask)
+	api.Get("/tasks/:id/similar", s.getSimilarTasks)
+	api.Post("/tasks/:id/merge", s.mergeTask)
+	api.Post("/tasks/:id/clone", s.cloneTask)
 	api.Put("/tasks/:id", s.updateTask)
 	api.Delete("/tasks/:id", s.deleteTask)
 	api.Post("/tasks/from-template", s.createTaskFromTemplate)
+	api.Post("/tasks/quick-add", s.createTaskQuickAdd)
+
+	// Search routes
+	api.Get("/search", s.searchTasks)
+	api.Get("/search/history", s.getSearchHistory)
+	api.Get("/complete", s.getCompletions)
+
+	// Filter profile routes
+	api.Get("/filter-profiles", s.getFilterProfiles)
+	api.Post("/filter-profiles", s.createFilterProfile)
+	api.Get("/filter-profiles/most-used", s.getMostUsedFilterProfiles)
+	api.Get("/filter-profiles/:id", s.getFilterProfile)
+	api.Put("/filter-profiles/:id", s.updateFilterProfile)
+	api.Delete("/filter-profiles/:id", s.deleteFilterProfile)
+	api.Post("/filter-profiles/:id/use", s.useFilterProfile)
 
 	// Project routes
 	api.Get("/projects", s.getProjects)
 	api.Post("/projects", s.createProject)
 	api.Get("/projects/:id", s.getProject)
 	api.Get("/projects/:id/tasks", s.getProjectTasks)
+	api.Post("/projects/:id/clone", s.cloneProject)
 	api.Put("/projects/:id/activate", s.activateProject)
 
 	// Template routes
 	api.Get("/templates", s.getTemplates)
 	api.Post("/template/init", s.initializeTemplates)
 
+	// Project template routes
+	api.Get("/project-templates", s.getProjectTemplates)
+	api.Post("/project-templates", s.createProjectTemplate)
+	api.Get("/project-templates/:id", s.getProjectTemplate)
+	api.Delete("/project-templates/:id", s.deleteProjectTemplate)
+	api.Post("/project-templates/:id/apply", s.applyProjectTemplate)
+
 	// Summary routes
 	api.Get("/summary", s.getSummary)
 
@@ -191,11 +222,15 @@ func (s *APIServer) setupRoutes() {
 	api.Post("/daemon/clients/register", s.registerClientHandler)
 	api.Post("/daemon/clients/unregister", s.unregisterClientHandler)
 	api.Post("/daemon/heartbeat", s.heartbeatHandler)
+	api.Get("/daemon/metrics", s.getDaemonMetricsHandler)
 
 	// Export/Import routes
 	api.Post("/export", s.exportData)
 	api.Post("/import", s.importData)
 
+	// Calendar feed route (token protected, for calendar app subscriptions)
+	api.Get("/calendar.ics", s.getCalendarFeed)
+
 	// MCP Protocol routes (for AI assistant integration)
 	api.Post("/mcp/*", s.handleMCPToolCall)
 
@@ -255,6 +290,14 @@ func (s *APIServer) getTasks(c *fiber.Ctx) error {
 	if offset := c.QueryInt("offset", 0); offset >= 0 {
 		filters["offset"] = offset
 	}
+	// Structured query, e.g. ?q=status:pending priority>=orta due<+7d
+	if q := c.Query("q"); q != "" {
+		query, err := gorev.ParseQuery(q)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		filters["query"] = query
+	}
 
 	// Call business logic with workspace context
 	iy := s.getIsYoneticiFromContext(c)
@@ -421,12 +464,185 @@ func (s *APIServer) createTaskFromTemplate(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success":             true,
+		"data":                gorev,
+		"possible_duplicates": possibleDuplicates(ctx, iy, gorev.Title, gorev.Description, gorev.ID),
+		"message":             "Task created from template successfully",
+	})
+}
+
+// createTaskQuickAdd creates a task from one line of quick-add syntax, e.g.
+// "Fix login timeout #auth !yuksek @BackendAPI due:friday est:3h"
+func (s *APIServer) createTaskQuickAdd(c *fiber.Ctx) error {
+	var req struct {
+		Text   string `json:"text"`
+		DryRun bool   `json:"dry_run"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+	if strings.TrimSpace(req.Text) == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "text is required")
+	}
+
+	iy := s.getIsYoneticiFromContext(c)
+	ctx := s.getContextFromRequest(c)
+	quickAdd, err := iy.HizliEkleAyristir(ctx, req.Text)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if req.DryRun {
+		return c.JSON(fiber.Map{
+			"success":             true,
+			"parsed":              quickAdd,
+			"possible_duplicates": possibleDuplicates(ctx, iy, quickAdd.Title, quickAdd.Description, ""),
+		})
+	}
+
+	gorev, err := iy.HizliGorevEkle(ctx, quickAdd)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("failed to create task: %v", err))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success":             true,
+		"data":                gorev,
+		"parsed":              quickAdd,
+		"possible_duplicates": possibleDuplicates(ctx, iy, gorev.Title, gorev.Description, gorev.ID),
+		"message":             "Task created successfully",
+	})
+}
+
+// getSimilarTasks lists the tasks most similar to a task
+func (s *APIServer) getSimilarTasks(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Task ID is required")
+	}
+
+	iy := s.getIsYoneticiFromContext(c)
+	ctx := s.getContextFromRequest(c)
+	if _, err := iy.VeriYonetici().GorevGetir(ctx, id); err != nil {
+		return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("failed to get task with ID %s: %v", id, err))
+	}
+
+	similar, err := iy.BenzerGorevler(ctx, gorev.SimilarityOptions{
+		TaskID:     id,
+		Limit:      c.QueryInt("limit", gorev.DefaultSimilarLimit),
+		MinScore:   c.QueryFloat("min_score", 0),
+		ActiveOnly: c.QueryBool("active_only", false),
+	})
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("similarity search failed: %v", err))
+	}
+
+	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    gorev,
-		"message": "Task created from template successfully",
+		"data":    similar,
+		"total":   len(similar),
+	})
+}
+
+// mergeTask merges the task :id into target_id, or previews the merge with dry_run
+func (s *APIServer) mergeTask(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var req struct {
+		TargetID string `json:"target_id"`
+		DryRun   bool   `json:"dry_run"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+	if req.TargetID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "target_id is required")
+	}
+
+	iy := s.getIsYoneticiFromContext(c)
+	ctx := s.getContextFromRequest(c)
+	for _, taskID := range []string{id, req.TargetID} {
+		if _, err := iy.VeriYonetici().GorevGetir(ctx, taskID); err != nil {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("failed to get task with ID %s: %v", taskID, err))
+		}
+	}
+
+	result, err := iy.GorevBirlestir(ctx, id, req.TargetID, req.DryRun)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	message := "Task merged successfully"
+	if req.DryRun {
+		message = "Merge preview; nothing was changed"
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+		"message": message,
+	})
+}
+
+// cloneTask copies a task with its subtasks, tags and internal dependencies
+func (s *APIServer) cloneTask(c *fiber.Ctx) error {
+	var options gorev.KlonlamaSecenekleri
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&options); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	iy := s.getIsYoneticiFromContext(c)
+	ctx := s.getContextFromRequest(c)
+	if _, err := iy.VeriYonetici().GorevGetir(ctx, c.Params("id")); err != nil {
+		return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("failed to get task with ID %s: %v", c.Params("id"), err))
+	}
+
+	result, err := iy.GorevKlonla(ctx, c.Params("id"), options)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+		"message": "Task cloned successfully",
 	})
 }
 
+// cloneProject copies a project with all its tasks
+func (s *APIServer) cloneProject(c *fiber.Ctx) error {
+	var options gorev.KlonlamaSecenekleri
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&options); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	iy := s.getIsYoneticiFromContext(c)
+	ctx := s.getContextFromRequest(c)
+	if _, err := iy.VeriYonetici().ProjeGetir(ctx, c.Params("id")); err != nil {
+		return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("failed to get project with ID %s: %v", c.Params("id"), err))
+	}
+
+	result, err := iy.ProjeKlonla(ctx, c.Params("id"), options)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+		"message": "Project cloned successfully",
+	})
+}
+
+// possibleDuplicates returns the open tasks that look like duplicates of a new task;
+// the warning is best effort, so index errors yield an empty list
+func possibleDuplicates(ctx context.Context, iy *gorev.IsYonetici, title, description, excludeID string) []gorev.SimilarTask {
+	duplicates, err := iy.OlasiKopyalar(ctx, title, description, excludeID)
+	if err != nil || duplicates == nil {
+		return []gorev.SimilarTask{}
+	}
+	return duplicates
+}
+
 // getIsYoneticiFromContext extracts workspace-specific IsYonetici from Fiber context
 // Falls back to global isYonetici if workspace context is not available (backward compatibility)
 func (s *APIServer) getIsYoneticiFromContext(c *fiber.Ctx) *gorev.IsYonetici {
@@ -147,6 +147,245 @@ func TestCreateTaskFromTemplate(t *testing.T) {
 	assert.True(t, resp.StatusCode >= 200 && resp.StatusCode < 600)
 }
 
+// TestCreateTaskQuickAdd tests creating a task from quick-add syntax
+func TestCreateTaskQuickAdd(t *testing.T) {
+	server, projectID, cleanup := setupComprehensiveTestServer(t)
+	defer cleanup()
+
+	post := func(payload map[string]interface{}) (int, map[string]json.RawMessage) {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest("POST", "/api/v1/tasks/quick-add", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := server.app.Test(req)
+		require.NoError(t, err)
+		var result map[string]json.RawMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp.StatusCode, result
+	}
+
+	status, result := post(map[string]interface{}{"text": "Fix login #auth !yuksek @TestProject due:2030-01-15 est:90m"})
+	require.Equal(t, 201, status)
+	var task gorev.Gorev
+	require.NoError(t, json.Unmarshal(result["data"], &task))
+	assert.Equal(t, "Fix login", task.Title)
+	assert.Equal(t, projectID, task.ProjeID)
+	assert.Equal(t, constants.PriorityHigh, task.Priority)
+	require.NotNil(t, task.DueDate)
+	assert.Equal(t, "2030-01-15", task.DueDate.Format(constants.DateFormatISO))
+	var parsed gorev.QuickAdd
+	require.NoError(t, json.Unmarshal(result["parsed"], &parsed))
+	assert.Equal(t, 1.5, parsed.EstimatedHours)
+	assert.Equal(t, []string{"auth"}, parsed.Tags)
+
+	// A dry run only parses
+	status, result = post(map[string]interface{}{"text": "Write docs tomorrow", "dry_run": true})
+	require.Equal(t, 200, status)
+	assert.Nil(t, result["data"])
+	require.NoError(t, json.Unmarshal(result["parsed"], &parsed))
+	assert.Equal(t, "Write docs", parsed.Title)
+	assert.NotNil(t, parsed.DueDate)
+
+	status, _ = post(map[string]interface{}{"text": "Fix login !someday"})
+	assert.Equal(t, 400, status)
+	status, _ = post(map[string]interface{}{"text": "Fix login @Missing"})
+	assert.Equal(t, 400, status)
+}
+
+// TestGetSimilarTasks tests similar tasks and duplicate warnings
+func TestGetSimilarTasks(t *testing.T) {
+	server, _, cleanup := setupComprehensiveTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	var ids []string
+	for _, title := range []string{"Fix login timeout on mobile", "Login timeout fix", "Write release notes"} {
+		task, err := server.isYonetici.HizliGorevEkle(ctx, &gorev.QuickAdd{Title: title})
+		require.NoError(t, err)
+		ids = append(ids, task.ID)
+	}
+
+	get := func(url string) (int, map[string]json.RawMessage) {
+		resp, err := server.app.Test(httptest.NewRequest("GET", url, nil))
+		require.NoError(t, err)
+		var result map[string]json.RawMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp.StatusCode, result
+	}
+
+	status, result := get("/api/v1/tasks/" + ids[0] + "/similar")
+	require.Equal(t, 200, status)
+	var similar []gorev.SimilarTask
+	require.NoError(t, json.Unmarshal(result["data"], &similar))
+	require.Len(t, similar, 1)
+	assert.Equal(t, ids[1], similar[0].Task.ID)
+	assert.Greater(t, similar[0].Score, gorev.DuplicateSimilarityThreshold)
+
+	status, _ = get("/api/v1/tasks/missing-id/similar")
+	assert.Equal(t, 404, status)
+
+	// Quick-add reports open look-alikes
+	body, _ := json.Marshal(map[string]interface{}{"text": "Login timeout on mobile", "dry_run": true})
+	req := httptest.NewRequest("POST", "/api/v1/tasks/quick-add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := server.app.Test(req)
+	require.NoError(t, err)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.NoError(t, json.Unmarshal(result["possible_duplicates"], &similar))
+	assert.Len(t, similar, 2)
+}
+
+// TestMergeTask tests merging a duplicate task into another
+func TestMergeTask(t *testing.T) {
+	server, _, cleanup := setupComprehensiveTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	target, err := server.isYonetici.HizliGorevEkle(ctx, &gorev.QuickAdd{Title: "Fix login timeout", Tags: []string{"auth"}})
+	require.NoError(t, err)
+	source, err := server.isYonetici.HizliGorevEkle(ctx, &gorev.QuickAdd{Title: "Login times out", Description: "After 5 minutes", Tags: []string{"bug"}})
+	require.NoError(t, err)
+
+	merge := func(sourceID string, payload map[string]interface{}) (int, map[string]json.RawMessage) {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest("POST", "/api/v1/tasks/"+sourceID+"/merge", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := server.app.Test(req)
+		require.NoError(t, err)
+		var result map[string]json.RawMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp.StatusCode, result
+	}
+
+	status, result := merge(source.ID, map[string]interface{}{"target_id": target.ID, "dry_run": true})
+	require.Equal(t, 200, status)
+	var merged gorev.BirlestirmeSonucu
+	require.NoError(t, json.Unmarshal(result["data"], &merged))
+	assert.True(t, merged.DryRun)
+	assert.Equal(t, []string{"bug"}, merged.AddedTags)
+	assert.Contains(t, merged.Target.Description, "After 5 minutes")
+	_, err = server.isYonetici.VeriYonetici().GorevGetir(ctx, source.ID)
+	assert.NoError(t, err, "dry run must not remove the source")
+
+	status, result = merge(source.ID, map[string]interface{}{"target_id": target.ID})
+	require.Equal(t, 200, status)
+	require.NoError(t, json.Unmarshal(result["data"], &merged))
+	assert.False(t, merged.DryRun)
+	assert.NotEmpty(t, merged.DuplicateLinkID)
+	assert.Len(t, merged.Target.Tags, 2)
+	_, err = server.isYonetici.VeriYonetici().GorevGetir(ctx, source.ID)
+	assert.Error(t, err, "the source moves to the archive")
+
+	status, _ = merge(source.ID, map[string]interface{}{"target_id": target.ID})
+	assert.Equal(t, 404, status)
+	status, _ = merge(target.ID, map[string]interface{}{"target_id": target.ID})
+	assert.Equal(t, 400, status)
+	status, _ = merge(target.ID, map[string]interface{}{})
+	assert.Equal(t, 400, status)
+}
+
+func TestCloneTaskAndProject(t *testing.T) {
+	server, projectID, cleanup := setupComprehensiveTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	parent, err := server.isYonetici.HizliGorevEkle(ctx, &gorev.QuickAdd{Title: "Release 1.0", Tags: []string{"release"}})
+	require.NoError(t, err)
+	_, err = server.isYonetici.AltGorevOlustur(ctx, parent.ID, "Write release notes", "", constants.PriorityMedium, "", nil)
+	require.NoError(t, err)
+
+	clone := func(path string, payload map[string]interface{}) (int, gorev.KlonlamaSonucu) {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest("POST", "/api/v1/"+path+"/clone", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := server.app.Test(req)
+		require.NoError(t, err)
+		var result struct {
+			Data gorev.KlonlamaSonucu `json:"data"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		return resp.StatusCode, result.Data
+	}
+
+	status, result := clone("tasks/"+parent.ID, map[string]interface{}{"reset_status": true, "due_date_shift_days": 7})
+	require.Equal(t, 201, status)
+	assert.Equal(t, 2, result.Tasks)
+	assert.Equal(t, 1, result.Tags)
+	require.NotNil(t, result.Root)
+	assert.NotEqual(t, parent.ID, result.Root.ID)
+	assert.Equal(t, "Release 1.0", result.Root.Title)
+
+	status, result = clone("projects/"+projectID, map[string]interface{}{"project_name": "Release 2.0"})
+	require.Equal(t, 201, status)
+	require.NotNil(t, result.Project)
+	assert.Equal(t, "Release 2.0", result.Project.Name)
+
+	status, _ = clone("tasks/missing", nil)
+	assert.Equal(t, 404, status)
+	status, _ = clone("projects/missing", nil)
+	assert.Equal(t, 404, status)
+}
+
+func TestProjectTemplates(t *testing.T) {
+	server, projectID, cleanup := setupComprehensiveTestServer(t)
+	defer cleanup()
+
+	send := func(method, path string, payload interface{}) (int, map[string]json.RawMessage) {
+		var body []byte
+		if payload != nil {
+			body, _ = json.Marshal(payload)
+		}
+		req := httptest.NewRequest(method, "/api/v1/project-templates"+path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := server.app.Test(req)
+		require.NoError(t, err)
+		var result map[string]json.RawMessage
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		return resp.StatusCode, result
+	}
+
+	template := map[string]interface{}{
+		"name":   "Release {{version}}",
+		"alias":  "release",
+		"fields": []map[string]interface{}{{"name": "version", "type": "text", "required": true}},
+		"tasks": []map[string]interface{}{
+			{"key": "freeze", "title": "Freeze {{version}}", "due": "T+1d",
+				"subtasks": []map[string]interface{}{{"title": "Cut branch"}}},
+			{"key": "publish", "title": "Publish {{version}}", "depends_on": []string{"freeze"}},
+		},
+	}
+	status, _ := send("POST", "", template)
+	require.Equal(t, 201, status)
+	status, _ = send("POST", "", map[string]interface{}{"name": "Empty"})
+	assert.Equal(t, 400, status)
+
+	status, result := send("GET", "", nil)
+	require.Equal(t, 200, status)
+	assert.Equal(t, "1", string(result["total"]))
+	status, _ = send("GET", "/release", nil)
+	assert.Equal(t, 200, status)
+
+	status, result = send("POST", "/release/apply", map[string]interface{}{"values": map[string]string{"version": "3.0"}})
+	require.Equal(t, 201, status)
+	var applied gorev.ProjeTemplateSonucu
+	require.NoError(t, json.Unmarshal(result["data"], &applied))
+	assert.True(t, applied.ProjectCreated)
+	assert.Equal(t, "Release 3.0", applied.Project.Name)
+	assert.Equal(t, 3, applied.Tasks)
+	assert.Equal(t, 1, applied.Links)
+
+	status, result = send("POST", "/release/apply", map[string]interface{}{"project_id": projectID, "values": map[string]string{"version": "3.1"}})
+	require.Equal(t, 201, status)
+	require.NoError(t, json.Unmarshal(result["data"], &applied))
+	assert.Equal(t, projectID, applied.Project.ID)
+
+	status, _ = send("POST", "/release/apply", nil)
+	assert.Equal(t, 400, status, "the required version is missing")
+	status, _ = send("DELETE", "/release", nil)
+	assert.Equal(t, 200, status)
+	status, _ = send("POST", "/release/apply", nil)
+	assert.Equal(t, 404, status)
+}
+
 // TestGetProject tests getting a single project
 func TestGetProject(t *testing.T) {
 	server, projectID, cleanup := setupComprehensiveTestServer(t)
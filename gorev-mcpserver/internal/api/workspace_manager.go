@@ -22,7 +22,9 @@ type WorkspaceManager struct {
 	migrationsFS        fs.FS                        // Embedded migrations filesystem (optional)
 	wsHub               *ws.Hub                      // WebSocket hub for real-time updates
 	centralizedDB       *gorev.VeriYonetici          // Shared DB for centralized mode
+	centralizedData     gorev.VeriYoneticiInterface  // centralizedDB behind the shared lookup cache
 	centralizedDBInitMu sync.Once                    // Ensures centralized DB is initialized once
+	ephemeral           bool                         // Keep workspace data in memory (gorev serve --ephemeral)
 	mu                  sync.RWMutex
 }
 
@@ -40,6 +42,14 @@ func (wm *WorkspaceManager) SetMigrationsFS(migrationsFS fs.FS) {
 	wm.migrationsFS = migrationsFS
 }
 
+// SetEphemeral makes newly registered workspaces keep their data in memory instead of
+// a .gorev/gorev.db file in the workspace folder
+func (wm *WorkspaceManager) SetEphemeral(ephemeral bool) {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+	wm.ephemeral = ephemeral
+}
+
 // initCentralizedDB initializes the shared database for centralized mode
 func (wm *WorkspaceManager) initCentralizedDB() error {
 	var initErr error
@@ -81,7 +91,9 @@ func (wm *WorkspaceManager) initCentralizedDB() error {
 		}
 		if err != nil {
 			initErr = fmt.Errorf("failed to initialize centralized database: %w", err)
+			return
 		}
+		wm.centralizedData = withLookupCache(wm.centralizedDB)
 	})
 	return initErr
 }
@@ -122,7 +134,7 @@ func (wm *WorkspaceManager) RegisterWorkspaceByID(workspaceID string, name strin
 
 	// Create IsYonetici with the shared centralized DB
 	// The workspace_id filtering will be done at query level
-	isYonetici := gorev.YeniIsYoneticiWithWorkspaceID(wm.centralizedDB, workspaceID)
+	isYonetici := gorev.YeniIsYoneticiWithWorkspaceID(wm.centralizedData, workspaceID)
 
 	// Get task count for this workspace
 	taskCount, _ := wm.getTaskCount(isYonetici)
@@ -176,6 +188,10 @@ func (wm *WorkspaceManager) RegisterWorkspace(path string, name string) (*Worksp
 		name = filepath.Base(absPath)
 	}
 
+	if wm.ephemeral {
+		return wm.registerEphemeralWorkspace(workspaceID, name, absPath), nil
+	}
+
 	// Determine database path (prefer workspace-local .gorev folder)
 	dbPath := filepath.Join(absPath, ".gorev", "gorev.db")
 
@@ -218,7 +234,7 @@ func (wm *WorkspaceManager) RegisterWorkspace(path string, name string) (*Worksp
 	}
 
 	// Initialize business logic manager
-	isYonetici := gorev.YeniIsYonetici(veriYonetici)
+	isYonetici := gorev.YeniIsYonetici(withLookupCache(veriYonetici))
 
 	// Get task count
 	taskCount, _ := wm.getTaskCount(isYonetici)
@@ -241,6 +257,43 @@ func (wm *WorkspaceManager) RegisterWorkspace(path string, name string) (*Worksp
 	return workspace, nil
 }
 
+// registerEphemeralWorkspace registers a workspace backed by in-memory storage; nothing
+// is written to the workspace folder. Callers must hold wm.mu.
+func (wm *WorkspaceManager) registerEphemeralWorkspace(workspaceID, name, absPath string) *WorkspaceContext {
+	var eventEmitter ws.EventEmitter
+	if wm.wsHub != nil {
+		eventEmitter = ws.NewHubEventEmitter(wm.wsHub)
+	} else {
+		eventEmitter = ws.NewNoOpEventEmitter()
+	}
+
+	// VeriYonetici stays nil: there is no SQLite database to back up, measure or close
+	isYonetici := gorev.YeniIsYonetici(gorev.NewMemoryVeriYoneticiWithEventEmitter(eventEmitter, workspaceID))
+
+	workspace := &WorkspaceContext{
+		ID:           workspaceID,
+		Name:         name,
+		Path:         absPath,
+		IsYonetici:   isYonetici,
+		EventEmitter: eventEmitter,
+		LastAccessed: time.Now(),
+		CreatedAt:    time.Now(),
+	}
+
+	wm.workspaces[workspaceID] = workspace
+	return workspace
+}
+
+// withLookupCache puts the read-through cache configured by GOREV_CACHE_SIZE and
+// GOREV_CACHE_TTL in front of veriYonetici
+func withLookupCache(veriYonetici *gorev.VeriYonetici) gorev.VeriYoneticiInterface {
+	cfg := config.GetGlobalConfig()
+	if cfg.CacheSize <= 0 {
+		return veriYonetici
+	}
+	return gorev.NewCachedVeriYonetici(veriYonetici, gorev.CacheOptions{MaxEntries: cfg.CacheSize, TTL: cfg.CacheTTL})
+}
+
 // GetWorkspace retrieves a workspace by ID
 // Returns any to satisfy middleware.WorkspaceGetter interface
 func (wm *WorkspaceManager) GetWorkspace(workspaceID string) (any, error) {
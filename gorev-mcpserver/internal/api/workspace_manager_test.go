@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"sync"
@@ -514,3 +515,39 @@ func TestWorkspaceContextInterface(t *testing.T) {
 		t.Errorf("WorkspaceInfo Name mismatch: expected %s, got %s", ws.Name, info.Name)
 	}
 }
+
+func TestRegisterWorkspace_Ephemeral(t *testing.T) {
+	wm := NewWorkspaceManager()
+	wm.SetEphemeral(true)
+	workspaceDir := setupTestWorkspaceDir(t)
+
+	ws, err := wm.RegisterWorkspace(workspaceDir, "Sandbox")
+	if err != nil {
+		t.Fatalf("RegisterWorkspace failed: %v", err)
+	}
+	if ws.IsYonetici == nil {
+		t.Fatal("Expected IsYonetici to be initialized")
+	}
+	if ws.VeriYonetici != nil || ws.DatabasePath != "" {
+		t.Error("Expected no SQLite database for an ephemeral workspace")
+	}
+	if _, err := os.Stat(filepath.Join(workspaceDir, ".gorev")); !os.IsNotExist(err) {
+		t.Error("Expected no .gorev directory in an ephemeral workspace")
+	}
+
+	if _, err := ws.IsYonetici.ProjeOlustur(context.Background(), "Deneme", ""); err != nil {
+		t.Fatalf("ProjeOlustur failed: %v", err)
+	}
+	again, err := wm.RegisterWorkspace(workspaceDir, "Sandbox")
+	if err != nil {
+		t.Fatalf("RegisterWorkspace (again) failed: %v", err)
+	}
+	projeler, err := again.IsYonetici.ProjeListele(context.Background())
+	if err != nil || len(projeler) != 1 {
+		t.Errorf("Expected the workspace to keep its data, got %d projects (err: %v)", len(projeler), err)
+	}
+
+	if err := wm.CloseAll(); err != nil {
+		t.Errorf("CloseAll failed: %v", err)
+	}
+}
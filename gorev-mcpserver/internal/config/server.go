@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"sync"
+	"time"
 )
 
 // ServerMode defines how the server handles workspace databases
@@ -33,6 +35,33 @@ type ServerConfig struct {
 	// AllowLocalPaths enables path-based workspace creation (security)
 	// When false, only workspace_id based operations are allowed
 	AllowLocalPaths bool
+
+	// CalendarFeedToken protects the /api/v1/calendar.ics feed
+	// When empty, the calendar feed is disabled
+	CalendarFeedToken string
+
+	// BackupInterval is the time between scheduled database backups of the daemon
+	// When zero, scheduled backups are disabled
+	BackupInterval time.Duration
+
+	// BackupDir is where scheduled backups are written
+	// Default: a backups/ directory next to each database
+	BackupDir string
+
+	// BackupKeepDaily and BackupKeepWeekly form the backup rotation policy
+	BackupKeepDaily  int
+	BackupKeepWeekly int
+
+	// ArchiveInterval is the time between runs of the per-workspace archive policy
+	// When zero, completed tasks are only archived on request
+	ArchiveInterval time.Duration
+
+	// CacheSize is the number of task and project lookups cached per database
+	// When zero or negative, the read-through cache is disabled
+	CacheSize int
+
+	// CacheTTL is how long a cached lookup is served
+	CacheTTL time.Duration
 }
 
 var (
@@ -65,7 +94,31 @@ func DefaultConfig() *ServerConfig {
 		CentralizedDBPath: dbPath,
 		Port:              port,
 		AllowLocalPaths:   mode == ModeLocal,
+		CalendarFeedToken: os.Getenv("GOREV_CALENDAR_TOKEN"),
+		BackupInterval:    envDuration("GOREV_BACKUP_INTERVAL", 0),
+		BackupDir:         os.Getenv("GOREV_BACKUP_DIR"),
+		BackupKeepDaily:   envInt("GOREV_BACKUP_KEEP_DAILY", 7),
+		BackupKeepWeekly:  envInt("GOREV_BACKUP_KEEP_WEEKLY", 4),
+		ArchiveInterval:   envDuration("GOREV_ARCHIVE_INTERVAL", 24*time.Hour),
+		CacheSize:         envInt("GOREV_CACHE_SIZE", 5000),
+		CacheTTL:          envDuration("GOREV_CACHE_TTL", 30*time.Second),
+	}
+}
+
+// envDuration reads a duration such as "24h" from the environment
+func envDuration(name string, fallback time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(name)); err == nil {
+		return value
+	}
+	return fallback
+}
+
+// envInt reads an integer from the environment
+func envInt(name string, fallback int) int {
+	if value, err := strconv.Atoi(os.Getenv(name)); err == nil {
+		return value
 	}
+	return fallback
 }
 
 // SetGlobalConfig sets the global server configuration
@@ -34,6 +34,9 @@ const (
 
 	// DependencyTypeDependsOn represents a depends-on dependency
 	DependencyTypeDependsOn = "depends_on"
+
+	// DependencyTypeDuplicates links a task merged away as a duplicate to the task it was merged into
+	DependencyTypeDuplicates = "duplicates"
 )
 
 // Common task limits and defaults
@@ -264,6 +267,16 @@ func IsValidDependencyType(depType string) bool {
 	return false
 }
 
+// IsValidCompletionKind checks if a given completion kind is valid
+func IsValidCompletionKind(kind string) bool {
+	for _, validKind := range ValidCompletionKinds {
+		if kind == validKind {
+			return true
+		}
+	}
+	return false
+}
+
 // Template-related constants for form options
 var (
 	// ValidEnvironments for deployment environments in templates
@@ -365,10 +378,26 @@ const (
 	ActionCreateSubtask = "create_subtask"
 	ActionChangeParent  = "change_parent"
 
+	// Archive actions
+	ActionRun      = "run"
+	ActionRestore  = "restore"
+	ActionSettings = "settings"
+
+	// Project template actions
+	ActionCreate = "create"
+	ActionApply  = "apply"
+
 	// Search modes
 	ModeNLP      = "nlp"
 	ModeAdvanced = "advanced"
 	ModeHistory  = "history"
+
+	// Completion kinds
+	CompletionKindAll      = "all"
+	CompletionKindTask     = "task"
+	CompletionKindTag      = "tag"
+	CompletionKindProject  = "project"
+	CompletionKindTemplate = "template"
 )
 
 // Valid action sets for unified tool validation
@@ -393,4 +422,13 @@ var (
 
 	// ValidSearchModes for gorev_search tool
 	ValidSearchModes = []string{ModeNLP, ModeAdvanced, ModeHistory}
+
+	// ValidArchiveActions for gorev_arsiv tool
+	ValidArchiveActions = []string{ActionRun, ActionRestore, ActionList, ActionSettings}
+
+	// ValidProjectTemplateActions for gorev_project_template tool
+	ValidProjectTemplateActions = []string{ActionList, ActionShow, ActionCreate, ActionDelete, ActionApply}
+
+	// ValidCompletionKinds for gorev_complete tool
+	ValidCompletionKinds = []string{CompletionKindAll, CompletionKindTask, CompletionKindTag, CompletionKindProject, CompletionKindTemplate}
 )
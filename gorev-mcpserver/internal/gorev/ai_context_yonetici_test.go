@@ -198,6 +198,14 @@ func (m *MockVeriYoneticiAI) AltGorevleriGetir(ctx context.Context, parentID str
 	return args.Get(0).([]*Gorev), args.Error(1)
 }
 
+func (m *MockVeriYoneticiAI) AltGorevleriTopluGetir(ctx context.Context, parentIDs []string) (map[string][]*Gorev, error) {
+	args := m.Called(parentIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string][]*Gorev), args.Error(1)
+}
+
 func (m *MockVeriYoneticiAI) TumAltGorevleriGetir(ctx context.Context, parentID string) ([]*Gorev, error) {
 	args := m.Called(parentID)
 	if args.Get(0) == nil {
@@ -232,6 +240,11 @@ func (m *MockVeriYoneticiAI) DaireBagimliligiKontrolEt(ctx context.Context, gore
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockVeriYoneticiAI) AltAgacProjesiniGuncelle(ctx context.Context, gorevID, projeID string) error {
+	args := m.Called(gorevID, projeID)
+	return args.Error(0)
+}
+
 func (m *MockVeriYoneticiAI) TemplateOlustur(ctx context.Context, template *GorevTemplate) error {
 	args := m.Called(template)
 	return args.Error(0)
@@ -381,6 +394,11 @@ func (m *MockVeriYoneticiAI) GorevListele(ctx context.Context, filters map[strin
 	return args.Get(0).([]*Gorev), args.Error(1)
 }
 
+func (m *MockVeriYoneticiAI) GorevSayfasiGetir(ctx context.Context, filters map[string]interface{}, limit, offset int) (*GorevSayfasi, error) {
+	args := m.Called(filters, limit, offset)
+	return args.Get(0).(*GorevSayfasi), args.Error(1)
+}
+
 func (m *MockVeriYoneticiAI) GorevOlustur(ctx context.Context, params map[string]interface{}) (string, error) {
 	args := m.Called(params)
 	return args.String(0), args.Error(1)
@@ -396,6 +414,21 @@ func (m *MockVeriYoneticiAI) GetDB() (*sql.DB, error) {
 	return args.Get(0).(*sql.DB), args.Error(1)
 }
 
+func (m *MockVeriYoneticiAI) GetReadDB() (*sql.DB, error) {
+	args := m.Called()
+	return args.Get(0).(*sql.DB), args.Error(1)
+}
+
+func (m *MockVeriYoneticiAI) YazmaIslemi(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	args := m.Called(ctx, fn)
+	return args.Error(0)
+}
+
+func (m *MockVeriYoneticiAI) YardimciYazmaIslemi(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	args := m.Called(ctx, fn)
+	return args.Error(0)
+}
+
 // TestSetActiveTask tests the SetActiveTask functionality
 func TestSetActiveTask(t *testing.T) {
 	tests := []struct {
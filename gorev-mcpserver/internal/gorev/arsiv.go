@@ -0,0 +1,545 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// varsayilanArsivWorkspace is the arsiv_ayarlari key of local workspace databases
+const varsayilanArsivWorkspace = "default"
+
+// ArsivAyarlari is the archival policy of a workspace
+type ArsivAyarlari struct {
+	WorkspaceID   string     `json:"workspace_id"`
+	RetentionDays int        `json:"retention_days"` // Completed tasks untouched for longer are archived; 0 disables archiving
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+// ArsivSonucu counts the rows moved by an archive or restore run
+type ArsivSonucu struct {
+	Tasks        int      `json:"tasks"`
+	Tags         int      `json:"tags"`
+	Links        int      `json:"links"`
+	Interactions int      `json:"interactions"`
+	FilePaths    int      `json:"file_paths"`
+	TaskIDs      []string `json:"task_ids,omitempty"`
+	// Archive runs only: the retention applied, 0 when archiving is disabled, and the completion cutoff
+	RetentionDays int       `json:"retention_days,omitempty"`
+	Cutoff        time.Time `json:"cutoff,omitempty"`
+}
+
+// arsivGorevKolonlari are the task columns copied between gorevler and gorevler_arsiv
+const arsivGorevKolonlari = `id, title, description, status, priority, project_id, parent_id, workspace_id,
+	created_at, updated_at, due_date, last_ai_interaction, estimated_hours, actual_hours`
+
+// arsivSecimTablosu holds the task IDs of the current archive or restore run. It is a
+// temporary table of the writer connection, so arbitrarily large selections do not
+// run into the bound parameter limit.
+const arsivSecimTablosu = "temp.arsiv_secim"
+
+// arsivOkumaDB returns the read pool; data managers without a database have no archive
+func (iy *IsYonetici) arsivOkumaDB() (*sql.DB, error) {
+	return iy.sqlOkumaDB("archive")
+}
+
+// sqlOkumaDB returns the read pool for features that need SQL; islem names the
+// feature in the error of data managers without a database
+func (iy *IsYonetici) sqlOkumaDB(islem string) (*sql.DB, error) {
+	if iy.veriYonetici == nil {
+		return nil, fmt.Errorf(i18n.T("error.dataManagerNotInitialized", nil))
+	}
+	db, err := iy.veriYonetici.GetReadDB()
+	if err != nil {
+		return nil, err
+	}
+	if db == nil {
+		return nil, fmt.Errorf(i18n.T("error.memoryBackendSQLUnavailable", map[string]interface{}{"Operation": islem}))
+	}
+	return db, nil
+}
+
+// arsivWorkspace returns the workspace whose archive settings and tasks iy manages
+func (iy *IsYonetici) arsivWorkspace() string {
+	if iy.workspaceID == "" {
+		return varsayilanArsivWorkspace
+	}
+	return iy.workspaceID
+}
+
+// arsivWorkspaceFiltresi restricts a query on alias to the workspace of iy in centralized mode
+func (iy *IsYonetici) arsivWorkspaceFiltresi(alias string) (string, []interface{}) {
+	if iy.workspaceID == "" {
+		return "", nil
+	}
+	return " AND " + alias + ".workspace_id = ?", []interface{}{iy.workspaceID}
+}
+
+// ArsivAyarlariGetir returns the archival policy of the workspace; workspaces
+// without a stored policy do not archive
+func (iy *IsYonetici) ArsivAyarlariGetir(ctx context.Context) (*ArsivAyarlari, error) {
+	db, err := iy.arsivOkumaDB()
+	if err != nil {
+		return nil, err
+	}
+
+	ayarlar := &ArsivAyarlari{WorkspaceID: iy.arsivWorkspace()}
+	var guncelleme time.Time
+	err = db.QueryRowContext(ctx, `SELECT retention_days, updated_at FROM arsiv_ayarlari WHERE workspace_id = ?`,
+		ayarlar.WorkspaceID).Scan(&ayarlar.RetentionDays, &guncelleme)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ayarlar, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.archiveFailed", map[string]interface{}{"Error": err}))
+	}
+	ayarlar.UpdatedAt = &guncelleme
+	return ayarlar, nil
+}
+
+// ArsivAyarlariKaydet stores the retention of the workspace in days; 0 disables archiving
+func (iy *IsYonetici) ArsivAyarlariKaydet(ctx context.Context, retentionDays int) (*ArsivAyarlari, error) {
+	if retentionDays < 0 {
+		return nil, fmt.Errorf(i18n.T("error.invalidRetentionDays", map[string]interface{}{"Days": retentionDays}))
+	}
+	if _, err := iy.arsivOkumaDB(); err != nil {
+		return nil, err
+	}
+
+	simdi := time.Now()
+	ayarlar := &ArsivAyarlari{WorkspaceID: iy.arsivWorkspace(), RetentionDays: retentionDays, UpdatedAt: &simdi}
+	err := iy.veriYonetici.YazmaIslemi(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO arsiv_ayarlari (workspace_id, retention_days, updated_at) VALUES (?, ?, ?)
+			ON CONFLICT(workspace_id) DO UPDATE SET retention_days = excluded.retention_days, updated_at = excluded.updated_at`,
+			ayarlar.WorkspaceID, ayarlar.RetentionDays, simdi)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.archiveFailed", map[string]interface{}{"Error": err}))
+	}
+	return ayarlar, nil
+}
+
+// GorevleriArsivle moves tasks that were completed more than retentionDays ago into
+// the archive tables. When retentionDays is 0 the workspace policy is used. The last
+// update of a completed task is taken as its completion time. A task is only archived
+// together with its whole subtree, so live subtasks never lose their parent; links to
+// live tasks move along with the archived side.
+func (iy *IsYonetici) GorevleriArsivle(ctx context.Context, retentionDays int) (*ArsivSonucu, error) {
+	if retentionDays < 0 {
+		return nil, fmt.Errorf(i18n.T("error.invalidRetentionDays", map[string]interface{}{"Days": retentionDays}))
+	}
+	if retentionDays == 0 {
+		ayarlar, err := iy.ArsivAyarlariGetir(ctx)
+		if err != nil {
+			return nil, err
+		}
+		retentionDays = ayarlar.RetentionDays
+	}
+	if _, err := iy.arsivOkumaDB(); err != nil {
+		return nil, err
+	}
+
+	if retentionDays == 0 {
+		return &ArsivSonucu{}, nil
+	}
+
+	simdi := time.Now()
+	sonuc := &ArsivSonucu{RetentionDays: retentionDays, Cutoff: simdi.AddDate(0, 0, -retentionDays)}
+
+	wsFiltre, wsArgs := iy.arsivWorkspaceFiltresi("g")
+	secim := `SELECT g.id FROM gorevler g
+		WHERE g.status = ? AND g.updated_at < ?` + wsFiltre + `
+		  AND NOT EXISTS (
+		      SELECT 1 FROM gorev_kapanis k JOIN gorevler d ON d.id = k.descendant_id
+		      WHERE k.ancestor_id = g.id AND k.depth > 0 AND (d.status != ? OR d.updated_at >= ?)
+		  )`
+	args := append([]interface{}{constants.TaskStatusCompleted, sonuc.Cutoff}, wsArgs...)
+	args = append(args, constants.TaskStatusCompleted, sonuc.Cutoff)
+
+	err := iy.veriYonetici.YazmaIslemi(ctx, func(tx *sql.Tx) error {
+		if err := arsivSecimiHazirla(ctx, tx, secim, args...); err != nil {
+			return err
+		}
+		defer arsivSecimiKaldir(ctx, tx)
+
+		ids, err := arsivSecimIDleri(ctx, tx)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		sonuc.TaskIDs = ids
+		return arsiveTasi(ctx, tx, simdi, sonuc)
+	})
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.archiveFailed", map[string]interface{}{"Error": err}))
+	}
+
+	olaylar := &degisiklikOlaylari{}
+	for _, id := range sonuc.TaskIDs {
+		olaylar.gorevSilindi(id)
+	}
+	iy.olaylariYayinla(olaylar)
+	return sonuc, nil
+}
+
+// ArsivdenGeriYukle moves an archived task back into the live tables together with
+// its archived ancestors, so that it keeps its place in the hierarchy, and its
+// archived subtasks. References to tasks or projects deleted in the meantime are
+// dropped. The restored tasks count as updated now, otherwise the next archive run
+// would move them straight back.
+func (iy *IsYonetici) ArsivdenGeriYukle(ctx context.Context, id string) (*ArsivSonucu, error) {
+	if _, err := iy.arsivOkumaDB(); err != nil {
+		return nil, err
+	}
+
+	wsFiltre, wsArgs := iy.arsivWorkspaceFiltresi("a")
+	secim := `WITH RECURSIVE ustler(id, parent_id) AS (
+		    SELECT a.id, a.parent_id FROM gorevler_arsiv a WHERE a.id = ?` + wsFiltre + `
+		    UNION
+		    SELECT a.id, a.parent_id FROM gorevler_arsiv a JOIN ustler u ON a.id = u.parent_id
+		), altlar(id) AS (
+		    SELECT a.id FROM gorevler_arsiv a WHERE a.id = ?` + wsFiltre + `
+		    UNION
+		    SELECT a.id FROM gorevler_arsiv a JOIN altlar x ON a.parent_id = x.id
+		)
+		SELECT id FROM ustler UNION SELECT id FROM altlar`
+	args := append([]interface{}{id}, wsArgs...)
+	args = append(args, id)
+	args = append(args, wsArgs...)
+
+	sonuc := &ArsivSonucu{}
+	err := iy.veriYonetici.YazmaIslemi(ctx, func(tx *sql.Tx) error {
+		if err := arsivSecimiHazirla(ctx, tx, secim, args...); err != nil {
+			return err
+		}
+		defer arsivSecimiKaldir(ctx, tx)
+
+		ids, err := arsivSecimIDleri(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return errArsivdeYok
+		}
+		sonuc.TaskIDs = ids
+
+		in := " IN (SELECT id FROM " + arsivSecimTablosu + ")"
+		geriYukleme := []struct {
+			sayac   *int
+			kopyala string
+			sil     string
+			args    []interface{}
+		}{
+			{&sonuc.Tasks,
+				`INSERT INTO gorevler (` + arsivGorevKolonlari + `)
+				 SELECT a.id, a.title, a.description, a.status, a.priority,
+				        CASE WHEN a.project_id IN (SELECT id FROM projeler) THEN a.project_id END,
+				        CASE WHEN a.parent_id` + in + ` OR a.parent_id IN (SELECT id FROM gorevler) THEN a.parent_id END,
+				        a.workspace_id, a.created_at, ?, a.due_date, a.last_ai_interaction, a.estimated_hours, a.actual_hours
+				 FROM gorevler_arsiv a WHERE a.id` + in,
+				`DELETE FROM gorevler_arsiv WHERE id` + in, []interface{}{time.Now()}},
+			{&sonuc.Tags,
+				`INSERT OR IGNORE INTO gorev_etiketleri (task_id, tag_id)
+				 SELECT task_id, tag_id FROM gorev_etiketleri_arsiv
+				 WHERE task_id` + in + ` AND tag_id IN (SELECT id FROM etiketler)`,
+				`DELETE FROM gorev_etiketleri_arsiv WHERE task_id` + in, nil},
+			{&sonuc.Interactions,
+				`INSERT OR IGNORE INTO ai_interactions (id, task_id, action_type, context, timestamp, workspace_id)
+				 SELECT id, task_id, action_type, context, timestamp, workspace_id FROM ai_interactions_arsiv WHERE task_id` + in,
+				`DELETE FROM ai_interactions_arsiv WHERE task_id` + in, nil},
+			{&sonuc.FilePaths,
+				`INSERT OR IGNORE INTO task_file_paths (id, task_id, file_path, created_at, updated_at)
+				 SELECT id, task_id, file_path, created_at, updated_at FROM task_file_paths_arsiv WHERE task_id` + in,
+				`DELETE FROM task_file_paths_arsiv WHERE task_id` + in, nil},
+			// Links come back once both ends are live again; links to still archived
+			// tasks stay in the archive until their other end is restored
+			{&sonuc.Links,
+				`INSERT OR IGNORE INTO baglantilar (id, source_id, target_id, connection_type, workspace_id)
+				 SELECT id, source_id, target_id, connection_type, workspace_id FROM baglantilar_arsiv
+				 WHERE (source_id` + in + ` OR target_id` + in + `)
+				   AND source_id IN (SELECT id FROM gorevler) AND target_id IN (SELECT id FROM gorevler)`,
+				`DELETE FROM baglantilar_arsiv WHERE id IN (SELECT id FROM baglantilar)`, nil},
+		}
+		for _, g := range geriYukleme {
+			if _, err := tx.ExecContext(ctx, g.kopyala, g.args...); err != nil {
+				return err
+			}
+			result, err := tx.ExecContext(ctx, g.sil)
+			if err != nil {
+				return err
+			}
+			n, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			*g.sayac = int(n)
+		}
+		return nil
+	})
+	if errors.Is(err, errArsivdeYok) {
+		return nil, fmt.Errorf(i18n.T("error.archivedTaskNotFound", map[string]interface{}{"ID": id}))
+	}
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.archiveFailed", map[string]interface{}{"Error": err}))
+	}
+
+	olaylar := &degisiklikOlaylari{}
+	for _, geriYuklenen := range sonuc.TaskIDs {
+		olaylar.gorevOlusturuldu(geriYuklenen, map[string]interface{}{"restored": true})
+	}
+	iy.olaylariYayinla(olaylar)
+	return sonuc, nil
+}
+
+// arsiveTasi moves the selected tasks with their tags, links, AI interactions and
+// file paths into the archive tables and adds the moved rows to sonuc
+func arsiveTasi(ctx context.Context, tx *sql.Tx, simdi time.Time, sonuc *ArsivSonucu) error {
+	in := " IN (SELECT id FROM " + arsivSecimTablosu + ")"
+	tasima := []struct {
+		sayac    *int
+		kopyala  string
+		ayir     string
+		sil      string
+		kopyaArg []interface{}
+	}{
+		{&sonuc.Tags,
+			`INSERT OR IGNORE INTO gorev_etiketleri_arsiv (task_id, tag_id)
+			 SELECT task_id, tag_id FROM gorev_etiketleri WHERE task_id` + in, "",
+			`DELETE FROM gorev_etiketleri WHERE task_id` + in, nil},
+		{&sonuc.Links,
+			`INSERT OR REPLACE INTO baglantilar_arsiv (id, source_id, target_id, connection_type, workspace_id)
+			 SELECT id, source_id, target_id, connection_type, workspace_id FROM baglantilar
+			 WHERE source_id` + in + ` OR target_id` + in, "",
+			`DELETE FROM baglantilar WHERE source_id` + in + ` OR target_id` + in, nil},
+		{&sonuc.Interactions,
+			`INSERT OR REPLACE INTO ai_interactions_arsiv (id, task_id, action_type, context, timestamp, workspace_id)
+			 SELECT id, task_id, action_type, context, timestamp, workspace_id FROM ai_interactions WHERE task_id` + in, "",
+			`DELETE FROM ai_interactions WHERE task_id` + in, nil},
+		{&sonuc.FilePaths,
+			`INSERT OR REPLACE INTO task_file_paths_arsiv (id, task_id, file_path, created_at, updated_at)
+			 SELECT id, task_id, file_path, created_at, updated_at FROM task_file_paths WHERE task_id` + in, "",
+			`DELETE FROM task_file_paths WHERE task_id` + in, nil},
+		{&sonuc.Tasks,
+			`INSERT INTO gorevler_arsiv (` + arsivGorevKolonlari + `, archived_at)
+			 SELECT ` + arsivGorevKolonlari + `, ? FROM gorevler WHERE id` + in,
+			// parent_id is ON DELETE RESTRICT, which is checked row by row
+			`UPDATE gorevler SET parent_id = NULL WHERE parent_id IS NOT NULL AND id` + in,
+			`DELETE FROM gorevler WHERE id` + in, []interface{}{simdi}},
+	}
+	// The active task is detached first and the tasks are removed last, so the
+	// move works whether or not foreign keys are enforced on the connection
+	if _, err := tx.ExecContext(ctx, `UPDATE ai_context SET active_task_id = NULL WHERE active_task_id`+in); err != nil {
+		return err
+	}
+	for _, t := range tasima {
+		if _, err := tx.ExecContext(ctx, t.kopyala, t.kopyaArg...); err != nil {
+			return err
+		}
+		if t.ayir != "" {
+			if _, err := tx.ExecContext(ctx, t.ayir); err != nil {
+				return err
+			}
+		}
+		result, err := tx.ExecContext(ctx, t.sil)
+		if err != nil {
+			return err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		*t.sayac = int(n)
+	}
+	return nil
+}
+
+// errArsivdeYok ends a restore transaction whose task is not in the archive
+var errArsivdeYok = errors.New("task not archived")
+
+// arsivSecimiHazirla fills the selection table of the writer connection with the IDs returned by secim
+func arsivSecimiHazirla(ctx context.Context, tx *sql.Tx, secim string, args ...interface{}) error {
+	if _, err := tx.ExecContext(ctx, `CREATE TEMP TABLE IF NOT EXISTS arsiv_secim (id TEXT PRIMARY KEY)`); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM `+arsivSecimTablosu); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO `+arsivSecimTablosu+` (id) `+secim, args...)
+	return err
+}
+
+// arsivSecimiKaldir empties the selection table so that the next run starts clean
+func arsivSecimiKaldir(ctx context.Context, tx *sql.Tx) {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM `+arsivSecimTablosu); err != nil {
+		log.Printf("WARNING: archive selection not cleared: %v", err)
+	}
+}
+
+// arsivSecimIDleri returns the selected task IDs in a stable order
+func arsivSecimIDleri(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM `+arsivSecimTablosu+` ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// arsivGorevSorgusu selects archived tasks with their project name and tag names;
+// the caller appends the WHERE clause on gorevler_arsiv a
+const arsivGorevSorgusu = `SELECT a.id, a.title, COALESCE(a.description, ''), a.status, a.priority,
+	       a.project_id, COALESCE(p.name, ''), a.parent_id, a.workspace_id,
+	       a.created_at, a.updated_at, a.due_date, a.archived_at,
+	       (SELECT GROUP_CONCAT(e.id || char(31) || e.name, char(30))
+	        FROM gorev_etiketleri_arsiv ge JOIN etiketler e ON e.id = ge.tag_id WHERE ge.task_id = a.id)
+	FROM gorevler_arsiv a LEFT JOIN projeler p ON p.id = a.project_id`
+
+// arsivGorevleriOku runs arsivGorevSorgusu with the given WHERE clause
+func arsivGorevleriOku(ctx context.Context, db *sql.DB, where string, args ...interface{}) ([]*Gorev, error) {
+	rows, err := db.QueryContext(ctx, arsivGorevSorgusu+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	gorevler := []*Gorev{}
+	for rows.Next() {
+		gorev := &Gorev{}
+		var projeID, parentID, etiketler sql.NullString
+		var arsivTarihi time.Time
+		if err := rows.Scan(&gorev.ID, &gorev.Title, &gorev.Description, &gorev.Status, &gorev.Priority,
+			&projeID, &gorev.ProjeName, &parentID, &gorev.WorkspaceID,
+			&gorev.CreatedAt, &gorev.UpdatedAt, &gorev.DueDate, &arsivTarihi, &etiketler); err != nil {
+			return nil, err
+		}
+		gorev.ProjeID = projeID.String
+		gorev.ParentID = parentID.String
+		gorev.ArchivedAt = &arsivTarihi
+		gorev.Tags = etiketleriAyristir(etiketler.String)
+		gorevler = append(gorevler, gorev)
+	}
+	return gorevler, rows.Err()
+}
+
+// etiketleriAyristir splits tags concatenated as id char(31) name, separated by char(30)
+func etiketleriAyristir(birlesik string) []*Etiket {
+	etiketler := []*Etiket{}
+	if birlesik == "" {
+		return etiketler
+	}
+	for _, etiket := range strings.Split(birlesik, "\x1e") {
+		if parca := strings.SplitN(etiket, "\x1f", 2); len(parca) == 2 {
+			etiketler = append(etiketler, &Etiket{ID: parca[0], Name: parca[1]})
+		}
+	}
+	return etiketler
+}
+
+// ArsivGorevGetir returns an archived task; its ArchivedAt field is set
+func (iy *IsYonetici) ArsivGorevGetir(ctx context.Context, id string) (*Gorev, error) {
+	db, err := iy.arsivOkumaDB()
+	if err != nil {
+		return nil, err
+	}
+	wsFiltre, wsArgs := iy.arsivWorkspaceFiltresi("a")
+	gorevler, err := arsivGorevleriOku(ctx, db, ` WHERE a.id = ?`+wsFiltre, append([]interface{}{id}, wsArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.archiveFailed", map[string]interface{}{"Error": err}))
+	}
+	if len(gorevler) == 0 {
+		return nil, fmt.Errorf(i18n.T("error.archivedTaskNotFound", map[string]interface{}{"ID": id}))
+	}
+	return gorevler[0], nil
+}
+
+// ArsivGorevleriListele returns the most recently archived tasks first; limit <= 0 returns all
+func (iy *IsYonetici) ArsivGorevleriListele(ctx context.Context, limit int) ([]*Gorev, error) {
+	db, err := iy.arsivOkumaDB()
+	if err != nil {
+		return nil, err
+	}
+	wsFiltre, wsArgs := iy.arsivWorkspaceFiltresi("a")
+	where := ` WHERE 1 = 1` + wsFiltre + ` ORDER BY a.archived_at DESC, a.id`
+	if limit > 0 {
+		where += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	gorevler, err := arsivGorevleriOku(ctx, db, where, wsArgs...)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.archiveFailed", map[string]interface{}{"Error": err}))
+	}
+	return gorevler, nil
+}
+
+// ArsivBaglantilariGetir returns the archived links of a task
+func (iy *IsYonetici) ArsivBaglantilariGetir(ctx context.Context, id string) ([]*Baglanti, error) {
+	db, err := iy.arsivOkumaDB()
+	if err != nil {
+		return nil, err
+	}
+	return arsivBaglantilariOku(ctx, db, ` WHERE source_id = ? OR target_id = ?`, id, id)
+}
+
+// arsivBaglantilariOku reads archived links with the given WHERE clause
+func arsivBaglantilariOku(ctx context.Context, db *sql.DB, where string, args ...interface{}) ([]*Baglanti, error) {
+	rows, err := db.QueryContext(ctx, `SELECT id, source_id, target_id, connection_type FROM baglantilar_arsiv`+where+` ORDER BY id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.archiveFailed", map[string]interface{}{"Error": err}))
+	}
+	defer func() { _ = rows.Close() }()
+
+	baglantilar := []*Baglanti{}
+	for rows.Next() {
+		baglanti := &Baglanti{}
+		if err := rows.Scan(&baglanti.ID, &baglanti.SourceID, &baglanti.TargetID, &baglanti.ConnectionType); err != nil {
+			return nil, fmt.Errorf(i18n.T("error.archiveFailed", map[string]interface{}{"Error": err}))
+		}
+		baglantilar = append(baglantilar, baglanti)
+	}
+	return baglantilar, rows.Err()
+}
+
+// ZamanlanmisArsivle applies the workspace policy every interval until ctx is
+// cancelled; the first run starts immediately. Data managers without a database
+// have no archive and return at once.
+func (iy *IsYonetici) ZamanlanmisArsivle(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	if _, err := iy.arsivOkumaDB(); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		sonuc, err := iy.GorevleriArsivle(ctx, 0)
+		if err != nil {
+			log.Printf("⚠️  Scheduled archive failed: %v", err)
+		} else if sonuc.Tasks > 0 {
+			log.Printf("🗃️  Archived %d tasks completed before %s", sonuc.Tasks, sonuc.Cutoff.Format(constants.DateFormatISO))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
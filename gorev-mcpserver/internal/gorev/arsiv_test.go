@@ -0,0 +1,227 @@
+package gorev
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+// addArchiveTestData adds tasks completed 100 days ago: a task with a tag, a dependency,
+// an AI interaction and a file path, a finished parent with a finished subtask, and a
+// finished parent whose subtask is still open
+func addArchiveTestData(t *testing.T, vy *VeriYonetici) {
+	old := time.Now().AddDate(0, 0, -100)
+	seedTestData(t, vy, testSeed{
+		tasks: []*Gorev{
+			{ID: "old-done", Title: "Legacy migration", Status: constants.TaskStatusCompleted, ProjeID: "test-project-1", CreatedAt: old, UpdatedAt: old},
+			{ID: "old-parent", Title: "Old parent", Status: constants.TaskStatusCompleted, CreatedAt: old, UpdatedAt: old},
+			{ID: "old-child", Title: "Old child", Status: constants.TaskStatusCompleted, ParentID: "old-parent", CreatedAt: old, UpdatedAt: old},
+			{ID: "busy-parent", Title: "Busy parent", Status: constants.TaskStatusCompleted, CreatedAt: old, UpdatedAt: old},
+			{ID: "open-child", Title: "Open child", Status: constants.TaskStatusPending, ParentID: "busy-parent", CreatedAt: old, UpdatedAt: old},
+		},
+		tags:           map[string][]string{"old-done": {"backend"}},
+		links:          []*Baglanti{{ID: "link-old", SourceID: "old-done", TargetID: "test-task-1", ConnectionType: "onceki"}},
+		files:          map[string][]string{"old-done": {"main.go"}},
+		aiInteractions: map[string]string{"old-done": "completed"},
+	})
+}
+
+func TestGorevleriArsivle(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	addArchiveTestData(t, vy)
+	ctx := context.Background()
+
+	// Without a retention setting nothing is archived
+	result, err := iy.GorevleriArsivle(ctx, 0)
+	if err != nil {
+		t.Fatalf("GorevleriArsivle failed: %v", err)
+	}
+	if result.RetentionDays != 0 || result.Tasks != 0 {
+		t.Fatalf("archiving should be disabled by default, got %+v", result)
+	}
+
+	if _, err := iy.ArsivAyarlariKaydet(ctx, -1); err == nil {
+		t.Error("negative retention should be rejected")
+	}
+	if _, err := iy.ArsivAyarlariKaydet(ctx, 30); err != nil {
+		t.Fatalf("ArsivAyarlariKaydet failed: %v", err)
+	}
+	settings, err := iy.ArsivAyarlariGetir(ctx)
+	if err != nil || settings.RetentionDays != 30 || settings.UpdatedAt == nil {
+		t.Fatalf("expected stored retention of 30 days, got %+v (%v)", settings, err)
+	}
+
+	result, err = iy.GorevleriArsivle(ctx, 0)
+	if err != nil {
+		t.Fatalf("GorevleriArsivle failed: %v", err)
+	}
+	// The recently completed test-task-2 and the parent of an open subtask stay live
+	if strings.Join(result.TaskIDs, ",") != "old-child,old-done,old-parent" {
+		t.Errorf("unexpected archived tasks: %v", result.TaskIDs)
+	}
+	if result.Tasks != 3 || result.Tags != 1 || result.Links != 1 || result.Interactions != 1 || result.FilePaths != 1 {
+		t.Errorf("unexpected archive counts: %+v", result)
+	}
+
+	if _, err := vy.GorevGetir(ctx, "old-done"); err == nil {
+		t.Error("archived task should be gone from gorevler")
+	}
+	links, err := vy.BaglantilariGetir(ctx, "test-task-1")
+	if err != nil || len(links) != 0 {
+		t.Errorf("dependency on the archived task should move to the archive, got %v (%v)", links, err)
+	}
+
+	task, err := iy.ArsivGorevGetir(ctx, "old-done")
+	if err != nil {
+		t.Fatalf("ArsivGorevGetir failed: %v", err)
+	}
+	if task.ArchivedAt == nil || task.ProjeName != "Test Project" || len(task.Tags) != 1 || task.Tags[0].Name != "backend" {
+		t.Errorf("archived task lost its details: %+v", task)
+	}
+	archivedLinks, err := iy.ArsivBaglantilariGetir(ctx, "old-done")
+	if err != nil || len(archivedLinks) != 1 {
+		t.Errorf("expected the archived dependency, got %v (%v)", archivedLinks, err)
+	}
+	listed, err := iy.ArsivGorevleriListele(ctx, 2)
+	if err != nil || len(listed) != 2 {
+		t.Errorf("expected 2 listed archived tasks, got %d (%v)", len(listed), err)
+	}
+
+	// The search index only holds live tasks; include_archived searches the archive as well
+	db, _ := vy.GetDB()
+	engine := NewSearchEngine(vy, db)
+	response, err := engine.Search(SearchOptions{Query: "legacy", Filters: map[string]interface{}{}})
+	if err != nil || len(response.Results) != 0 {
+		t.Errorf("archived task should not be in the live search, got %+v (%v)", response, err)
+	}
+	response, err = engine.Search(SearchOptions{Query: "legacy", Filters: map[string]interface{}{}, IncludeArchived: true})
+	if err != nil || len(response.Results) != 1 || response.Results[0].Task.ArchivedAt == nil {
+		t.Errorf("expected the archived task with include_archived, got %+v (%v)", response, err)
+	}
+
+	report, err := iy.VeritabaniDenetle(ctx, DoctorOptions{})
+	if err != nil || !report.Healthy() {
+		t.Errorf("database should stay consistent after archiving, got %+v (%v)", report, err)
+	}
+}
+
+func TestArsivExport(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	addArchiveTestData(t, vy)
+	ctx := context.Background()
+
+	if _, err := iy.GorevleriArsivle(ctx, 30); err != nil {
+		t.Fatalf("GorevleriArsivle failed: %v", err)
+	}
+
+	options := ExportOptions{IncludeCompleted: true, IncludeDependencies: true, IncludeArchived: true}
+	exportData, err := iy.ExportData(ctx, options)
+	if err != nil {
+		t.Fatalf("ExportData failed: %v", err)
+	}
+	archived := 0
+	for _, task := range exportData.Tasks {
+		if task.ArchivedAt != nil {
+			archived++
+		}
+	}
+	if archived != 3 {
+		t.Errorf("expected 3 archived tasks in the export, got %d", archived)
+	}
+	if len(exportData.Dependencies) != 1 || exportData.Dependencies[0].ID != "link-old" {
+		t.Errorf("expected the archived dependency in the export, got %v", exportData.Dependencies)
+	}
+
+	var buf bytes.Buffer
+	stats, err := iy.ExportNDJSON(ctx, &buf, options, nil)
+	if err != nil {
+		t.Fatalf("ExportNDJSON failed: %v", err)
+	}
+	if stats.Tasks != len(exportData.Tasks) || stats.Links != 1 || stats.Tags != 1 {
+		t.Errorf("unexpected NDJSON export counts: %+v", stats)
+	}
+	if !strings.Contains(buf.String(), `"archived_at"`) {
+		t.Error("archived tasks should carry archived_at in the NDJSON export")
+	}
+
+	options.IncludeArchived = false
+	stats, err = iy.ExportNDJSON(ctx, &bytes.Buffer{}, options, nil)
+	if err != nil || stats.Tasks != len(exportData.Tasks)-3 {
+		t.Errorf("archived tasks should be left out on request, got %+v (%v)", stats, err)
+	}
+}
+
+func TestArsivdenGeriYukle(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	addArchiveTestData(t, vy)
+	ctx := context.Background()
+
+	if _, err := iy.GorevleriArsivle(ctx, 30); err != nil {
+		t.Fatalf("GorevleriArsivle failed: %v", err)
+	}
+
+	if _, err := iy.ArsivdenGeriYukle(ctx, "missing"); err == nil {
+		t.Error("restoring an unknown task should fail")
+	}
+
+	// A subtask comes back together with its archived parent
+	result, err := iy.ArsivdenGeriYukle(ctx, "old-child")
+	if err != nil {
+		t.Fatalf("ArsivdenGeriYukle failed: %v", err)
+	}
+	if strings.Join(result.TaskIDs, ",") != "old-child,old-parent" {
+		t.Errorf("unexpected restored tasks: %v", result.TaskIDs)
+	}
+	child, err := vy.GorevGetir(ctx, "old-child")
+	if err != nil || child.ParentID != "old-parent" {
+		t.Fatalf("restored subtask should keep its parent, got %+v (%v)", child, err)
+	}
+	subtasks, err := vy.AltGorevleriGetir(ctx, "old-parent")
+	if err != nil || len(subtasks) != 1 {
+		t.Errorf("hierarchy should be restored, got %v (%v)", subtasks, err)
+	}
+
+	result, err = iy.ArsivdenGeriYukle(ctx, "old-done")
+	if err != nil {
+		t.Fatalf("ArsivdenGeriYukle failed: %v", err)
+	}
+	if result.Tasks != 1 || result.Tags != 1 || result.Links != 1 || result.Interactions != 1 || result.FilePaths != 1 {
+		t.Errorf("unexpected restore counts: %+v", result)
+	}
+	task, err := vy.GorevGetir(ctx, "old-done")
+	if err != nil || len(task.Tags) != 1 {
+		t.Fatalf("restored task should have its tag, got %+v (%v)", task, err)
+	}
+	links, err := vy.BaglantilariGetir(ctx, "old-done")
+	if err != nil || len(links) != 1 {
+		t.Errorf("restored task should have its dependency, got %v (%v)", links, err)
+	}
+
+	// Restored tasks start a new retention period
+	result, err = iy.GorevleriArsivle(ctx, 30)
+	if err != nil || result.Tasks != 0 {
+		t.Errorf("restored tasks should not be archived again right away, got %+v (%v)", result, err)
+	}
+	if _, err := iy.ArsivGorevGetir(ctx, "old-done"); err == nil {
+		t.Error("restored task should be gone from the archive")
+	}
+
+	report, err := iy.VeritabaniDenetle(ctx, DoctorOptions{})
+	if err != nil || !report.Healthy() {
+		t.Errorf("database should stay consistent after restoring, got %+v (%v)", report, err)
+	}
+}
+
+func TestArsivMemoryBackend(t *testing.T) {
+	iy := YeniIsYonetici(NewMemoryVeriYonetici())
+	if _, err := iy.GorevleriArsivle(context.Background(), 30); err == nil {
+		t.Error("the memory backend has no archive")
+	}
+	if _, err := iy.ArsivGorevGetir(context.Background(), "any"); err == nil {
+		t.Error("the memory backend has no archive")
+	}
+}
@@ -0,0 +1,343 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/msenol/gorev/internal/i18n"
+	sqlite "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// backupTimeLayout is the timestamp embedded in backup file names
+const backupTimeLayout = "20060102-150405"
+
+// restoreStepPages is the number of pages copied per step while restoring, so that
+// other connections can interleave their writes between steps
+const restoreStepPages = 256
+
+// BackupInfo describes a backup file
+type BackupInfo struct {
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size"`
+}
+
+// BackupRotation is the retention policy applied after each backup.
+// The newest backup of each of the last Daily days and of the last Weekly ISO weeks is kept;
+// the newest backup is always kept. Zero values keep everything.
+type BackupRotation struct {
+	Daily  int `json:"daily"`
+	Weekly int `json:"weekly"`
+}
+
+// BackupFileName returns the file name of a backup taken at t, e.g. gorev-20250301-020000.db
+func BackupFileName(label string, t time.Time) string {
+	return fmt.Sprintf("%s-%s.db", label, t.Format(backupTimeLayout))
+}
+
+// Yedekle writes a consistent copy of the open database to destPath and verifies it.
+// VACUUM INTO reads a snapshot that includes committed WAL frames, so it is safe while
+// other connections keep writing. The read pool is query_only, which VACUUM INTO
+// refuses, so file databases are backed up through a separate connection.
+func (vy *VeriYonetici) Yedekle(ctx context.Context, destPath string) (*BackupInfo, error) {
+	var dbPath string
+	if err := vy.okuma.QueryRowContext(ctx, "SELECT file FROM pragma_database_list WHERE name = 'main'").Scan(&dbPath); err != nil {
+		return nil, fmt.Errorf(i18n.T("error.backupFailed", map[string]interface{}{"Path": destPath, "Error": err}))
+	}
+	if dbPath == "" {
+		// In-memory databases are only reachable through their own connections
+		return backupDB(ctx, vy.db, destPath)
+	}
+	return BackupDatabase(ctx, dbPath, destPath)
+}
+
+// BackupDatabase opens the database at dbPath and writes a verified copy to destPath.
+// It can be used while a daemon or MCP server has the database open.
+func BackupDatabase(ctx context.Context, dbPath, destPath string) (*BackupInfo, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, fmt.Errorf(i18n.T("error.fileNotFound", map[string]interface{}{"Path": dbPath}))
+	}
+	db, err := openBackupSource(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = db.Close() }()
+
+	return backupDB(ctx, db, destPath)
+}
+
+// openBackupSource opens a database for backup or restore with the same busy timeout as the server
+func openBackupSource(dbPath string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.dbOpenFailed", map[string]interface{}{"Error": err}))
+	}
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("PRAGMA busy_timeout=10000"); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf(i18n.T("error.busyTimeoutFailed", map[string]interface{}{"Error": err}))
+	}
+	return db, nil
+}
+
+// backupDB runs VACUUM INTO a temporary file next to destPath, verifies the copy
+// and renames it into place, so destPath never holds a partial backup
+func backupDB(ctx context.Context, db *sql.DB, destPath string) (*BackupInfo, error) {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return nil, fmt.Errorf(i18n.T("error.failedToCreateDirectory", map[string]interface{}{"Path": filepath.Dir(destPath), "Error": err}))
+	}
+
+	tmpPath := destPath + ".tmp"
+	_ = os.Remove(tmpPath) // VACUUM INTO refuses to overwrite an existing file
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if _, err := db.ExecContext(ctx, "VACUUM INTO ?", tmpPath); err != nil {
+		return nil, fmt.Errorf(i18n.T("error.backupFailed", map[string]interface{}{"Path": destPath, "Error": err}))
+	}
+	if err := VerifyBackup(ctx, tmpPath); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		return nil, fmt.Errorf(i18n.T("error.backupFailed", map[string]interface{}{"Path": destPath, "Error": err}))
+	}
+
+	info := &BackupInfo{Path: destPath, CreatedAt: time.Now()}
+	if stat, err := os.Stat(destPath); err == nil {
+		info.Size = stat.Size()
+	}
+	return info, nil
+}
+
+// VerifyBackup runs PRAGMA integrity_check on a backup file
+func VerifyBackup(ctx context.Context, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf(i18n.T("error.fileNotFound", map[string]interface{}{"Path": path}))
+	}
+
+	db, err := sql.Open("sqlite", "file:"+filepath.ToSlash(path)+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.dbOpenFailed", map[string]interface{}{"Error": err}))
+	}
+	defer func() { _ = db.Close() }()
+
+	problems, err := integrityCheck(ctx, db)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.backupVerifyFailed", map[string]interface{}{"Path": path, "Error": err}))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf(i18n.T("error.backupVerifyFailed", map[string]interface{}{"Path": path, "Error": strings.Join(problems, "; ")}))
+	}
+	return nil
+}
+
+// integrityCheck runs PRAGMA integrity_check and returns the reported problems; none means the database is intact
+func integrityCheck(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "PRAGMA integrity_check")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	problems := []string{}
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, err
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	return problems, rows.Err()
+}
+
+// RestoreDatabase verifies backupPath and copies it over the database at dbPath with
+// SQLite's online backup API. Connections that have the database open (a running daemon)
+// see the restored content on their next query; the WAL is handled by SQLite. A backup
+// whose schema is not the latest of migrationsFS is refused, since a running daemon
+// expects that schema.
+func RestoreDatabase(ctx context.Context, backupPath, dbPath string, migrationsFS fs.FS) error {
+	if err := VerifyBackup(ctx, backupPath); err != nil {
+		return err
+	}
+	if err := checkBackupSchema(ctx, backupPath, migrationsFS); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+		return fmt.Errorf(i18n.T("error.failedToCreateDirectory", map[string]interface{}{"Path": filepath.Dir(dbPath), "Error": err}))
+	}
+
+	db, err := openBackupSource(dbPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.restoreFailed", map[string]interface{}{"Path": dbPath, "Error": err}))
+	}
+	defer func() { _ = conn.Close() }()
+
+	err = conn.Raw(func(driverConn interface{}) error {
+		restorer, ok := driverConn.(interface {
+			NewRestore(srcURI string) (*sqlite.Backup, error)
+		})
+		if !ok {
+			return fmt.Errorf("sqlite driver does not support the backup API")
+		}
+
+		restore, err := restorer.NewRestore(backupPath)
+		if err != nil {
+			return err
+		}
+		for {
+			more, stepErr := restore.Step(restoreStepPages)
+			if stepErr != nil && isBusyError(stepErr) {
+				// Another connection is writing; wait and continue from where we left off
+				select {
+				case <-ctx.Done():
+					_ = restore.Finish()
+					return ctx.Err()
+				case <-time.After(50 * time.Millisecond):
+				}
+				continue
+			}
+			if stepErr != nil {
+				_ = restore.Finish()
+				return stepErr
+			}
+			if !more {
+				break
+			}
+		}
+		return restore.Finish()
+	})
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.restoreFailed", map[string]interface{}{"Path": dbPath, "Error": err}))
+	}
+	return nil
+}
+
+// checkBackupSchema refuses a backup that is dirty, or older or newer than the migrations of migrationsFS
+func checkBackupSchema(ctx context.Context, path string, migrationsFS fs.FS) error {
+	db, err := sql.Open("sqlite", "file:"+filepath.ToSlash(path)+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.dbOpenFailed", map[string]interface{}{"Error": err}))
+	}
+	defer func() { _ = db.Close() }()
+
+	migrator, err := NewMigrator(db, migrationsFS)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.backupSchemaMismatch", map[string]interface{}{"Path": path, "Error": err}))
+	}
+	status, err := migrator.CheckCompatible(ctx)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.backupSchemaMismatch", map[string]interface{}{"Path": path, "Error": err}))
+	}
+	if len(status.Pending()) > 0 {
+		return fmt.Errorf(i18n.T("error.backupSchemaOutdated", map[string]interface{}{"Path": path, "Version": status.Current, "Latest": status.Latest}))
+	}
+	return nil
+}
+
+// isBusyError reports whether err is SQLITE_BUSY or SQLITE_LOCKED
+func isBusyError(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	// Extended result codes keep the primary code in the low byte
+	switch sqliteErr.Code() & 0xff {
+	case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+		return true
+	}
+	return false
+}
+
+// ListBackups returns the backups with the given label in dir, newest first
+func ListBackups(dir, label string) ([]*BackupInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*BackupInfo{}, nil
+		}
+		return nil, err
+	}
+
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(label) + `-(\d{8}-\d{6})\.db$`)
+	backups := []*BackupInfo{}
+	for _, entry := range entries {
+		match := pattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		createdAt, err := time.ParseInLocation(backupTimeLayout, match[1], time.Local)
+		if err != nil {
+			continue
+		}
+		info := &BackupInfo{Path: filepath.Join(dir, entry.Name()), CreatedAt: createdAt}
+		if stat, err := entry.Info(); err == nil {
+			info.Size = stat.Size()
+		}
+		backups = append(backups, info)
+	}
+
+	sort.Slice(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+	return backups, nil
+}
+
+// RotateBackups deletes the backups with the given label in dir that the policy does not keep
+// and returns the deleted files
+func RotateBackups(dir, label string, policy BackupRotation) ([]string, error) {
+	if policy.Daily <= 0 && policy.Weekly <= 0 {
+		return nil, nil
+	}
+
+	backups, err := ListBackups(dir, label)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make(map[string]bool)
+	days := make(map[string]bool)
+	weeks := make(map[string]bool)
+	for i, backup := range backups {
+		if i == 0 {
+			keep[backup.Path] = true
+		}
+		day := backup.CreatedAt.Format("2006-01-02")
+		if !days[day] && len(days) < policy.Daily {
+			days[day] = true
+			keep[backup.Path] = true
+		}
+		year, week := backup.CreatedAt.ISOWeek()
+		weekKey := fmt.Sprintf("%d-%02d", year, week)
+		if !weeks[weekKey] && len(weeks) < policy.Weekly {
+			weeks[weekKey] = true
+			keep[backup.Path] = true
+		}
+	}
+
+	removed := []string{}
+	for _, backup := range backups {
+		if keep[backup.Path] {
+			continue
+		}
+		if err := os.Remove(backup.Path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, backup.Path)
+	}
+	return removed, nil
+}
@@ -0,0 +1,204 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "gorev.db")
+
+	vy, err := YeniVeriYonetici(dbPath, "file://../../internal/veri/migrations")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer func() { _ = vy.Kapat() }()
+	setupTestData(t, vy)
+
+	// Backup through the open connection and through a second connection (as the CLI does)
+	first, err := vy.Yedekle(ctx, filepath.Join(dir, "backups", BackupFileName("gorev", time.Now())))
+	if err != nil {
+		t.Fatalf("Yedekle failed: %v", err)
+	}
+	if first.Size == 0 {
+		t.Error("backup should not be empty")
+	}
+	second := filepath.Join(dir, "backups", "copy.db")
+	if _, err := BackupDatabase(ctx, dbPath, second); err != nil {
+		t.Fatalf("BackupDatabase failed: %v", err)
+	}
+	if _, err := os.Stat(second + ".tmp"); !os.IsNotExist(err) {
+		t.Error("temporary backup file should be removed")
+	}
+
+	// Changes after the backup disappear on restore, even though vy keeps its connection open
+	later := &Gorev{ID: "after-backup", Title: "After backup", Status: constants.TaskStatusPending, Priority: constants.PriorityLow, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := vy.GorevKaydet(ctx, later); err != nil {
+		t.Fatalf("GorevKaydet failed: %v", err)
+	}
+	if err := RestoreDatabase(ctx, first.Path, dbPath, os.DirFS("../../internal/veri/migrations")); err != nil {
+		t.Fatalf("RestoreDatabase failed: %v", err)
+	}
+	if _, err := vy.GorevGetir(ctx, "after-backup"); err == nil {
+		t.Error("task created after the backup should be gone after restore")
+	}
+	if _, err := vy.GorevGetir(ctx, "test-task-1"); err != nil {
+		t.Errorf("backed up task should be restored: %v", err)
+	}
+}
+
+func TestVerifyBackupRejectsCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.db")
+	if err := os.WriteFile(path, []byte("definitely not a database"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := VerifyBackup(context.Background(), path); err == nil {
+		t.Error("expected corrupt backup to fail verification")
+	}
+	if err := RestoreDatabase(context.Background(), path, filepath.Join(t.TempDir(), "gorev.db"), os.DirFS("../../internal/veri/migrations")); err == nil {
+		t.Error("restore should refuse a corrupt backup")
+	}
+}
+
+func TestRestoreRefusesOtherSchemaVersions(t *testing.T) {
+	ctx := context.Background()
+	migrationsFS := os.DirFS("../../internal/veri/migrations")
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "gorev.db")
+	vy, err := YeniVeriYonetici(dbPath, "file://../../internal/veri/migrations")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer func() { _ = vy.Kapat() }()
+	setupTestData(t, vy)
+
+	// A backup taken before the last migrations
+	older := filepath.Join(dir, "older.db")
+	m, err := NewMigrator(openBackupTestDB(t, older), migrationsFS)
+	if err != nil {
+		t.Fatalf("NewMigrator failed: %v", err)
+	}
+	if _, err := m.Up(ctx, 2); err != nil {
+		t.Fatalf("Up(2) failed: %v", err)
+	}
+
+	// A backup taken by a newer release
+	newer := filepath.Join(dir, "newer.db")
+	if _, err := BackupDatabase(ctx, dbPath, newer); err != nil {
+		t.Fatalf("BackupDatabase failed: %v", err)
+	}
+	if _, err := openBackupTestDB(t, newer).Exec(`INSERT INTO schema_migrations (version, dirty) VALUES (9999, 0)`); err != nil {
+		t.Fatalf("Failed to record a newer migration: %v", err)
+	}
+
+	for _, backup := range []string{older, newer} {
+		if err := RestoreDatabase(ctx, backup, dbPath, migrationsFS); err == nil {
+			t.Errorf("restore of %s should be refused", filepath.Base(backup))
+		}
+	}
+	if _, err := vy.GorevGetir(ctx, "test-task-1"); err != nil {
+		t.Errorf("refused restores should leave the database alone: %v", err)
+	}
+}
+
+func TestIsBusyError(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "gorev.db")
+	holder := openBackupTestDB(t, dbPath)
+	if _, err := holder.Exec(`CREATE TABLE t (id INTEGER)`); err != nil {
+		t.Fatalf("CREATE TABLE failed: %v", err)
+	}
+	tx, err := holder.Begin()
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.Exec(`INSERT INTO t VALUES (1)`); err != nil {
+		t.Fatalf("INSERT failed: %v", err)
+	}
+
+	// The second connection does not wait for the write lock
+	_, err = openBackupTestDB(t, dbPath).Exec(`INSERT INTO t VALUES (2)`)
+	if err == nil || !isBusyError(err) {
+		t.Errorf("expected a busy error, got %v", err)
+	}
+	if isBusyError(os.ErrNotExist) {
+		t.Error("other errors are not busy errors")
+	}
+}
+
+// openBackupTestDB opens a single connection to a database file without a busy timeout
+func openBackupTestDB(t *testing.T, path string) *sql.DB {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestRotateBackups(t *testing.T) {
+	dir := t.TempDir()
+	// Monday 2025-03-10 and the days before it
+	base := time.Date(2025, 3, 10, 2, 0, 0, 0, time.Local)
+	stamps := []time.Time{
+		base.Add(20 * time.Hour), // same day as base, newer
+		base,
+		base.AddDate(0, 0, -1), // Sunday, previous ISO week
+		base.AddDate(0, 0, -2),
+		base.AddDate(0, 0, -8), // two weeks back
+		base.AddDate(0, 0, -15),
+	}
+	for _, stamp := range stamps {
+		if err := os.WriteFile(filepath.Join(dir, BackupFileName("gorev", stamp)), []byte("x"), 0644); err != nil {
+			t.Fatalf("Failed to write backup: %v", err)
+		}
+	}
+	// Files of other labels and pre-restore copies are never rotated
+	other := filepath.Join(dir, BackupFileName("gorev-prerestore", base.AddDate(0, 0, -30)))
+	if err := os.WriteFile(other, []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+
+	removed, err := RotateBackups(dir, "gorev", BackupRotation{Daily: 2, Weekly: 3})
+	if err != nil {
+		t.Fatalf("RotateBackups failed: %v", err)
+	}
+
+	remaining, err := ListBackups(dir, "gorev")
+	if err != nil {
+		t.Fatalf("ListBackups failed: %v", err)
+	}
+	kept := []string{}
+	for _, backup := range remaining {
+		kept = append(kept, backup.CreatedAt.Format("2006-01-02 15"))
+	}
+	sort.Strings(kept)
+	// Daily: 03-10 22h and 03-09; weekly: + 03-02 (newest of the third week)
+	want := []string{"2025-03-02 02", "2025-03-09 02", "2025-03-10 22"}
+	if len(kept) != len(want) {
+		t.Fatalf("kept %v, want %v (removed %v)", kept, want, removed)
+	}
+	for i := range want {
+		if kept[i] != want[i] {
+			t.Errorf("kept %v, want %v", kept, want)
+			break
+		}
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Error("backups with another label should not be rotated")
+	}
+
+	if removed, err := RotateBackups(dir, "gorev", BackupRotation{}); err != nil || len(removed) != 0 {
+		t.Errorf("empty policy should keep everything, removed %v (%v)", removed, err)
+	}
+}
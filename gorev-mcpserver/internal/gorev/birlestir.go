@@ -0,0 +1,255 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// BirlestirmeSonucu describes the merge of a duplicate task into another one
+type BirlestirmeSonucu struct {
+	Source *Gorev `json:"source"`
+	// Target is the combined task; after a dry run it is an unsaved preview
+	Target    *Gorev   `json:"target"`
+	DryRun    bool     `json:"dry_run"`
+	AddedTags []string `json:"added_tags"`
+	Subtasks  []string `json:"subtasks"` // IDs of the direct subtasks moved below the target
+	// ProjectMoves counts the tasks of the source subtree moved into the target's project
+	ProjectMoves int `json:"project_moves,omitempty"`
+	Links        int `json:"links"` // links re-pointed from the source to the target
+	// DroppedLinks counts links between source and target and links the target already
+	// has; they stay with the archived source
+	DroppedLinks    int    `json:"dropped_links"`
+	FilePaths       int    `json:"file_paths"`
+	Interactions    int    `json:"interactions"`
+	DuplicateLinkID string `json:"duplicate_link_id,omitempty"`
+}
+
+// GorevBirlestir merges the duplicate task kaynakID into hedefID: the target gets the
+// union of both tags, the subtasks, links, file paths and AI interactions of the
+// source, and the source description below its own. The source is then cancelled
+// and moved to the archive with a duplicates link to the target, so that it can be
+// restored with ArsivdenGeriYukle. A dry run only computes the result.
+func (iy *IsYonetici) GorevBirlestir(ctx context.Context, kaynakID, hedefID string, dryRun bool) (*BirlestirmeSonucu, error) {
+	db, err := iy.arsivOkumaDB()
+	if err != nil {
+		return nil, err
+	}
+	if kaynakID == hedefID {
+		return nil, fmt.Errorf(i18n.T("error.mergeSameTask", map[string]interface{}{"ID": kaynakID}))
+	}
+	kaynak, err := iy.veriYonetici.GorevGetir(ctx, kaynakID)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.sourceTaskNotFound", map[string]interface{}{"Error": err}))
+	}
+	hedef, err := iy.veriYonetici.GorevGetir(ctx, hedefID)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.targetTaskNotFound", map[string]interface{}{"Error": err}))
+	}
+
+	// The subtasks of the source move below the target, which must not be one of them
+	var altGorevMi bool
+	err = db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM gorev_kapanis WHERE ancestor_id = ? AND descendant_id = ? AND depth > 0)`,
+		kaynak.ID, hedef.ID).Scan(&altGorevMi)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.mergeFailed", map[string]interface{}{"Error": err}))
+	}
+	if altGorevMi {
+		return nil, fmt.Errorf(i18n.T("error.mergeTargetIsSubtask", map[string]interface{}{"Source": kaynak.ID, "Target": hedef.ID}))
+	}
+
+	sonuc, tasinacakBaglantilar, err := iy.birlestirmePlani(ctx, db, kaynak, hedef)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.mergeFailed", map[string]interface{}{"Error": err}))
+	}
+	sonuc.DryRun = dryRun
+	if dryRun {
+		return sonuc, nil
+	}
+
+	// Subtasks moved into the target's project change with their whole subtree
+	tasinanlar := sonuc.Subtasks
+	if sonuc.ProjectMoves > 0 {
+		tumAltGorevler, err := iy.veriYonetici.TumAltGorevleriGetir(ctx, kaynak.ID)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("error.mergeFailed", map[string]interface{}{"Error": err}))
+		}
+		tasinanlar = nil
+		for _, alt := range tumAltGorevler {
+			tasinanlar = append(tasinanlar, alt.ID)
+		}
+	}
+
+	simdi := time.Now()
+	duplicateLinkID := uuid.New().String()
+	type adim struct {
+		sorgu string
+		args  []interface{}
+	}
+	err = iy.veriYonetici.YazmaIslemi(ctx, func(tx *sql.Tx) error {
+		adimlar := []adim{
+			{`UPDATE gorevler SET description = ?, updated_at = ? WHERE id = ?`,
+				[]interface{}{sonuc.Target.Description, simdi, hedef.ID}},
+			{`INSERT OR IGNORE INTO gorev_etiketleri (task_id, tag_id)
+			  SELECT ?, tag_id FROM gorev_etiketleri WHERE task_id = ?`,
+				[]interface{}{hedef.ID, kaynak.ID}},
+		}
+		// Subtasks live in the project of their parent
+		if sonuc.ProjectMoves > 0 {
+			adimlar = append(adimlar, adim{`UPDATE gorevler SET project_id = ?, updated_at = ?
+			   WHERE id IN (SELECT descendant_id FROM gorev_kapanis WHERE ancestor_id = ? AND depth > 0)`,
+				[]interface{}{sql.NullString{String: hedef.ProjeID, Valid: hedef.ProjeID != ""}, simdi, kaynak.ID}})
+		}
+		adimlar = append(adimlar, []adim{
+			{`UPDATE gorevler SET parent_id = ?, updated_at = ? WHERE parent_id = ?`,
+				[]interface{}{hedef.ID, simdi, kaynak.ID}},
+			// Paths the target already watches stay with the source
+			{`UPDATE OR IGNORE task_file_paths SET task_id = ?, updated_at = ? WHERE task_id = ?`,
+				[]interface{}{hedef.ID, simdi, kaynak.ID}},
+			{`UPDATE ai_interactions SET task_id = ? WHERE task_id = ?`,
+				[]interface{}{hedef.ID, kaynak.ID}},
+			{`UPDATE ai_context SET active_task_id = ? WHERE active_task_id = ?`,
+				[]interface{}{hedef.ID, kaynak.ID}},
+			{`UPDATE gorevler SET status = ?, updated_at = ? WHERE id = ?`,
+				[]interface{}{constants.TaskStatusCancelled, simdi, kaynak.ID}},
+			{`INSERT INTO baglantilar (id, source_id, target_id, connection_type) VALUES (?, ?, ?, ?)`,
+				[]interface{}{duplicateLinkID, kaynak.ID, hedef.ID, constants.DependencyTypeDuplicates}},
+		}...)
+		for _, baglanti := range tasinacakBaglantilar {
+			adimlar = append(adimlar, adim{`UPDATE baglantilar SET source_id = ?, target_id = ? WHERE id = ?`,
+				[]interface{}{baglanti.SourceID, baglanti.TargetID, baglanti.ID}})
+		}
+		for _, islem := range adimlar {
+			if _, err := tx.ExecContext(ctx, islem.sorgu, islem.args...); err != nil {
+				return err
+			}
+		}
+
+		if err := arsivSecimiHazirla(ctx, tx, `SELECT ?`, kaynak.ID); err != nil {
+			return err
+		}
+		defer arsivSecimiKaldir(ctx, tx)
+		return arsiveTasi(ctx, tx, simdi, &ArsivSonucu{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.mergeFailed", map[string]interface{}{"Error": err}))
+	}
+
+	olaylar := &degisiklikOlaylari{}
+	olaylar.gorevGuncellendi(hedef.ID, map[string]interface{}{"merged_from": kaynak.ID})
+	for _, id := range tasinanlar {
+		olaylar.gorevGuncellendi(id, map[string]interface{}{"merged_into": hedef.ID})
+	}
+	olaylar.gorevSilindi(kaynak.ID)
+	iy.olaylariYayinla(olaylar)
+
+	sonuc.DuplicateLinkID = duplicateLinkID
+	sonuc.Source.Status = constants.TaskStatusCancelled
+	sonuc.Source.ArchivedAt = &simdi
+	if guncel, err := iy.veriYonetici.GorevGetir(ctx, hedef.ID); err == nil {
+		sonuc.Target = guncel
+	}
+	return sonuc, nil
+}
+
+// birlestirmePlani computes the combined target and what moves from the source; it
+// also returns the links of the source re-pointed to the target
+func (iy *IsYonetici) birlestirmePlani(ctx context.Context, db *sql.DB, kaynak, hedef *Gorev) (*BirlestirmeSonucu, []*Baglanti, error) {
+	birlesik := *hedef
+	birlesik.Description = birlesikAciklama(ctx, hedef, kaynak)
+	sonuc := &BirlestirmeSonucu{Source: kaynak, Target: &birlesik, AddedTags: []string{}, Subtasks: []string{}}
+
+	birlesik.Tags = append([]*Etiket{}, hedef.Tags...)
+	etiketVar := make(map[string]bool, len(hedef.Tags))
+	for _, etiket := range hedef.Tags {
+		etiketVar[etiket.ID] = true
+	}
+	for _, etiket := range kaynak.Tags {
+		if !etiketVar[etiket.ID] {
+			etiketVar[etiket.ID] = true
+			birlesik.Tags = append(birlesik.Tags, etiket)
+			sonuc.AddedTags = append(sonuc.AddedTags, etiket.Name)
+		}
+	}
+
+	altGorevler, err := iy.veriYonetici.AltGorevleriGetir(ctx, kaynak.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, alt := range altGorevler {
+		sonuc.Subtasks = append(sonuc.Subtasks, alt.ID)
+	}
+	if len(altGorevler) > 0 && kaynak.ProjeID != hedef.ProjeID {
+		tumAltGorevler, err := iy.veriYonetici.TumAltGorevleriGetir(ctx, kaynak.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		sonuc.ProjectMoves = len(tumAltGorevler)
+	}
+
+	baglantiAnahtari := func(b *Baglanti) string {
+		return b.SourceID + "\x00" + b.TargetID + "\x00" + b.ConnectionType
+	}
+	hedefBaglantilari, err := iy.veriYonetici.BaglantilariGetir(ctx, hedef.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	baglantiVar := make(map[string]bool, len(hedefBaglantilari))
+	for _, baglanti := range hedefBaglantilari {
+		baglantiVar[baglantiAnahtari(baglanti)] = true
+	}
+	kaynakBaglantilari, err := iy.veriYonetici.BaglantilariGetir(ctx, kaynak.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var tasinacak []*Baglanti
+	for _, baglanti := range kaynakBaglantilari {
+		yeni := *baglanti
+		if yeni.SourceID == kaynak.ID {
+			yeni.SourceID = hedef.ID
+		}
+		if yeni.TargetID == kaynak.ID {
+			yeni.TargetID = hedef.ID
+		}
+		if yeni.SourceID == yeni.TargetID || baglantiVar[baglantiAnahtari(&yeni)] {
+			sonuc.DroppedLinks++
+			continue
+		}
+		baglantiVar[baglantiAnahtari(&yeni)] = true
+		tasinacak = append(tasinacak, &yeni)
+	}
+	sonuc.Links = len(tasinacak)
+
+	err = db.QueryRowContext(ctx, `SELECT
+		    (SELECT COUNT(*) FROM task_file_paths s WHERE s.task_id = ?
+		       AND NOT EXISTS (SELECT 1 FROM task_file_paths t WHERE t.task_id = ? AND t.file_path = s.file_path)),
+		    (SELECT COUNT(*) FROM ai_interactions WHERE task_id = ?)`,
+		kaynak.ID, hedef.ID, kaynak.ID).Scan(&sonuc.FilePaths, &sonuc.Interactions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sonuc, tasinacak, nil
+}
+
+// birlesikAciklama appends the description of the source below the target's, headed
+// by the task it came from
+func birlesikAciklama(ctx context.Context, hedef, kaynak *Gorev) string {
+	var parcalar []string
+	if aciklama := strings.TrimSpace(hedef.Description); aciklama != "" {
+		parcalar = append(parcalar, aciklama, "---")
+	}
+	parcalar = append(parcalar, i18n.TWithLang(i18n.FromContext(ctx), "merge.mergedFrom", map[string]interface{}{
+		"Title": kaynak.Title,
+		"ID":    kaynak.ID,
+	}))
+	if aciklama := strings.TrimSpace(kaynak.Description); aciklama != "" {
+		parcalar = append(parcalar, aciklama)
+	}
+	return strings.Join(parcalar, "\n\n")
+}
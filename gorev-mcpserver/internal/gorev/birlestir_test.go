@@ -0,0 +1,178 @@
+package gorev
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+// addMergeTestData adds the duplicate "dup" outside any project with two tags, a
+// subtask with its own subtask, links, file paths, an AI interaction and the active
+// task slot, and the task "main" in test-project-1 that it duplicates
+func addMergeTestData(t *testing.T, vy *VeriYonetici) {
+	seedTestData(t, vy, testSeed{
+		tasks: []*Gorev{
+			{ID: "main", Title: "Login fails on Safari", Description: "Login button does nothing", ProjeID: "test-project-1"},
+			{ID: "dup", Title: "Safari login broken", Description: "Steps: open Safari, click login"},
+			{ID: "dup-child", Title: "Reproduce on iOS", ParentID: "dup"},
+			{ID: "dup-grandchild", Title: "Get an iOS device", ParentID: "dup-child"},
+		},
+		tags: map[string][]string{"main": {"bug"}, "dup": {"bug", "auth"}},
+		links: []*Baglanti{
+			{ID: "link-main", SourceID: "main", TargetID: "test-task-1", ConnectionType: "onceki"},
+			{ID: "link-same", SourceID: "dup", TargetID: "test-task-1", ConnectionType: "onceki"}, // main has it already
+			{ID: "link-in", SourceID: "test-task-2", TargetID: "dup", ConnectionType: "onceki"},
+			{ID: "link-self", SourceID: "dup", TargetID: "main", ConnectionType: "onceki"}, // would link main to itself
+		},
+		files:          map[string][]string{"main": {"login.go"}, "dup": {"login.go", "safari.css"}},
+		aiInteractions: map[string]string{"dup": "viewed"},
+	})
+	if _, err := vy.db.Exec(`UPDATE ai_context SET active_task_id = 'dup' WHERE id = 1`); err != nil {
+		t.Fatalf("Failed to set active task: %v", err)
+	}
+}
+
+func TestGorevBirlestir_DryRun(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	addMergeTestData(t, vy)
+	ctx := context.Background()
+
+	preview, err := iy.GorevBirlestir(ctx, "dup", "main", true)
+	if err != nil {
+		t.Fatalf("GorevBirlestir dry run failed: %v", err)
+	}
+	if !preview.DryRun || preview.Links != 1 || preview.DroppedLinks != 2 || preview.FilePaths != 1 ||
+		preview.Interactions != 1 || preview.ProjectMoves != 2 || strings.Join(preview.Subtasks, ",") != "dup-child" {
+		t.Errorf("unexpected preview counts: %+v", preview)
+	}
+	if strings.Join(preview.AddedTags, ",") != "auth" || len(preview.Target.Tags) != 2 {
+		t.Errorf("expected auth to be added to bug, got %v and %d tags", preview.AddedTags, len(preview.Target.Tags))
+	}
+	description := preview.Target.Description
+	if !strings.HasPrefix(description, "Login button does nothing") || !strings.Contains(description, "\n\n---\n\n") ||
+		!strings.HasSuffix(description, "Steps: open Safari, click login") {
+		t.Errorf("unexpected combined description: %q", description)
+	}
+
+	// Nothing was changed
+	if _, err := vy.GorevGetir(ctx, "dup"); err != nil {
+		t.Errorf("dry run removed the source: %v", err)
+	}
+	main, err := vy.GorevGetir(ctx, "main")
+	if err != nil || main.Description != "Login button does nothing" || len(main.Tags) != 1 {
+		t.Errorf("dry run changed the target: %+v (%v)", main, err)
+	}
+
+	for _, ids := range [][2]string{{"dup", "dup"}, {"dup", "dup-grandchild"}, {"missing", "main"}, {"dup", "missing"}} {
+		if _, err := iy.GorevBirlestir(ctx, ids[0], ids[1], true); err == nil {
+			t.Errorf("merging %s into %s should fail", ids[0], ids[1])
+		}
+	}
+}
+
+func TestGorevBirlestir(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	addMergeTestData(t, vy)
+	ctx := context.Background()
+
+	result, err := iy.GorevBirlestir(ctx, "dup", "main", false)
+	if err != nil {
+		t.Fatalf("GorevBirlestir failed: %v", err)
+	}
+	if result.DuplicateLinkID == "" || result.Source.Status != constants.TaskStatusCancelled {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	main, err := vy.GorevGetir(ctx, "main")
+	if err != nil {
+		t.Fatalf("GorevGetir failed: %v", err)
+	}
+	var tagNames []string
+	for _, tag := range main.Tags {
+		tagNames = append(tagNames, tag.Name)
+	}
+	sort.Strings(tagNames)
+	if strings.Join(tagNames, ",") != "auth,bug" || !strings.Contains(main.Description, "Steps: open Safari") {
+		t.Errorf("target not combined: tags %v, description %q", tagNames, main.Description)
+	}
+
+	// Subtasks move below the target and into its project
+	for _, id := range []string{"dup-child", "dup-grandchild"} {
+		task, err := vy.GorevGetir(ctx, id)
+		if err != nil || task.ProjeID != "test-project-1" {
+			t.Errorf("%s not moved into the target's project: %+v (%v)", id, task, err)
+		}
+	}
+	if child, _ := vy.GorevGetir(ctx, "dup-child"); child == nil || child.ParentID != "main" {
+		t.Errorf("subtask not moved below the target: %+v", child)
+	}
+
+	links, err := vy.BaglantilariGetir(ctx, "main")
+	if err != nil {
+		t.Fatalf("BaglantilariGetir failed: %v", err)
+	}
+	var linkIDs []string
+	for _, link := range links {
+		linkIDs = append(linkIDs, link.ID)
+	}
+	sort.Strings(linkIDs)
+	if strings.Join(linkIDs, ",") != "link-in,link-main" {
+		t.Errorf("expected link-in to be re-pointed to main, got %v", linkIDs)
+	}
+
+	paths, err := vy.GorevDosyaYollariGetir("main")
+	if err != nil || len(paths) != 2 {
+		t.Errorf("expected both file paths on main, got %v (%v)", paths, err)
+	}
+	var interactions int
+	var activeTask string
+	if err := vy.db.QueryRow(`SELECT COUNT(*) FROM ai_interactions WHERE task_id = 'main'`).Scan(&interactions); err != nil || interactions != 1 {
+		t.Errorf("expected the AI interaction on main, got %d (%v)", interactions, err)
+	}
+	if err := vy.db.QueryRow(`SELECT active_task_id FROM ai_context WHERE id = 1`).Scan(&activeTask); err != nil || activeTask != "main" {
+		t.Errorf("expected main to become the active task, got %q (%v)", activeTask, err)
+	}
+
+	// The source is cancelled and archived with a duplicates link
+	if _, err := vy.GorevGetir(ctx, "dup"); err == nil {
+		t.Error("source should have left the live tables")
+	}
+	archived, err := iy.ArsivGorevGetir(ctx, "dup")
+	if err != nil || archived.Status != constants.TaskStatusCancelled {
+		t.Fatalf("source not archived as cancelled: %+v (%v)", archived, err)
+	}
+	archivedLinks, err := iy.ArsivBaglantilariGetir(ctx, "dup")
+	if err != nil {
+		t.Fatalf("ArsivBaglantilariGetir failed: %v", err)
+	}
+	duplicateLink := false
+	for _, link := range archivedLinks {
+		if link.ID == result.DuplicateLinkID && link.TargetID == "main" && link.ConnectionType == constants.DependencyTypeDuplicates {
+			duplicateLink = true
+		}
+	}
+	if !duplicateLink {
+		t.Errorf("expected an archived duplicates link to main, got %+v", archivedLinks)
+	}
+
+	// Restoring brings the duplicates link back
+	if _, err := iy.ArsivdenGeriYukle(ctx, "dup"); err != nil {
+		t.Fatalf("ArsivdenGeriYukle failed: %v", err)
+	}
+	links, err = vy.BaglantilariGetir(ctx, "dup")
+	if err != nil {
+		t.Fatalf("BaglantilariGetir failed: %v", err)
+	}
+	duplicateLink = false
+	for _, link := range links {
+		if link.ConnectionType == constants.DependencyTypeDuplicates && link.TargetID == "main" {
+			duplicateLink = true
+		}
+	}
+	if !duplicateLink {
+		t.Errorf("expected the duplicates link after restore, got %+v", links)
+	}
+}
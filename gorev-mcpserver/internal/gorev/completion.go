@@ -0,0 +1,456 @@
+package gorev
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+const (
+	// DefaultCompletionLimit is the number of completions returned when no limit is given
+	DefaultCompletionLimit = 10
+	// MaxCompletionLimit caps the number of completions of one query
+	MaxCompletionLimit = 50
+	// DefaultCompletionTTL is how long the completion index is used before it is
+	// refreshed in the background. Writes through CachedVeriYonetici start the refresh
+	// at once.
+	DefaultCompletionTTL = 30 * time.Second
+	// completionUntrackedTTL is the lifetime of an index over a data manager that does
+	// not report writes; such an index is rebuilt before the query that finds it expired
+	completionUntrackedTTL = time.Second
+)
+
+// Completion is one type-ahead suggestion. Value is what a client inserts: the ID of
+// tasks and projects, the name of tags and the alias (or ID) of templates. Count is
+// the number of tasks of tags and projects.
+type Completion struct {
+	Kind   string  `json:"kind"`
+	Value  string  `json:"value"`
+	Label  string  `json:"label"`
+	Detail string  `json:"detail,omitempty"`
+	Count  int     `json:"count,omitempty"`
+	Score  float64 `json:"score"`
+}
+
+// CompletionOptions selects the completions of a query. An empty Kind completes all
+// kinds; an empty Query lists the most relevant entries.
+type CompletionOptions struct {
+	Query string
+	Kind  string
+	Limit int
+}
+
+// completionEntry is an indexed completion with the fields used for ranking
+type completionEntry struct {
+	Completion
+	folded   string   // Folded label
+	words    []string // Folded words of the label
+	keys     []string // Folded IDs and aliases matched by prefix
+	updated  time.Time
+	uses     int
+	inactive bool // Completed or cancelled task
+}
+
+// CompletionIndex answers type-ahead queries for tasks, tags, projects and templates
+// from an in-memory snapshot of the workspace. The snapshot is refreshed in the
+// background when the generation of a CachedVeriYonetici shows a write or once it is
+// older than DefaultCompletionTTL; queries keep using the current snapshot meanwhile,
+// so they do not wait for the database. The generation counts the writes of every
+// workspace of the data manager, and a burst of writes runs one refresh at a time.
+type CompletionIndex struct {
+	veriYonetici VeriYoneticiInterface
+	workspaceID  string
+
+	buildMu    sync.Mutex // Serializes rebuilds
+	mu         sync.RWMutex
+	entries    []completionEntry
+	built      bool
+	builtAt    time.Time
+	generation uint64
+	refreshing bool
+}
+
+// NewCompletionIndex creates an empty index over the tasks of workspaceID (all tasks
+// when empty); it is built by the first query
+func NewCompletionIndex(veriYonetici VeriYoneticiInterface, workspaceID string) *CompletionIndex {
+	return &CompletionIndex{
+		veriYonetici: veriYonetici,
+		workspaceID:  workspaceID,
+	}
+}
+
+// dataGeneration returns the write generation of the data manager, if it reports one
+func (ci *CompletionIndex) dataGeneration() (uint64, bool) {
+	if tracker, ok := ci.veriYonetici.(interface{ Generation() uint64 }); ok {
+		return tracker.Generation(), true
+	}
+	return 0, false
+}
+
+// snapshot returns the indexed entries, rebuilding or refreshing them as needed
+func (ci *CompletionIndex) snapshot(ctx context.Context) ([]completionEntry, error) {
+	generation, tracked := ci.dataGeneration()
+
+	ci.mu.RLock()
+	entries, built, age, builtGeneration := ci.entries, ci.built, time.Since(ci.builtAt), ci.generation
+	ci.mu.RUnlock()
+
+	switch {
+	case !built || (!tracked && age > completionUntrackedTTL):
+		return ci.rebuild(ctx, false)
+	case tracked && generation != builtGeneration, age > DefaultCompletionTTL:
+		// Writes of other processes are not tracked, so the TTL refreshes them too
+		ci.refreshInBackground()
+	}
+	return entries, nil
+}
+
+// rebuild loads a new snapshot; unless forced, it keeps a snapshot that a concurrent
+// rebuild has just made
+func (ci *CompletionIndex) rebuild(ctx context.Context, force bool) ([]completionEntry, error) {
+	ci.buildMu.Lock()
+	defer ci.buildMu.Unlock()
+
+	generation, tracked := ci.dataGeneration()
+	if !force {
+		ci.mu.RLock()
+		fresh := ci.built && (tracked && generation == ci.generation || !tracked && time.Since(ci.builtAt) <= completionUntrackedTTL)
+		entries := ci.entries
+		ci.mu.RUnlock()
+		if fresh {
+			return entries, nil
+		}
+	}
+
+	entries, err := ci.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ci.mu.Lock()
+	ci.entries = entries
+	ci.built = true
+	ci.builtAt = time.Now()
+	ci.generation = generation
+	ci.mu.Unlock()
+	return entries, nil
+}
+
+// refreshInBackground starts a rebuild unless one is running
+func (ci *CompletionIndex) refreshInBackground() {
+	ci.mu.Lock()
+	if ci.refreshing {
+		ci.mu.Unlock()
+		return
+	}
+	ci.refreshing = true
+	ci.mu.Unlock()
+
+	go func() {
+		defer func() {
+			ci.mu.Lock()
+			ci.refreshing = false
+			ci.mu.Unlock()
+		}()
+		_, _ = ci.rebuild(context.Background(), true)
+	}()
+}
+
+// load reads tasks, tags, projects and templates into completion entries
+func (ci *CompletionIndex) load(ctx context.Context) ([]completionEntry, error) {
+	filters := map[string]interface{}{}
+	if ci.workspaceID != "" {
+		filters["workspace_id"] = ci.workspaceID
+	}
+	gorevler, err := ci.veriYonetici.GorevListele(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.completionIndexFailed", map[string]interface{}{"Error": err}))
+	}
+	projeler, err := ci.veriYonetici.ProjeleriGetir(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.completionIndexFailed", map[string]interface{}{"Error": err}))
+	}
+	templateler, err := ci.veriYonetici.TemplateListele(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.completionIndexFailed", map[string]interface{}{"Error": err}))
+	}
+
+	entries := make([]completionEntry, 0, len(gorevler)+len(projeler)+len(templateler))
+	projeGorevSayisi := make(map[string]int)
+	projeGuncelleme := make(map[string]time.Time)
+	type etiketKullanimi struct {
+		uses    int
+		updated time.Time
+	}
+	etiketler := make(map[string]*etiketKullanimi)
+
+	for _, gorev := range gorevler {
+		detail := gorev.Status
+		if gorev.ProjeName != "" {
+			detail = gorev.ProjeName + " · " + gorev.Status
+		}
+		entry := newCompletionEntry(Completion{
+			Kind:   constants.CompletionKindTask,
+			Value:  gorev.ID,
+			Label:  gorev.Title,
+			Detail: detail,
+		}, gorev.UpdatedAt, 0, gorev.ID)
+		entry.inactive = gorev.Status == constants.TaskStatusCompleted || gorev.Status == constants.TaskStatusCancelled
+		entries = append(entries, entry)
+
+		if gorev.ProjeID != "" {
+			projeGorevSayisi[gorev.ProjeID]++
+			if gorev.UpdatedAt.After(projeGuncelleme[gorev.ProjeID]) {
+				projeGuncelleme[gorev.ProjeID] = gorev.UpdatedAt
+			}
+		}
+		for _, etiket := range gorev.Tags {
+			kullanim, ok := etiketler[etiket.Name]
+			if !ok {
+				kullanim = &etiketKullanimi{}
+				etiketler[etiket.Name] = kullanim
+			}
+			kullanim.uses++
+			if gorev.UpdatedAt.After(kullanim.updated) {
+				kullanim.updated = gorev.UpdatedAt
+			}
+		}
+	}
+
+	for isim, kullanim := range etiketler {
+		entries = append(entries, newCompletionEntry(Completion{
+			Kind:  constants.CompletionKindTag,
+			Value: isim,
+			Label: isim,
+			Count: kullanim.uses,
+		}, kullanim.updated, kullanim.uses))
+	}
+
+	for _, proje := range projeler {
+		updated := proje.UpdatedAt
+		if projeGuncelleme[proje.ID].After(updated) {
+			updated = projeGuncelleme[proje.ID]
+		}
+		entries = append(entries, newCompletionEntry(Completion{
+			Kind:  constants.CompletionKindProject,
+			Value: proje.ID,
+			Label: proje.Name,
+			Count: projeGorevSayisi[proje.ID],
+		}, updated, projeGorevSayisi[proje.ID], proje.ID))
+	}
+
+	for _, template := range templateler {
+		if !template.Active {
+			continue
+		}
+		value := template.ID
+		if template.Alias != "" {
+			value = template.Alias
+		}
+		entries = append(entries, newCompletionEntry(Completion{
+			Kind:   constants.CompletionKindTemplate,
+			Value:  value,
+			Label:  template.Name,
+			Detail: template.Category,
+		}, time.Time{}, 0, template.ID, template.Alias))
+	}
+
+	return entries, nil
+}
+
+// newCompletionEntry prepares an entry for matching
+func newCompletionEntry(completion Completion, updated time.Time, uses int, keys ...string) completionEntry {
+	folded := foldCompletionText(completion.Label)
+	entry := completionEntry{
+		Completion: completion,
+		folded:     folded,
+		words: strings.FieldsFunc(folded, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		}),
+		updated: updated,
+		uses:    uses,
+	}
+	for _, key := range keys {
+		if key != "" {
+			entry.keys = append(entry.keys, foldCompletionText(key))
+		}
+	}
+	return entry
+}
+
+// completionFolder maps Turkish letters to their ASCII base, so "gor" finds "Görev"
+var completionFolder = strings.NewReplacer("ı", "i", "ğ", "g", "ü", "u", "ş", "s", "ö", "o", "ç", "c", "â", "a", "î", "i", "û", "u")
+
+// foldCompletionText lower-cases text and removes Turkish diacritics
+func foldCompletionText(text string) string {
+	return completionFolder.Replace(strings.ToLower(strings.ReplaceAll(text, "İ", "i")))
+}
+
+// matchScore rates how well the folded query matches the entry, 0 for no match
+func (e *completionEntry) matchScore(query string) float64 {
+	switch {
+	case query == "":
+		return 0.5
+	case e.folded == query:
+		return 1
+	case strings.HasPrefix(e.folded, query):
+		return 0.9
+	}
+	for _, key := range e.keys {
+		if strings.HasPrefix(key, query) {
+			return 0.8
+		}
+	}
+	for _, word := range e.words {
+		if strings.HasPrefix(word, query) {
+			return 0.75
+		}
+	}
+	if strings.Contains(e.folded, query) {
+		return 0.55
+	}
+	if len(query) >= 3 {
+		if span := subsequenceSpan(e.folded, query); span > 0 {
+			return 0.35 * float64(len([]rune(query))) / float64(span)
+		}
+	}
+	return 0
+}
+
+// subsequenceSpan returns the length of the shortest prefix-anchored window of text
+// that contains the runes of query in order, 0 if there is none
+func subsequenceSpan(text, query string) int {
+	q := []rune(query)
+	start, i := -1, 0
+	for pos, r := range []rune(text) {
+		if r != q[i] {
+			continue
+		}
+		if start < 0 {
+			start = pos
+		}
+		i++
+		if i == len(q) {
+			return pos - start + 1
+		}
+	}
+	return 0
+}
+
+// completionBoost ranks entries matching equally well: recently updated, often used
+// and recently worked on entries come first
+func completionBoost(e *completionEntry, now time.Time, recent map[string]float64) float64 {
+	boost := 0.0
+	if !e.updated.IsZero() {
+		days := now.Sub(e.updated).Hours() / 24
+		if days < 0 {
+			days = 0
+		}
+		boost += 0.25 / (1 + days/7)
+	}
+	if e.uses > 0 {
+		boost += 0.15 * math.Min(1, math.Log1p(float64(e.uses))/math.Log1p(20))
+	}
+	if e.Kind == constants.CompletionKindTask {
+		boost += recent[e.Value]
+	}
+	if e.inactive {
+		boost -= 0.3
+	}
+	return boost
+}
+
+// recentTaskBoosts weights the active task and the recent tasks of the AI context
+func (ci *CompletionIndex) recentTaskBoosts() map[string]float64 {
+	boosts := make(map[string]float64)
+	aiContext, err := ci.veriYonetici.AIContextGetir()
+	if err != nil || aiContext == nil {
+		return boosts
+	}
+	for i, id := range aiContext.RecentTasks {
+		boosts[id] = 0.4 * (1 - float64(i)/float64(len(aiContext.RecentTasks)))
+	}
+	if aiContext.ActiveTaskID != "" {
+		boosts[aiContext.ActiveTaskID] = 0.5
+	}
+	return boosts
+}
+
+// Complete returns the best completions for a query, ranked by match quality, then by
+// recency, use counts and the recent tasks of the AI context
+func (ci *CompletionIndex) Complete(ctx context.Context, options CompletionOptions) ([]Completion, error) {
+	if options.Kind != "" && !constants.IsValidCompletionKind(options.Kind) {
+		return nil, fmt.Errorf(i18n.T("error.invalidCompletionKind", map[string]interface{}{
+			"Kind":  options.Kind,
+			"Valid": strings.Join(constants.ValidCompletionKinds, ", "),
+		}))
+	}
+	kind := options.Kind
+	if kind == constants.CompletionKindAll {
+		kind = ""
+	}
+	limit := options.Limit
+	if limit <= 0 {
+		limit = DefaultCompletionLimit
+	}
+	if limit > MaxCompletionLimit {
+		limit = MaxCompletionLimit
+	}
+
+	entries, err := ci.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := foldCompletionText(strings.TrimSpace(options.Query))
+	now := time.Now()
+	var recent map[string]float64
+	if kind == "" || kind == constants.CompletionKindTask {
+		recent = ci.recentTaskBoosts()
+	}
+
+	var completions []Completion
+	for i := range entries {
+		entry := &entries[i]
+		if kind != "" && entry.Kind != kind {
+			continue
+		}
+		match := entry.matchScore(query)
+		if match == 0 {
+			continue
+		}
+		completion := entry.Completion
+		completion.Score = math.Round((match+completionBoost(entry, now, recent))*1000) / 1000
+		completions = append(completions, completion)
+	}
+
+	sort.Slice(completions, func(i, j int) bool {
+		if completions[i].Score != completions[j].Score {
+			return completions[i].Score > completions[j].Score
+		}
+		return completions[i].Label < completions[j].Label
+	})
+	if len(completions) > limit {
+		completions = completions[:limit]
+	}
+	if completions == nil {
+		completions = []Completion{}
+	}
+	return completions, nil
+}
+
+// OtomatikTamamla returns type-ahead completions from the completion index of the workspace
+func (iy *IsYonetici) OtomatikTamamla(ctx context.Context, options CompletionOptions) ([]Completion, error) {
+	iy.tamamlamaOnce.Do(func() {
+		iy.tamamlamaIndeks = NewCompletionIndex(iy.veriYonetici, iy.workspaceID)
+	})
+	return iy.tamamlamaIndeks.Complete(ctx, options)
+}
@@ -0,0 +1,151 @@
+package gorev
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCompletionTestData adds two projects, four tasks with tags and two templates next to
+// the default ones
+func newCompletionTestData(t *testing.T) VeriYoneticiInterface {
+	ctx := context.Background()
+	vy := NewMemoryVeriYonetici()
+
+	require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: "p-web", Name: "Web Sitesi"}))
+	require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: "p-api", Name: "Backend API"}))
+
+	old := time.Now().AddDate(0, -2, 0)
+	for _, task := range []*Gorev{
+		{ID: "g-login", Title: "Giriş sayfasını düzelt", ProjeID: "p-web", Status: constants.TaskStatusPending, UpdatedAt: time.Now()},
+		{ID: "g-logout", Title: "Logout button", ProjeID: "p-web", Status: constants.TaskStatusPending, UpdatedAt: old},
+		{ID: "g-deploy", Title: "Deployment pipeline", ProjeID: "p-api", Status: constants.TaskStatusInProgress, UpdatedAt: old},
+		{ID: "g-done", Title: "Deploy docs", ProjeID: "p-api", Status: constants.TaskStatusCompleted, UpdatedAt: time.Now()},
+	} {
+		task.Priority = constants.PriorityMedium
+		task.CreatedAt = task.UpdatedAt
+		require.NoError(t, vy.GorevKaydet(ctx, task))
+	}
+
+	for id, names := range map[string][]string{
+		"g-login":  {"frontend", "güvenlik"},
+		"g-logout": {"frontend"},
+		"g-deploy": {"devops"},
+	} {
+		tags, err := vy.EtiketleriGetirVeyaOlustur(ctx, names)
+		require.NoError(t, err)
+		require.NoError(t, vy.GorevEtiketleriniAyarla(ctx, id, tags))
+	}
+
+	require.NoError(t, vy.TemplateOlustur(ctx, &GorevTemplate{Name: "Sürüm Notu", Alias: "surum", Category: "Teknik", Active: true}))
+	require.NoError(t, vy.TemplateOlustur(ctx, &GorevTemplate{Name: "Sürüm Eski", Alias: "surum-eski", Active: false}))
+	return vy
+}
+
+func completionValues(completions []Completion) []string {
+	values := make([]string, 0, len(completions))
+	for _, completion := range completions {
+		values = append(values, completion.Value)
+	}
+	return values
+}
+
+func TestCompletionIndex_Complete(t *testing.T) {
+	ctx := context.Background()
+	index := NewCompletionIndex(newCompletionTestData(t), "")
+
+	// Turkish letters fold to ASCII and word prefixes match
+	completions, err := index.Complete(ctx, CompletionOptions{Query: "sayfa"})
+	require.NoError(t, err)
+	require.NotEmpty(t, completions)
+	assert.Equal(t, "g-login", completions[0].Value)
+	assert.Equal(t, "Web Sitesi · "+constants.TaskStatusPending, completions[0].Detail)
+
+	completions, err = index.Complete(ctx, CompletionOptions{Query: "GIRIS"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g-login"}, completionValues(completions))
+
+	// Tags carry their use counts; kinds can be restricted
+	completions, err = index.Complete(ctx, CompletionOptions{Query: "f", Kind: constants.CompletionKindTag})
+	require.NoError(t, err)
+	require.Len(t, completions, 1)
+	assert.Equal(t, Completion{Kind: constants.CompletionKindTag, Value: "frontend", Label: "frontend", Count: 2, Score: completions[0].Score}, completions[0])
+
+	completions, err = index.Complete(ctx, CompletionOptions{Query: "guv", Kind: constants.CompletionKindTag})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"güvenlik"}, completionValues(completions))
+
+	// Projects match by name and ID
+	completions, err = index.Complete(ctx, CompletionOptions{Query: "p-a", Kind: constants.CompletionKindProject})
+	require.NoError(t, err)
+	require.Len(t, completions, 1)
+	assert.Equal(t, "Backend API", completions[0].Label)
+	assert.Equal(t, 2, completions[0].Count)
+
+	// Only active templates are offered, by alias
+	completions, err = index.Complete(ctx, CompletionOptions{Query: "sur", Kind: constants.CompletionKindTemplate})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"surum"}, completionValues(completions))
+
+	// Subsequences match when nothing better does
+	completions, err = index.Complete(ctx, CompletionOptions{Query: "dplpln", Kind: constants.CompletionKindTask})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g-deploy"}, completionValues(completions))
+
+	// Open tasks rank before completed ones
+	completions, err = index.Complete(ctx, CompletionOptions{Query: "deploy", Kind: constants.CompletionKindTask})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g-deploy", "g-done"}, completionValues(completions))
+
+	completions, err = index.Complete(ctx, CompletionOptions{Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, completions, 2)
+
+	completions, err = index.Complete(ctx, CompletionOptions{Query: "zzz", Kind: constants.CompletionKindAll})
+	require.NoError(t, err)
+	assert.Empty(t, completions)
+
+	_, err = index.Complete(ctx, CompletionOptions{Kind: "person"})
+	assert.Error(t, err)
+}
+
+func TestCompletionIndex_RecentTasksRankFirst(t *testing.T) {
+	ctx := context.Background()
+	vy := newCompletionTestData(t)
+	index := NewCompletionIndex(vy, "")
+
+	// Without a query, the task updated today comes first
+	completions, err := index.Complete(ctx, CompletionOptions{Kind: constants.CompletionKindTask})
+	require.NoError(t, err)
+	require.Len(t, completions, 4)
+	assert.Equal(t, "g-login", completions[0].Value)
+	assert.Equal(t, "g-done", completions[3].Value)
+
+	require.NoError(t, vy.AIContextKaydet(&AIContext{ActiveTaskID: "g-logout", RecentTasks: []string{"g-logout"}, SessionData: map[string]interface{}{}}))
+	completions, err = index.Complete(ctx, CompletionOptions{Kind: constants.CompletionKindTask})
+	require.NoError(t, err)
+	assert.Equal(t, "g-logout", completions[0].Value)
+}
+
+func TestCompletionIndex_RebuildsAfterCachedWrites(t *testing.T) {
+	ctx := context.Background()
+	cached := NewCachedVeriYonetici(newCompletionTestData(t), CacheOptions{})
+	index := NewCompletionIndex(cached, "")
+
+	completions, err := index.Complete(ctx, CompletionOptions{Query: "release"})
+	require.NoError(t, err)
+	assert.Empty(t, completions)
+
+	// The write starts a background rebuild; the query after it is served at once
+	require.NoError(t, cached.GorevKaydet(ctx, &Gorev{ID: "g-release", Title: "Release notes", Status: constants.TaskStatusPending, Priority: constants.PriorityLow}))
+	_, err = index.Complete(ctx, CompletionOptions{Query: "release"})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		completions, err := index.Complete(ctx, CompletionOptions{Query: "release"})
+		return err == nil && len(completions) == 1 && completions[0].Value == "g-release"
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,320 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// doctorSampleLimit is the number of affected rows listed per issue
+const doctorSampleLimit = 5
+
+// DoctorOptions controls which repairs DatabaseDoctor performs
+type DoctorOptions struct {
+	Fix    bool `json:"fix"`    // Repair the detected issues in one transaction
+	Vacuum bool `json:"vacuum"` // Run VACUUM after the checks
+}
+
+// DoctorIssue is the result of a single consistency check
+type DoctorIssue struct {
+	Check   string   `json:"check"`
+	Count   int      `json:"count"`
+	Samples []string `json:"samples,omitempty"`
+	Fixed   bool     `json:"fixed"`
+}
+
+// DoctorReport is the result of a database check
+type DoctorReport struct {
+	IntegrityProblems []string      `json:"integrity_problems"`
+	Issues            []DoctorIssue `json:"issues"`
+	Skipped           []string      `json:"skipped,omitempty"` // Checks whose tables do not exist in this database
+	Analyzed          bool          `json:"analyzed"`
+	Vacuumed          bool          `json:"vacuumed"`
+	Duration          time.Duration `json:"duration"`
+}
+
+// Healthy reports whether the integrity check passed and no inconsistencies remain
+func (r *DoctorReport) Healthy() bool {
+	if len(r.IntegrityProblems) > 0 {
+		return false
+	}
+	for _, issue := range r.Issues {
+		if issue.Count > 0 && !issue.Fixed {
+			return false
+		}
+	}
+	return true
+}
+
+// doctorCheck finds rows with dangling references and describes how to repair them
+type doctorCheck struct {
+	name   string
+	tables []string // Tables the check needs; the check is skipped when one is missing
+	find   string   // Query returning one identifying text per affected row
+	fix    []string // Statements that repair all affected rows
+}
+
+// doctorChecks lists the consistency checks in repair order: rows are deleted or
+// detached before the search index is rebuilt from the repaired tables
+var doctorChecks = []doctorCheck{
+	{
+		name:   "orphaned_task_tags",
+		tables: []string{"gorev_etiketleri", "gorevler", "etiketler"},
+		find: `SELECT task_id || ' -> ' || tag_id FROM gorev_etiketleri
+			WHERE task_id NOT IN (SELECT id FROM gorevler) OR tag_id NOT IN (SELECT id FROM etiketler)`,
+		fix: []string{`DELETE FROM gorev_etiketleri
+			WHERE task_id NOT IN (SELECT id FROM gorevler) OR tag_id NOT IN (SELECT id FROM etiketler)`},
+	},
+	{
+		name:   "dangling_dependencies",
+		tables: []string{"baglantilar", "gorevler"},
+		find: `SELECT source_id || ' -> ' || target_id FROM baglantilar
+			WHERE source_id NOT IN (SELECT id FROM gorevler) OR target_id NOT IN (SELECT id FROM gorevler)`,
+		fix: []string{`DELETE FROM baglantilar
+			WHERE source_id NOT IN (SELECT id FROM gorevler) OR target_id NOT IN (SELECT id FROM gorevler)`},
+	},
+	{
+		// Subtasks of deleted parents become root tasks instead of disappearing from the tree
+		name:   "missing_parent",
+		tables: []string{"gorevler"},
+		find: `SELECT id || ' (parent ' || parent_id || ')' FROM gorevler
+			WHERE parent_id IS NOT NULL AND parent_id != '' AND parent_id NOT IN (SELECT id FROM gorevler)`,
+		fix: []string{`UPDATE gorevler SET parent_id = NULL
+			WHERE parent_id IS NOT NULL AND parent_id != '' AND parent_id NOT IN (SELECT id FROM gorevler)`},
+	},
+	{
+		name:   "missing_project",
+		tables: []string{"gorevler", "projeler"},
+		find: `SELECT id || ' (project ' || project_id || ')' FROM gorevler
+			WHERE project_id IS NOT NULL AND project_id != '' AND project_id NOT IN (SELECT id FROM projeler)`,
+		fix: []string{`UPDATE gorevler SET project_id = NULL
+			WHERE project_id IS NOT NULL AND project_id != '' AND project_id NOT IN (SELECT id FROM projeler)`},
+	},
+	{
+		name:   "dangling_active_project",
+		tables: []string{"aktif_proje", "projeler"},
+		find:   `SELECT project_id FROM aktif_proje WHERE project_id NOT IN (SELECT id FROM projeler)`,
+		fix:    []string{`DELETE FROM aktif_proje WHERE project_id NOT IN (SELECT id FROM projeler)`},
+	},
+	{
+		name:   "stale_task_file_paths",
+		tables: []string{"task_file_paths", "gorevler"},
+		find:   `SELECT task_id || ': ' || file_path FROM task_file_paths WHERE task_id NOT IN (SELECT id FROM gorevler)`,
+		fix:    []string{`DELETE FROM task_file_paths WHERE task_id NOT IN (SELECT id FROM gorevler)`},
+	},
+	{
+		name:   "dangling_active_task",
+		tables: []string{"ai_context", "gorevler"},
+		find: `SELECT CAST(active_task_id AS TEXT) FROM ai_context
+			WHERE active_task_id IS NOT NULL AND active_task_id NOT IN (SELECT id FROM gorevler)`,
+		fix: []string{`UPDATE ai_context SET active_task_id = NULL
+			WHERE active_task_id IS NOT NULL AND active_task_id NOT IN (SELECT id FROM gorevler)`},
+	},
+	{
+		// The closure table is compared with the pairs reachable through parent_id
+		name:   "hierarchy_closure_out_of_sync",
+		tables: []string{"gorev_kapanis", "gorevler"},
+		find: `WITH RECURSIVE yollar(ancestor_id, descendant_id, depth) AS (
+				SELECT id, id, 0 FROM gorevler
+				UNION ALL
+				SELECT y.ancestor_id, g.id, y.depth + 1 FROM yollar y
+				JOIN gorevler g ON g.parent_id = y.descendant_id
+				WHERE y.depth < 1000
+			),
+			beklenen AS (
+				SELECT ancestor_id, descendant_id, MIN(depth) AS depth FROM yollar GROUP BY ancestor_id, descendant_id
+			)
+			SELECT 'missing ' || ancestor_id || ' -> ' || descendant_id FROM (
+				SELECT ancestor_id, descendant_id, depth FROM beklenen
+				EXCEPT SELECT ancestor_id, descendant_id, depth FROM gorev_kapanis
+			)
+			UNION ALL
+			SELECT 'stale ' || ancestor_id || ' -> ' || descendant_id FROM (
+				SELECT ancestor_id, descendant_id, depth FROM gorev_kapanis
+				EXCEPT SELECT ancestor_id, descendant_id, depth FROM beklenen
+			)`,
+		fix: []string{
+			`DELETE FROM gorev_kapanis`,
+			`INSERT INTO gorev_kapanis(ancestor_id, descendant_id, depth)
+			WITH RECURSIVE kapanis(ancestor_id, descendant_id, depth) AS (
+				SELECT id, id, 0 FROM gorevler
+				UNION ALL
+				SELECT k.ancestor_id, g.id, k.depth + 1 FROM kapanis k
+				JOIN gorevler g ON g.parent_id = k.descendant_id
+				WHERE k.depth < 1000
+			)
+			SELECT ancestor_id, descendant_id, MIN(depth) FROM kapanis GROUP BY ancestor_id, descendant_id`,
+		},
+	},
+	{
+		name:   "search_index_out_of_sync",
+		tables: []string{"gorevler_fts", "gorevler_fts_docs", "gorevler", "gorev_etiketleri", "etiketler", "projeler"},
+		find: `SELECT 'missing ' || id FROM gorevler WHERE id NOT IN (SELECT task_id FROM gorevler_fts)
+			UNION ALL
+			SELECT 'stale ' || task_id FROM gorevler_fts WHERE task_id NOT IN (SELECT id FROM gorevler)
+			UNION ALL
+			SELECT 'unmapped ' || id FROM gorevler WHERE id NOT IN (SELECT task_id FROM gorevler_fts_docs)`,
+		fix: []string{
+			`DELETE FROM gorevler_fts`,
+			`DELETE FROM gorevler_fts_docs`,
+			`INSERT INTO gorevler_fts_docs(task_id) SELECT id FROM gorevler`,
+			`INSERT INTO gorevler_fts(rowid, task_id, title, description, tags, project_name)
+			SELECT d.doc_id, g.id, g.title, COALESCE(g.description, ''), COALESCE((
+				SELECT GROUP_CONCAT(e.name, ' ')
+				FROM gorev_etiketleri ge
+				JOIN etiketler e ON ge.tag_id = e.id
+				WHERE ge.task_id = g.id
+			), ''), COALESCE((SELECT p.name FROM projeler p WHERE p.id = g.project_id), '')
+			FROM gorevler g
+			JOIN gorevler_fts_docs d ON d.task_id = g.id`,
+			`INSERT INTO gorevler_fts(gorevler_fts) VALUES('optimize')`,
+		},
+	},
+}
+
+// VeritabaniDenetle checks the workspace database for dangling references and runs
+// PRAGMA integrity_check and ANALYZE; with options.Fix the issues are repaired
+func (iy *IsYonetici) VeritabaniDenetle(ctx context.Context, options DoctorOptions) (*DoctorReport, error) {
+	if iy.veriYonetici == nil {
+		return nil, fmt.Errorf(i18n.T("error.dataManagerNotInitialized", nil))
+	}
+	db, err := iy.veriYonetici.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	if db == nil {
+		return nil, fmt.Errorf(i18n.T("error.memoryBackendSQLUnavailable", map[string]interface{}{"Operation": "doctor"}))
+	}
+	report, err := DatabaseDoctor(ctx, db, options)
+	if options.Fix {
+		// Repairs bypass the data manager, so cached rows may be stale
+		if cache, ok := iy.veriYonetici.(*CachedVeriYonetici); ok {
+			cache.OnbellegiTemizle()
+		}
+	}
+	return report, err
+}
+
+// DatabaseDoctor runs the consistency checks on db. Repairs run in a single transaction,
+// so a failing repair leaves the database unchanged.
+func DatabaseDoctor(ctx context.Context, db *sql.DB, options DoctorOptions) (*DoctorReport, error) {
+	start := time.Now()
+	report := &DoctorReport{Issues: []DoctorIssue{}}
+
+	problems, err := integrityCheck(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.doctorCheckFailed", map[string]interface{}{"Check": "integrity_check", "Error": err}))
+	}
+	report.IntegrityProblems = problems
+
+	checks := []doctorCheck{}
+	for _, check := range doctorChecks {
+		ok, err := doctorTablesExist(ctx, db, check.tables)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("error.doctorCheckFailed", map[string]interface{}{"Check": check.name, "Error": err}))
+		}
+		if !ok {
+			report.Skipped = append(report.Skipped, check.name)
+			continue
+		}
+		issue, err := runDoctorCheck(ctx, db, check)
+		if err != nil {
+			return nil, err
+		}
+		report.Issues = append(report.Issues, issue)
+		checks = append(checks, check)
+	}
+
+	if options.Fix {
+		if err := applyDoctorFixes(ctx, db, checks, report.Issues); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := db.ExecContext(ctx, "ANALYZE"); err != nil {
+		return nil, fmt.Errorf(i18n.T("error.doctorCheckFailed", map[string]interface{}{"Check": "ANALYZE", "Error": err}))
+	}
+	report.Analyzed = true
+
+	if options.Vacuum {
+		if _, err := db.ExecContext(ctx, "VACUUM"); err != nil {
+			return nil, fmt.Errorf(i18n.T("error.doctorCheckFailed", map[string]interface{}{"Check": "VACUUM", "Error": err}))
+		}
+		report.Vacuumed = true
+	}
+
+	report.Duration = time.Since(start)
+	return report, nil
+}
+
+// runDoctorCheck counts the rows a check finds and keeps a few of them as samples
+func runDoctorCheck(ctx context.Context, db *sql.DB, check doctorCheck) (DoctorIssue, error) {
+	issue := DoctorIssue{Check: check.name}
+	rows, err := db.QueryContext(ctx, check.find)
+	if err != nil {
+		return issue, fmt.Errorf(i18n.T("error.doctorCheckFailed", map[string]interface{}{"Check": check.name, "Error": err}))
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var sample sql.NullString
+		if err := rows.Scan(&sample); err != nil {
+			return issue, fmt.Errorf(i18n.T("error.doctorCheckFailed", map[string]interface{}{"Check": check.name, "Error": err}))
+		}
+		issue.Count++
+		if len(issue.Samples) < doctorSampleLimit {
+			issue.Samples = append(issue.Samples, sample.String)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return issue, fmt.Errorf(i18n.T("error.doctorCheckFailed", map[string]interface{}{"Check": check.name, "Error": err}))
+	}
+	return issue, nil
+}
+
+// applyDoctorFixes repairs every check that found rows and marks its issue as fixed
+func applyDoctorFixes(ctx context.Context, db *sql.DB, checks []doctorCheck, issues []DoctorIssue) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.transactionFailed", map[string]interface{}{"Error": err}))
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for i, check := range checks {
+		if issues[i].Count == 0 {
+			continue
+		}
+		for _, stmt := range check.fix {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return fmt.Errorf(i18n.T("error.doctorFixFailed", map[string]interface{}{"Check": check.name, "Error": err}))
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(i18n.T("error.transactionCommitFailed", map[string]interface{}{"Error": err}))
+	}
+
+	for i := range issues {
+		if issues[i].Count > 0 {
+			issues[i].Fixed = true
+		}
+	}
+	return nil
+}
+
+// doctorTablesExist reports whether all tables exist
+func doctorTablesExist(ctx context.Context, db *sql.DB, tables []string) (bool, error) {
+	for _, table := range tables {
+		var count int
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&count); err != nil {
+			return false, err
+		}
+		if count == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
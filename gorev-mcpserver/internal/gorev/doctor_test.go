@@ -0,0 +1,87 @@
+package gorev
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// corruptDoctorTestData leaves one dangling reference of every kind the doctor checks
+func corruptDoctorTestData(t *testing.T, vy *VeriYonetici) {
+	statements := []string{
+		`INSERT INTO gorev_etiketleri (task_id, tag_id) VALUES ('test-task-1', 'deleted-tag')`,
+		`INSERT INTO baglantilar (id, source_id, target_id, connection_type) VALUES ('dangling-link', 'test-task-1', 'deleted-task', 'onceki')`,
+		`INSERT INTO gorevler (id, title, description, status, priority, project_id, parent_id, created_at, updated_at)
+			VALUES ('orphan-subtask', 'Orphan subtask', '', 'beklemede', 'orta', 'deleted-project', 'deleted-task', datetime('now'), datetime('now'))`,
+		`INSERT OR REPLACE INTO aktif_proje (id, project_id) VALUES (1, 'deleted-project')`,
+		`INSERT INTO task_file_paths (task_id, file_path) VALUES ('deleted-task', 'main.go')`,
+		`UPDATE ai_context SET active_task_id = 'deleted-task' WHERE id = 1`,
+		`DELETE FROM gorevler_fts WHERE task_id = 'test-task-2'`,
+		`DELETE FROM gorev_kapanis WHERE ancestor_id = 'test-task-2' AND depth = 0`,
+	}
+	// One Exec keeps the statements on the connection where foreign keys are switched off
+	if _, err := vy.db.Exec("PRAGMA foreign_keys=OFF;\n" + strings.Join(statements, ";\n") + ";\nPRAGMA foreign_keys=ON;"); err != nil {
+		t.Fatalf("Failed to prepare corrupt data: %v", err)
+	}
+}
+
+func TestDatabaseDoctor(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	ctx := context.Background()
+
+	report, err := iy.VeritabaniDenetle(ctx, DoctorOptions{})
+	if err != nil {
+		t.Fatalf("VeritabaniDenetle failed: %v", err)
+	}
+	if !report.Healthy() || !report.Analyzed || len(report.Skipped) != 0 {
+		t.Fatalf("fresh database should be healthy with all checks run, got %+v", report)
+	}
+
+	corruptDoctorTestData(t, vy)
+
+	report, err = iy.VeritabaniDenetle(ctx, DoctorOptions{})
+	if err != nil {
+		t.Fatalf("VeritabaniDenetle failed: %v", err)
+	}
+	if report.Healthy() {
+		t.Fatal("corrupt database should not be reported healthy")
+	}
+	for _, issue := range report.Issues {
+		if issue.Count != 1 || issue.Fixed || len(issue.Samples) != 1 {
+			t.Errorf("check %s: expected 1 unfixed issue, got %+v", issue.Check, issue)
+		}
+	}
+	if len(report.Issues) != len(doctorChecks) {
+		t.Errorf("expected %d checks, got %d", len(doctorChecks), len(report.Issues))
+	}
+
+	report, err = iy.VeritabaniDenetle(ctx, DoctorOptions{Fix: true, Vacuum: true})
+	if err != nil {
+		t.Fatalf("VeritabaniDenetle with fix failed: %v", err)
+	}
+	if !report.Healthy() || !report.Vacuumed {
+		t.Errorf("all issues should be fixed, got %+v", report)
+	}
+
+	report, err = iy.VeritabaniDenetle(ctx, DoctorOptions{})
+	if err != nil {
+		t.Fatalf("VeritabaniDenetle failed: %v", err)
+	}
+	for _, issue := range report.Issues {
+		if issue.Count != 0 {
+			t.Errorf("check %s still finds %d rows after repair", issue.Check, issue.Count)
+		}
+	}
+
+	// Dangling parents and projects are detached, the task itself is kept
+	task, err := vy.GorevGetir(ctx, "orphan-subtask")
+	if err != nil {
+		t.Fatalf("repaired task should still exist: %v", err)
+	}
+	if task.ParentID != "" || task.ProjeID != "" {
+		t.Errorf("dangling parent and project should be cleared, got %q/%q", task.ParentID, task.ProjeID)
+	}
+	if _, err := vy.GorevGetir(ctx, "test-task-1"); err != nil {
+		t.Errorf("healthy tasks must not be touched: %v", err)
+	}
+}
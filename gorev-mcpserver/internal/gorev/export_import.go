@@ -2,6 +2,7 @@ package gorev
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -76,7 +77,7 @@ type TaskTagAssociation struct {
 
 // ExportOptions contains options for data export
 type ExportOptions struct {
-	Format              string     `json:"format"` // json, csv
+	Format              string     `json:"format"` // json, ndjson, csv, ics, taskwarrior, todotxt
 	OutputPath          string     `json:"output_path"`
 	DateRange           *DateRange `json:"date_range,omitempty"`
 	ProjectFilter       []string   `json:"project_filter,omitempty"`
@@ -85,16 +86,38 @@ type ExportOptions struct {
 	IncludeMetadata     bool       `json:"include_metadata"`
 	IncludeAIContext    bool       `json:"include_ai_context"`
 	IncludeTemplates    bool       `json:"include_templates"`
+	IncludeArchived     bool       `json:"include_archived"` // Archived tasks are exported with archived_at set
 }
 
 // ImportOptions contains options for data import
 type ImportOptions struct {
-	FilePath           string            `json:"file_path"`
-	ImportMode         string            `json:"import_mode"`         // merge, replace
-	ConflictResolution string            `json:"conflict_resolution"` // skip, overwrite, prompt
-	PreserveIDs        bool              `json:"preserve_ids"`
-	ProjectMapping     map[string]string `json:"project_mapping,omitempty"`
-	DryRun             bool              `json:"dry_run"`
+	FilePath           string             `json:"file_path"`
+	Format             string             `json:"format,omitempty"`    // json, ndjson, csv, tsv, jira, github, trello, taskwarrior, todotxt (detected from file extension when empty)
+	ImportMode         string             `json:"import_mode"`         // merge, replace
+	ConflictResolution string             `json:"conflict_resolution"` // skip, overwrite, prompt
+	PreserveIDs        bool               `json:"preserve_ids"`
+	ProjectMapping     map[string]string  `json:"project_mapping,omitempty"`
+	ColumnMapping      map[string]string  `json:"column_mapping,omitempty"` // CSV header -> task field
+	DryRun             bool               `json:"dry_run"`
+	ChunkSize          int                `json:"chunk_size,omitempty"` // Tasks/links per transaction for ndjson imports
+	Progress           StreamProgressFunc `json:"-"`                    // Optional progress callback for ndjson imports
+}
+
+// importIDMap tracks old -> new IDs of imported entities so that references
+// between them (task project, parent, tags, dependencies) stay intact
+type importIDMap struct {
+	projects map[string]string
+	tasks    map[string]string
+	imported map[string]bool // New IDs of tasks created or overwritten by this import
+}
+
+// newImportIDMap creates an empty ID map
+func newImportIDMap() *importIDMap {
+	return &importIDMap{
+		projects: make(map[string]string),
+		tasks:    make(map[string]string),
+		imported: make(map[string]bool),
+	}
 }
 
 // DateRange represents a date range for filtering
@@ -223,6 +246,14 @@ func (iy *IsYonetici) ExportData(ctx context.Context, options ExportOptions) (*E
 		filteredTasks = append(filteredTasks, task)
 	}
 
+	if options.IncludeArchived {
+		archivedTasks, err := iy.exportArchivedTasks(ctx, options)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("error.failedToExportTasks", map[string]interface{}{"Error": err}))
+		}
+		filteredTasks = append(filteredTasks, archivedTasks...)
+	}
+
 	exportData.Tasks = filteredTasks
 	exportData.Metadata.TotalTasks = len(filteredTasks)
 
@@ -305,8 +336,16 @@ func (iy *IsYonetici) SaveExportToFile(ctx context.Context, exportData *ExportFo
 	switch options.Format {
 	case "json", "":
 		return iy.saveAsJSON(exportData, outputPath)
+	case "ndjson":
+		return iy.saveAsNDJSON(exportData, outputPath)
 	case "csv":
 		return iy.saveAsCSV(exportData, outputPath)
+	case "ics":
+		return iy.saveAsICS(exportData, outputPath)
+	case "taskwarrior":
+		return iy.saveAsTaskwarrior(exportData, outputPath)
+	case "todotxt":
+		return iy.saveAsTodoTxt(exportData, outputPath)
 	default:
 		return fmt.Errorf(i18n.T("error.unsupportedExportFormat", map[string]interface{}{"Format": options.Format}))
 	}
@@ -461,7 +500,9 @@ func (iy *IsYonetici) validateExportFileOptions(options ExportOptions) error {
 		return fmt.Errorf(i18n.T("error.outputPathRequired", nil))
 	}
 
-	if options.Format != "" && options.Format != "json" && options.Format != "csv" {
+	switch options.Format {
+	case "", "json", "ndjson", "csv", "ics", "taskwarrior", "todotxt":
+	default:
 		return fmt.Errorf(i18n.T("error.invalidFormat", map[string]interface{}{"Format": options.Format}))
 	}
 
@@ -497,7 +538,11 @@ func (iy *IsYonetici) exportTaskDependencies(ctx context.Context, tasks []*Gorev
 	// Get dependencies for each task and combine them
 	dependencies := []*Baglanti{}
 	for _, task := range tasks {
-		taskDeps, err := iy.veriYonetici.BaglantilariGetir(ctx, task.ID)
+		getLinks := iy.veriYonetici.BaglantilariGetir
+		if task.ArchivedAt != nil {
+			getLinks = iy.ArsivBaglantilariGetir
+		}
+		taskDeps, err := getLinks(ctx, task.ID)
 		if err != nil {
 			continue // Skip on error
 		}
@@ -515,6 +560,35 @@ func (iy *IsYonetici) exportTaskDependencies(ctx context.Context, tasks []*Gorev
 	return filteredDependencies, nil
 }
 
+// exportArchivedTasks returns the archived tasks matching the export options. Data
+// managers without a database have no archive.
+func (iy *IsYonetici) exportArchivedTasks(ctx context.Context, options ExportOptions) ([]*Gorev, error) {
+	db, err := iy.veriYonetici.GetReadDB()
+	if err != nil || db == nil {
+		return nil, err
+	}
+
+	where, args := exportTaskFilter(options, "a")
+	archived, err := arsivGorevleriOku(ctx, db, where+" ORDER BY a.created_at, a.id", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []*Gorev{}
+	for _, task := range archived {
+		if options.DateRange != nil {
+			if options.DateRange.From != nil && task.CreatedAt.Before(*options.DateRange.From) {
+				continue
+			}
+			if options.DateRange.To != nil && task.CreatedAt.After(*options.DateRange.To) {
+				continue
+			}
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
 // exportAIContext exports AI context data
 func (iy *IsYonetici) exportAIContext(ctx context.Context, tasks []*Gorev) ([]*AIInteraction, error) {
 	// Create a map of task IDs for filtering
@@ -539,14 +613,19 @@ func (iy *IsYonetici) ImportData(ctx context.Context, options ImportOptions) (*I
 		return nil, fmt.Errorf(i18n.T("error.invalidImportOptions", map[string]interface{}{"Error": err}))
 	}
 
-	// Load import data
-	importData, err := iy.loadImportData(options.FilePath)
+	// NDJSON is read and committed incrementally instead of being loaded as a whole
+	if detectImportFormat(options) == "ndjson" {
+		return iy.importNDJSONFile(ctx, options)
+	}
+
+	// Load import data (JSON export or a format converted into ExportFormat)
+	importData, loadWarnings, err := iy.loadImportData(ctx, options)
 	if err != nil {
 		return nil, fmt.Errorf(i18n.T("error.failedToLoadImportData", map[string]interface{}{"Error": err}))
 	}
 
 	// Validate import data structure
-	if err := iy.validateImportData(importData); err != nil {
+	if err := iy.validateImportData(ctx, importData); err != nil {
 		return nil, fmt.Errorf(i18n.T("error.invalidImportData", map[string]interface{}{"Error": err}))
 	}
 
@@ -554,7 +633,7 @@ func (iy *IsYonetici) ImportData(ctx context.Context, options ImportOptions) (*I
 		Success:   true,
 		Conflicts: []ConflictResolution{},
 		Errors:    []string{},
-		Warnings:  []string{},
+		Warnings:  append([]string{}, loadWarnings...),
 	}
 
 	// If dry run, analyze conflicts without making changes
@@ -571,10 +650,11 @@ func (iy *IsYonetici) ImportData(ctx context.Context, options ImportOptions) (*I
 	// This is acceptable for import as most operations are already atomic at DB level
 
 	// Import in order: Projects -> Tags -> Templates -> Tasks -> Dependencies -> AI Context
+	ids := newImportIDMap()
 
 	// Import projects
 	if len(importData.Projects) > 0 {
-		imported, conflicts, err := iy.importProjects(ctx, importData.Projects, options)
+		imported, conflicts, err := iy.importProjects(ctx, importData.Projects, options, ids)
 		if err != nil {
 			result.Errors = append(result.Errors, fmt.Sprintf("Projects: %v", err))
 			result.Success = false
@@ -610,7 +690,7 @@ func (iy *IsYonetici) ImportData(ctx context.Context, options ImportOptions) (*I
 
 	// Import tasks
 	if len(importData.Tasks) > 0 {
-		imported, conflicts, err := iy.importTasks(ctx, importData.Tasks, options)
+		imported, conflicts, err := iy.importTasks(ctx, importData.Tasks, options, ids)
 		if err != nil {
 			result.Errors = append(result.Errors, fmt.Sprintf("Tasks: %v", err))
 			result.Success = false
@@ -622,14 +702,14 @@ func (iy *IsYonetici) ImportData(ctx context.Context, options ImportOptions) (*I
 
 	// Import task-tag associations
 	if len(importData.TaskTags) > 0 {
-		if err := iy.importTaskTagAssociations(ctx, importData.TaskTags, options); err != nil {
+		if err := iy.importTaskTagAssociations(ctx, importData.TaskTags, importData.Tags, ids); err != nil {
 			result.Warnings = append(result.Warnings, fmt.Sprintf("Task-Tag associations: %v", err))
 		}
 	}
 
 	// Import dependencies
 	if len(importData.Dependencies) > 0 {
-		if err := iy.importDependencies(ctx, importData.Dependencies, options); err != nil {
+		if err := iy.importDependencies(ctx, importData.Dependencies, ids); err != nil {
 			result.Warnings = append(result.Warnings, fmt.Sprintf("Dependencies: %v", err))
 		}
 	}
@@ -637,17 +717,18 @@ func (iy *IsYonetici) ImportData(ctx context.Context, options ImportOptions) (*I
 	return result, nil
 }
 
-// loadImportData loads and parses import data from file
-func (iy *IsYonetici) loadImportData(filePath string) (*ExportFormat, error) {
+// loadImportData loads and parses import data from file.
+// Non-JSON formats are converted into ExportFormat; conversion warnings are returned alongside.
+func (iy *IsYonetici) loadImportData(ctx context.Context, options ImportOptions) (*ExportFormat, []string, error) {
 	// Normalize input path (expand ~ and resolve relative paths)
-	normalizedPath, err := NormalizePath(filePath)
+	normalizedPath, err := NormalizePath(options.FilePath)
 	if err != nil {
-		return nil, fmt.Errorf(i18n.T("error.invalidFilePath", map[string]interface{}{"Error": err}))
+		return nil, nil, fmt.Errorf(i18n.T("error.invalidFilePath", map[string]interface{}{"Error": err}))
 	}
 
 	file, err := os.Open(normalizedPath)
 	if err != nil {
-		return nil, fmt.Errorf(i18n.T("error.failedToOpenFile", map[string]interface{}{"Path": normalizedPath, "Error": err}))
+		return nil, nil, fmt.Errorf(i18n.T("error.failedToOpenFile", map[string]interface{}{"Path": normalizedPath, "Error": err}))
 	}
 	defer func() {
 		if cerr := file.Close(); cerr != nil {
@@ -655,13 +736,49 @@ func (iy *IsYonetici) loadImportData(filePath string) (*ExportFormat, error) {
 		}
 	}()
 
-	var importData ExportFormat
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(&importData); err != nil {
-		return nil, fmt.Errorf(i18n.T("error.failedToDecodeJSON", map[string]interface{}{"Error": err}))
+	// JSON is Gorev's own export format; other formats are converted by importer plugins
+	switch format := detectImportFormat(options); format {
+	case "json":
+		var importData ExportFormat
+		decoder := json.NewDecoder(file)
+		if err := decoder.Decode(&importData); err != nil {
+			return nil, nil, fmt.Errorf(i18n.T("error.failedToDecodeJSON", map[string]interface{}{"Error": err}))
+		}
+		return &importData, nil, nil
+	default:
+		imp, ok := importers[format]
+		if !ok {
+			return nil, nil, fmt.Errorf(i18n.T("error.unsupportedImportFormat", map[string]interface{}{
+				"Format":    format,
+				"Supported": strings.Join(SupportedImportFormats(), ", "),
+			}))
+		}
+		b := iy.newImportBuilder(ctx, format, options)
+		if err := imp.load(ctx, b, file, options); err != nil {
+			return nil, nil, err
+		}
+		return b.result()
 	}
+}
 
-	return &importData, nil
+// detectImportFormat returns the explicit import format or derives it from the file extension
+func detectImportFormat(options ImportOptions) string {
+	if options.Format != "" {
+		return strings.ToLower(options.Format)
+	}
+
+	switch strings.ToLower(filepath.Ext(options.FilePath)) {
+	case ".csv":
+		return "csv"
+	case ".tsv", ".tab":
+		return "tsv"
+	case ".txt":
+		return "todotxt"
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	default:
+		return "json"
+	}
 }
 
 // validateImportOptions validates import options
@@ -689,7 +806,7 @@ func (iy *IsYonetici) validateImportOptions(options ImportOptions) error {
 }
 
 // validateImportData validates the structure of import data
-func (iy *IsYonetici) validateImportData(importData *ExportFormat) error {
+func (iy *IsYonetici) validateImportData(ctx context.Context, importData *ExportFormat) error {
 	if importData.Version == "" {
 		return fmt.Errorf(i18n.T("error.missingVersion", nil))
 	}
@@ -702,6 +819,11 @@ func (iy *IsYonetici) validateImportData(importData *ExportFormat) error {
 
 	for _, task := range importData.Tasks {
 		if task.ProjeID != "" && !projectIDMap[task.ProjeID] {
+			// Tasks may also reference projects that already exist in the database (e.g. CSV imports)
+			if existing, err := iy.veriYonetici.ProjeGetir(ctx, task.ProjeID); err == nil && existing != nil {
+				projectIDMap[task.ProjeID] = true
+				continue
+			}
 			return fmt.Errorf(i18n.T("error.invalidTaskProjectReference",
 				map[string]interface{}{"TaskID": task.ID, "ProjectID": task.ProjeID}))
 		}
@@ -782,11 +904,12 @@ func (iy *IsYonetici) analyzeImportConflicts(ctx context.Context, importData *Ex
 }
 
 // importProjects imports project data
-func (iy *IsYonetici) importProjects(ctx context.Context, projects []*Proje, options ImportOptions) (int, []ConflictResolution, error) {
+func (iy *IsYonetici) importProjects(ctx context.Context, projects []*Proje, options ImportOptions, ids *importIDMap) (int, []ConflictResolution, error) {
 	imported := 0
 	conflicts := []ConflictResolution{}
 
 	for _, project := range projects {
+		originalID := project.ID
 		projectID := project.ID
 
 		// Generate new ID if not preserving IDs
@@ -833,11 +956,15 @@ func (iy *IsYonetici) importProjects(ctx context.Context, projects []*Proje, opt
 		} else {
 			// No conflict, create new project
 			project.ID = projectID
+			if project.WorkspaceID == "" {
+				project.WorkspaceID = iy.workspaceID
+			}
 			if err := iy.veriYonetici.ProjeKaydet(ctx, project); err != nil {
 				return imported, conflicts, err
 			}
 		}
 
+		ids.projects[originalID] = projectID
 		imported++
 	}
 
@@ -930,22 +1057,37 @@ func (iy *IsYonetici) importTemplates(ctx context.Context, templates []*GorevTem
 }
 
 // importTasks imports task data
-func (iy *IsYonetici) importTasks(ctx context.Context, tasks []*Gorev, options ImportOptions) (int, []ConflictResolution, error) {
+func (iy *IsYonetici) importTasks(ctx context.Context, tasks []*Gorev, options ImportOptions, ids *importIDMap) (int, []ConflictResolution, error) {
 	imported := 0
 	conflicts := []ConflictResolution{}
 
+	// Assign target IDs up front so parent references can be remapped regardless of order
 	for _, task := range tasks {
 		taskID := task.ID
-
-		// Generate new ID if not preserving IDs
-		if !options.PreserveIDs {
+		if !options.PreserveIDs || taskID == "" {
 			taskID = uuid.New().String()
 		}
+		ids.tasks[task.ID] = taskID
+	}
+
+	for _, task := range orderTasksParentFirst(tasks) {
+		taskID := ids.tasks[task.ID]
 
-		// Map project ID if project mapping is provided
-		if task.ProjeID != "" && options.ProjectMapping != nil {
+		// Map project ID: explicit project mapping wins, otherwise follow imported project IDs
+		if task.ProjeID != "" {
 			if newProjectID, exists := options.ProjectMapping[task.ProjeID]; exists {
 				task.ProjeID = newProjectID
+			} else if newProjectID, exists := ids.projects[task.ProjeID]; exists {
+				task.ProjeID = newProjectID
+			}
+		}
+
+		// Map parent ID to the imported parent, or keep it if it points at an existing task
+		if task.ParentID != "" {
+			if newParentID, exists := ids.tasks[task.ParentID]; exists {
+				task.ParentID = newParentID
+			} else if parent, err := iy.veriYonetici.GorevGetir(ctx, task.ParentID); err != nil || parent == nil {
+				task.ParentID = ""
 			}
 		}
 
@@ -970,7 +1112,7 @@ func (iy *IsYonetici) importTasks(ctx context.Context, tasks []*Gorev, options I
 					"description": task.Description,
 					"status":      task.Status,
 					"priority":    task.Priority,
-					"project_id":  task.ProjeID,
+					"project_id":  sql.NullString{String: task.ProjeID, Valid: task.ProjeID != ""},
 					"due_date":    task.DueDate,
 				}); err != nil {
 					return imported, conflicts, err
@@ -992,59 +1134,126 @@ func (iy *IsYonetici) importTasks(ctx context.Context, tasks []*Gorev, options I
 				continue
 			}
 		} else {
-			// No conflict, create new task using GorevOlustur with params map
+			// No conflict, create new task with all imported fields
 			task.ID = taskID
-			params := map[string]interface{}{
-				"id":          taskID,
-				"title":       task.Title,
-				"description": task.Description,
-				"status":      task.Status,
-				"priority":    task.Priority,
-				"project_id":  task.ProjeID,
-				"parent_id":   task.ParentID,
-				"due_date":    task.DueDate,
+			if task.Status == "" {
+				task.Status = constants.TaskStatusPending
+			}
+			if task.Priority == "" {
+				task.Priority = constants.PriorityMedium
+			}
+			if task.CreatedAt.IsZero() {
+				task.CreatedAt = time.Now()
+			}
+			if task.UpdatedAt.IsZero() {
+				task.UpdatedAt = task.CreatedAt
+			}
+			if task.WorkspaceID == "" {
+				task.WorkspaceID = iy.workspaceID
 			}
-			if _, err := iy.veriYonetici.GorevOlustur(ctx, params); err != nil {
+			if err := iy.veriYonetici.GorevKaydet(ctx, task); err != nil {
 				log.Printf("Import: Failed to create new task %s: %v", taskID, err)
 				return imported, conflicts, err
 			}
 			log.Printf("Import: Successfully created new task %s", taskID)
 		}
 
+		ids.imported[taskID] = true
 		imported++
 	}
 
 	return imported, conflicts, nil
 }
 
-// importTaskTagAssociations imports task-tag associations
-func (iy *IsYonetici) importTaskTagAssociations(ctx context.Context, taskTags []TaskTagAssociation, options ImportOptions) error {
+// orderTasksParentFirst returns tasks ordered so that every parent precedes its subtasks
+func orderTasksParentFirst(tasks []*Gorev) []*Gorev {
+	byID := make(map[string]*Gorev, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	ordered := make([]*Gorev, 0, len(tasks))
+	visited := make(map[string]bool, len(tasks))
+	var visit func(task *Gorev)
+	visit = func(task *Gorev) {
+		if visited[task.ID] {
+			return
+		}
+		visited[task.ID] = true
+		if parent, ok := byID[task.ParentID]; ok {
+			visit(parent)
+		}
+		ordered = append(ordered, task)
+	}
+	for _, task := range tasks {
+		visit(task)
+	}
+
+	return ordered
+}
+
+// importTaskTagAssociations imports task-tag associations for tasks created or overwritten by this import
+func (iy *IsYonetici) importTaskTagAssociations(ctx context.Context, taskTags []TaskTagAssociation, tags []*Etiket, ids *importIDMap) error {
+	tagNames := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		tagNames[tag.ID] = tag.Name
+	}
+
+	// Group tag names per imported task, keeping file order
+	taskOrder := []string{}
+	namesByTask := make(map[string][]string)
 	for _, taskTag := range taskTags {
-		// Verify task exists
-		task, err := iy.veriYonetici.GorevGetir(ctx, taskTag.TaskID)
-		if err != nil || task == nil {
-			continue // Skip if task doesn't exist
+		taskID, exists := ids.tasks[taskTag.TaskID]
+		if !exists || !ids.imported[taskID] {
+			continue // Skip if task wasn't imported
+		}
+		name, exists := tagNames[taskTag.TagID]
+		if !exists {
+			continue
+		}
+		if _, seen := namesByTask[taskID]; !seen {
+			taskOrder = append(taskOrder, taskID)
 		}
+		namesByTask[taskID] = append(namesByTask[taskID], name)
+	}
 
-		// Get all tags for the task and add the association using GorevEtiketleriniAyarla
-		// This is a simplified approach - we'd need the actual tag by ID
-		// For now, skip tag associations as we don't have a direct method to add individual tags
-		log.Printf("Note: Task-tag association skipped for task %s - requires tag lookup by ID", taskTag.TaskID)
+	var failed []string
+	for _, taskID := range taskOrder {
+		etiketler, err := iy.veriYonetici.EtiketleriGetirVeyaOlustur(ctx, namesByTask[taskID])
+		if err == nil {
+			err = iy.veriYonetici.GorevEtiketleriniAyarla(ctx, taskID, etiketler)
+		}
+		if err != nil {
+			log.Printf("Warning: Failed to set tags for task %s: %v", taskID, err)
+			failed = append(failed, taskID)
+		}
 	}
 
+	if len(failed) > 0 {
+		return fmt.Errorf(i18n.T("error.importTagAssignFailed", map[string]interface{}{"Tasks": strings.Join(failed, ", ")}))
+	}
 	return nil
 }
 
 // importDependencies imports task dependencies
-func (iy *IsYonetici) importDependencies(ctx context.Context, dependencies []*Baglanti, options ImportOptions) error {
+func (iy *IsYonetici) importDependencies(ctx context.Context, dependencies []*Baglanti, ids *importIDMap) error {
 	for _, dep := range dependencies {
+		sourceID := dep.SourceID
+		if newID, exists := ids.tasks[sourceID]; exists {
+			sourceID = newID
+		}
+		targetID := dep.TargetID
+		if newID, exists := ids.tasks[targetID]; exists {
+			targetID = newID
+		}
+
 		// Verify both tasks exist
-		source, err := iy.veriYonetici.GorevGetir(ctx, dep.SourceID)
+		source, err := iy.veriYonetici.GorevGetir(ctx, sourceID)
 		if err != nil || source == nil {
 			continue // Skip if source task doesn't exist
 		}
 
-		target, err := iy.veriYonetici.GorevGetir(ctx, dep.TargetID)
+		target, err := iy.veriYonetici.GorevGetir(ctx, targetID)
 		if err != nil || target == nil {
 			continue // Skip if target task doesn't exist
 		}
@@ -1052,15 +1261,49 @@ func (iy *IsYonetici) importDependencies(ctx context.Context, dependencies []*Ba
 		// Create dependency using BaglantiEkle
 		newDep := &Baglanti{
 			ID:             uuid.New().String(),
-			SourceID:       dep.SourceID,
-			TargetID:       dep.TargetID,
+			SourceID:       sourceID,
+			TargetID:       targetID,
 			ConnectionType: dep.ConnectionType,
 		}
 		if err := iy.veriYonetici.BaglantiEkle(ctx, newDep); err != nil {
 			// Log warning but continue
-			log.Printf("Warning: Failed to create dependency from %s to %s: %v", dep.SourceID, dep.TargetID, err)
+			log.Printf("Warning: Failed to create dependency from %s to %s: %v", sourceID, targetID, err)
 		}
 	}
 
 	return nil
 }
+
+// exportIndex provides lookups over export data for formats that inline projects, tags and dependencies per task
+type exportIndex struct {
+	projectNames map[string]string   // project ID -> name
+	tagNames     map[string][]string // task ID -> tag names
+	blockers     map[string][]string // task ID -> IDs of tasks that must be completed first
+}
+
+// newExportIndex builds lookups from export data
+func newExportIndex(exportData *ExportFormat) *exportIndex {
+	idx := &exportIndex{
+		projectNames: make(map[string]string, len(exportData.Projects)),
+		tagNames:     make(map[string][]string),
+		blockers:     make(map[string][]string),
+	}
+	for _, project := range exportData.Projects {
+		idx.projectNames[project.ID] = project.Name
+	}
+
+	tagByID := make(map[string]string, len(exportData.Tags))
+	for _, tag := range exportData.Tags {
+		tagByID[tag.ID] = tag.Name
+	}
+	for _, taskTag := range exportData.TaskTags {
+		if name, ok := tagByID[taskTag.TagID]; ok {
+			idx.tagNames[taskTag.TaskID] = append(idx.tagNames[taskTag.TaskID], name)
+		}
+	}
+
+	for _, dep := range exportData.Dependencies {
+		idx.blockers[dep.TargetID] = append(idx.blockers[dep.TargetID], dep.SourceID)
+	}
+	return idx
+}
@@ -527,53 +527,3 @@ func TestValidateImportOptions(t *testing.T) {
 		})
 	}
 }
-
-// Test helper functions
-
-func setupTestData(t *testing.T, vy *VeriYonetici) {
-	// Test projesi oluştur
-	proje := &Proje{
-		ID:         "test-project-1",
-		Name:       "Test Project",
-		Definition: "Test project description",
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
-	}
-	err := vy.ProjeKaydet(context.Background(), proje)
-	if err != nil {
-		t.Fatalf("Failed to create test project: %v", err)
-	}
-
-	// Test görevleri oluştur
-	tasks := []*Gorev{
-		{
-			ID:          "test-task-1",
-			Title:       "Test Task 1",
-			Description: "Test task description",
-			Status:      constants.TaskStatusPending,
-			Priority:    constants.PriorityHigh,
-			ProjeID:     proje.ID,
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-		{
-			ID:          "test-task-2",
-			Title:       "Test Task 2",
-			Description: "Another test task",
-			Status:      constants.TaskStatusCompleted,
-			Priority:    constants.PriorityMedium,
-			ProjeID:     proje.ID,
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-	}
-
-	for _, task := range tasks {
-		err := vy.GorevKaydet(context.Background(), task)
-		if err != nil {
-			t.Fatalf("Failed to create test task: %v", err)
-		}
-	}
-
-	// Etiket test için şimdilik atlıyoruz - EtiketKaydet metodu yok
-}
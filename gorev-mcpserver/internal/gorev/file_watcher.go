@@ -518,7 +518,7 @@ func (fw *FileWatcher) GetStats() map[string]interface{} {
 // loadFromDatabase loads all file watches from database on initialization
 func (fw *FileWatcher) loadFromDatabase() error {
 	// Get all unique task IDs from database
-	db, err := fw.veriYonetici.GetDB()
+	db, err := fw.veriYonetici.GetReadDB()
 	if err != nil {
 		return fmt.Errorf(i18n.T("error.databaseGetFailed", map[string]interface{}{"Error": err}))
 	}
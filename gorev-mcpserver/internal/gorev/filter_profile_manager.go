@@ -1,10 +1,12 @@
 package gorev
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/msenol/gorev/internal/i18n"
@@ -24,6 +26,52 @@ type SearchFilters struct {
 	DueBefore      string   `json:"due_before,omitempty"`
 }
 
+// QueryString writes the filters in the query language; values of one filter are
+// alternatives, different filters must all match
+func (f SearchFilters) QueryString() string {
+	var parts []string
+	anyOf := func(field string, values []string) {
+		terms := make([]string, 0, len(values))
+		for _, value := range values {
+			if value != "" {
+				terms = append(terms, field+":"+quoteQueryValue(value))
+			}
+		}
+		switch len(terms) {
+		case 0:
+		case 1:
+			parts = append(parts, terms[0])
+		default:
+			parts = append(parts, "("+strings.Join(terms, " OR ")+")")
+		}
+	}
+	anyOf(queryFieldStatus, f.Status)
+	anyOf(queryFieldPriority, f.Priority)
+	anyOf(queryFieldProject, f.ProjectIDs)
+	anyOf(queryFieldTag, f.Tags)
+
+	for _, bound := range []struct{ term, value string }{
+		{queryFieldCreated + ">=", f.CreatedAfter},
+		{queryFieldCreated + "<=", f.CreatedBefore},
+		{queryFieldDue + ">=", f.DueAfter},
+		{queryFieldDue + "<=", f.DueBefore},
+	} {
+		if bound.value != "" {
+			parts = append(parts, bound.term+quoteQueryValue(bound.value))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteQueryValue quotes a field value that would otherwise end the term early or
+// read as a keyword
+func quoteQueryValue(value string) string {
+	if strings.ContainsAny(value, " \t()\"\\") || strings.EqualFold(value, queryNone) {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+	}
+	return value
+}
+
 // FilterProfile represents a saved filter configuration
 type FilterProfile struct {
 	ID          string        `json:"id"`
@@ -38,16 +86,56 @@ type FilterProfile struct {
 	UpdatedAt   time.Time     `json:"updated_at"`
 }
 
+// Query parses the profile's filters and search query into one query. Syntax errors
+// refer to positions in SearchQuery.
+func (p *FilterProfile) Query() (*Query, error) {
+	search, err := ParseQuery(p.SearchQuery)
+	if err != nil {
+		return nil, err
+	}
+	filters := p.Filters.QueryString()
+	if filters == "" {
+		return search, nil
+	}
+	if search.IsEmpty() {
+		return ParseQuery(filters)
+	}
+	return ParseQuery(filters + " (" + p.SearchQuery + ")")
+}
+
 // FilterProfileManager handles filter profile operations
 type FilterProfileManager struct {
-	db *sql.DB
+	veriYonetici VeriYoneticiInterface
+	db           *sql.DB
 }
 
-// NewFilterProfileManager creates a new filter profile manager
-func NewFilterProfileManager(db *sql.DB) *FilterProfileManager {
+// NewFilterProfileManager creates a new filter profile manager that reads from db,
+// normally the read pool, and queues its writes through vy
+func NewFilterProfileManager(vy VeriYoneticiInterface, db *sql.DB) *FilterProfileManager {
 	return &FilterProfileManager{
-		db: db,
+		veriYonetici: vy,
+		db:           db,
+	}
+}
+
+// exec runs a write statement
+func (fpm *FilterProfileManager) exec(query string, args ...interface{}) (sql.Result, error) {
+	return yardimciExec(fpm.veriYonetici, fpm.db, query, args...)
+}
+
+// yardimciExec runs a write statement on a table without task data through the write
+// queue of vy, so that it waits its turn with the other writes. Without vy it runs on db.
+func yardimciExec(vy VeriYoneticiInterface, db *sql.DB, query string, args ...interface{}) (sql.Result, error) {
+	if vy == nil {
+		return db.Exec(query, args...)
 	}
+	var result sql.Result
+	err := vy.YardimciYazmaIslemi(context.Background(), func(tx *sql.Tx) error {
+		var err error
+		result, err = tx.Exec(query, args...)
+		return err
+	})
+	return result, err
 }
 
 // SaveFilterProfile saves a new filter profile or updates existing one
@@ -205,7 +293,7 @@ func (fpm *FilterProfileManager) DeleteProfile(id string) error {
 	}
 
 	query := "DELETE FROM filter_profiles WHERE id = ?"
-	result, err := fpm.db.Exec(query, id)
+	result, err := fpm.exec(query, id)
 	if err != nil {
 		return fmt.Errorf(i18n.T("error.filterProfileDeleteFailed", map[string]interface{}{"Error": err}))
 	}
@@ -233,6 +321,11 @@ func (fpm *FilterProfileManager) createFilterProfile(profile *FilterProfile) err
 		return fmt.Errorf(i18n.T("errors.filter_profile_name_required", nil))
 	}
 
+	// The filters and the search query must form a valid query
+	if _, err := profile.Query(); err != nil {
+		return err
+	}
+
 	// Check if name already exists
 	exists, err := fpm.filterProfileNameExists(profile.Name, "")
 	if err != nil {
@@ -256,7 +349,7 @@ func (fpm *FilterProfileManager) createFilterProfile(profile *FilterProfile) err
 		VALUES (?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := fpm.db.Exec(query,
+	result, err := fpm.exec(query,
 		profile.Name,
 		profile.Description,
 		string(filtersJSON),
@@ -292,6 +385,11 @@ func (fpm *FilterProfileManager) updateFilterProfile(profile *FilterProfile) err
 		return fmt.Errorf(i18n.T("errors.filter_profile_name_required", nil))
 	}
 
+	// The filters and the search query must form a valid query
+	if _, err := profile.Query(); err != nil {
+		return err
+	}
+
 	// Check if name already exists (excluding current profile)
 	exists, err := fpm.filterProfileNameExists(profile.Name, profile.ID)
 	if err != nil {
@@ -316,7 +414,7 @@ func (fpm *FilterProfileManager) updateFilterProfile(profile *FilterProfile) err
 		WHERE id = ?
 	`
 
-	result, err := fpm.db.Exec(query,
+	result, err := fpm.exec(query,
 		profile.Name,
 		profile.Description,
 		string(filtersJSON),
@@ -355,34 +453,52 @@ func (fpm *FilterProfileManager) GetFilterProfile(id int) (*FilterProfile, error
 		WHERE id = ?
 	`
 
+	profile, err := scanFilterProfile(fpm.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf(i18n.T("errors.filter_profile_not_found", map[string]interface{}{
+			"id": id,
+		}))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// scanFilterProfile reads a row of id, name, description, filters, search_query,
+// is_default, created_at, last_used_at and use_count; the default profiles have no
+// search query
+func scanFilterProfile(row interface {
+	Scan(dest ...interface{}) error
+}) (*FilterProfile, error) {
 	var profile FilterProfile
 	var filtersJSON string
+	var description, searchQuery sql.NullString
 	var lastUsedAt sql.NullTime
 
-	err := fpm.db.QueryRow(query, id).Scan(
+	err := row.Scan(
 		&profile.ID,
 		&profile.Name,
-		&profile.Description,
+		&description,
 		&filtersJSON,
-		&profile.SearchQuery,
+		&searchQuery,
 		&profile.IsDefault,
 		&profile.CreatedAt,
 		&lastUsedAt,
 		&profile.UseCount,
 	)
-
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf(i18n.T("errors.filter_profile_not_found", map[string]interface{}{
-				"id": id,
-			}))
-		}
 		return nil, fmt.Errorf(i18n.T("error.filterProfileGetFailed", map[string]interface{}{"Error": err}))
 	}
+	profile.Description = description.String
+	profile.SearchQuery = searchQuery.String
 
 	// Parse filters JSON
-	err = json.Unmarshal([]byte(filtersJSON), &profile.Filters)
-	if err != nil {
+	if err := json.Unmarshal([]byte(filtersJSON), &profile.Filters); err != nil {
 		return nil, fmt.Errorf(i18n.T("error.filterProfileParseFailed", map[string]interface{}{"Error": err}))
 	}
 
@@ -418,40 +534,13 @@ func (fpm *FilterProfileManager) ListFilterProfiles(defaultsOnly bool) ([]*Filte
 	var profiles []*FilterProfile
 
 	for rows.Next() {
-		var profile FilterProfile
-		var filtersJSON string
-		var lastUsedAt sql.NullTime
-
-		err := rows.Scan(
-			&profile.ID,
-			&profile.Name,
-			&profile.Description,
-			&filtersJSON,
-			&profile.SearchQuery,
-			&profile.IsDefault,
-			&profile.CreatedAt,
-			&lastUsedAt,
-			&profile.UseCount,
-		)
-
+		profile, err := scanFilterProfile(rows)
 		if err != nil {
-			log.Printf("%s", i18n.T("error.scanResultFailed", map[string]interface{}{"Error": err}))
-			continue
-		}
-
-		// Parse filters JSON
-		err = json.Unmarshal([]byte(filtersJSON), &profile.Filters)
-		if err != nil {
-			log.Printf("%s", i18n.T("error.filterProfileParseFailed", map[string]interface{}{"Error": err}))
+			log.Printf("%s", err)
 			continue
 		}
 
-		// Set last used time if available
-		if lastUsedAt.Valid {
-			profile.LastUsedAt = &lastUsedAt.Time
-		}
-
-		profiles = append(profiles, &profile)
+		profiles = append(profiles, profile)
 	}
 
 	return profiles, nil
@@ -470,7 +559,7 @@ func (fpm *FilterProfileManager) DeleteFilterProfile(id int) error {
 	}
 
 	query := "DELETE FROM filter_profiles WHERE id = ?"
-	result, err := fpm.db.Exec(query, id)
+	result, err := fpm.exec(query, id)
 	if err != nil {
 		return fmt.Errorf(i18n.T("error.filterProfileDeleteFailed", map[string]interface{}{"Error": err}))
 	}
@@ -500,7 +589,7 @@ func (fpm *FilterProfileManager) MarkProfileUsed(id int) error {
 		WHERE id = ?
 	`
 
-	result, err := fpm.db.Exec(query, time.Now(), id)
+	result, err := fpm.exec(query, time.Now(), id)
 	if err != nil {
 		return fmt.Errorf(i18n.T("error.filterProfileUseFailed", map[string]interface{}{"Error": err}))
 	}
@@ -548,40 +637,13 @@ func (fpm *FilterProfileManager) GetMostUsedProfiles(limit int) ([]*FilterProfil
 	var profiles []*FilterProfile
 
 	for rows.Next() {
-		var profile FilterProfile
-		var filtersJSON string
-		var lastUsedAt sql.NullTime
-
-		err := rows.Scan(
-			&profile.ID,
-			&profile.Name,
-			&profile.Description,
-			&filtersJSON,
-			&profile.SearchQuery,
-			&profile.IsDefault,
-			&profile.CreatedAt,
-			&lastUsedAt,
-			&profile.UseCount,
-		)
-
-		if err != nil {
-			log.Printf("%s", i18n.T("error.scanResultFailed", map[string]interface{}{"Error": err}))
-			continue
-		}
-
-		// Parse filters JSON
-		err = json.Unmarshal([]byte(filtersJSON), &profile.Filters)
+		profile, err := scanFilterProfile(rows)
 		if err != nil {
-			log.Printf("%s", i18n.T("error.filterProfileParseFailed", map[string]interface{}{"Error": err}))
+			log.Printf("%s", err)
 			continue
 		}
 
-		// Set last used time if available
-		if lastUsedAt.Valid {
-			profile.LastUsedAt = &lastUsedAt.Time
-		}
-
-		profiles = append(profiles, &profile)
+		profiles = append(profiles, profile)
 	}
 
 	return profiles, nil
@@ -607,40 +669,13 @@ func (fpm *FilterProfileManager) SearchFilterProfiles(query string) ([]*FilterPr
 	var profiles []*FilterProfile
 
 	for rows.Next() {
-		var profile FilterProfile
-		var filtersJSON string
-		var lastUsedAt sql.NullTime
-
-		err := rows.Scan(
-			&profile.ID,
-			&profile.Name,
-			&profile.Description,
-			&filtersJSON,
-			&profile.SearchQuery,
-			&profile.IsDefault,
-			&profile.CreatedAt,
-			&lastUsedAt,
-			&profile.UseCount,
-		)
-
+		profile, err := scanFilterProfile(rows)
 		if err != nil {
-			log.Printf("%s", i18n.T("error.scanResultFailed", map[string]interface{}{"Error": err}))
+			log.Printf("%s", err)
 			continue
 		}
 
-		// Parse filters JSON
-		err = json.Unmarshal([]byte(filtersJSON), &profile.Filters)
-		if err != nil {
-			log.Printf("%s", i18n.T("error.filterProfileParseFailed", map[string]interface{}{"Error": err}))
-			continue
-		}
-
-		// Set last used time if available
-		if lastUsedAt.Valid {
-			profile.LastUsedAt = &lastUsedAt.Time
-		}
-
-		profiles = append(profiles, &profile)
+		profiles = append(profiles, profile)
 	}
 
 	return profiles, nil
@@ -723,7 +758,7 @@ func (fpm *FilterProfileManager) CleanOldSearchHistory(daysOld int) error {
 	cutoffDate := time.Now().AddDate(0, 0, -daysOld)
 
 	query := "DELETE FROM search_history WHERE created_at < ?"
-	result, err := fpm.db.Exec(query, cutoffDate)
+	result, err := fpm.exec(query, cutoffDate)
 	if err != nil {
 		return fmt.Errorf(i18n.T("error.searchHistoryCleanFailed", map[string]interface{}{"Error": err}))
 	}
@@ -0,0 +1,289 @@
+package gorev
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// iCalendar component selection for ICalOptions.Component
+const (
+	ICalComponentTodo  = "vtodo"
+	ICalComponentEvent = "vevent"
+	ICalComponentBoth  = "both"
+)
+
+const (
+	icalProdID          = "-//Gorev//Gorev Task Calendar//EN"
+	icalUIDDomain       = "gorev"
+	icalDateFormat      = "20060102"
+	icalDateTimeFormat  = "20060102T150405Z"
+	icalMaxLineOctets   = 75
+	icalDefaultRefresh  = 15 * time.Minute
+	icalDefaultCalendar = "Gorev"
+)
+
+// ICalOptions controls how tasks are rendered into an iCalendar document
+type ICalOptions struct {
+	Component       string            `json:"component"`        // vtodo, vevent, both
+	CalendarName    string            `json:"calendar_name"`    // X-WR-CALNAME shown by calendar apps
+	RefreshInterval time.Duration     `json:"refresh_interval"` // Suggested polling interval for subscribers
+	ProjectNames    map[string]string `json:"-"`                // Project ID -> name, used for X-GOREV-PROJECT
+}
+
+// WriteICal writes every task that has a due date as iCalendar VTODO and/or VEVENT
+// entries (RFC 5545). Tasks without a due date are skipped.
+func WriteICal(w io.Writer, tasks []*Gorev, options ICalOptions) error {
+	component := options.Component
+	if component == "" {
+		component = ICalComponentBoth
+	}
+	if component != ICalComponentTodo && component != ICalComponentEvent && component != ICalComponentBoth {
+		return fmt.Errorf(i18n.T("error.invalidICalComponent", map[string]interface{}{"Component": component}))
+	}
+
+	calendarName := options.CalendarName
+	if calendarName == "" {
+		calendarName = icalDefaultCalendar
+	}
+	refresh := options.RefreshInterval
+	if refresh <= 0 {
+		refresh = icalDefaultRefresh
+	}
+
+	iw := &icalWriter{w: bufio.NewWriter(w)}
+	stamp := time.Now().UTC().Format(icalDateTimeFormat)
+
+	iw.line("BEGIN:VCALENDAR")
+	iw.line("VERSION:2.0")
+	iw.line("PRODID:" + icalProdID)
+	iw.line("CALSCALE:GREGORIAN")
+	iw.line("METHOD:PUBLISH")
+	iw.line("X-WR-CALNAME:" + icalEscapeText(calendarName))
+	iw.line("REFRESH-INTERVAL;VALUE=DURATION:" + icalDuration(refresh))
+	iw.line("X-PUBLISHED-TTL:" + icalDuration(refresh))
+
+	for _, task := range tasks {
+		if task == nil || task.DueDate == nil {
+			continue
+		}
+		if component == ICalComponentTodo || component == ICalComponentBoth {
+			iw.writeTodo(task, stamp, options.ProjectNames)
+		}
+		if component == ICalComponentEvent || component == ICalComponentBoth {
+			iw.writeEvent(task, stamp, options.ProjectNames)
+		}
+	}
+
+	iw.line("END:VCALENDAR")
+
+	if iw.err != nil {
+		return iw.err
+	}
+	return iw.w.Flush()
+}
+
+// saveAsICS saves tasks with due dates from export data as an iCalendar file
+func (iy *IsYonetici) saveAsICS(exportData *ExportFormat, outputPath string) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.failedToCreateFile", map[string]interface{}{"Path": outputPath, "Error": err}))
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			fmt.Printf("Warning: failed to close file %s: %v\n", outputPath, cerr)
+		}
+	}()
+
+	projectNames := make(map[string]string)
+	for _, project := range exportData.Projects {
+		projectNames[project.ID] = project.Name
+	}
+
+	if err := WriteICal(file, exportData.Tasks, ICalOptions{ProjectNames: projectNames}); err != nil {
+		return fmt.Errorf(i18n.T("error.failedToWriteFile", map[string]interface{}{"Error": err}))
+	}
+
+	return nil
+}
+
+// icalWriter writes folded, CRLF-terminated content lines and remembers the first error
+type icalWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line writes a single content line, folding it at 75 octets as required by RFC 5545
+func (iw *icalWriter) line(content string) {
+	if iw.err != nil {
+		return
+	}
+
+	var b strings.Builder
+	lineLen := 0
+	for _, r := range content {
+		size := len(string(r))
+		if lineLen+size > icalMaxLineOctets {
+			b.WriteString("\r\n ")
+			lineLen = 1
+		}
+		b.WriteRune(r)
+		lineLen += size
+	}
+	b.WriteString("\r\n")
+
+	_, iw.err = iw.w.WriteString(b.String())
+}
+
+// writeTodo writes a task as a VTODO component
+func (iw *icalWriter) writeTodo(task *Gorev, stamp string, projectNames map[string]string) {
+	iw.line("BEGIN:VTODO")
+	iw.line("UID:" + icalUID(task.ID))
+	iw.line("DTSTAMP:" + stamp)
+	iw.writeCommon(task, projectNames)
+	iw.line(icalDateProperty("DUE", *task.DueDate))
+	iw.line("STATUS:" + icalTodoStatus(task.Status))
+	if task.Status == constants.TaskStatusCompleted {
+		iw.line("COMPLETED:" + task.UpdatedAt.UTC().Format(icalDateTimeFormat))
+		iw.line("PERCENT-COMPLETE:100")
+	}
+	if task.ParentID != "" {
+		iw.line("RELATED-TO;RELTYPE=PARENT:" + icalUID(task.ParentID))
+	}
+	iw.line("END:VTODO")
+}
+
+// writeEvent writes a task's due date as a VEVENT component so that calendar
+// apps without VTODO support still show it
+func (iw *icalWriter) writeEvent(task *Gorev, stamp string, projectNames map[string]string) {
+	due := *task.DueDate
+
+	iw.line("BEGIN:VEVENT")
+	iw.line("UID:" + icalUID(task.ID+"-due"))
+	iw.line("DTSTAMP:" + stamp)
+	iw.writeCommon(task, projectNames)
+	iw.line(icalDateProperty("DTSTART", due))
+	if icalIsAllDay(due) {
+		iw.line(icalDateProperty("DTEND", due.AddDate(0, 0, 1)))
+	}
+	iw.line("TRANSP:TRANSPARENT")
+	iw.line("STATUS:" + icalEventStatus(task.Status))
+	iw.line("END:VEVENT")
+}
+
+// writeCommon writes the properties shared by VTODO and VEVENT
+func (iw *icalWriter) writeCommon(task *Gorev, projectNames map[string]string) {
+	if !task.CreatedAt.IsZero() {
+		iw.line("CREATED:" + task.CreatedAt.UTC().Format(icalDateTimeFormat))
+	}
+	if !task.UpdatedAt.IsZero() {
+		iw.line("LAST-MODIFIED:" + task.UpdatedAt.UTC().Format(icalDateTimeFormat))
+	}
+	iw.line("SUMMARY:" + icalEscapeText(task.Title))
+	if task.Description != "" {
+		iw.line("DESCRIPTION:" + icalEscapeText(task.Description))
+	}
+	if priority := icalPriority(task.Priority); priority > 0 {
+		iw.line(fmt.Sprintf("PRIORITY:%d", priority))
+	}
+	if len(task.Tags) > 0 {
+		categories := make([]string, 0, len(task.Tags))
+		for _, tag := range task.Tags {
+			categories = append(categories, icalEscapeText(tag.Name))
+		}
+		iw.line("CATEGORIES:" + strings.Join(categories, ","))
+	}
+
+	projectName := task.ProjeName
+	if projectName == "" && task.ProjeID != "" {
+		projectName = projectNames[task.ProjeID]
+	}
+	if projectName != "" {
+		iw.line("X-GOREV-PROJECT:" + icalEscapeText(projectName))
+	}
+}
+
+// icalUID builds a globally unique identifier for a task component
+func icalUID(id string) string {
+	return id + "@" + icalUIDDomain
+}
+
+// icalIsAllDay reports whether a due date carries no time-of-day component
+func icalIsAllDay(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
+
+// icalDateProperty formats a date property as DATE for all-day values and UTC DATE-TIME otherwise
+func icalDateProperty(name string, t time.Time) string {
+	if icalIsAllDay(t) {
+		return name + ";VALUE=DATE:" + t.Format(icalDateFormat)
+	}
+	return name + ":" + t.UTC().Format(icalDateTimeFormat)
+}
+
+// icalTodoStatus maps a task status to a VTODO STATUS value
+func icalTodoStatus(status string) string {
+	switch status {
+	case constants.TaskStatusInProgress:
+		return "IN-PROCESS"
+	case constants.TaskStatusCompleted:
+		return "COMPLETED"
+	case constants.TaskStatusCancelled:
+		return "CANCELLED"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// icalEventStatus maps a task status to a VEVENT STATUS value
+func icalEventStatus(status string) string {
+	if status == constants.TaskStatusCancelled {
+		return "CANCELLED"
+	}
+	return "CONFIRMED"
+}
+
+// icalPriority maps a task priority to the iCalendar 1-9 scale (0 = undefined)
+func icalPriority(priority string) int {
+	switch priority {
+	case constants.PriorityHigh:
+		return 1
+	case constants.PriorityMedium:
+		return 5
+	case constants.PriorityLow:
+		return 9
+	default:
+		return 0
+	}
+}
+
+// icalEscapeText escapes a TEXT value per RFC 5545 section 3.3.11
+func icalEscapeText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// icalDuration formats a duration as an iCalendar DURATION value (e.g. PT15M)
+func icalDuration(d time.Duration) string {
+	minutes := int(d.Minutes())
+	if minutes < 1 {
+		minutes = 1
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("PT%dH", minutes/60)
+	}
+	return fmt.Sprintf("PT%dM", minutes)
+}
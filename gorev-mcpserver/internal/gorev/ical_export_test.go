@@ -0,0 +1,175 @@
+package gorev
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+func TestWriteICal(t *testing.T) {
+	due := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	dueWithTime := time.Date(2025, 3, 15, 9, 30, 0, 0, time.UTC)
+
+	tasks := []*Gorev{
+		{
+			ID:          "task-1",
+			Title:       "Release v1, final",
+			Description: "Line one\nLine two; with semicolon",
+			Status:      constants.TaskStatusInProgress,
+			Priority:    constants.PriorityHigh,
+			ProjeName:   "Backend",
+			DueDate:     &due,
+			Tags:        []*Etiket{{ID: "t1", Name: "release"}, {ID: "t2", Name: "urgent"}},
+			CreatedAt:   due.AddDate(0, 0, -7),
+			UpdatedAt:   due.AddDate(0, 0, -1),
+		},
+		{
+			ID:        "task-2",
+			Title:     "Done task",
+			Status:    constants.TaskStatusCompleted,
+			Priority:  constants.PriorityLow,
+			ParentID:  "task-1",
+			DueDate:   &dueWithTime,
+			UpdatedAt: dueWithTime,
+		},
+		{
+			ID:       "task-3",
+			Title:    "No due date",
+			Status:   constants.TaskStatusPending,
+			Priority: constants.PriorityMedium,
+		},
+	}
+
+	t.Run("both components", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteICal(&buf, tasks, ICalOptions{CalendarName: "Test"}); err != nil {
+			t.Fatalf("WriteICal failed: %v", err)
+		}
+		out := buf.String()
+
+		expected := []string{
+			"BEGIN:VCALENDAR\r\n",
+			"X-WR-CALNAME:Test\r\n",
+			"UID:task-1@gorev\r\n",
+			"UID:task-1-due@gorev\r\n",
+			"SUMMARY:Release v1\\, final\r\n",
+			"DESCRIPTION:Line one\\nLine two\\; with semicolon\r\n",
+			"DUE;VALUE=DATE:20250314\r\n",
+			"DTSTART;VALUE=DATE:20250314\r\n",
+			"DTEND;VALUE=DATE:20250315\r\n",
+			"STATUS:IN-PROCESS\r\n",
+			"PRIORITY:1\r\n",
+			"CATEGORIES:release,urgent\r\n",
+			"X-GOREV-PROJECT:Backend\r\n",
+			"DUE:20250315T093000Z\r\n",
+			"STATUS:COMPLETED\r\n",
+			"PERCENT-COMPLETE:100\r\n",
+			"PRIORITY:9\r\n",
+			"RELATED-TO;RELTYPE=PARENT:task-1@gorev\r\n",
+			"END:VCALENDAR\r\n",
+		}
+		for _, want := range expected {
+			if !strings.Contains(out, want) {
+				t.Errorf("output missing %q", want)
+			}
+		}
+
+		if strings.Contains(out, "No due date") {
+			t.Error("tasks without due date should be skipped")
+		}
+		if got := strings.Count(out, "BEGIN:VTODO"); got != 2 {
+			t.Errorf("expected 2 VTODO entries, got %d", got)
+		}
+		if got := strings.Count(out, "BEGIN:VEVENT"); got != 2 {
+			t.Errorf("expected 2 VEVENT entries, got %d", got)
+		}
+	})
+
+	t.Run("todo only", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteICal(&buf, tasks, ICalOptions{Component: ICalComponentTodo}); err != nil {
+			t.Fatalf("WriteICal failed: %v", err)
+		}
+		if strings.Contains(buf.String(), "BEGIN:VEVENT") {
+			t.Error("VEVENT should not be written when component is vtodo")
+		}
+	})
+
+	t.Run("invalid component", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteICal(&buf, tasks, ICalOptions{Component: "vjournal"}); err == nil {
+			t.Error("expected error for invalid component")
+		}
+	})
+
+	t.Run("long lines are folded", func(t *testing.T) {
+		long := &Gorev{ID: "long", Title: strings.Repeat("ğ", 100), DueDate: &due}
+		var buf bytes.Buffer
+		if err := WriteICal(&buf, []*Gorev{long}, ICalOptions{}); err != nil {
+			t.Fatalf("WriteICal failed: %v", err)
+		}
+		for _, line := range strings.Split(buf.String(), "\r\n") {
+			if len(line) > 75 {
+				t.Errorf("line exceeds 75 octets (%d): %q", len(line), line)
+			}
+		}
+	})
+}
+
+func TestSaveExportAsICS(t *testing.T) {
+	vy, err := YeniVeriYonetici(":memory:", "file://../../internal/veri/migrations")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer func() {
+		_ = vy.Kapat()
+	}()
+
+	iy := YeniIsYonetici(vy)
+	setupTestData(t, vy)
+
+	due := time.Now().AddDate(0, 0, 3).Truncate(24 * time.Hour)
+	if err := vy.GorevKaydet(context.Background(), &Gorev{
+		ID:        "test-task-due",
+		Title:     "Task with due date",
+		Status:    constants.TaskStatusPending,
+		Priority:  constants.PriorityMedium,
+		ProjeID:   "test-project-1",
+		DueDate:   &due,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	data, err := iy.ExportData(context.Background(), ExportOptions{IncludeCompleted: true})
+	if err != nil {
+		t.Fatalf("ExportData failed: %v", err)
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "tasks.ics")
+	if err := iy.SaveExportToFile(context.Background(), data, ExportOptions{OutputPath: outputPath, Format: "ics"}); err != nil {
+		t.Fatalf("SaveExportToFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read ics file: %v", err)
+	}
+	out := string(content)
+	if !strings.Contains(out, "UID:test-task-due@gorev") {
+		t.Error("task with due date should be exported")
+	}
+	if !strings.Contains(out, "X-GOREV-PROJECT:Test Project") {
+		t.Error("project name should be exported")
+	}
+	if strings.Contains(out, "test-task-1@gorev") {
+		t.Error("task without due date should not be exported")
+	}
+}
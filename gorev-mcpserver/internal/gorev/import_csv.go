@@ -0,0 +1,281 @@
+package gorev
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// Task fields a CSV column can be mapped to
+const (
+	csvFieldID          = "id"
+	csvFieldTitle       = "title"
+	csvFieldDescription = "description"
+	csvFieldStatus      = "status"
+	csvFieldPriority    = "priority"
+	csvFieldProject     = "project"
+	csvFieldParent      = "parent"
+	csvFieldDueDate     = "due_date"
+	csvFieldTags        = "tags"
+	csvFieldCreatedAt   = "created_at"
+	csvFieldUpdatedAt   = "updated_at"
+	csvFieldIgnore      = "ignore"
+)
+
+// csvHeaderAliases maps normalized CSV headers to task fields.
+// Covers Gorev's own CSV export plus common spreadsheet column names (EN/TR).
+var csvHeaderAliases = map[string]string{
+	"id": csvFieldID, "task_id": csvFieldID, "gorev_id": csvFieldID,
+	"title": csvFieldTitle, "name": csvFieldTitle, "summary": csvFieldTitle, "task": csvFieldTitle,
+	"baslik": csvFieldTitle, "başlık": csvFieldTitle, "gorev": csvFieldTitle, "görev": csvFieldTitle,
+	"description": csvFieldDescription, "details": csvFieldDescription, "notes": csvFieldDescription,
+	"aciklama": csvFieldDescription, "açıklama": csvFieldDescription,
+	"status": csvFieldStatus, "state": csvFieldStatus, "durum": csvFieldStatus,
+	"priority": csvFieldPriority, "oncelik": csvFieldPriority, "öncelik": csvFieldPriority,
+	"project": csvFieldProject, "project_name": csvFieldProject, "project_id": csvFieldProject,
+	"proje": csvFieldProject, "proje_id": csvFieldProject,
+	"parent": csvFieldParent, "parent_id": csvFieldParent, "parent_title": csvFieldParent,
+	"parent_task": csvFieldParent, "ust_gorev": csvFieldParent, "üst_görev": csvFieldParent,
+	"due": csvFieldDueDate, "due_date": csvFieldDueDate, "deadline": csvFieldDueDate,
+	"son_tarih": csvFieldDueDate,
+	"tags":      csvFieldTags, "labels": csvFieldTags, "etiketler": csvFieldTags, "etiket": csvFieldTags,
+	"created": csvFieldCreatedAt, "created_at": csvFieldCreatedAt, "olusturma_tarihi": csvFieldCreatedAt,
+	"updated": csvFieldUpdatedAt, "updated_at": csvFieldUpdatedAt, "guncelleme_tarihi": csvFieldUpdatedAt,
+}
+
+// csvKnownFields is the set of valid targets for ImportOptions.ColumnMapping
+var csvKnownFields = map[string]bool{
+	csvFieldID: true, csvFieldTitle: true, csvFieldDescription: true, csvFieldStatus: true,
+	csvFieldPriority: true, csvFieldProject: true, csvFieldParent: true, csvFieldDueDate: true,
+	csvFieldTags: true, csvFieldCreatedAt: true, csvFieldUpdatedAt: true, csvFieldIgnore: true,
+}
+
+// normalizeCSVHeader lowercases a header and replaces separators with underscores
+func normalizeCSVHeader(header string) string {
+	header = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header, "\uFEFF")))
+	return strings.NewReplacer(" ", "_", "-", "_", ".", "_").Replace(header)
+}
+
+// csvColumnFields resolves each CSV column to a task field using the custom mapping first and header aliases second
+func csvColumnFields(headers []string, mapping map[string]string) ([]string, []string, error) {
+	normalizedMapping := make(map[string]string, len(mapping))
+	for header, field := range mapping {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if !csvKnownFields[field] {
+			return nil, nil, fmt.Errorf(i18n.T("error.unknownColumnMappingField", map[string]interface{}{"Column": header, "Field": field}))
+		}
+		normalizedMapping[normalizeCSVHeader(header)] = field
+	}
+
+	fields := make([]string, len(headers))
+	warnings := []string{}
+	hasTitle := false
+	for i, header := range headers {
+		key := normalizeCSVHeader(header)
+		field, ok := normalizedMapping[key]
+		if !ok {
+			field, ok = csvHeaderAliases[key]
+		}
+		if !ok {
+			field = csvFieldIgnore
+			if key != "" {
+				warnings = append(warnings, i18n.T("import.csvColumnIgnored", map[string]interface{}{"Column": header}))
+			}
+		}
+		fields[i] = field
+		if field == csvFieldTitle {
+			hasTitle = true
+		}
+	}
+
+	if !hasTitle {
+		return nil, nil, fmt.Errorf(i18n.T("error.csvMissingTitleColumn", nil))
+	}
+	return fields, warnings, nil
+}
+
+// csvImportRow is a parsed CSV row whose parent reference is resolved after all rows are read
+type csvImportRow struct {
+	line      int
+	task      *Gorev
+	parentRef string
+}
+
+// csvImporter imports generic CSV/TSV task lists, including Gorev's own CSV export
+type csvImporter struct {
+	format string
+}
+
+func init() {
+	registerImporter("csv", csvImporter{format: "csv"})
+	registerImporter("tsv", csvImporter{format: "tsv"})
+}
+
+// readCSVRecords reads all records of a CSV/TSV file, detecting the delimiter from the header line
+func readCSVRecords(r io.Reader, format string) ([][]string, error) {
+	br := bufio.NewReader(r)
+
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	reader.Comma = detectCSVDelimiter(br, format)
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.csvReadFailed", map[string]interface{}{"Error": err}))
+	}
+	return records, nil
+}
+
+// load converts CSV rows into tasks, mapping columns by header or ImportOptions.ColumnMapping
+func (ci csvImporter) load(ctx context.Context, b *importBuilder, r io.Reader, options ImportOptions) error {
+	records, err := readCSVRecords(r, ci.format)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return fmt.Errorf(i18n.T("error.csvMissingTitleColumn", nil))
+	}
+
+	fields, warnings, err := csvColumnFields(records[0], options.ColumnMapping)
+	if err != nil {
+		return err
+	}
+	b.warnings = append(b.warnings, warnings...)
+
+	rows := []*csvImportRow{}
+	for i, record := range records[1:] {
+		line := i + 2
+		row := &csvImportRow{line: line, task: &Gorev{}}
+		var tagNames []string
+		empty := true
+
+		for col, value := range record {
+			if col >= len(fields) {
+				break
+			}
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			empty = false
+
+			switch fields[col] {
+			case csvFieldID:
+				row.task.ID = value
+			case csvFieldTitle:
+				row.task.Title = value
+			case csvFieldDescription:
+				row.task.Description = value
+			case csvFieldStatus:
+				if status, ok := normalizeImportStatus(value); ok {
+					row.task.Status = status
+				} else {
+					b.warn("import.csvUnknownStatus", map[string]interface{}{"Line": line, "Value": value})
+				}
+			case csvFieldPriority:
+				if priority, ok := normalizeImportPriority(value); ok {
+					row.task.Priority = priority
+				} else {
+					b.warn("import.csvUnknownPriority", map[string]interface{}{"Line": line, "Value": value})
+				}
+			case csvFieldProject:
+				row.task.ProjeID = b.projectID(value, "")
+			case csvFieldParent:
+				row.parentRef = value
+			case csvFieldDueDate, csvFieldCreatedAt, csvFieldUpdatedAt:
+				parsed, err := parseImportDate(value)
+				if err != nil {
+					b.warn("import.csvInvalidDate", map[string]interface{}{"Line": line, "Value": value})
+					continue
+				}
+				switch fields[col] {
+				case csvFieldDueDate:
+					row.task.DueDate = parsed
+				case csvFieldCreatedAt:
+					row.task.CreatedAt = *parsed
+				default:
+					row.task.UpdatedAt = *parsed
+				}
+			case csvFieldTags:
+				tagNames = append(tagNames, splitImportTags(value)...)
+			}
+		}
+
+		if empty {
+			continue
+		}
+		if row.task.Title == "" {
+			b.warn("import.csvMissingTitle", map[string]interface{}{"Line": line})
+			continue
+		}
+
+		b.addTask(row.task, tagNames)
+		rows = append(rows, row)
+	}
+
+	resolveCSVParents(ctx, b, rows)
+	return nil
+}
+
+// resolveCSVParents resolves parent references by task ID or title, looking at
+// rows of the same file first and existing tasks second
+func resolveCSVParents(ctx context.Context, b *importBuilder, rows []*csvImportRow) {
+	byID := make(map[string]*Gorev, len(rows))
+	byTitle := make(map[string]*Gorev, len(rows))
+	for _, row := range rows {
+		byID[row.task.ID] = row.task
+		key := strings.ToLower(row.task.Title)
+		if _, exists := byTitle[key]; !exists {
+			byTitle[key] = row.task
+		}
+	}
+
+	for _, row := range rows {
+		if row.parentRef == "" {
+			continue
+		}
+
+		parentID := ""
+		if parent, ok := byID[row.parentRef]; ok {
+			parentID = parent.ID
+		} else if parent, ok := byTitle[strings.ToLower(row.parentRef)]; ok {
+			parentID = parent.ID
+		} else {
+			parentID = b.existingTaskID(ctx, row.parentRef)
+		}
+
+		if parentID == "" || parentID == row.task.ID {
+			b.warn("import.csvParentNotFound", map[string]interface{}{"Line": row.line, "Value": row.parentRef})
+			continue
+		}
+		row.task.ParentID = parentID
+	}
+}
+
+// detectCSVDelimiter returns the column delimiter: tab for TSV, otherwise ',' unless
+// the header line uses ';' (common for spreadsheets saved with a comma decimal separator)
+func detectCSVDelimiter(br *bufio.Reader, format string) rune {
+	if format == "tsv" {
+		return '\t'
+	}
+
+	peek, _ := br.Peek(4096)
+	header := string(peek)
+	if idx := strings.IndexAny(header, "\r\n"); idx >= 0 {
+		header = header[:idx]
+	}
+	if strings.Count(header, ";") > strings.Count(header, ",") {
+		return ';'
+	}
+	if strings.Count(header, "\t") > strings.Count(header, ",") {
+		return '\t'
+	}
+	return ','
+}
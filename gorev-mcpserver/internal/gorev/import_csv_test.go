@@ -0,0 +1,226 @@
+package gorev
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+func newImportTestManager(t *testing.T) (*IsYonetici, *VeriYonetici) {
+	vy, err := YeniVeriYonetici(":memory:", "file://../../internal/veri/migrations")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = vy.Kapat()
+	})
+	setupTestData(t, vy)
+	return YeniIsYonetici(vy), vy
+}
+
+func writeImportFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write import file: %v", err)
+	}
+	return path
+}
+
+func findTaskByTitle(t *testing.T, iy *IsYonetici, title string) *Gorev {
+	tasks, err := iy.GorevListele(context.Background(), map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("GorevListele failed: %v", err)
+	}
+	for _, task := range tasks {
+		if task.Title == title {
+			// GorevGetir loads tags as well
+			full, err := iy.veriYonetici.GorevGetir(context.Background(), task.ID)
+			if err != nil {
+				t.Fatalf("GorevGetir failed: %v", err)
+			}
+			return full
+		}
+	}
+	t.Fatalf("task %q not found", title)
+	return nil
+}
+
+func TestImportCSV(t *testing.T) {
+	iy, _ := newImportTestManager(t)
+	ctx := context.Background()
+
+	csvContent := "Summary,State,Priority,Project,Parent,Due,Labels,Owner\n" +
+		"Epic task,In Progress,High,Test Project,,2025-06-01,\"backend, api\",alice\n" +
+		"Child task,done,p3,Test Project,Epic task,,api|docs,bob\n" +
+		"New project task,weird,urgent,Imported Project,test-task-1,,,carol\n" +
+		",todo,low,,,,,\n"
+	path := writeImportFile(t, "tasks.csv", csvContent)
+
+	result, err := iy.ImportData(ctx, ImportOptions{FilePath: path, ImportMode: "merge", ConflictResolution: "skip"})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if result.ImportedTasks != 3 {
+		t.Errorf("expected 3 imported tasks, got %d", result.ImportedTasks)
+	}
+	if result.ImportedProjects != 1 {
+		t.Errorf("expected 1 new project, got %d", result.ImportedProjects)
+	}
+
+	// Ignored "Owner" column, unknown status "weird" and the row without title
+	if len(result.Warnings) != 3 {
+		t.Errorf("expected 3 warnings, got %d: %s", len(result.Warnings), strings.Join(result.Warnings, "; "))
+	}
+
+	epic := findTaskByTitle(t, iy, "Epic task")
+	if epic.Status != constants.TaskStatusInProgress || epic.Priority != constants.PriorityHigh {
+		t.Errorf("unexpected epic status/priority: %s/%s", epic.Status, epic.Priority)
+	}
+	if epic.ProjeID != "test-project-1" {
+		t.Errorf("epic should be added to the existing project, got %q", epic.ProjeID)
+	}
+	if epic.DueDate == nil || epic.DueDate.Format(constants.DateFormatISO) != "2025-06-01" {
+		t.Errorf("unexpected due date: %v", epic.DueDate)
+	}
+	if len(epic.Tags) != 2 {
+		t.Errorf("expected 2 tags on epic, got %d", len(epic.Tags))
+	}
+
+	child := findTaskByTitle(t, iy, "Child task")
+	if child.ParentID != epic.ID {
+		t.Errorf("child parent should resolve by title to %s, got %q", epic.ID, child.ParentID)
+	}
+	if child.Status != constants.TaskStatusCompleted || child.Priority != constants.PriorityLow {
+		t.Errorf("unexpected child status/priority: %s/%s", child.Status, child.Priority)
+	}
+
+	other := findTaskByTitle(t, iy, "New project task")
+	if other.Status != constants.TaskStatusPending {
+		t.Errorf("unknown status should fall back to pending, got %s", other.Status)
+	}
+	if other.ParentID != "test-task-1" {
+		t.Errorf("parent should resolve to existing task ID, got %q", other.ParentID)
+	}
+	if other.ProjeID == "" || other.ProjeID == "test-project-1" {
+		t.Errorf("task should belong to the newly created project, got %q", other.ProjeID)
+	}
+}
+
+func TestImportCSVColumnMappingAndTSV(t *testing.T) {
+	iy, _ := newImportTestManager(t)
+	ctx := context.Background()
+
+	path := writeImportFile(t, "tasks.txt", "Ticket\tWhat\tKind\n42\tMapped task\tbug;ui\n")
+	result, err := iy.ImportData(ctx, ImportOptions{
+		FilePath:      path,
+		Format:        "tsv",
+		ColumnMapping: map[string]string{"What": "title", "Kind": "tags", "Ticket": "ignore"},
+	})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if result.ImportedTasks != 1 {
+		t.Fatalf("expected 1 imported task, got %d", result.ImportedTasks)
+	}
+	if task := findTaskByTitle(t, iy, "Mapped task"); len(task.Tags) != 2 {
+		t.Errorf("expected 2 tags, got %d", len(task.Tags))
+	}
+
+	if _, err := iy.ImportData(ctx, ImportOptions{
+		FilePath:      path,
+		Format:        "tsv",
+		ColumnMapping: map[string]string{"What": "headline"},
+	}); err == nil {
+		t.Error("expected error for unknown mapping field")
+	}
+
+	noTitle := writeImportFile(t, "no_title.csv", "Foo,Bar\n1,2\n")
+	if _, err := iy.ImportData(ctx, ImportOptions{FilePath: noTitle}); err == nil {
+		t.Error("expected error when CSV has no title column")
+	}
+}
+
+func TestImportCSVDryRunConflicts(t *testing.T) {
+	iy, _ := newImportTestManager(t)
+	ctx := context.Background()
+
+	path := writeImportFile(t, "tasks.csv", "ID;Title;Status\ntest-task-1;Renamed;open\nbrand-new;Fresh;open\n")
+	result, err := iy.ImportData(ctx, ImportOptions{FilePath: path, DryRun: true, PreserveIDs: true, ConflictResolution: "skip"})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].Type != "task" {
+		t.Fatalf("expected one task conflict, got %+v", result.Conflicts)
+	}
+
+	if task := findTaskByTitle(t, iy, "Test Task 1"); task == nil {
+		t.Error("dry run must not modify existing tasks")
+	}
+}
+
+func TestImportCSVRoundTrip(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	ctx := context.Background()
+
+	etiketler, err := vy.EtiketleriGetirVeyaOlustur(ctx, []string{"alpha", "beta"})
+	if err != nil {
+		t.Fatalf("EtiketleriGetirVeyaOlustur failed: %v", err)
+	}
+	if err := vy.GorevEtiketleriniAyarla(ctx, "test-task-1", etiketler); err != nil {
+		t.Fatalf("GorevEtiketleriniAyarla failed: %v", err)
+	}
+
+	data, err := iy.ExportData(ctx, ExportOptions{IncludeCompleted: true})
+	if err != nil {
+		t.Fatalf("ExportData failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "export.csv")
+	if err := iy.SaveExportToFile(ctx, data, ExportOptions{OutputPath: path, Format: "csv"}); err != nil {
+		t.Fatalf("SaveExportToFile failed: %v", err)
+	}
+
+	target, _ := newImportTestManager(t)
+	result, err := target.ImportData(ctx, ImportOptions{FilePath: path})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if result.ImportedTasks != len(data.Tasks) {
+		t.Errorf("expected %d imported tasks, got %d", len(data.Tasks), result.ImportedTasks)
+	}
+	for _, warning := range result.Warnings {
+		t.Errorf("unexpected warning importing Gorev's own CSV export: %s", warning)
+	}
+}
+
+func TestNormalizeImportValues(t *testing.T) {
+	statuses := map[string]string{
+		"Done": constants.TaskStatusCompleted, "WIP": constants.TaskStatusInProgress,
+		"open": constants.TaskStatusPending, "Won't Do": constants.TaskStatusCancelled,
+		"tamamlandi": constants.TaskStatusCompleted,
+	}
+	for in, want := range statuses {
+		if got, ok := normalizeImportStatus(in); !ok || got != want {
+			t.Errorf("normalizeImportStatus(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+
+	priorities := map[string]string{
+		"Critical": constants.PriorityHigh, "normal": constants.PriorityMedium, "P3": constants.PriorityLow,
+	}
+	for in, want := range priorities {
+		if got, ok := normalizeImportPriority(in); !ok || got != want {
+			t.Errorf("normalizeImportPriority(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+
+	if tags := splitImportTags("[alpha beta]"); len(tags) != 2 {
+		t.Errorf("expected bracketed export tags to split, got %v", tags)
+	}
+	if tags := splitImportTags("#a, b; a |c"); len(tags) != 3 {
+		t.Errorf("expected 3 unique tags, got %v", tags)
+	}
+}
@@ -0,0 +1,148 @@
+package gorev
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// githubTaskListRef matches task list items referencing another issue ("- [ ] #12"),
+// which GitHub uses to track sub-issues in the parent issue body
+var githubTaskListRef = regexp.MustCompile(`(?m)^\s*[-*]\s+\[[ xX]\]\s+(?:https://github\.com/[^/\s]+/[^/\s]+/issues/|#)(\d+)\b`)
+
+// githubRepoFromURL extracts "owner/repo" from an issue URL
+var githubRepoFromURL = regexp.MustCompile(`^https://github\.com/([^/]+/[^/]+)/issues/\d+`)
+
+// githubIssue is an issue as written by `gh issue list --json ...`
+type githubIssue struct {
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	State       string     `json:"state"`
+	StateReason string     `json:"stateReason"`
+	URL         string     `json:"url"`
+	CreatedAt   *time.Time `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt"`
+	Labels      []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Milestone *struct {
+		Title string     `json:"title"`
+		DueOn *time.Time `json:"dueOn"`
+	} `json:"milestone"`
+}
+
+// githubImporter imports issues exported with `gh issue list --state all --json ...`
+type githubImporter struct{}
+
+func init() {
+	registerImporter("github", githubImporter{})
+}
+
+// load maps GitHub issues to tasks: one project per repository, labels to tags and
+// priority/status, milestone due dates to due dates and task list references to subtasks
+func (githubImporter) load(ctx context.Context, b *importBuilder, r io.Reader, options ImportOptions) error {
+	var issues []githubIssue
+	if err := json.NewDecoder(r).Decode(&issues); err != nil {
+		return fmt.Errorf(i18n.T("error.githubInvalidJSON", map[string]interface{}{"Error": err}))
+	}
+
+	byNumber := make(map[int]*Gorev, len(issues))
+	for _, issue := range issues {
+		if issue.Title == "" {
+			continue
+		}
+
+		ref := "#" + strconv.Itoa(issue.Number)
+		repo := "GitHub"
+		if match := githubRepoFromURL.FindStringSubmatch(issue.URL); match != nil {
+			repo = match[1]
+		}
+
+		task := &Gorev{
+			ID:          b.stableID("issue", repo+ref),
+			Title:       issue.Title,
+			Description: appendSourceReference(issue.Body, "GitHub", firstNonEmpty(issue.URL, ref)),
+			Status:      constants.TaskStatusPending,
+			Priority:    constants.PriorityMedium,
+			ProjeID:     b.projectID(repo, ""),
+		}
+
+		switch strings.ToUpper(issue.State) {
+		case "CLOSED":
+			task.Status = constants.TaskStatusCompleted
+			if strings.EqualFold(issue.StateReason, "NOT_PLANNED") {
+				task.Status = constants.TaskStatusCancelled
+			}
+		case "OPEN", "":
+			// Pending unless a workflow label says otherwise
+		default:
+			b.warn("import.unknownStatus", map[string]interface{}{"Item": ref, "Value": issue.State})
+		}
+
+		tags := make([]string, 0, len(issue.Labels)+1)
+		for _, label := range issue.Labels {
+			tags = append(tags, label.Name)
+			if priority, ok := labelPriority(label.Name); ok {
+				task.Priority = priority
+			}
+			// Open issues carrying a workflow label ("in progress", "doing") keep that state
+			if status, ok := normalizeImportStatus(label.Name); ok && task.Status == constants.TaskStatusPending && status == constants.TaskStatusInProgress {
+				task.Status = status
+			}
+		}
+		if issue.Milestone != nil {
+			tags = append(tags, issue.Milestone.Title)
+			task.DueDate = issue.Milestone.DueOn
+		}
+		if issue.CreatedAt != nil {
+			task.CreatedAt = *issue.CreatedAt
+		}
+		if issue.UpdatedAt != nil {
+			task.UpdatedAt = *issue.UpdatedAt
+		}
+
+		b.addTask(task, tags)
+		byNumber[issue.Number] = task
+	}
+
+	// Issues listed in another issue's task list become its subtasks
+	byID := make(map[string]*Gorev, len(byNumber))
+	for _, task := range byNumber {
+		byID[task.ID] = task
+	}
+	for _, issue := range issues {
+		parent, ok := byNumber[issue.Number]
+		if !ok {
+			continue
+		}
+		for _, match := range githubTaskListRef.FindAllStringSubmatch(issue.Body, -1) {
+			number, _ := strconv.Atoi(match[1])
+			child, ok := byNumber[number]
+			if !ok || child == parent || child.ParentID != "" || isImportAncestor(byID, child, parent) {
+				continue
+			}
+			child.ParentID = parent.ID
+		}
+	}
+
+	return nil
+}
+
+// isImportAncestor reports whether candidate is an ancestor of task, to avoid cyclic parent links
+func isImportAncestor(byID map[string]*Gorev, candidate, task *Gorev) bool {
+	for current := task; current != nil && current.ParentID != ""; current = byID[current.ParentID] {
+		if current.ParentID == candidate.ID {
+			return true
+		}
+	}
+	return false
+}
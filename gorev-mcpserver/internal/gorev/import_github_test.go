@@ -0,0 +1,76 @@
+package gorev
+
+import (
+	"context"
+	"testing"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+func TestImportGitHub(t *testing.T) {
+	iy, _ := newImportTestManager(t)
+	ctx := context.Background()
+
+	issues := `[
+	  {"number": 1, "title": "Tracking issue", "state": "OPEN", "url": "https://github.com/acme/app/issues/1",
+	   "body": "Sub-issues:\n- [x] #2\n- [ ] https://github.com/acme/app/issues/3\n",
+	   "labels": [{"name": "priority: high"}, {"name": "in progress"}],
+	   "milestone": {"title": "v1.0", "dueOn": "2025-05-01T00:00:00Z"},
+	   "createdAt": "2025-01-10T12:00:00Z", "updatedAt": "2025-01-12T12:00:00Z"},
+	  {"number": 2, "title": "Done child", "state": "CLOSED", "stateReason": "COMPLETED", "url": "https://github.com/acme/app/issues/2",
+	   "body": "", "labels": [{"name": "bug"}]},
+	  {"number": 3, "title": "Open child", "state": "OPEN", "url": "https://github.com/acme/app/issues/3", "body": "- [ ] #1"},
+	  {"number": 4, "title": "Not planned", "state": "CLOSED", "stateReason": "NOT_PLANNED", "url": "https://github.com/acme/app/issues/4"}
+	]`
+	path := writeImportFile(t, "issues.json", issues)
+
+	result, err := iy.ImportData(ctx, ImportOptions{FilePath: path, Format: "github"})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if result.ImportedTasks != 4 || result.ImportedProjects != 1 {
+		t.Fatalf("expected 4 tasks and 1 project, got %d tasks and %d projects", result.ImportedTasks, result.ImportedProjects)
+	}
+
+	parent := findTaskByTitle(t, iy, "Tracking issue")
+	if parent.Status != constants.TaskStatusInProgress || parent.Priority != constants.PriorityHigh {
+		t.Errorf("unexpected status/priority: %s/%s", parent.Status, parent.Priority)
+	}
+	if parent.DueDate == nil || parent.DueDate.Format(constants.DateFormatISO) != "2025-05-01" {
+		t.Errorf("milestone due date should be used, got %v", parent.DueDate)
+	}
+	if parent.ParentID != "" {
+		t.Errorf("cyclic task list reference must not create a parent link, got %q", parent.ParentID)
+	}
+
+	done := findTaskByTitle(t, iy, "Done child")
+	if done.ParentID != parent.ID || done.Status != constants.TaskStatusCompleted {
+		t.Errorf("unexpected done child: parent %q status %s", done.ParentID, done.Status)
+	}
+	if open := findTaskByTitle(t, iy, "Open child"); open.ParentID != parent.ID {
+		t.Errorf("URL task list reference should link parent, got %q", open.ParentID)
+	}
+	if cancelled := findTaskByTitle(t, iy, "Not planned"); cancelled.Status != constants.TaskStatusCancelled {
+		t.Errorf("not planned issue should be cancelled, got %s", cancelled.Status)
+	}
+
+	projects, err := iy.ProjeListele(ctx)
+	if err != nil {
+		t.Fatalf("ProjeListele failed: %v", err)
+	}
+	found := false
+	for _, project := range projects {
+		found = found || project.Name == "acme/app"
+	}
+	if !found {
+		t.Error("repository project should be created")
+	}
+}
+
+func TestImportGitHubInvalidJSON(t *testing.T) {
+	iy, _ := newImportTestManager(t)
+	path := writeImportFile(t, "issues.json", `{"not": "a list"}`)
+	if _, err := iy.ImportData(context.Background(), ImportOptions{FilePath: path, Format: "github"}); err == nil {
+		t.Error("expected error for invalid GitHub export")
+	}
+}
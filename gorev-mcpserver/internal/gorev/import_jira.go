@@ -0,0 +1,228 @@
+package gorev
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// jiraDateLayouts are the date formats used by Jira CSV exports ("Created", "Due Date")
+var jiraDateLayouts = []string{
+	"02/Jan/06 3:04 PM",
+	"2/Jan/06 3:04 PM",
+	"02/Jan/06 15:04",
+	"02/Jan/06",
+	"2/Jan/06",
+}
+
+// jiraCancelledResolutions are Jira resolutions meaning the issue was closed without being done
+var jiraCancelledResolutions = map[string]bool{
+	"won't do": true, "won't fix": true, "wontfix": true, "duplicate": true,
+	"cannot reproduce": true, "incomplete": true, "declined": true, "obsolete": true,
+}
+
+// jiraImporter imports issues from a Jira "Export CSV (all fields)" file
+type jiraImporter struct{}
+
+func init() {
+	registerImporter("jira", jiraImporter{})
+}
+
+// jiraIssue is a Jira CSV row with the references that are resolved after all rows are read
+type jiraIssue struct {
+	task      *Gorev
+	key       string
+	id        string
+	parentRef string
+	blocks    []string // Keys of issues this issue blocks
+	blockedBy []string // Keys of issues blocking this issue
+}
+
+// load maps Jira issues to tasks: projects by project name, labels, components and
+// issue type to tags, sub-tasks/epic children via "Parent id" and "Blocks" links to dependencies
+func (jiraImporter) load(ctx context.Context, b *importBuilder, r io.Reader, options ImportOptions) error {
+	records, err := readCSVRecords(r, "csv")
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return fmt.Errorf(i18n.T("error.jiraMissingColumns", nil))
+	}
+
+	// Jira repeats columns such as "Labels" or "Sprint" once per value, so keep every index
+	columns := make(map[string][]int)
+	for i, header := range records[0] {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header, "\uFEFF")))
+		columns[name] = append(columns[name], i)
+	}
+	if len(columns["summary"]) == 0 || len(columns["issue key"]) == 0 {
+		return fmt.Errorf(i18n.T("error.jiraMissingColumns", nil))
+	}
+
+	issues := []*jiraIssue{}
+	byRef := make(map[string]*jiraIssue)
+	for i, record := range records[1:] {
+		line := i + 2
+		get := func(column string) string {
+			for _, idx := range columns[column] {
+				if idx < len(record) && strings.TrimSpace(record[idx]) != "" {
+					return strings.TrimSpace(record[idx])
+				}
+			}
+			return ""
+		}
+		all := func(column string) []string {
+			var values []string
+			for _, idx := range columns[column] {
+				if idx < len(record) {
+					values = append(values, splitJiraValues(record[idx])...)
+				}
+			}
+			return values
+		}
+		cells := func(column string) []string {
+			var values []string
+			for _, idx := range columns[column] {
+				if idx < len(record) && strings.TrimSpace(record[idx]) != "" {
+					values = append(values, strings.TrimSpace(record[idx]))
+				}
+			}
+			return values
+		}
+
+		issue := &jiraIssue{
+			key:       get("issue key"),
+			id:        get("issue id"),
+			parentRef: firstNonEmpty(get("parent id"), get("parent"), get("parent key")),
+			blocks:    all("outward issue link (blocks)"),
+			blockedBy: all("inward issue link (blocks)"),
+		}
+		title := get("summary")
+		if title == "" || issue.key == "" {
+			b.warn("import.csvMissingTitle", map[string]interface{}{"Line": line})
+			continue
+		}
+
+		task := &Gorev{
+			ID:          b.stableID("issue", issue.key),
+			Title:       title,
+			Description: appendSourceReference(get("description"), "Jira", issue.key),
+			Status:      jiraStatus(b, issue.key, get("status"), get("status category"), get("resolution")),
+			Priority:    constants.PriorityMedium,
+		}
+		if value := get("priority"); value != "" {
+			if priority, ok := normalizeImportPriority(value); ok {
+				task.Priority = priority
+			} else {
+				b.warn("import.unknownPriority", map[string]interface{}{"Item": issue.key, "Value": value})
+			}
+		}
+		task.ProjeID = b.projectID(firstNonEmpty(get("project name"), get("project key"), "Jira"), get("project description"))
+		task.DueDate = parseJiraDate(b, issue.key, get("due date"))
+		if created := parseJiraDate(b, issue.key, get("created")); created != nil {
+			task.CreatedAt = *created
+		}
+		if updated := parseJiraDate(b, issue.key, get("updated")); updated != nil {
+			task.UpdatedAt = *updated
+		}
+
+		tags := append(all("labels"), cells("component/s")...)
+		if issueType := get("issue type"); issueType != "" {
+			tags = append(tags, strings.ToLower(issueType))
+		}
+
+		issue.task = task
+		b.addTask(task, tags)
+		issues = append(issues, issue)
+		byRef[issue.key] = issue
+		if issue.id != "" {
+			byRef[issue.id] = issue
+		}
+	}
+
+	for _, issue := range issues {
+		if issue.parentRef != "" {
+			if parent, ok := byRef[issue.parentRef]; ok && parent != issue {
+				issue.task.ParentID = parent.task.ID
+			} else if parentID := b.existingTaskID(ctx, issue.parentRef); parentID != "" {
+				issue.task.ParentID = parentID
+			} else {
+				b.warn("import.parentNotFound", map[string]interface{}{"Item": issue.key, "Value": issue.parentRef})
+			}
+		}
+
+		// Links to issues outside the export are dropped silently; the other side may not be migrated yet
+		for _, key := range issue.blocks {
+			if blocked, ok := byRef[key]; ok {
+				b.addDependency(issue.task.ID, blocked.task.ID)
+			}
+		}
+		for _, key := range issue.blockedBy {
+			if blocker, ok := byRef[key]; ok {
+				b.addDependency(blocker.task.ID, issue.task.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// jiraStatus maps a Jira status to a Gorev status, falling back to the status category and resolution
+func jiraStatus(b *importBuilder, key, status, category, resolution string) string {
+	if jiraCancelledResolutions[strings.ToLower(resolution)] {
+		return constants.TaskStatusCancelled
+	}
+	if mapped, ok := normalizeImportStatus(status); ok {
+		return mapped
+	}
+	if mapped, ok := normalizeImportStatus(category); ok {
+		return mapped
+	}
+	if resolution != "" && !strings.EqualFold(resolution, "unresolved") {
+		return constants.TaskStatusCompleted
+	}
+	if status != "" {
+		b.warn("import.unknownStatus", map[string]interface{}{"Item": key, "Value": status})
+	}
+	return constants.TaskStatusPending
+}
+
+// parseJiraDate parses a Jira export date, accepting the generic import formats as well
+func parseJiraDate(b *importBuilder, key, value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	for _, layout := range jiraDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t
+		}
+	}
+	t, err := parseImportDate(value)
+	if err != nil {
+		b.warn("import.invalidDate", map[string]interface{}{"Item": key, "Value": value})
+		return nil
+	}
+	return t
+}
+
+// splitJiraValues splits a multi-value Jira cell such as labels or issue keys of links
+func splitJiraValues(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == ';'
+	})
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+package gorev
+
+import (
+	"context"
+	"testing"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+func TestImportJira(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	ctx := context.Background()
+
+	jiraCSV := "Summary,Issue key,Issue id,Issue Type,Status,Priority,Resolution,Created,Due Date,Labels,Labels,Parent id,Project name,Outward issue link (Blocks),Description\n" +
+		"Payment epic,PAY-1,10001,Epic,In Progress,Highest,,01/Mar/24 9:15 AM,15/Apr/24,payments,,,Payments,PAY-3,Epic description\n" +
+		"Card form,PAY-2,10002,Sub-task,Done,Low,Done,02/Mar/24 10:00 AM,,frontend,ui,10001,Payments,,\n" +
+		"Webhooks,PAY-3,10003,Story,Code Review,Medium,,03/Mar/24 2:30 PM,,,,,Payments,,\n" +
+		"Old idea,PAY-4,10004,Story,Closed,Low,Won't Do,04/Mar/24 8:00 AM,,,,,Payments,,\n"
+	path := writeImportFile(t, "jira.csv", jiraCSV)
+
+	dryRun, err := iy.ImportData(ctx, ImportOptions{FilePath: path, Format: "jira", DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if len(dryRun.Conflicts) != 0 {
+		t.Errorf("expected no conflicts on first import, got %d", len(dryRun.Conflicts))
+	}
+
+	result, err := iy.ImportData(ctx, ImportOptions{FilePath: path, Format: "jira", PreserveIDs: true, ConflictResolution: "skip"})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if result.ImportedTasks != 4 || result.ImportedProjects != 1 {
+		t.Fatalf("expected 4 tasks and 1 project, got %d tasks and %d projects", result.ImportedTasks, result.ImportedProjects)
+	}
+	// "Code Review" is not a known status
+	if len(result.Warnings) != 1 {
+		t.Errorf("expected 1 warning, got %v", result.Warnings)
+	}
+
+	epic := findTaskByTitle(t, iy, "Payment epic")
+	if epic.Status != constants.TaskStatusInProgress || epic.Priority != constants.PriorityHigh {
+		t.Errorf("unexpected epic status/priority: %s/%s", epic.Status, epic.Priority)
+	}
+	if epic.DueDate == nil || epic.DueDate.Format(constants.DateFormatISO) != "2024-04-15" {
+		t.Errorf("unexpected due date: %v", epic.DueDate)
+	}
+
+	child := findTaskByTitle(t, iy, "Card form")
+	if child.ParentID != epic.ID {
+		t.Errorf("sub-task should be linked to its parent, got %q", child.ParentID)
+	}
+	if child.Status != constants.TaskStatusCompleted || len(child.Tags) != 3 {
+		t.Errorf("unexpected child status/tags: %s/%d", child.Status, len(child.Tags))
+	}
+
+	if idea := findTaskByTitle(t, iy, "Old idea"); idea.Status != constants.TaskStatusCancelled {
+		t.Errorf("won't do resolution should map to cancelled, got %s", idea.Status)
+	}
+
+	webhooks := findTaskByTitle(t, iy, "Webhooks")
+	links, err := vy.BaglantilariGetir(ctx, webhooks.ID)
+	if err != nil {
+		t.Fatalf("BaglantilariGetir failed: %v", err)
+	}
+	if len(links) != 1 || links[0].SourceID != epic.ID {
+		t.Errorf("expected PAY-1 to block PAY-3, got %+v", links)
+	}
+
+	// Re-importing the same export with preserved IDs reports every issue as a conflict
+	again, err := iy.ImportData(ctx, ImportOptions{FilePath: path, Format: "jira", PreserveIDs: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("second dry run failed: %v", err)
+	}
+	// The project now exists and is matched by name, so only tasks conflict
+	if len(again.Conflicts) != 4 {
+		t.Errorf("expected 4 task conflicts, got %d", len(again.Conflicts))
+	}
+}
+
+func TestImportJiraRequiresColumns(t *testing.T) {
+	iy, _ := newImportTestManager(t)
+	path := writeImportFile(t, "plain.csv", "Title,Status\nA,open\n")
+	if _, err := iy.ImportData(context.Background(), ImportOptions{FilePath: path, Format: "jira"}); err == nil {
+		t.Error("expected error for CSV without Jira columns")
+	}
+}
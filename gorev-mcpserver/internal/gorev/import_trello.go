@@ -0,0 +1,162 @@
+package gorev
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// trelloBoard is the subset of a Trello board JSON export (Menu → Print, export and share → Export as JSON)
+type trelloBoard struct {
+	Name  string `json:"name"`
+	Desc  string `json:"desc"`
+	Lists []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Closed bool   `json:"closed"`
+	} `json:"lists"`
+	Cards []struct {
+		ID               string     `json:"id"`
+		Name             string     `json:"name"`
+		Desc             string     `json:"desc"`
+		IDList           string     `json:"idList"`
+		Closed           bool       `json:"closed"`
+		Due              *time.Time `json:"due"`
+		DueComplete      bool       `json:"dueComplete"`
+		ShortURL         string     `json:"shortUrl"`
+		DateLastActivity *time.Time `json:"dateLastActivity"`
+		Labels           []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"labels"`
+	} `json:"cards"`
+	Checklists []struct {
+		ID         string `json:"id"`
+		IDCard     string `json:"idCard"`
+		CheckItems []struct {
+			ID    string     `json:"id"`
+			Name  string     `json:"name"`
+			State string     `json:"state"`
+			Due   *time.Time `json:"due"`
+		} `json:"checkItems"`
+	} `json:"checklists"`
+}
+
+// trelloImporter imports a Trello board JSON export
+type trelloImporter struct{}
+
+func init() {
+	registerImporter("trello", trelloImporter{})
+}
+
+// load maps a Trello board to a project: cards become tasks whose status comes from their
+// list ("To Do", "Doing", "Done"), other list names are kept as tags, labels become tags
+// and checklist items become subtasks of their card
+func (trelloImporter) load(ctx context.Context, b *importBuilder, r io.Reader, options ImportOptions) error {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return fmt.Errorf(i18n.T("error.trelloInvalidJSON", map[string]interface{}{"Error": err}))
+	}
+
+	projectID := b.projectID(firstNonEmpty(board.Name, "Trello"), board.Desc)
+
+	lists := make(map[string]string, len(board.Lists))
+	closedLists := make(map[string]bool)
+	for _, list := range board.Lists {
+		lists[list.ID] = list.Name
+		closedLists[list.ID] = list.Closed
+	}
+
+	cards := make(map[string]*Gorev, len(board.Cards))
+	for _, card := range board.Cards {
+		if card.Name == "" {
+			continue
+		}
+
+		task := &Gorev{
+			ID:          b.stableID("card", card.ID),
+			Title:       card.Name,
+			Description: appendSourceReference(card.Desc, "Trello", card.ShortURL),
+			Status:      constants.TaskStatusPending,
+			Priority:    constants.PriorityMedium,
+			ProjeID:     projectID,
+			DueDate:     card.Due,
+			CreatedAt:   trelloIDTime(card.ID),
+		}
+		if card.DateLastActivity != nil {
+			task.UpdatedAt = *card.DateLastActivity
+		}
+
+		tags := []string{}
+		listName := lists[card.IDList]
+		if status, ok := normalizeImportStatus(listName); ok {
+			task.Status = status
+		} else if listName != "" {
+			tags = append(tags, listName)
+		}
+		if card.DueComplete {
+			task.Status = constants.TaskStatusCompleted
+		}
+		// Archived cards that were not finished are treated as dropped
+		if (card.Closed || closedLists[card.IDList]) && task.Status != constants.TaskStatusCompleted {
+			task.Status = constants.TaskStatusCancelled
+		}
+
+		for _, label := range card.Labels {
+			name := firstNonEmpty(label.Name, label.Color)
+			if priority, ok := labelPriority(name); ok {
+				task.Priority = priority
+			}
+			tags = append(tags, name)
+		}
+
+		b.addTask(task, tags)
+		cards[card.ID] = task
+	}
+
+	for _, checklist := range board.Checklists {
+		parent, ok := cards[checklist.IDCard]
+		if !ok {
+			continue
+		}
+		for _, item := range checklist.CheckItems {
+			if item.Name == "" {
+				continue
+			}
+			subtask := &Gorev{
+				ID:        b.stableID("checkitem", item.ID),
+				Title:     item.Name,
+				Status:    constants.TaskStatusPending,
+				Priority:  parent.Priority,
+				ProjeID:   projectID,
+				ParentID:  parent.ID,
+				DueDate:   item.Due,
+				CreatedAt: trelloIDTime(item.ID),
+			}
+			if item.State == "complete" {
+				subtask.Status = constants.TaskStatusCompleted
+			}
+			b.addTask(subtask, nil)
+		}
+	}
+
+	return nil
+}
+
+// trelloIDTime returns the creation time encoded in the first 8 hex digits of a Trello object ID
+func trelloIDTime(id string) time.Time {
+	if len(id) < 8 {
+		return time.Time{}
+	}
+	seconds, err := strconv.ParseInt(id[:8], 16, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
@@ -0,0 +1,71 @@
+package gorev
+
+import (
+	"context"
+	"testing"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+func TestImportTrello(t *testing.T) {
+	iy, _ := newImportTestManager(t)
+	ctx := context.Background()
+
+	board := `{
+	  "name": "Test Project",
+	  "lists": [
+	    {"id": "l1", "name": "To Do"}, {"id": "l2", "name": "Doing"},
+	    {"id": "l3", "name": "Done"}, {"id": "l4", "name": "Ideas"}
+	  ],
+	  "cards": [
+	    {"id": "5f0c8a0b0000000000000001", "name": "Write docs", "idList": "l2", "due": "2025-02-01T12:00:00.000Z",
+	     "labels": [{"name": "High Priority", "color": "red"}, {"name": "", "color": "green"}]},
+	    {"id": "5f0c8a0b0000000000000002", "name": "Ship it", "idList": "l3"},
+	    {"id": "5f0c8a0b0000000000000003", "name": "Maybe later", "idList": "l4"},
+	    {"id": "5f0c8a0b0000000000000004", "name": "Archived", "idList": "l1", "closed": true}
+	  ],
+	  "checklists": [
+	    {"id": "c1", "idCard": "5f0c8a0b0000000000000001", "checkItems": [
+	      {"id": "5f0c8a0b00000000000000a1", "name": "Outline", "state": "complete"},
+	      {"id": "5f0c8a0b00000000000000a2", "name": "Review", "state": "incomplete"}
+	    ]}
+	  ]
+	}`
+	path := writeImportFile(t, "board.json", board)
+
+	result, err := iy.ImportData(ctx, ImportOptions{FilePath: path, Format: "trello"})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if result.ImportedTasks != 6 {
+		t.Fatalf("expected 6 tasks, got %d", result.ImportedTasks)
+	}
+	if result.ImportedProjects != 0 {
+		t.Errorf("board should be imported into the existing project, got %d new projects", result.ImportedProjects)
+	}
+
+	docs := findTaskByTitle(t, iy, "Write docs")
+	if docs.Status != constants.TaskStatusInProgress || docs.Priority != constants.PriorityHigh {
+		t.Errorf("unexpected status/priority: %s/%s", docs.Status, docs.Priority)
+	}
+	if docs.ProjeID != "test-project-1" || docs.DueDate == nil || len(docs.Tags) != 2 {
+		t.Errorf("unexpected project/due/tags: %s/%v/%d", docs.ProjeID, docs.DueDate, len(docs.Tags))
+	}
+	if docs.CreatedAt.Year() != 2020 {
+		t.Errorf("creation time should come from the card ID, got %v", docs.CreatedAt)
+	}
+
+	if outline := findTaskByTitle(t, iy, "Outline"); outline.ParentID != docs.ID || outline.Status != constants.TaskStatusCompleted {
+		t.Errorf("checklist item should be a completed subtask, got parent %q status %s", outline.ParentID, outline.Status)
+	}
+	if ship := findTaskByTitle(t, iy, "Ship it"); ship.Status != constants.TaskStatusCompleted {
+		t.Errorf("card in Done list should be completed, got %s", ship.Status)
+	}
+	later := findTaskByTitle(t, iy, "Maybe later")
+	if later.Status != constants.TaskStatusPending || len(later.Tags) != 1 || later.Tags[0].Name != "Ideas" {
+		t.Errorf("unknown list should be kept as tag, got status %s tags %v", later.Status, later.Tags)
+	}
+	if archived := findTaskByTitle(t, iy, "Archived"); archived.Status != constants.TaskStatusCancelled {
+		t.Errorf("archived card should be cancelled, got %s", archived.Status)
+	}
+}
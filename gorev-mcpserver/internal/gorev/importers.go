@@ -0,0 +1,346 @@
+package gorev
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// importer converts a file exported by another tool into ExportFormat, so that
+// it goes through the regular import pipeline (project mapping, conflicts, dry run)
+type importer interface {
+	// load reads the source file and adds its projects, tasks, tags and links to the builder
+	load(ctx context.Context, b *importBuilder, r io.Reader, options ImportOptions) error
+}
+
+// importers holds the registered importer plugins by format name
+var importers = map[string]importer{}
+
+// registerImporter registers an importer plugin for a format name
+func registerImporter(format string, imp importer) {
+	importers[format] = imp
+}
+
+// SupportedImportFormats returns the import formats accepted by ImportData
+func SupportedImportFormats() []string {
+	formats := []string{"json", "ndjson"}
+	for format := range importers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats[2:])
+	return formats
+}
+
+// importBuilder collects the output of an importer plugin into an ExportFormat.
+// Projects are matched against existing ones by name, tags are deduplicated by name
+// and task IDs are derived from the source system's identifiers so that re-importing
+// the same file with preserve_ids reports conflicts instead of creating duplicates.
+type importBuilder struct {
+	iy       *IsYonetici
+	source   string
+	data     *ExportFormat
+	warnings []string
+
+	existingProjects map[string]string // lowercased name or ID -> project ID
+	newProjects      map[string]*Proje // lowercased name -> project created by this import
+	tagIDs           map[string]string // lowercased tag name -> tag ID
+	existingTasks    map[string]string // lowercased title -> task ID, loaded lazily
+}
+
+// newImportBuilder creates a builder for an import from the given source system
+func (iy *IsYonetici) newImportBuilder(ctx context.Context, source string, options ImportOptions) *importBuilder {
+	b := &importBuilder{
+		iy:     iy,
+		source: source,
+		data: &ExportFormat{
+			Version: source,
+			Metadata: ExportMetadata{
+				ExportDate:  time.Now(),
+				ExportedBy:  "gorev_import",
+				Description: options.FilePath,
+			},
+			Projects:     []*Proje{},
+			Tasks:        []*Gorev{},
+			Tags:         []*Etiket{},
+			TaskTags:     []TaskTagAssociation{},
+			Templates:    []*GorevTemplate{},
+			Dependencies: []*Baglanti{},
+		},
+		existingProjects: make(map[string]string),
+		newProjects:      make(map[string]*Proje),
+		tagIDs:           make(map[string]string),
+	}
+
+	// Existing projects are referenced rather than re-created
+	if projects, err := iy.ProjeListele(ctx); err == nil {
+		for _, project := range projects {
+			b.existingProjects[strings.ToLower(project.Name)] = project.ID
+			b.existingProjects[strings.ToLower(project.ID)] = project.ID
+		}
+	}
+
+	return b
+}
+
+// stableID derives a deterministic ID from an identifier of the source system
+func (b *importBuilder) stableID(kind, key string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("gorev-import:"+b.source+":"+kind+":"+key)).String()
+}
+
+// warn records a non fatal problem found in the source file
+func (b *importBuilder) warn(messageID string, data map[string]interface{}) {
+	b.warnings = append(b.warnings, i18n.T(messageID, data))
+}
+
+// projectID returns the ID of an existing project (by ID or name) or of a project created for this import
+func (b *importBuilder) projectID(name, definition string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ""
+	}
+
+	key := strings.ToLower(name)
+	if id, ok := b.existingProjects[key]; ok {
+		return id
+	}
+	if project, ok := b.newProjects[key]; ok {
+		return project.ID
+	}
+
+	project := &Proje{
+		ID:          b.stableID("project", key),
+		Name:        name,
+		Definition:  definition,
+		WorkspaceID: b.iy.workspaceID,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	b.newProjects[key] = project
+	b.data.Projects = append(b.data.Projects, project)
+	return project.ID
+}
+
+// addTask adds a task with its tag names, filling in defaults for missing fields
+func (b *importBuilder) addTask(task *Gorev, tagNames []string) {
+	if task.ID == "" {
+		task.ID = uuid.New().String()
+	}
+	if task.Status == "" {
+		task.Status = constants.TaskStatusPending
+	}
+	if task.Priority == "" {
+		task.Priority = constants.PriorityMedium
+	}
+
+	seen := make(map[string]bool, len(tagNames))
+	for _, name := range tagNames {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		tagID, exists := b.tagIDs[key]
+		if !exists {
+			tagID = uuid.New().String()
+			b.tagIDs[key] = tagID
+			b.data.Tags = append(b.data.Tags, &Etiket{ID: tagID, Name: name})
+		}
+		b.data.TaskTags = append(b.data.TaskTags, TaskTagAssociation{TaskID: task.ID, TagID: tagID})
+	}
+
+	b.data.Tasks = append(b.data.Tasks, task)
+}
+
+// addDependency records that sourceID must be completed before targetID can start
+func (b *importBuilder) addDependency(sourceID, targetID string) {
+	if sourceID == "" || targetID == "" || sourceID == targetID {
+		return
+	}
+	for _, dep := range b.data.Dependencies {
+		if dep.SourceID == sourceID && dep.TargetID == targetID {
+			return
+		}
+	}
+	b.data.Dependencies = append(b.data.Dependencies, &Baglanti{
+		ID:             uuid.New().String(),
+		SourceID:       sourceID,
+		TargetID:       targetID,
+		ConnectionType: "onceki",
+	})
+}
+
+// existingTaskID resolves a reference to a task already in the database by ID or title
+func (b *importBuilder) existingTaskID(ctx context.Context, ref string) string {
+	if existing, err := b.iy.veriYonetici.GorevGetir(ctx, ref); err == nil && existing != nil {
+		return existing.ID
+	}
+
+	// Load existing task titles lazily, only when a reference can't be resolved by ID
+	if b.existingTasks == nil {
+		b.existingTasks = make(map[string]string)
+		if tasks, err := b.iy.GorevListele(ctx, map[string]interface{}{}); err == nil {
+			for _, task := range tasks {
+				key := strings.ToLower(task.Title)
+				if _, exists := b.existingTasks[key]; !exists {
+					b.existingTasks[key] = task.ID
+				}
+			}
+		}
+	}
+	return b.existingTasks[strings.ToLower(ref)]
+}
+
+// result returns the collected import data and warnings
+func (b *importBuilder) result() (*ExportFormat, []string, error) {
+	b.data.Metadata.TotalTasks = len(b.data.Tasks)
+	b.data.Metadata.TotalProjects = len(b.data.Projects)
+	return b.data, b.warnings, nil
+}
+
+// appendSourceReference appends a reference to the original item to a task description
+func appendSourceReference(description, label, ref string) string {
+	if ref == "" {
+		return description
+	}
+	if description == "" {
+		return fmt.Sprintf("%s: %s", label, ref)
+	}
+	return fmt.Sprintf("%s\n\n%s: %s", strings.TrimRight(description, "\n"), label, ref)
+}
+
+// labelPriority derives a priority from labels such as "priority: high", "P1" or "urgent"
+func labelPriority(label string) (string, bool) {
+	value := strings.ToLower(strings.TrimSpace(label))
+	prefixed := false
+	for _, prefix := range []string{"priority:", "priority/", "priority-", "priority ", "prio:", "prio/", "öncelik:", "oncelik:"} {
+		if strings.HasPrefix(value, prefix) {
+			value = strings.TrimSpace(strings.TrimPrefix(value, prefix))
+			prefixed = true
+			break
+		}
+	}
+	if strings.HasSuffix(value, " priority") {
+		value = strings.TrimSpace(strings.TrimSuffix(value, " priority"))
+		prefixed = true
+	}
+
+	switch value {
+	case "p0", "p1", "p2", "p3", "urgent", "critical", "blocker", "acil":
+		prefixed = true
+	}
+	if !prefixed {
+		return "", false
+	}
+	return normalizeImportPriority(value)
+}
+
+// importStatusAliases maps external status values to Gorev task statuses
+var importStatusAliases = map[string]string{
+	"beklemede": constants.TaskStatusPending, "pending": constants.TaskStatusPending,
+	"todo": constants.TaskStatusPending, "to do": constants.TaskStatusPending,
+	"open": constants.TaskStatusPending, "new": constants.TaskStatusPending,
+	"backlog": constants.TaskStatusPending, "not started": constants.TaskStatusPending,
+	"waiting": constants.TaskStatusPending, "bekliyor": constants.TaskStatusPending,
+	"devam_ediyor": constants.TaskStatusInProgress, "in progress": constants.TaskStatusInProgress,
+	"in_progress": constants.TaskStatusInProgress, "doing": constants.TaskStatusInProgress,
+	"started": constants.TaskStatusInProgress, "active": constants.TaskStatusInProgress,
+	"wip": constants.TaskStatusInProgress, "in review": constants.TaskStatusInProgress,
+	"devam ediyor": constants.TaskStatusInProgress,
+	"tamamlandi":   constants.TaskStatusCompleted, "tamamlandı": constants.TaskStatusCompleted,
+	"done": constants.TaskStatusCompleted, "completed": constants.TaskStatusCompleted,
+	"complete": constants.TaskStatusCompleted, "closed": constants.TaskStatusCompleted,
+	"finished": constants.TaskStatusCompleted, "resolved": constants.TaskStatusCompleted,
+	"iptal": constants.TaskStatusCancelled, "cancelled": constants.TaskStatusCancelled,
+	"canceled": constants.TaskStatusCancelled, "won't do": constants.TaskStatusCancelled,
+	"wontfix": constants.TaskStatusCancelled, "dropped": constants.TaskStatusCancelled,
+	"deleted": constants.TaskStatusCancelled,
+}
+
+// importPriorityAliases maps external priority values to Gorev priorities
+var importPriorityAliases = map[string]string{
+	"yuksek": constants.PriorityHigh, "yüksek": constants.PriorityHigh, "high": constants.PriorityHigh,
+	"h": constants.PriorityHigh, "urgent": constants.PriorityHigh, "critical": constants.PriorityHigh,
+	"highest": constants.PriorityHigh, "blocker": constants.PriorityHigh, "p0": constants.PriorityHigh, "p1": constants.PriorityHigh,
+	"1": constants.PriorityHigh, "acil": constants.PriorityHigh,
+	"orta": constants.PriorityMedium, "medium": constants.PriorityMedium, "normal": constants.PriorityMedium,
+	"m": constants.PriorityMedium, "p2": constants.PriorityMedium, "2": constants.PriorityMedium,
+	"major": constants.PriorityMedium,
+	"dusuk": constants.PriorityLow, "düşük": constants.PriorityLow, "low": constants.PriorityLow,
+	"l": constants.PriorityLow, "minor": constants.PriorityLow, "lowest": constants.PriorityLow,
+	"trivial": constants.PriorityLow, "p3": constants.PriorityLow, "3": constants.PriorityLow,
+}
+
+// importDateLayouts are the date formats accepted by importers, tried in order
+var importDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	constants.DateTimeFormatFull,
+	"2006-01-02 15:04",
+	constants.DateFormatISO,
+	"2006/01/02",
+	"02.01.2006",
+	"20060102T150405Z",
+	"20060102",
+}
+
+// normalizeImportStatus maps an external status value to a Gorev status
+func normalizeImportStatus(value string) (string, bool) {
+	status, ok := importStatusAliases[strings.ToLower(strings.TrimSpace(value))]
+	return status, ok
+}
+
+// normalizeImportPriority maps an external priority value to a Gorev priority
+func normalizeImportPriority(value string) (string, bool) {
+	priority, ok := importPriorityAliases[strings.ToLower(strings.TrimSpace(value))]
+	return priority, ok
+}
+
+// parseImportDate parses a date using the layouts accepted by importers
+func parseImportDate(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range importDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf(i18n.T("error.invalidDateFormat", map[string]interface{}{"Error": value}))
+}
+
+// splitImportTags splits a tag cell on ',', ';' or '|'.
+// Gorev's own CSV export writes tags as "[a b]", which is split on whitespace.
+func splitImportTags(value string) []string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	var parts []string
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		parts = strings.Fields(strings.Trim(value, "[]"))
+	} else {
+		parts = strings.FieldsFunc(value, func(r rune) bool {
+			return r == ',' || r == ';' || r == '|'
+		})
+	}
+
+	tags := make([]string, 0, len(parts))
+	seen := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		tag := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(part), "#"))
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 	"regexp"
-	"sort"
 	"strings"
 	"time"
 
@@ -27,6 +26,12 @@ func NewIntelligentTaskCreator(vy VeriYoneticiInterface) *IntelligentTaskCreator
 	}
 }
 
+// SetSimilarityIndex shares a similarity index, e.g. IsYonetici.BenzerlikIndeksi, for
+// finding similar tasks
+func (itc *IntelligentTaskCreator) SetSimilarityIndex(index *SimilarityIndex) {
+	itc.suggestionEngine.SetSimilarityIndex(index)
+}
+
 // TaskCreationRequest represents an intelligent task creation request
 type TaskCreationRequest struct {
 	Title           string            `json:"title"`
@@ -299,38 +304,22 @@ func (itc *IntelligentTaskCreator) estimateTimeFromContent(title, description st
 
 // findSimilarTasks finds tasks similar to the given title and description
 func (itc *IntelligentTaskCreator) findSimilarTasks(ctx context.Context, title, description string, limit int) []SimilarTaskInfo {
-	allTasks, err := itc.veriYonetici.GorevListele(ctx, map[string]interface{}{})
+	similarTasks, err := itc.suggestionEngine.similarity().Similar(ctx, SimilarityOptions{
+		Text:  strings.Repeat(title+" ", similarityTitleWeight) + description,
+		Limit: limit,
+	})
 	if err != nil {
 		return nil
 	}
 
-	targetWords := extractKeywords(title + " " + description)
-	var similarities []SimilarTaskInfo
-
-	for _, task := range allTasks {
-		taskWords := extractKeywords(task.Title + " " + task.Description)
-		similarity := calculateSimilarity(targetWords, taskWords)
-
-		if similarity > 0.2 { // 20% similarity threshold
-			reason := itc.generateSimilarityReason(targetWords, taskWords)
-			similarities = append(similarities, SimilarTaskInfo{
-				Task:            task,
-				SimilarityScore: similarity,
-				Reason:          reason,
-			})
-		}
-	}
-
-	// Sort by similarity score
-	sort.Slice(similarities, func(i, j int) bool {
-		return similarities[i].SimilarityScore > similarities[j].SimilarityScore
-	})
-
-	// Limit results
-	if len(similarities) > limit {
-		similarities = similarities[:limit]
+	similarities := make([]SimilarTaskInfo, 0, len(similarTasks))
+	for _, similar := range similarTasks {
+		similarities = append(similarities, SimilarTaskInfo{
+			Task:            similar.Task,
+			SimilarityScore: similar.Score,
+			Reason:          itc.generateSimilarityReason(similar.CommonTerms),
+		})
 	}
-
 	return similarities
 }
 
@@ -503,20 +492,8 @@ func (itc *IntelligentTaskCreator) countKeywords(content string, keywords []stri
 	return count
 }
 
-func (itc *IntelligentTaskCreator) generateSimilarityReason(words1, words2 []string) string {
-	commonWords := []string{}
-	wordSet1 := make(map[string]bool)
-
-	for _, word := range words1 {
-		wordSet1[word] = true
-	}
-
-	for _, word := range words2 {
-		if wordSet1[word] && len(word) > 3 { // Only meaningful words
-			commonWords = append(commonWords, word)
-		}
-	}
-
+// generateSimilarityReason describes a match by the terms the tasks share
+func (itc *IntelligentTaskCreator) generateSimilarityReason(commonWords []string) string {
 	if len(commonWords) == 0 {
 		return "Genel benzerlik"
 	}
@@ -3,6 +3,7 @@ package gorev
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +14,12 @@ import (
 type IsYonetici struct {
 	veriYonetici VeriYoneticiInterface
 	workspaceID  string // Workspace ID for centralized mode filtering
+
+	tamamlamaOnce   sync.Once
+	tamamlamaIndeks *CompletionIndex // Built on the first completion query
+
+	benzerlikOnce   sync.Once
+	benzerlikIndeks *SimilarityIndex // Built on the first similarity query
 }
 
 func YeniIsYonetici(veriYonetici VeriYoneticiInterface) *IsYonetici {
@@ -103,63 +110,35 @@ func (iy *IsYonetici) GorevListele(ctx context.Context, filters map[string]inter
 		return nil, err
 	}
 
-	// Performans optimizasyonu: Tüm görevlerin ID'lerini topla
-	if len(gorevler) == 0 {
-		return gorevler, nil
-	}
-
-	gorevIDs := make([]string, len(gorevler))
-	for i, gorev := range gorevler {
-		gorevIDs[i] = gorev.ID
-	}
-
-	// Tek sorguda tüm görevlerin bağımlılık sayılarını hesapla (N+1 sorgu problemi çözüldü)
-	bagimliSayilari, err := iy.veriYonetici.BulkBagimlilikSayilariGetir(gorevIDs)
-	if err != nil {
-		// Hata durumunda bile devam et, sadece bağımlılık sayıları 0 olarak kalır
-		bagimliSayilari = make(map[string]int)
-	}
-
-	// Tek sorguda tüm görevlerin tamamlanmamış bağımlılık sayılarını hesapla
-	tamamlanmamisSayilari, err := iy.veriYonetici.BulkTamamlanmamiaBagimlilikSayilariGetir(gorevIDs)
-	if err != nil {
-		// Hata durumunda bile devam et, sadece tamamlanmamış bağımlılık sayıları 0 olarak kalır
-		tamamlanmamisSayilari = make(map[string]int)
+	// Etiketler, proje adları ve bağımlılık sayıları listeyle birlikte gelir;
+	// kök görevlerin alt görevleri tek sorguda eklenir (N+1 sorgu yok)
+	var kokIDler []string
+	for _, gorev := range gorevler {
+		if gorev.ParentID == "" {
+			kokIDler = append(kokIDler, gorev.ID)
+		}
 	}
 
-	// Bu göreve bağımlı olanları hesaplamak için bulk query kullan
-	buGoreveBagimliSayilari, err := iy.veriYonetici.BulkBuGoreveBagimliSayilariGetir(gorevIDs)
+	altGorevler, err := iy.veriYonetici.AltGorevleriTopluGetir(ctx, kokIDler)
 	if err != nil {
-		// Hata durumunda bile devam et, sadece bağımlı sayıları 0 olarak kalır
-		buGoreveBagimliSayilari = make(map[string]int)
+		// Hata durumunda bile devam et, görevler alt görevsiz döner
+		return gorevler, nil
 	}
-
-	// Her görev için hesaplanan değerleri ata
 	for _, gorev := range gorevler {
-		if count, exists := bagimliSayilari[gorev.ID]; exists {
-			gorev.DependencyCount = count
-		}
-
-		if count, exists := tamamlanmamisSayilari[gorev.ID]; exists {
-			gorev.UncompletedDependencyCount = count
-		}
-
-		if count, exists := buGoreveBagimliSayilari[gorev.ID]; exists {
-			gorev.DependentOnThisCount = count
-		}
-
-		// Alt görevleri getir (parent_id null olan görevler için)
-		if gorev.ParentID == "" {
-			altGorevler, err := iy.veriYonetici.AltGorevleriGetir(ctx, gorev.ID)
-			if err == nil && len(altGorevler) > 0 {
-				gorev.Subtasks = altGorevler
-			}
+		if subtasks := altGorevler[gorev.ID]; len(subtasks) > 0 {
+			gorev.Subtasks = subtasks
 		}
 	}
 
 	return gorevler, nil
 }
 
+// GorevSayfasiGetir returns one page of root tasks with their subtasks; see
+// VeriYoneticiInterface.GorevSayfasiGetir
+func (iy *IsYonetici) GorevSayfasiGetir(ctx context.Context, filters map[string]interface{}, limit, offset int) (*GorevSayfasi, error) {
+	return iy.veriYonetici.GorevSayfasiGetir(ctx, iy.addWorkspaceFilter(filters), limit, offset)
+}
+
 func (iy *IsYonetici) GorevDurumGuncelle(ctx context.Context, id, durum string) error {
 	// Validate status values
 	validStatuses := constants.GetValidTaskStatuses()
@@ -265,21 +244,10 @@ func (iy *IsYonetici) GorevDuzenle(ctx context.Context, id, baslik, aciklama, on
 		gorev.Priority = oncelik
 	}
 	if projeVar {
-		// Proje değiştiriliyorsa, tüm alt görevleri de taşı
+		// Proje değiştiriliyorsa, tüm alt görevleri de tek sorguda taşı
 		if gorev.ProjeID != projeID {
-			altGorevler, err := iy.veriYonetici.TumAltGorevleriGetir(ctx, id)
-			if err != nil {
-				return fmt.Errorf(i18n.T("error.subtasksFetchFailed", map[string]interface{}{"Error": err}))
-			}
-
-			// Tüm alt görevlerin projesini güncelle
-			for _, altGorev := range altGorevler {
-				if err := iy.veriYonetici.GorevGuncelle(ctx, altGorev.ID, map[string]interface{}{
-					"project_id": projeID,
-					"updated_at": time.Now(),
-				}); err != nil {
-					return fmt.Errorf(i18n.T("error.subtaskUpdateFailed", map[string]interface{}{"Error": err}))
-				}
+			if err := iy.veriYonetici.AltAgacProjesiniGuncelle(ctx, id, projeID); err != nil {
+				return fmt.Errorf(i18n.T("error.subtaskUpdateFailed", map[string]interface{}{"Error": err}))
 			}
 		}
 		gorev.ProjeID = projeID
@@ -354,41 +322,7 @@ func (iy *IsYonetici) ProjeGorevleri(ctx context.Context, projeID string) ([]*Go
 		return nil, err
 	}
 
-	// Her görev için bağımlılık sayılarını hesapla
-	for _, gorev := range gorevler {
-		// Bu görevin bağımlılıklarını al (bu görev başka görevlere bağımlı)
-		baglantilar, err := iy.veriYonetici.BaglantilariGetir(ctx, gorev.ID)
-		if err == nil && len(baglantilar) > 0 {
-			gorev.DependencyCount = len(baglantilar)
-
-			// Tamamlanmamış bağımlılıkları say
-			tamamlanmamisSayisi := 0
-			for _, baglanti := range baglantilar {
-				hedefGorev, err := iy.veriYonetici.GorevGetir(ctx, baglanti.TargetID)
-				if err == nil && hedefGorev.Status != constants.TaskStatusCompleted {
-					tamamlanmamisSayisi++
-				}
-			}
-			gorev.UncompletedDependencyCount = tamamlanmamisSayisi
-		}
-
-		// Bu göreve bağımlı olan görevleri bul
-		buGoreveBagimliSayisi := 0
-		tumGorevler, _ := iy.veriYonetici.GorevleriGetir(ctx, "", "", "")
-		for _, digerGorev := range tumGorevler {
-			digerBaglantilar, err := iy.veriYonetici.BaglantilariGetir(ctx, digerGorev.ID)
-			if err == nil {
-				for _, baglanti := range digerBaglantilar {
-					if baglanti.TargetID == gorev.ID {
-						buGoreveBagimliSayisi++
-						break
-					}
-				}
-			}
-		}
-		gorev.DependentOnThisCount = buGoreveBagimliSayisi
-	}
-
+	// Bağımlılık sayıları ProjeGorevleriGetir sorgusunda hesaplanır
 	return gorevler, nil
 }
 
@@ -826,6 +826,17 @@ func (m *MockVeriYonetici) AltGorevleriGetir(ctx context.Context, parentID strin
 	return result, nil
 }
 
+func (m *MockVeriYonetici) AltGorevleriTopluGetir(ctx context.Context, parentIDs []string) (map[string][]*Gorev, error) {
+	result := make(map[string][]*Gorev)
+	for _, parentID := range parentIDs {
+		altGorevler, _ := m.AltGorevleriGetir(ctx, parentID)
+		if len(altGorevler) > 0 {
+			result[parentID] = altGorevler
+		}
+	}
+	return result, nil
+}
+
 func (m *MockVeriYonetici) TumAltGorevleriGetir(ctx context.Context, parentID string) ([]*Gorev, error) {
 	// Simplified implementation for testing
 	return m.AltGorevleriGetir(ctx, parentID)
@@ -876,6 +887,23 @@ func (m *MockVeriYonetici) DaireBagimliligiKontrolEt(ctx context.Context, gorevI
 	return gorevID == hedefParentID, nil
 }
 
+func (m *MockVeriYonetici) AltAgacProjesiniGuncelle(ctx context.Context, gorevID, projeID string) error {
+	kuyruk := []string{gorevID}
+	for len(kuyruk) > 0 {
+		id := kuyruk[0]
+		kuyruk = kuyruk[1:]
+		if gorev, ok := m.gorevler[id]; ok {
+			gorev.ProjeID = projeID
+		}
+		for _, gorev := range m.gorevler {
+			if gorev.ParentID == id {
+				kuyruk = append(kuyruk, gorev.ID)
+			}
+		}
+	}
+	return nil
+}
+
 // AI Context Management methods
 func (m *MockVeriYonetici) AIContextGetir() (*AIContext, error) {
 	if m.shouldReturnError {
@@ -953,6 +981,14 @@ func (m *MockVeriYonetici) GorevDetay(ctx context.Context, id string) (*Gorev, e
 	return m.GorevGetir(context.Background(), id)
 }
 
+func (m *MockVeriYonetici) GorevSayfasiGetir(ctx context.Context, filters map[string]interface{}, limit, offset int) (*GorevSayfasi, error) {
+	gorevler, err := m.GorevListele(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	return gorevSayfasiAyir(gorevler, limit, offset), nil
+}
+
 func (m *MockVeriYonetici) GorevListele(ctx context.Context, filters map[string]interface{}) ([]*Gorev, error) {
 	if m.shouldFailGorevListele {
 		return nil, errors.New("mock error: gorev listele failed")
@@ -1004,6 +1040,18 @@ func (m *MockVeriYonetici) GetDB() (*sql.DB, error) {
 	return nil, nil
 }
 
+func (m *MockVeriYonetici) GetReadDB() (*sql.DB, error) {
+	return nil, nil
+}
+
+func (m *MockVeriYonetici) YazmaIslemi(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return fmt.Errorf("not implemented in mock")
+}
+
+func (m *MockVeriYonetici) YardimciYazmaIslemi(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return fmt.Errorf("not implemented in mock")
+}
+
 // Tests
 
 func TestYeniIsYonetici(t *testing.T) {
@@ -0,0 +1,243 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// KlonlamaSecenekleri controls where clones go and how they differ from the originals
+type KlonlamaSecenekleri struct {
+	ProjectID   string `json:"project_id,omitempty"`   // Target project of task clones; empty keeps the source project
+	ProjectName string `json:"project_name,omitempty"` // Name of a project clone; default "<name> (copy)"
+	ResetStatus bool   `json:"reset_status,omitempty"` // Clones start as beklemede
+	// Due dates of the clones are shifted by these offsets
+	DueDateShiftDays   int  `json:"due_date_shift_days,omitempty"`
+	DueDateShiftMonths int  `json:"due_date_shift_months,omitempty"`
+	IncludeFilePaths   bool `json:"include_file_paths,omitempty"` // Copy the watched file paths too
+}
+
+// KlonlamaSonucu describes a clone
+type KlonlamaSonucu struct {
+	Root      *Gorev            `json:"root,omitempty"`    // Clone of the task given to GorevKlonla
+	Project   *Proje            `json:"project,omitempty"` // Project created by ProjeKlonla
+	Tasks     int               `json:"tasks"`
+	Links     int               `json:"links"`
+	Tags      int               `json:"tags"`
+	FilePaths int               `json:"file_paths"`
+	IDMap     map[string]string `json:"id_map"` // Source task ID → clone ID
+}
+
+// GorevKlonla copies a task with all its subtasks, their tags and the dependencies
+// among them. A clone in the source project keeps the parent of the task; in another
+// project it becomes a root task.
+func (iy *IsYonetici) GorevKlonla(ctx context.Context, id string, secenekler KlonlamaSecenekleri) (*KlonlamaSonucu, error) {
+	kok, err := iy.veriYonetici.GorevGetir(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.TEntityNotFound(i18n.FromContext(ctx), "task", err))
+	}
+	projeID := kok.ProjeID
+	if secenekler.ProjectID != "" {
+		if _, err := iy.veriYonetici.ProjeGetir(ctx, secenekler.ProjectID); err != nil {
+			return nil, fmt.Errorf(i18n.T("error.projectNotFound", map[string]interface{}{"Error": err}))
+		}
+		projeID = secenekler.ProjectID
+	}
+
+	altGorevler, err := iy.veriYonetici.TumAltGorevleriGetir(ctx, kok.ID)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.cloneFailed", map[string]interface{}{"Error": err}))
+	}
+	gorevler, err := iy.klonlanacakGorevler(ctx, append([]*Gorev{kok}, altGorevler...))
+	if err != nil {
+		return nil, err
+	}
+
+	kokParentID := ""
+	if projeID == kok.ProjeID {
+		kokParentID = kok.ParentID
+	}
+	sonuc, err := iy.gorevleriKlonla(ctx, gorevler, projeID, kokParentID, secenekler, nil)
+	if err != nil {
+		return nil, err
+	}
+	if sonuc.Root, err = iy.veriYonetici.GorevGetir(ctx, sonuc.IDMap[kok.ID]); err != nil {
+		return nil, fmt.Errorf(i18n.T("error.cloneFailed", map[string]interface{}{"Error": err}))
+	}
+	return sonuc, nil
+}
+
+// ProjeKlonla creates a copy of a project with all its tasks, their hierarchy, tags
+// and dependencies
+func (iy *IsYonetici) ProjeKlonla(ctx context.Context, projeID string, secenekler KlonlamaSecenekleri) (*KlonlamaSonucu, error) {
+	proje, err := iy.veriYonetici.ProjeGetir(ctx, projeID)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.projectNotFound", map[string]interface{}{"Error": err}))
+	}
+	projeGorevleri, err := iy.veriYonetici.ProjeGorevleriGetir(ctx, proje.ID)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.cloneFailed", map[string]interface{}{"Error": err}))
+	}
+	gorevler, err := iy.klonlanacakGorevler(ctx, projeGorevleri)
+	if err != nil {
+		return nil, err
+	}
+
+	isim := secenekler.ProjectName
+	if isim == "" {
+		isim = i18n.TWithLang(i18n.FromContext(ctx), "clone.projectName", map[string]interface{}{"Name": proje.Name})
+	}
+	simdi := time.Now()
+	yeniProje := &Proje{
+		ID:          uuid.New().String(),
+		Name:        isim,
+		Definition:  proje.Definition,
+		WorkspaceID: iy.workspaceID,
+		CreatedAt:   simdi,
+		UpdatedAt:   simdi,
+	}
+	return iy.gorevleriKlonla(ctx, gorevler, yeniProje.ID, "", secenekler, yeniProje)
+}
+
+// klonlanacakGorevler reloads tasks with their tags
+func (iy *IsYonetici) klonlanacakGorevler(ctx context.Context, gorevler []*Gorev) ([]*Gorev, error) {
+	tamGorevler := make([]*Gorev, 0, len(gorevler))
+	for _, gorev := range gorevler {
+		tam, err := iy.veriYonetici.GorevGetir(ctx, gorev.ID)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("error.cloneFailed", map[string]interface{}{"Error": err}))
+		}
+		tamGorevler = append(tamGorevler, tam)
+	}
+	return tamGorevler, nil
+}
+
+// gorevleriKlonla saves copies of tasks with new IDs in projeID. Parents among the
+// tasks are replaced by their clones; tasks whose parent is not copied get
+// disariParentID. Links are only copied when both ends are copied. yeniProje, the
+// project of a project clone, and the copies are written in one transaction, so a
+// failure leaves nothing behind.
+func (iy *IsYonetici) gorevleriKlonla(ctx context.Context, gorevler []*Gorev, projeID, disariParentID string, secenekler KlonlamaSecenekleri, yeniProje *Proje) (*KlonlamaSonucu, error) {
+	sonuc := &KlonlamaSonucu{Project: yeniProje, IDMap: make(map[string]string, len(gorevler))}
+	for _, gorev := range gorevler {
+		sonuc.IDMap[gorev.ID] = uuid.New().String()
+	}
+	hata := func(err error) (*KlonlamaSonucu, error) {
+		return nil, fmt.Errorf(i18n.T("error.cloneFailed", map[string]interface{}{"Error": err}))
+	}
+
+	// Everything copied is read before the transaction starts
+	dosyaYollari := make(map[string][]string)
+	if secenekler.IncludeFilePaths {
+		for _, gorev := range gorevler {
+			yollar, err := iy.veriYonetici.GorevDosyaYollariGetir(gorev.ID)
+			if err != nil {
+				return hata(err)
+			}
+			dosyaYollari[gorev.ID] = yollar
+		}
+	}
+	// Links to tasks outside the clone stay with the originals
+	var baglantilar []*Baglanti
+	kopyalanan := make(map[string]bool)
+	for _, gorev := range gorevler {
+		gorevBaglantilari, err := iy.veriYonetici.BaglantilariGetir(ctx, gorev.ID)
+		if err != nil {
+			return hata(err)
+		}
+		for _, baglanti := range gorevBaglantilari {
+			kaynakID, kaynakVar := sonuc.IDMap[baglanti.SourceID]
+			hedefID, hedefVar := sonuc.IDMap[baglanti.TargetID]
+			if kopyalanan[baglanti.ID] || !kaynakVar || !hedefVar {
+				continue
+			}
+			kopyalanan[baglanti.ID] = true
+			baglantilar = append(baglantilar, &Baglanti{
+				ID:             uuid.New().String(),
+				SourceID:       kaynakID,
+				TargetID:       hedefID,
+				ConnectionType: baglanti.ConnectionType,
+			})
+		}
+	}
+
+	simdi := time.Now()
+	workspaceID := iy.workspaceID
+	if workspaceID == "" {
+		workspaceID = "default"
+	}
+	olaylar := &degisiklikOlaylari{}
+	err := iy.veriYonetici.YazmaIslemi(ctx, func(tx *sql.Tx) error {
+		if yeniProje != nil {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO projeler (id, name, definition, workspace_id, created_at, updated_at)
+				VALUES (?, ?, ?, ?, ?, ?)`,
+				yeniProje.ID, yeniProje.Name, yeniProje.Definition, workspaceID, yeniProje.CreatedAt, yeniProje.UpdatedAt); err != nil {
+				return err
+			}
+			olaylar.projeOlusturuldu(yeniProje)
+		}
+
+		for _, gorev := range orderTasksParentFirst(gorevler) {
+			klonID := sonuc.IDMap[gorev.ID]
+			parentID := sql.NullString{String: disariParentID, Valid: disariParentID != ""}
+			if id, ok := sonuc.IDMap[gorev.ParentID]; ok {
+				parentID = sql.NullString{String: id, Valid: true}
+			}
+			durum := gorev.Status
+			if secenekler.ResetStatus {
+				durum = constants.TaskStatusPending
+			}
+			var sonTarih *time.Time
+			if gorev.DueDate != nil {
+				tarih := gorev.DueDate.AddDate(0, secenekler.DueDateShiftMonths, secenekler.DueDateShiftDays)
+				sonTarih = &tarih
+			}
+			if _, err := tx.ExecContext(ctx, `INSERT INTO gorevler
+				(id, title, description, status, priority, project_id, parent_id, workspace_id, created_at, updated_at, due_date)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				klonID, gorev.Title, gorev.Description, durum, gorev.Priority,
+				sql.NullString{String: projeID, Valid: projeID != ""}, parentID, workspaceID, simdi, simdi, sonTarih); err != nil {
+				return err
+			}
+			sonuc.Tasks++
+			olaylar.gorevOlusturuldu(klonID, map[string]interface{}{
+				"title":       gorev.Title,
+				"status":      durum,
+				"priority":    gorev.Priority,
+				"cloned_from": gorev.ID,
+			})
+
+			for _, etiket := range gorev.Tags {
+				if _, err := tx.ExecContext(ctx, `INSERT INTO gorev_etiketleri (task_id, tag_id) VALUES (?, ?)`, klonID, etiket.ID); err != nil {
+					return err
+				}
+				sonuc.Tags++
+			}
+			for _, yol := range dosyaYollari[gorev.ID] {
+				if _, err := tx.ExecContext(ctx, `INSERT INTO task_file_paths (task_id, file_path) VALUES (?, ?)`, klonID, yol); err != nil {
+					return err
+				}
+				sonuc.FilePaths++
+			}
+		}
+
+		for _, baglanti := range baglantilar {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO baglantilar (id, source_id, target_id, connection_type) VALUES (?, ?, ?, ?)`,
+				baglanti.ID, baglanti.SourceID, baglanti.TargetID, baglanti.ConnectionType); err != nil {
+				return err
+			}
+			sonuc.Links++
+		}
+		return nil
+	})
+	if err != nil {
+		return hata(err)
+	}
+	iy.olaylariYayinla(olaylar)
+	return sonuc, nil
+}
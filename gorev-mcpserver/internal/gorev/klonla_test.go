@@ -0,0 +1,190 @@
+package gorev
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+// addCloneTestData adds the tree "epic" → "story" → "subtask" in test-project-1
+// with a tag, a file path, a due date, a link inside the tree and a link to
+// test-task-1 outside it
+func addCloneTestData(t *testing.T, vy *VeriYonetici) time.Time {
+	due := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	seedTestData(t, vy, testSeed{
+		tasks: []*Gorev{
+			{ID: "epic", Title: "Checkout", Status: constants.TaskStatusInProgress, Priority: constants.PriorityHigh, ProjeID: "test-project-1", DueDate: &due},
+			{ID: "story", Title: "Payment form", Status: constants.TaskStatusCompleted, Priority: constants.PriorityHigh, ProjeID: "test-project-1", ParentID: "epic"},
+			{ID: "subtask", Title: "Card validation", Status: constants.TaskStatusPending, Priority: constants.PriorityHigh, ProjeID: "test-project-1", ParentID: "story"},
+		},
+		tags: map[string][]string{"story": {"payments"}},
+		links: []*Baglanti{
+			{ID: "link-inner", SourceID: "subtask", TargetID: "story", ConnectionType: "onceki"},
+			{ID: "link-outer", SourceID: "test-task-1", TargetID: "epic", ConnectionType: "onceki"},
+		},
+		files: map[string][]string{"subtask": {"card.go"}},
+	})
+	return due
+}
+
+func TestGorevKlonla(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	due := addCloneTestData(t, vy)
+	ctx := context.Background()
+
+	result, err := iy.GorevKlonla(ctx, "epic", KlonlamaSecenekleri{})
+	if err != nil {
+		t.Fatalf("GorevKlonla failed: %v", err)
+	}
+	if result.Tasks != 3 || result.Links != 1 || result.Tags != 1 || result.FilePaths != 0 {
+		t.Errorf("unexpected counts: %+v", result)
+	}
+	root := result.Root
+	if root == nil || root.ID == "epic" || root.Title != "Checkout" || root.ProjeID != "test-project-1" ||
+		root.Status != constants.TaskStatusInProgress || root.DueDate == nil || !root.DueDate.Equal(due) {
+		t.Fatalf("unexpected root clone: %+v", root)
+	}
+
+	story, err := vy.GorevGetir(ctx, result.IDMap["story"])
+	if err != nil || story.ParentID != root.ID || len(story.Tags) != 1 || story.Tags[0].Name != "payments" {
+		t.Errorf("story not cloned below the root with its tag: %+v (%v)", story, err)
+	}
+	subtask, err := vy.GorevGetir(ctx, result.IDMap["subtask"])
+	if err != nil || subtask.ParentID != story.ID {
+		t.Errorf("subtask not cloned below the story clone: %+v (%v)", subtask, err)
+	}
+
+	// Only the link inside the tree is copied
+	links, err := vy.BaglantilariGetir(ctx, subtask.ID)
+	if err != nil || len(links) != 1 || links[0].TargetID != story.ID {
+		t.Errorf("expected the inner link between the clones, got %+v (%v)", links, err)
+	}
+	if links, _ := vy.BaglantilariGetir(ctx, root.ID); len(links) != 0 {
+		t.Errorf("the outer link should stay with the original, got %+v", links)
+	}
+
+	// The originals are untouched
+	original, err := vy.GorevGetir(ctx, "story")
+	if err != nil || original.ParentID != "epic" {
+		t.Errorf("original changed: %+v (%v)", original, err)
+	}
+
+	if _, err := iy.GorevKlonla(ctx, "missing", KlonlamaSecenekleri{}); err == nil {
+		t.Error("cloning a missing task should fail")
+	}
+	if _, err := iy.GorevKlonla(ctx, "epic", KlonlamaSecenekleri{ProjectID: "missing"}); err == nil {
+		t.Error("cloning into a missing project should fail")
+	}
+}
+
+func TestGorevKlonla_Options(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	due := addCloneTestData(t, vy)
+	ctx := context.Background()
+
+	other, err := iy.ProjeOlustur(ctx, "Other", "")
+	if err != nil {
+		t.Fatalf("ProjeOlustur failed: %v", err)
+	}
+	result, err := iy.GorevKlonla(ctx, "story", KlonlamaSecenekleri{
+		ProjectID:        other.ID,
+		ResetStatus:      true,
+		DueDateShiftDays: 7,
+		IncludeFilePaths: true,
+	})
+	if err != nil {
+		t.Fatalf("GorevKlonla failed: %v", err)
+	}
+	if result.Tasks != 2 || result.FilePaths != 1 {
+		t.Errorf("unexpected counts: %+v", result)
+	}
+	if result.Root.ParentID != "" || result.Root.ProjeID != other.ID || result.Root.Status != constants.TaskStatusPending {
+		t.Errorf("root clone should be a pending root task of the other project: %+v", result.Root)
+	}
+	paths, err := vy.GorevDosyaYollariGetir(result.IDMap["subtask"])
+	if err != nil || len(paths) != 1 || paths[0] != "card.go" {
+		t.Errorf("expected the file path on the clone, got %v (%v)", paths, err)
+	}
+
+	// Due dates are shifted
+	epic, err := iy.GorevKlonla(ctx, "epic", KlonlamaSecenekleri{DueDateShiftDays: 3, DueDateShiftMonths: 1})
+	if err != nil {
+		t.Fatalf("GorevKlonla failed: %v", err)
+	}
+	if want := due.AddDate(0, 1, 3); epic.Root.DueDate == nil || !epic.Root.DueDate.Equal(want) {
+		t.Errorf("expected due date %v, got %v", want, epic.Root.DueDate)
+	}
+}
+
+func TestProjeKlonla(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	addCloneTestData(t, vy)
+	ctx := context.Background()
+
+	result, err := iy.ProjeKlonla(ctx, "test-project-1", KlonlamaSecenekleri{ProjectName: "Sprint 2"})
+	if err != nil {
+		t.Fatalf("ProjeKlonla failed: %v", err)
+	}
+	if result.Project == nil || result.Project.Name != "Sprint 2" || result.Project.ID == "test-project-1" {
+		t.Fatalf("unexpected project clone: %+v", result.Project)
+	}
+	tasks, err := vy.ProjeGorevleriGetir(ctx, result.Project.ID)
+	if err != nil {
+		t.Fatalf("ProjeGorevleriGetir failed: %v", err)
+	}
+	var titles []string
+	for _, task := range tasks {
+		titles = append(titles, task.Title)
+	}
+	sort.Strings(titles)
+	if len(tasks) != result.Tasks || !strings.Contains(strings.Join(titles, ","), "Card validation,Checkout") {
+		t.Errorf("unexpected cloned tasks: %v (%d)", titles, result.Tasks)
+	}
+	subtask, err := vy.GorevGetir(ctx, result.IDMap["subtask"])
+	if err != nil || subtask.ParentID != result.IDMap["story"] {
+		t.Errorf("hierarchy not kept: %+v (%v)", subtask, err)
+	}
+	// Both ends of link-outer are in the project now
+	if result.Links != 2 {
+		t.Errorf("expected both links to be copied, got %d", result.Links)
+	}
+
+	if _, err := iy.ProjeKlonla(ctx, "missing", KlonlamaSecenekleri{}); err == nil {
+		t.Error("cloning a missing project should fail")
+	}
+}
+
+func TestKlonlama_FailureLeavesNothing(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	addCloneTestData(t, vy)
+	ctx := context.Background()
+
+	// Links are written last, so a failing link insert comes after every other write
+	if _, err := vy.db.Exec(`CREATE TRIGGER fail_link BEFORE INSERT ON baglantilar BEGIN SELECT RAISE(ABORT, 'link insert failed'); END`); err != nil {
+		t.Fatalf("Failed to create trigger: %v", err)
+	}
+	counts := func() string {
+		var projects, tasks, tags, paths int
+		if err := vy.db.QueryRow(`SELECT (SELECT COUNT(*) FROM projeler), (SELECT COUNT(*) FROM gorevler),
+			(SELECT COUNT(*) FROM gorev_etiketleri), (SELECT COUNT(*) FROM task_file_paths)`).Scan(&projects, &tasks, &tags, &paths); err != nil {
+			t.Fatalf("Failed to count rows: %v", err)
+		}
+		return fmt.Sprintf("projects=%d tasks=%d tags=%d paths=%d", projects, tasks, tags, paths)
+	}
+	before := counts()
+
+	if _, err := iy.GorevKlonla(ctx, "epic", KlonlamaSecenekleri{IncludeFilePaths: true}); err == nil {
+		t.Error("GorevKlonla should fail")
+	}
+	if _, err := iy.ProjeKlonla(ctx, "test-project-1", KlonlamaSecenekleri{IncludeFilePaths: true}); err == nil {
+		t.Error("ProjeKlonla should fail")
+	}
+	if after := counts(); after != before {
+		t.Errorf("a failed clone should write nothing: before %s, after %s", before, after)
+	}
+}
@@ -0,0 +1,427 @@
+package gorev
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+//go:embed language_packs/*.json
+var embeddedLanguagePacks embed.FS
+
+// LanguagePackDirEnv names the directory of user language packs; ~/.gorev/languages
+// is used when it is not set
+const LanguagePackDirEnv = "GOREV_LANGUAGE_PACKS"
+
+// Relative time expressions of a language pack
+const (
+	relativeToday            = "today"
+	relativeTomorrow         = "tomorrow"
+	relativeDayAfterTomorrow = "day_after_tomorrow"
+	relativeYesterday        = "yesterday"
+	relativeThisWeek         = "this_week"
+	relativeNextWeek         = "next_week"
+	relativeNextMonth        = "next_month"
+)
+
+// Date modifiers of a language pack: "next friday", "this friday", "in 3 days" and
+// "3 gün sonra"
+const (
+	dateModifierNext  = "next"
+	dateModifierThis  = "this"
+	dateModifierIn    = "in"
+	dateModifierLater = "later"
+)
+
+// languagePackKeys lists the keys each section of a language pack may use
+var languagePackKeys = map[string][]string{
+	"actions":          {"list", "create", "update", "complete", "delete", "search", "status"},
+	"time_expressions": {relativeToday, relativeTomorrow, relativeDayAfterTomorrow, relativeYesterday, relativeThisWeek, relativeNextWeek, relativeNextMonth},
+	"weekdays":         {"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"},
+	"date_modifiers":   {dateModifierNext, dateModifierThis, dateModifierIn, dateModifierLater},
+	"date_units":       {"day", "week", "month"},
+	"priorities":       {"urgent", "high", "medium", "low"},
+	"priority_words":   {"high", "medium", "low"},
+	"statuses":         {"open", "completed", "in_progress", "pending"},
+}
+
+// LanguagePack is the vocabulary of one language for the NLP processor. Packs are JSON
+// files: tr and en are embedded, and more are loaded from LanguagePackDir. Each section
+// maps a fixed key (see languagePackKeys) to the phrases of the language; categories
+// may use any key.
+type LanguagePack struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+
+	// Actions maps list, create, update, complete, delete, search and status to phrases
+	Actions map[string][]string `json:"actions,omitempty"`
+	// TimeExpressions maps today, tomorrow, day_after_tomorrow, yesterday, this_week,
+	// next_week and next_month to phrases
+	TimeExpressions map[string][]string `json:"time_expressions,omitempty"`
+	// Weekdays maps English day names to day names and abbreviations
+	Weekdays map[string][]string `json:"weekdays,omitempty"`
+	// DateModifiers maps next, this and in (words before a weekday or count) and later
+	// (words after a count, as in "3 gün sonra") to words
+	DateModifiers map[string][]string `json:"date_modifiers,omitempty"`
+	// DateUnits maps day, week and month to unit words
+	DateUnits map[string][]string `json:"date_units,omitempty"`
+	// NumberWords maps number words to numbers
+	NumberWords map[string]int `json:"number_words,omitempty"`
+	// Priorities maps urgent, high, medium and low to phrases of search queries
+	Priorities map[string][]string `json:"priorities,omitempty"`
+	// PriorityWords maps high, medium and low to words after ! in quick-add syntax
+	PriorityWords map[string][]string `json:"priority_words,omitempty"`
+	// Statuses maps open, completed, in_progress and pending to phrases
+	Statuses map[string][]string `json:"statuses,omitempty"`
+	// Categories maps category names to phrases
+	Categories map[string][]string `json:"categories,omitempty"`
+
+	TagPrefixes       []string `json:"tag_prefixes,omitempty"`
+	TaskWords         []string `json:"task_words,omitempty"`
+	RecentTaskPhrases []string `json:"recent_task_phrases,omitempty"`
+
+	// Stopwords are left out of similarity comparisons
+	Stopwords []string `json:"stopwords,omitempty"`
+	// StemSuffixes are stripped from words before similarity comparisons (see stem)
+	StemSuffixes []string `json:"stem_suffixes,omitempty"`
+}
+
+// ParseLanguagePack reads a language pack from JSON and checks its keys
+func ParseLanguagePack(data []byte) (*LanguagePack, error) {
+	var pack LanguagePack
+	if err := json.Unmarshal(data, &pack); err != nil {
+		return nil, fmt.Errorf(i18n.T("error.languagePackInvalid", map[string]interface{}{"Error": err}))
+	}
+	pack.Code = strings.ToLower(strings.TrimSpace(pack.Code))
+	if pack.Code == "" {
+		return nil, fmt.Errorf(i18n.T("error.languagePackCodeRequired"))
+	}
+
+	sections := map[string]map[string][]string{
+		"actions":          pack.Actions,
+		"time_expressions": pack.TimeExpressions,
+		"weekdays":         pack.Weekdays,
+		"date_modifiers":   pack.DateModifiers,
+		"date_units":       pack.DateUnits,
+		"priorities":       pack.Priorities,
+		"priority_words":   pack.PriorityWords,
+		"statuses":         pack.Statuses,
+	}
+	for section, phrases := range sections {
+		for key := range phrases {
+			if !slices.Contains(languagePackKeys[section], key) {
+				return nil, fmt.Errorf(i18n.T("error.languagePackUnknownKey", map[string]interface{}{"Code": pack.Code, "Section": section, "Key": key}))
+			}
+		}
+	}
+	return &pack, nil
+}
+
+// LoadLanguagePacks reads the *.json language packs of dir. A missing directory has no
+// packs; files that cannot be read are skipped and their errors returned together with
+// the packs that could.
+func LoadLanguagePacks(dir string) ([]*LanguagePack, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var packs []*LanguagePack
+	var errs []error
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			var pack *LanguagePack
+			if pack, err = ParseLanguagePack(data); err == nil {
+				packs = append(packs, pack)
+				continue
+			}
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", path, err))
+	}
+	return packs, errors.Join(errs...)
+}
+
+// LanguagePackDir returns the directory of user language packs
+func LanguagePackDir() string {
+	if dir := os.Getenv(LanguagePackDirEnv); dir != "" {
+		return dir
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".gorev", "languages")
+}
+
+var (
+	languagePacksMu    sync.Mutex
+	languagePacksOnce  sync.Once
+	languagePacks      []*LanguagePack
+	languageVocabulary *nlpVocabulary
+)
+
+// loadDefaultLanguagePacks registers the embedded packs, then those of LanguagePackDir
+func loadDefaultLanguagePacks() {
+	entries, _ := embeddedLanguagePacks.ReadDir("language_packs")
+	for _, entry := range entries {
+		data, err := embeddedLanguagePacks.ReadFile("language_packs/" + entry.Name())
+		if err == nil {
+			var pack *LanguagePack
+			if pack, err = ParseLanguagePack(data); err == nil {
+				registerLanguagePack(pack)
+				continue
+			}
+		}
+		log.Printf("Embedded language pack %s skipped: %v", entry.Name(), err)
+	}
+
+	dir := LanguagePackDir()
+	if dir == "" {
+		return
+	}
+	packs, err := LoadLanguagePacks(dir)
+	if err != nil {
+		log.Printf("Language packs in %s skipped: %v", dir, err)
+	}
+	for _, pack := range packs {
+		registerLanguagePack(pack)
+	}
+}
+
+// RegisterLanguagePack adds a language pack to the ones new NLP processors use. A pack
+// with the code of a registered one replaces it.
+func RegisterLanguagePack(pack *LanguagePack) {
+	languagePacksOnce.Do(loadDefaultLanguagePacks)
+	languagePacksMu.Lock()
+	defer languagePacksMu.Unlock()
+	registerLanguagePack(pack)
+}
+
+// registerLanguagePack adds or replaces a pack; the caller holds languagePacksMu or
+// runs inside languagePacksOnce
+func registerLanguagePack(pack *LanguagePack) {
+	languageVocabulary = nil
+	for i, registered := range languagePacks {
+		if registered.Code == pack.Code {
+			languagePacks[i] = pack
+			return
+		}
+	}
+	languagePacks = append(languagePacks, pack)
+}
+
+// LanguagePacks returns the registered language packs in registration order
+func LanguagePacks() []*LanguagePack {
+	languagePacksOnce.Do(loadDefaultLanguagePacks)
+	languagePacksMu.Lock()
+	defer languagePacksMu.Unlock()
+	return append([]*LanguagePack(nil), languagePacks...)
+}
+
+// defaultNLPVocabulary returns the vocabulary of the registered language packs
+func defaultNLPVocabulary() *nlpVocabulary {
+	languagePacksOnce.Do(loadDefaultLanguagePacks)
+	languagePacksMu.Lock()
+	defer languagePacksMu.Unlock()
+	if languageVocabulary == nil {
+		languageVocabulary = newNLPVocabulary(languagePacks)
+	}
+	return languageVocabulary
+}
+
+// nlpPhrase is a phrase of a language pack and the value it stands for
+type nlpPhrase struct {
+	phrase string
+	value  string
+}
+
+// nlpVocabulary is the merged vocabulary of language packs. Phrase lists are lowercase
+// and sorted so that longer phrases are tried first; maps are keyed by folded words
+// (see foldCompletionText) for quick-add syntax.
+type nlpVocabulary struct {
+	actions         []nlpPhrase
+	timeExpressions []nlpPhrase
+	priorities      []nlpPhrase
+	statuses        []nlpPhrase
+	categories      []nlpPhrase
+	createPhrases   []string
+	tagPrefixes     []string
+	taskWords       []string
+	recentTasks     []string
+
+	dateExpressions map[string]string
+	dateStarters    map[string]bool // First words of multi-word date expressions
+	weekdays        map[string]time.Weekday
+	dateModifiers   map[string]string
+	dateUnits       map[string]struct{ days, months int }
+	numberWords     map[string]int
+	priorityWords   map[string]string
+	stopwords       map[string]bool
+	stemSuffixes    []string // Longest first
+}
+
+// nlpDateUnits are the steps of the date units of a language pack
+var nlpDateUnits = map[string]struct{ days, months int }{
+	"day": {1, 0}, "week": {7, 0}, "month": {0, 1},
+}
+
+// nlpPriorityWords maps the priority_words keys of a language pack to priorities
+var nlpPriorityWords = map[string]string{
+	"high": constants.PriorityHigh, "medium": constants.PriorityMedium, "low": constants.PriorityLow,
+}
+
+// newNLPVocabulary merges language packs
+func newNLPVocabulary(packs []*LanguagePack) *nlpVocabulary {
+	v := &nlpVocabulary{
+		dateExpressions: map[string]string{},
+		dateStarters:    map[string]bool{},
+		weekdays:        map[string]time.Weekday{},
+		dateModifiers:   map[string]string{},
+		dateUnits:       map[string]struct{ days, months int }{},
+		numberWords:     map[string]int{},
+		stopwords:       map[string]bool{},
+		// Priority values are accepted in every language
+		priorityWords: map[string]string{
+			constants.PriorityHigh:   constants.PriorityHigh,
+			constants.PriorityMedium: constants.PriorityMedium,
+			constants.PriorityLow:    constants.PriorityLow,
+		},
+	}
+
+	for _, pack := range packs {
+		v.actions = appendNLPPhrases(v.actions, pack.Actions)
+		v.timeExpressions = appendNLPPhrases(v.timeExpressions, pack.TimeExpressions)
+		v.priorities = appendNLPPhrases(v.priorities, pack.Priorities)
+		v.statuses = appendNLPPhrases(v.statuses, pack.Statuses)
+		v.categories = appendNLPPhrases(v.categories, pack.Categories)
+		v.createPhrases = appendLower(v.createPhrases, pack.Actions["create"])
+		v.tagPrefixes = appendLower(v.tagPrefixes, pack.TagPrefixes)
+		v.taskWords = appendLower(v.taskWords, pack.TaskWords)
+		v.recentTasks = appendLower(v.recentTasks, pack.RecentTaskPhrases)
+
+		for relative, phrases := range pack.TimeExpressions {
+			for _, phrase := range phrases {
+				words := strings.Fields(foldCompletionText(phrase))
+				if len(words) == 0 {
+					continue
+				}
+				v.dateExpressions[strings.Join(words, " ")] = relative
+				if len(words) > 1 {
+					v.dateStarters[words[0]] = true
+				}
+			}
+		}
+		for day, words := range pack.Weekdays {
+			weekday := time.Weekday(slices.Index(languagePackKeys["weekdays"], day))
+			for _, word := range words {
+				v.weekdays[foldCompletionText(word)] = weekday
+			}
+		}
+		for modifier, words := range pack.DateModifiers {
+			for _, word := range words {
+				v.dateModifiers[foldCompletionText(word)] = modifier
+			}
+		}
+		for unit, words := range pack.DateUnits {
+			for _, word := range words {
+				v.dateUnits[foldCompletionText(word)] = nlpDateUnits[unit]
+			}
+		}
+		for word, number := range pack.NumberWords {
+			v.numberWords[foldCompletionText(word)] = number
+		}
+		for priority, words := range pack.PriorityWords {
+			for _, word := range words {
+				v.priorityWords[foldCompletionText(word)] = nlpPriorityWords[priority]
+			}
+		}
+		for _, word := range pack.Stopwords {
+			v.stopwords[foldCompletionText(word)] = true
+		}
+		for _, suffix := range pack.StemSuffixes {
+			if suffix = foldCompletionText(strings.TrimSpace(suffix)); suffix != "" && !slices.Contains(v.stemSuffixes, suffix) {
+				v.stemSuffixes = append(v.stemSuffixes, suffix)
+			}
+		}
+	}
+
+	for _, phrases := range [][]nlpPhrase{v.actions, v.timeExpressions, v.priorities, v.statuses, v.categories} {
+		sort.SliceStable(phrases, func(i, j int) bool {
+			return longerPhrase(phrases[i].phrase, phrases[j].phrase)
+		})
+	}
+	sort.SliceStable(v.createPhrases, func(i, j int) bool {
+		return longerPhrase(v.createPhrases[i], v.createPhrases[j])
+	})
+	sort.SliceStable(v.stemSuffixes, func(i, j int) bool {
+		return longerPhrase(v.stemSuffixes[i], v.stemSuffixes[j])
+	})
+	return v
+}
+
+// match returns the value of the first phrase contained in query
+func (v *nlpVocabulary) match(phrases []nlpPhrase, query string) (string, bool) {
+	for _, p := range phrases {
+		if strings.Contains(query, p.phrase) {
+			return p.value, true
+		}
+	}
+	return "", false
+}
+
+// number reads a count written in digits or as a number word
+func (v *nlpVocabulary) number(word string) (int, bool) {
+	if n, ok := v.numberWords[word]; ok {
+		return n, true
+	}
+	n, err := strconv.Atoi(word)
+	return n, err == nil
+}
+
+// longerPhrase orders phrases by word count, then length, then alphabetically
+func longerPhrase(a, b string) bool {
+	wordsA, wordsB := len(strings.Fields(a)), len(strings.Fields(b))
+	if wordsA != wordsB {
+		return wordsA > wordsB
+	}
+	if lenA, lenB := utf8.RuneCountInString(a), utf8.RuneCountInString(b); lenA != lenB {
+		return lenA > lenB
+	}
+	return a < b
+}
+
+func appendNLPPhrases(phrases []nlpPhrase, section map[string][]string) []nlpPhrase {
+	for value, list := range section {
+		for _, phrase := range list {
+			if phrase = strings.ToLower(strings.TrimSpace(phrase)); phrase != "" {
+				phrases = append(phrases, nlpPhrase{phrase: phrase, value: value})
+			}
+		}
+	}
+	return phrases
+}
+
+func appendLower(list []string, values []string) []string {
+	for _, value := range values {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" && !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
@@ -0,0 +1,144 @@
+package gorev
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// germanPackPath is the example pack shipped with the docs
+const germanPackPath = "../../../docs/examples/language-packs/de.json"
+
+func TestParseLanguagePack(t *testing.T) {
+	pack, err := ParseLanguagePack([]byte(`{"code": " DE ", "statuses": {"open": ["offene"]}, "categories": {"docs": ["doku"]}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "de", pack.Code)
+
+	for _, data := range []string{
+		`{"code": "de"`,
+		`{"name": "Deutsch"}`,
+		`{"code": "de", "statuses": {"offen": ["offene"]}}`,
+		`{"code": "de", "weekdays": {"montag": ["mo"]}}`,
+	} {
+		_, err := ParseLanguagePack([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
+
+func TestLoadLanguagePacks(t *testing.T) {
+	dir := t.TempDir()
+	data, err := os.ReadFile(germanPackPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "de.json"), data, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"code":`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte(`not a pack`), 0644))
+
+	// Broken files are reported without losing the others
+	packs, err := LoadLanguagePacks(dir)
+	assert.ErrorContains(t, err, "broken.json")
+	require.Len(t, packs, 1)
+	assert.Equal(t, "de", packs[0].Code)
+
+	packs, err = LoadLanguagePacks(filepath.Join(dir, "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, packs)
+
+	t.Setenv(LanguagePackDirEnv, dir)
+	assert.Equal(t, dir, LanguagePackDir())
+}
+
+func TestNLPProcessor_GermanLanguagePack(t *testing.T) {
+	packs, err := LoadLanguagePacks(filepath.Dir(germanPackPath))
+	require.NoError(t, err)
+	nlp := NewNLPProcessorWithLanguagePacks(packs...)
+	nlp.TimeZone = time.UTC
+	nlp.now = newQuickAddTestProcessor().now
+
+	intent, err := nlp.ProcessQuery("Zeige offene Aufgaben mit hohe Priorität morgen schlagwort:api")
+	require.NoError(t, err)
+	assert.Equal(t, "list", intent.Action)
+	assert.Equal(t, "open", intent.Filters["status"])
+	assert.Equal(t, "high", intent.Filters["priority"])
+	assert.Equal(t, []string{"api"}, intent.Filters["tags"])
+	require.NotNil(t, intent.TimeRange)
+	assert.Equal(t, "tomorrow", intent.TimeRange.Relative)
+	assert.Equal(t, "2026-10-15", intent.TimeRange.Start.Format(constants.DateFormatISO))
+
+	assert.Equal(t, "complete", nlp.parseAction("aufgabe #12 erledige"))
+	assert.Equal(t, []string{"recent:1"}, nlp.parseTaskReferences("letzte aufgabe"))
+
+	// Only the given packs are used
+	assert.Nil(t, nlp.parseTimeExpressions("tomorrow"))
+
+	tests := []struct {
+		text     string
+		title    string
+		due      string
+		priority string
+	}{
+		{"Bericht senden in drei Tagen !hoch", "Bericht senden", "2026-10-17", constants.PriorityHigh},
+		{"Bericht senden nächsten Freitag", "Bericht senden", "2026-10-23", ""},
+		{"Bericht senden übermorgen !niedrig", "Bericht senden", "2026-10-16", constants.PriorityLow},
+		{"Bericht senden due:mo !orta", "Bericht senden", "2026-10-19", constants.PriorityMedium},
+	}
+	for _, tt := range tests {
+		quickAdd, err := nlp.ParseQuickAdd(tt.text)
+		require.NoError(t, err, tt.text)
+		assert.Equal(t, tt.title, quickAdd.Title, tt.text)
+		assert.Equal(t, tt.priority, quickAdd.Priority, tt.text)
+		require.NotNil(t, quickAdd.DueDate, tt.text)
+		assert.Equal(t, tt.due, quickAdd.DueDate.Format(constants.DateFormatISO), tt.text)
+	}
+}
+
+func TestNLPProcessor_NumberWords(t *testing.T) {
+	nlp := newQuickAddTestProcessor()
+
+	for text, due := range map[string]string{
+		"Raporu üç gün sonra gönder": "2026-10-17",
+		"Call the bank in two weeks": "2026-10-28",
+		"Renew domain in a month":    "2026-11-14",
+	} {
+		quickAdd, err := nlp.ParseQuickAdd(text)
+		require.NoError(t, err, text)
+		require.NotNil(t, quickAdd.DueDate, text)
+		assert.Equal(t, due, quickAdd.DueDate.Format(constants.DateFormatISO), text)
+	}
+}
+
+func TestRegisterLanguagePack(t *testing.T) {
+	registered := LanguagePacks()
+	t.Cleanup(func() {
+		languagePacksMu.Lock()
+		languagePacks = registered
+		languageVocabulary = nil
+		languagePacksMu.Unlock()
+	})
+	assert.GreaterOrEqual(t, len(registered), 2)
+
+	pack, err := ParseLanguagePack([]byte(`{"code": "de", "time_expressions": {"day_after_tomorrow": ["übermorgen"]}}`))
+	require.NoError(t, err)
+	RegisterLanguagePack(pack)
+
+	// New processors see the pack next to the embedded ones
+	nlp := NewNLPProcessor()
+	nlp.TimeZone = time.UTC
+	nlp.now = newQuickAddTestProcessor().now
+	quickAdd, err := nlp.ParseQuickAdd("Backup übermorgen !high")
+	require.NoError(t, err)
+	require.NotNil(t, quickAdd.DueDate)
+	assert.Equal(t, "2026-10-16", quickAdd.DueDate.Format(constants.DateFormatISO))
+	assert.Equal(t, constants.PriorityHigh, quickAdd.Priority)
+
+	// A pack with the same code replaces the registered one
+	replacement, err := ParseLanguagePack([]byte(`{"code": "de", "name": "Deutsch"}`))
+	require.NoError(t, err)
+	RegisterLanguagePack(replacement)
+	assert.Len(t, LanguagePacks(), len(registered)+1)
+	assert.Nil(t, nlp.parseTimeExpressions("übermorgen"))
+}
@@ -0,0 +1,1431 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// MemoryVeriYonetici is a VeriYoneticiInterface implementation that keeps all data in
+// process memory. It follows the behavior of the SQLite VeriYonetici, including its
+// foreign key and unique constraints, so it can replace it in tests and in throwaway
+// sandboxes (gorev serve --ephemeral). Nothing is persisted; GetDB returns no database.
+type MemoryVeriYonetici struct {
+	mu sync.RWMutex
+
+	gorevler      map[string]*memoryGorev
+	sira          int64 // insertion counter, used as a stable tie-breaker like SQLite's rowid
+	projeler      map[string]*Proje
+	etiketler     map[string]*Etiket // by ID
+	etiketIsimler map[string]string  // name -> ID
+	gorevEtiket   map[string][]string
+	baglantilar   []*Baglanti
+	aktifProjeID  string
+	templateler   []*GorevTemplate
+	aiContext     *memoryAIContext
+	etkilesimler  []*AIInteraction
+	etkilesimSira int64
+	dosyaYollari  []memoryDosyaYolu
+
+	eventEmitter EventEmitter
+	workspaceID  string
+}
+
+// memoryGorev holds a task row; Tags are kept in gorevEtiket, the other columns in ekstra
+type memoryGorev struct {
+	gorev  Gorev
+	sira   int64
+	ekstra map[string]interface{}
+}
+
+// memoryAIContext stores the ai_context row with its JSON columns, so values read back
+// have the same types as with SQLite
+type memoryAIContext struct {
+	activeTaskID string
+	recentTasks  string
+	sessionData  string
+	lastUpdated  time.Time
+}
+
+type memoryDosyaYolu struct {
+	taskID string
+	path   string
+}
+
+// memoryEkGorevKolonlari are gorevler columns that GorevGuncelle accepts but Gorev does not expose
+var memoryEkGorevKolonlari = map[string]bool{
+	"last_ai_interaction": true,
+	"estimated_hours":     true,
+	"actual_hours":        true,
+}
+
+// gecerliEtkilesimTipleri mirrors the CHECK constraint of ai_interactions.action_type
+var gecerliEtkilesimTipleri = map[string]bool{
+	"viewed":         true,
+	"created":        true,
+	"updated":        true,
+	"completed":      true,
+	"set_active":     true,
+	"bulk_operation": true,
+}
+
+// NewMemoryVeriYonetici creates an empty in-memory data store with the default templates
+func NewMemoryVeriYonetici() *MemoryVeriYonetici {
+	return NewMemoryVeriYoneticiWithEventEmitter(nil, "")
+}
+
+// NewMemoryVeriYoneticiWithEventEmitter creates an in-memory data store that emits change events
+func NewMemoryVeriYoneticiWithEventEmitter(eventEmitter EventEmitter, workspaceID string) *MemoryVeriYonetici {
+	vy := &MemoryVeriYonetici{
+		gorevler:      make(map[string]*memoryGorev),
+		projeler:      make(map[string]*Proje),
+		etiketler:     make(map[string]*Etiket),
+		etiketIsimler: make(map[string]string),
+		gorevEtiket:   make(map[string][]string),
+		eventEmitter:  eventEmitter,
+		workspaceID:   workspaceID,
+	}
+
+	// Varsayılan template'leri oluştur (SQLite veri yöneticisi gibi)
+	if err := vy.VarsayilanTemplateleriOlustur(context.Background()); err != nil {
+		log.Printf("WARNING: Failed to create default templates: %v", err)
+	}
+
+	return vy
+}
+
+// Görevler
+
+func (vy *MemoryVeriYonetici) GorevKaydet(ctx context.Context, gorev *Gorev) error {
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	if _, ok := vy.gorevler[gorev.ID]; ok {
+		return fmt.Errorf(i18n.T("error.duplicateID", map[string]interface{}{"Entity": "task", "ID": gorev.ID}))
+	}
+	if err := vy.referanslariKontrolEt(ctx, gorev.ProjeID, gorev.ParentID); err != nil {
+		return err
+	}
+
+	kayit := cloneGorev(gorev)
+	kayit.Tags = nil
+	kayit.Subtasks = nil
+	kayit.Bagimliliklar = nil
+	kayit.ProjeName = ""
+	kayit.Level = 0
+	kayit.DependencyCount, kayit.UncompletedDependencyCount, kayit.DependentOnThisCount = 0, 0, 0
+	if kayit.WorkspaceID == "" {
+		kayit.WorkspaceID = "default"
+	}
+
+	vy.sira++
+	vy.gorevler[gorev.ID] = &memoryGorev{gorev: *kayit, sira: vy.sira, ekstra: make(map[string]interface{})}
+
+	if vy.eventEmitter != nil {
+		vy.eventEmitter.EmitTaskCreated(vy.workspaceID, gorev.ID, map[string]interface{}{
+			"title":    gorev.Title,
+			"status":   gorev.Status,
+			"priority": gorev.Priority,
+		})
+	}
+
+	return nil
+}
+
+// referanslariKontrolEt mirrors the foreign keys of gorevler.project_id and gorevler.parent_id
+func (vy *MemoryVeriYonetici) referanslariKontrolEt(ctx context.Context, projeID, parentID string) error {
+	lang := i18n.FromContext(ctx)
+	if projeID != "" {
+		if _, ok := vy.projeler[projeID]; !ok {
+			return fmt.Errorf(i18n.TEntityNotFoundByID(lang, "project", projeID))
+		}
+	}
+	if parentID != "" {
+		if _, ok := vy.gorevler[parentID]; !ok {
+			return fmt.Errorf(i18n.TEntityNotFoundByID(lang, "task", parentID))
+		}
+	}
+	return nil
+}
+
+func (vy *MemoryVeriYonetici) GorevGetir(ctx context.Context, id string) (*Gorev, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	kayit, ok := vy.gorevler[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+
+	gorev := vy.gorevKopyasi(kayit)
+	gorev.WorkspaceID = ""
+	if proje, ok := vy.projeler[gorev.ProjeID]; ok {
+		gorev.ProjeName = proje.Name
+	}
+	return gorev, nil
+}
+
+// gorevKopyasi returns a copy of the stored task with its tags
+func (vy *MemoryVeriYonetici) gorevKopyasi(kayit *memoryGorev) *Gorev {
+	gorev := cloneGorev(&kayit.gorev)
+	for _, etiketID := range vy.gorevEtiket[gorev.ID] {
+		if etiket, ok := vy.etiketler[etiketID]; ok {
+			gorev.Tags = append(gorev.Tags, &Etiket{ID: etiket.ID, Name: etiket.Name})
+		}
+	}
+	return gorev
+}
+
+// sadeGorevKopyasi returns a copy of the stored task with the columns hierarchy queries read
+func sadeGorevKopyasi(kayit *memoryGorev) *Gorev {
+	gorev := cloneGorev(&kayit.gorev)
+	gorev.WorkspaceID = ""
+	return gorev
+}
+
+func (vy *MemoryVeriYonetici) GorevleriGetir(ctx context.Context, durum, sirala, filtre string) ([]*Gorev, error) {
+	return vy.GorevleriGetirWithWorkspace(ctx, durum, sirala, filtre, "")
+}
+
+// GorevleriGetirWithWorkspace retrieves tasks with optional workspace filtering
+func (vy *MemoryVeriYonetici) GorevleriGetirWithWorkspace(ctx context.Context, durum, sirala, filtre, workspaceID string) ([]*Gorev, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	bugun := time.Now().UTC().Truncate(24 * time.Hour)
+	haftaSonra := bugun.AddDate(0, 0, 7)
+
+	return vy.gorevListesi(func(g *Gorev) bool {
+		if workspaceID != "" && g.WorkspaceID != workspaceID {
+			return false
+		}
+		if durum != "" && g.Status != durum {
+			return false
+		}
+		switch filtre {
+		case "acil":
+			return g.DueDate != nil && !g.DueDate.UTC().Before(bugun) && g.DueDate.UTC().Before(haftaSonra)
+		case "gecmis":
+			return g.DueDate != nil && g.DueDate.UTC().Before(bugun)
+		}
+		return true
+	}, sirala), nil
+}
+
+// gorevListesi returns the tasks matching filtre with project names, dependency counts
+// and tags, in the order the SQLite list queries use
+func (vy *MemoryVeriYonetici) gorevListesi(filtre func(*Gorev) bool, sirala string) []*Gorev {
+	kayitlar := make([]*memoryGorev, 0, len(vy.gorevler))
+	for _, kayit := range vy.gorevler {
+		if filtre(&kayit.gorev) {
+			kayitlar = append(kayitlar, kayit)
+		}
+	}
+	sort.Slice(kayitlar, func(i, j int) bool { return kayitlar[i].sira < kayitlar[j].sira })
+
+	switch sirala {
+	case "son_tarih_asc":
+		// SQLite NULL değerleri artan sıralamada başa koyar
+		sort.SliceStable(kayitlar, func(i, j int) bool {
+			a, b := kayitlar[i].gorev.DueDate, kayitlar[j].gorev.DueDate
+			if a == nil || b == nil {
+				return a == nil && b != nil
+			}
+			return a.Before(*b)
+		})
+	case "son_tarih_desc":
+		sort.SliceStable(kayitlar, func(i, j int) bool {
+			a, b := kayitlar[i].gorev.DueDate, kayitlar[j].gorev.DueDate
+			if a == nil || b == nil {
+				return a != nil && b == nil
+			}
+			return a.After(*b)
+		})
+	default:
+		sort.SliceStable(kayitlar, func(i, j int) bool {
+			return kayitlar[i].gorev.CreatedAt.After(kayitlar[j].gorev.CreatedAt)
+		})
+	}
+
+	gorevler := make([]*Gorev, 0, len(kayitlar))
+	for _, kayit := range kayitlar {
+		gorev := vy.gorevKopyasi(kayit)
+		if proje, ok := vy.projeler[gorev.ProjeID]; ok {
+			gorev.ProjeName = proje.Name
+		}
+		for _, b := range vy.baglantilar {
+			if b.TargetID == gorev.ID {
+				gorev.DependencyCount++
+				if kaynak, ok := vy.gorevler[b.SourceID]; ok && kaynak.gorev.Status != constants.TaskStatusCompleted {
+					gorev.UncompletedDependencyCount++
+				}
+			}
+			if b.SourceID == gorev.ID {
+				gorev.DependentOnThisCount++
+			}
+		}
+		gorevler = append(gorevler, gorev)
+	}
+	return gorevler
+}
+
+func (vy *MemoryVeriYonetici) GorevGuncelle(ctx context.Context, taskID string, params interface{}) error {
+	paramsMap, ok := params.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf(i18n.T("error.invalidParamsType"))
+	}
+
+	if len(paramsMap) == 0 {
+		return nil // No updates to perform
+	}
+
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	kayit, bulundu := vy.gorevler[taskID]
+	guncel := Gorev{}
+	if bulundu {
+		guncel = kayit.gorev
+	}
+	ekstra := make(map[string]interface{})
+
+	for key, value := range paramsMap {
+		var err error
+		switch key {
+		case "title":
+			guncel.Title, err = memoryMetinDegeri(key, value)
+		case "description":
+			guncel.Description, err = memoryMetinDegeri(key, value)
+		case "status":
+			guncel.Status, err = memoryMetinDegeri(key, value)
+		case "priority":
+			guncel.Priority, err = memoryMetinDegeri(key, value)
+		case "project_id":
+			guncel.ProjeID, err = memoryMetinDegeri(key, value)
+		case "parent_id":
+			guncel.ParentID, err = memoryMetinDegeri(key, value)
+		case "workspace_id":
+			guncel.WorkspaceID, err = memoryMetinDegeri(key, value)
+		case "due_date":
+			guncel.DueDate, err = memoryZamanDegeri(key, value)
+		case "created_at", "updated_at":
+			var t *time.Time
+			if t, err = memoryZamanDegeri(key, value); err == nil {
+				if t == nil {
+					t = &time.Time{}
+				}
+				if key == "created_at" {
+					guncel.CreatedAt = *t
+				} else {
+					guncel.UpdatedAt = *t
+				}
+			}
+		default:
+			if !memoryEkGorevKolonlari[key] {
+				return fmt.Errorf(i18n.T("error.unknownTaskColumn", map[string]interface{}{"Field": key}))
+			}
+			ekstra[key] = value
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// SQLite gibi eşleşen satır yoksa güncelleme sessizce hiçbir şey yapmaz
+	if bulundu {
+		if guncel.ProjeID != kayit.gorev.ProjeID || guncel.ParentID != kayit.gorev.ParentID {
+			if err := vy.referanslariKontrolEt(ctx, guncel.ProjeID, guncel.ParentID); err != nil {
+				return err
+			}
+			if guncel.ParentID != kayit.gorev.ParentID && guncel.ParentID != "" && vy.altAgactaMi(taskID, guncel.ParentID) {
+				return fmt.Errorf(i18n.T("error.circularDependency"))
+			}
+		}
+		kayit.gorev = guncel
+		for key, value := range ekstra {
+			kayit.ekstra[key] = value
+		}
+	}
+
+	if vy.eventEmitter != nil {
+		vy.eventEmitter.EmitTaskUpdated(vy.workspaceID, taskID, paramsMap)
+	}
+
+	return nil
+}
+
+// memoryMetinDegeri converts a GorevGuncelle value for a text column; nil means NULL
+func memoryMetinDegeri(key string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case *string:
+		if v == nil {
+			return "", nil
+		}
+		return *v, nil
+	case sql.NullString:
+		return v.String, nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	return "", fmt.Errorf(i18n.T("error.invalidColumnValue", map[string]interface{}{"Field": key}))
+}
+
+// memoryZamanDegeri converts a GorevGuncelle value for a date column; nil means NULL
+func memoryZamanDegeri(key string, value interface{}) (*time.Time, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		return &v, nil
+	case *time.Time:
+		if v == nil {
+			return nil, nil
+		}
+		t := *v
+		return &t, nil
+	case sql.NullTime:
+		if !v.Valid {
+			return nil, nil
+		}
+		return &v.Time, nil
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return &t, nil
+			}
+		}
+		return nil, fmt.Errorf(i18n.T("error.invalidDateFormat", map[string]interface{}{"Error": v}))
+	}
+	return nil, fmt.Errorf(i18n.T("error.invalidColumnValue", map[string]interface{}{"Field": key}))
+}
+
+func (vy *MemoryVeriYonetici) GorevSil(ctx context.Context, id string) error {
+	vy.mu.Lock()
+
+	if _, ok := vy.gorevler[id]; !ok {
+		vy.mu.Unlock()
+		return fmt.Errorf(i18n.TEntityNotFound(i18n.FromContext(ctx), "task", errors.New("not found")))
+	}
+
+	// parent_id ON DELETE RESTRICT
+	altSayisi := 0
+	for _, kayit := range vy.gorevler {
+		if kayit.gorev.ParentID == id {
+			altSayisi++
+		}
+	}
+	if altSayisi > 0 {
+		vy.mu.Unlock()
+		return fmt.Errorf(i18n.T("error.taskHasSubtasksCannotDelete", map[string]interface{}{"Count": altSayisi}))
+	}
+
+	delete(vy.gorevler, id)
+	delete(vy.gorevEtiket, id)
+
+	baglantilar := vy.baglantilar[:0]
+	for _, b := range vy.baglantilar {
+		if b.SourceID != id && b.TargetID != id {
+			baglantilar = append(baglantilar, b)
+		}
+	}
+	vy.baglantilar = baglantilar
+
+	etkilesimler := vy.etkilesimler[:0]
+	for _, e := range vy.etkilesimler {
+		if e.GorevID != id {
+			etkilesimler = append(etkilesimler, e)
+		}
+	}
+	vy.etkilesimler = etkilesimler
+
+	yollar := vy.dosyaYollari[:0]
+	for _, y := range vy.dosyaYollari {
+		if y.taskID != id {
+			yollar = append(yollar, y)
+		}
+	}
+	vy.dosyaYollari = yollar
+
+	if vy.aiContext != nil && vy.aiContext.activeTaskID == id {
+		vy.aiContext.activeTaskID = ""
+	}
+	vy.mu.Unlock()
+
+	if vy.eventEmitter != nil {
+		vy.eventEmitter.EmitTaskDeleted(vy.workspaceID, id)
+	}
+
+	return nil
+}
+
+// GorevDetay retrieves detailed task information
+func (vy *MemoryVeriYonetici) GorevDetay(ctx context.Context, taskID string) (*Gorev, error) {
+	return vy.GorevGetir(ctx, taskID)
+}
+
+// GorevListele retrieves tasks based on filters
+func (vy *MemoryVeriYonetici) GorevListele(ctx context.Context, filters map[string]interface{}) ([]*Gorev, error) {
+	status, sirala, filtre, workspaceID := gorevListeleFiltreleri(filters)
+	gorevler, err := vy.GorevleriGetirWithWorkspace(ctx, status, sirala, filtre, workspaceID)
+	sorgu := gorevListeleSorgusu(filters)
+	projeID, _ := filters["proje_id"].(string)
+	etiket, _ := filters["etiket"].(string)
+	if err != nil || (sorgu.IsEmpty() && projeID == "" && etiket == "") {
+		return gorevler, err
+	}
+
+	// The query needs project names and tags, which the listed copies carry
+	eslesenler := gorevler[:0]
+	for _, gorev := range gorevler {
+		if projeID != "" && gorev.ProjeID != projeID {
+			continue
+		}
+		if etiket != "" && !gorevEtiketiVar(gorev, etiket) {
+			continue
+		}
+		if sorgu.IsEmpty() || sorgu.Matches(gorev) {
+			eslesenler = append(eslesenler, gorev)
+		}
+	}
+	return eslesenler, nil
+}
+
+// gorevEtiketiVar reports whether the task has the tag with exactly this name
+func gorevEtiketiVar(gorev *Gorev, isim string) bool {
+	for _, e := range gorev.Tags {
+		if e.Name == isim {
+			return true
+		}
+	}
+	return false
+}
+
+// GorevSayfasiGetir pages the GorevListele list
+func (vy *MemoryVeriYonetici) GorevSayfasiGetir(ctx context.Context, filters map[string]interface{}, limit, offset int) (*GorevSayfasi, error) {
+	gorevler, err := vy.GorevListele(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	return gorevSayfasiAyir(gorevler, limit, offset), nil
+}
+
+// GorevOlustur creates a new task
+func (vy *MemoryVeriYonetici) GorevOlustur(ctx context.Context, params map[string]interface{}) (string, error) {
+	gorev := yeniGorevParametrelerden(params)
+	if err := vy.GorevKaydet(ctx, gorev); err != nil {
+		return "", err
+	}
+	return gorev.ID, nil
+}
+
+// GorevBagimlilikGetir retrieves task dependencies
+func (vy *MemoryVeriYonetici) GorevBagimlilikGetir(ctx context.Context, taskID string) ([]*Gorev, error) {
+	return gorevBagimlilikGetir(ctx, vy, taskID)
+}
+
+// Projeler
+
+func (vy *MemoryVeriYonetici) ProjeKaydet(ctx context.Context, proje *Proje) error {
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	if _, ok := vy.projeler[proje.ID]; ok {
+		return fmt.Errorf(i18n.T("error.duplicateID", map[string]interface{}{"Entity": "project", "ID": proje.ID}))
+	}
+
+	kayit := *proje
+	kayit.TaskCount = 0
+	if kayit.WorkspaceID == "" {
+		kayit.WorkspaceID = "default"
+	}
+	vy.projeler[proje.ID] = &kayit
+	return nil
+}
+
+func (vy *MemoryVeriYonetici) ProjeGetir(ctx context.Context, id string) (*Proje, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	kayit, ok := vy.projeler[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	proje := *kayit
+	proje.WorkspaceID = ""
+	return &proje, nil
+}
+
+func (vy *MemoryVeriYonetici) ProjeleriGetir(ctx context.Context) ([]*Proje, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	var projeler []*Proje
+	for _, kayit := range vy.projeler {
+		proje := *kayit
+		proje.WorkspaceID = ""
+		for _, g := range vy.gorevler {
+			if g.gorev.ProjeID == proje.ID {
+				proje.TaskCount++
+			}
+		}
+		projeler = append(projeler, &proje)
+	}
+	sort.SliceStable(projeler, func(i, j int) bool {
+		if projeler[i].CreatedAt.Equal(projeler[j].CreatedAt) {
+			return projeler[i].ID < projeler[j].ID
+		}
+		return projeler[i].CreatedAt.After(projeler[j].CreatedAt)
+	})
+	return projeler, nil
+}
+
+func (vy *MemoryVeriYonetici) ProjeGorevleriGetir(ctx context.Context, projeID string) ([]*Gorev, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	// Boş projeID projesiz görevleri getirir
+	return vy.gorevListesi(func(g *Gorev) bool { return g.ProjeID == projeID }, ""), nil
+}
+
+// AktifProjeAyarla aktif projeyi ayarlar
+func (vy *MemoryVeriYonetici) AktifProjeAyarla(ctx context.Context, projeID string) error {
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	if _, ok := vy.projeler[projeID]; !ok {
+		return fmt.Errorf(i18n.TWithLang(i18n.FromContext(ctx), "error.projectNotFoundId", map[string]interface{}{"Id": projeID}))
+	}
+	vy.aktifProjeID = projeID
+	return nil
+}
+
+// AktifProjeGetir aktif projeyi getirir
+func (vy *MemoryVeriYonetici) AktifProjeGetir(ctx context.Context) (string, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+	return vy.aktifProjeID, nil
+}
+
+// AktifProjeKaldir aktif proje ayarını kaldırır
+func (vy *MemoryVeriYonetici) AktifProjeKaldir(ctx context.Context) error {
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+	vy.aktifProjeID = ""
+	return nil
+}
+
+// Bağlantılar
+
+func (vy *MemoryVeriYonetici) BaglantiEkle(ctx context.Context, baglanti *Baglanti) error {
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	for _, b := range vy.baglantilar {
+		if b.ID == baglanti.ID {
+			return fmt.Errorf(i18n.T("error.duplicateID", map[string]interface{}{"Entity": "dependency", "ID": baglanti.ID}))
+		}
+	}
+	lang := i18n.FromContext(ctx)
+	for _, id := range []string{baglanti.SourceID, baglanti.TargetID} {
+		if _, ok := vy.gorevler[id]; !ok {
+			return fmt.Errorf(i18n.TEntityNotFoundByID(lang, "task", id))
+		}
+	}
+
+	kayit := *baglanti
+	vy.baglantilar = append(vy.baglantilar, &kayit)
+	return nil
+}
+
+// BaglantiSil removes a dependency relationship between two tasks
+func (vy *MemoryVeriYonetici) BaglantiSil(ctx context.Context, kaynakID, hedefID string) error {
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	baglantilar := vy.baglantilar[:0]
+	for _, b := range vy.baglantilar {
+		if b.SourceID != kaynakID || b.TargetID != hedefID {
+			baglantilar = append(baglantilar, b)
+		}
+	}
+	silinen := len(vy.baglantilar) - len(baglantilar)
+	vy.baglantilar = baglantilar
+
+	if silinen == 0 {
+		return fmt.Errorf(i18n.T("error.dependencyNotFound", map[string]interface{}{"Source": kaynakID, "Target": hedefID}))
+	}
+	return nil
+}
+
+func (vy *MemoryVeriYonetici) BaglantilariGetir(ctx context.Context, gorevID string) ([]*Baglanti, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	var baglantilar []*Baglanti
+	for _, b := range vy.baglantilar {
+		if b.SourceID == gorevID || b.TargetID == gorevID {
+			kopya := *b
+			baglantilar = append(baglantilar, &kopya)
+		}
+	}
+	return baglantilar, nil
+}
+
+// BulkBagimlilikSayilariGetir görevlerin bağımlılık sayılarını hesaplar
+func (vy *MemoryVeriYonetici) BulkBagimlilikSayilariGetir(gorevIDs []string) (map[string]int, error) {
+	return vy.baglantiSay(gorevIDs, func(b *Baglanti) (string, bool) { return b.TargetID, true }), nil
+}
+
+// BulkTamamlanmamiaBagimlilikSayilariGetir görevlerin tamamlanmamış bağımlılık sayılarını hesaplar
+func (vy *MemoryVeriYonetici) BulkTamamlanmamiaBagimlilikSayilariGetir(gorevIDs []string) (map[string]int, error) {
+	return vy.baglantiSay(gorevIDs, func(b *Baglanti) (string, bool) {
+		kaynak, ok := vy.gorevler[b.SourceID]
+		return b.TargetID, ok && kaynak.gorev.Status != constants.TaskStatusCompleted
+	}), nil
+}
+
+// BulkBuGoreveBagimliSayilariGetir görevlere bağımlı olan görev sayılarını hesaplar
+func (vy *MemoryVeriYonetici) BulkBuGoreveBagimliSayilariGetir(gorevIDs []string) (map[string]int, error) {
+	return vy.baglantiSay(gorevIDs, func(b *Baglanti) (string, bool) { return b.SourceID, true }), nil
+}
+
+// baglantiSay counts the links for which anahtar returns one of gorevIDs; like the
+// GROUP BY queries, tasks without links are left out of the result
+func (vy *MemoryVeriYonetici) baglantiSay(gorevIDs []string, anahtar func(*Baglanti) (string, bool)) map[string]int {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	sonuc := make(map[string]int)
+	if len(gorevIDs) == 0 {
+		return sonuc
+	}
+	istenen := make(map[string]bool, len(gorevIDs))
+	for _, id := range gorevIDs {
+		istenen[id] = true
+	}
+	for _, b := range vy.baglantilar {
+		if id, say := anahtar(b); say && istenen[id] {
+			sonuc[id]++
+		}
+	}
+	return sonuc
+}
+
+// Etiketler
+
+func (vy *MemoryVeriYonetici) EtiketleriGetirVeyaOlustur(ctx context.Context, isimler []string) ([]*Etiket, error) {
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	etiketler := make([]*Etiket, 0, len(isimler))
+	for _, isim := range isimler {
+		isim = strings.TrimSpace(isim)
+		if isim == "" {
+			continue
+		}
+		id, ok := vy.etiketIsimler[isim]
+		if !ok {
+			id = uuid.New().String()
+			vy.etiketler[id] = &Etiket{ID: id, Name: isim}
+			vy.etiketIsimler[isim] = id
+		}
+		etiketler = append(etiketler, &Etiket{ID: id, Name: isim})
+	}
+	return etiketler, nil
+}
+
+func (vy *MemoryVeriYonetici) GorevEtiketleriniAyarla(ctx context.Context, gorevID string, etiketler []*Etiket) error {
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	if _, ok := vy.gorevler[gorevID]; !ok {
+		return fmt.Errorf(i18n.T("error.taskTagAddFailed", map[string]interface{}{
+			"Tag": "", "Error": i18n.TEntityNotFoundByID(i18n.FromContext(ctx), "task", gorevID)}))
+	}
+
+	// Yeni bağlantılar tek seferde uygulanır; hata durumunda mevcut etiketler korunur
+	ids := make([]string, 0, len(etiketler))
+	eklenen := make(map[string]bool, len(etiketler))
+	for _, etiket := range etiketler {
+		if _, ok := vy.etiketler[etiket.ID]; !ok {
+			return fmt.Errorf(i18n.T("error.taskTagAddFailed", map[string]interface{}{
+				"Tag": etiket.Name, "Error": i18n.TEntityNotFoundByID(i18n.FromContext(ctx), "tag", etiket.ID)}))
+		}
+		if eklenen[etiket.ID] {
+			return fmt.Errorf(i18n.T("error.taskTagAddFailed", map[string]interface{}{
+				"Tag": etiket.Name, "Error": i18n.T("error.duplicateID", map[string]interface{}{"Entity": "tag", "ID": etiket.ID})}))
+		}
+		eklenen[etiket.ID] = true
+		ids = append(ids, etiket.ID)
+	}
+	vy.gorevEtiket[gorevID] = ids
+	return nil
+}
+
+// Template'ler
+
+// TemplateOlustur yeni bir görev template'i oluşturur
+func (vy *MemoryVeriYonetici) TemplateOlustur(ctx context.Context, template *GorevTemplate) error {
+	template.ID = uuid.New().String()
+
+	// SQLite'taki JSON dönüşümüyle aynı hataları vermesi için alanlar serileştirilir
+	alanlarJSON, err := json.Marshal(template.Fields)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.fieldsJsonFailed", map[string]interface{}{"Error": err}))
+	}
+	ornekDegerlerJSON, err := json.Marshal(template.SampleValues)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.exampleValuesJsonFailed", map[string]interface{}{"Error": err}))
+	}
+
+	kayit := &GorevTemplate{
+		ID:                  template.ID,
+		Name:                template.Name,
+		Definition:          template.Definition,
+		Alias:               template.Alias,
+		DefaultTitle:        template.DefaultTitle,
+		DescriptionTemplate: template.DescriptionTemplate,
+		Category:            template.Category,
+		Active:              template.Active,
+		LanguageCode:        template.LanguageCode,
+	}
+	if err := json.Unmarshal(alanlarJSON, &kayit.Fields); err != nil {
+		return fmt.Errorf(i18n.TParseFailed(i18n.FromContext(ctx), "fields", err))
+	}
+	if err := json.Unmarshal(ornekDegerlerJSON, &kayit.SampleValues); err != nil {
+		return fmt.Errorf(i18n.T("error.exampleValuesParseFailed", map[string]interface{}{"Error": err}))
+	}
+	if kayit.LanguageCode == "" {
+		kayit.LanguageCode = "tr"
+	}
+	baseTemplateID := template.ID
+	if template.BaseTemplateID != nil {
+		baseTemplateID = *template.BaseTemplateID
+	}
+	kayit.BaseTemplateID = &baseTemplateID
+
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	for _, t := range vy.templateler {
+		if t.Name == kayit.Name {
+			return fmt.Errorf(i18n.TCreateFailed(i18n.FromContext(ctx), "template",
+				errors.New(i18n.T("error.templateNameExists", map[string]interface{}{"Name": kayit.Name}))))
+		}
+		if t.Alias == kayit.Alias && t.LanguageCode == kayit.LanguageCode {
+			return fmt.Errorf(i18n.TCreateFailed(i18n.FromContext(ctx), "template",
+				errors.New(i18n.T("error.templateAliasExists", map[string]interface{}{"Alias": kayit.Alias, "Language": kayit.LanguageCode}))))
+		}
+	}
+	vy.templateler = append(vy.templateler, kayit)
+	return nil
+}
+
+// TemplateListele tüm active template'leri listeler (language-aware)
+func (vy *MemoryVeriYonetici) TemplateListele(ctx context.Context, kategori string) ([]*GorevTemplate, error) {
+	lang := i18n.FromContext(ctx)
+	if lang == "" {
+		lang = "tr"
+	}
+
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	var templates []*GorevTemplate
+	for _, t := range vy.templateler {
+		if !t.Active || t.LanguageCode != lang || (kategori != "" && t.Category != kategori) {
+			continue
+		}
+		templates = append(templates, cloneTemplate(t))
+	}
+	sort.SliceStable(templates, func(i, j int) bool {
+		if kategori == "" && templates[i].Category != templates[j].Category {
+			return templates[i].Category < templates[j].Category
+		}
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+// TemplateGetir belirli bir template'i getirir
+func (vy *MemoryVeriYonetici) TemplateGetir(ctx context.Context, templateID string) (*GorevTemplate, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	for _, t := range vy.templateler {
+		if t.ID == templateID {
+			return cloneTemplate(t), nil
+		}
+	}
+	return nil, fmt.Errorf(i18n.T("error.templateNotFoundId", map[string]interface{}{"Id": templateID}))
+}
+
+// TemplateAliasIleGetir alias ile template getirir (language-aware)
+func (vy *MemoryVeriYonetici) TemplateAliasIleGetir(ctx context.Context, alias string) (*GorevTemplate, error) {
+	lang := i18n.FromContext(ctx)
+	if lang == "" {
+		lang = "tr"
+	}
+
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	for _, t := range vy.templateler {
+		if t.Alias == alias && t.Active && t.LanguageCode == lang {
+			return cloneTemplate(t), nil
+		}
+	}
+	return nil, fmt.Errorf(i18n.T("error.templateNotFoundAlias", map[string]interface{}{"Alias": alias}))
+}
+
+// TemplateIDVeyaAliasIleGetir ID veya alias ile template getirir
+func (vy *MemoryVeriYonetici) TemplateIDVeyaAliasIleGetir(ctx context.Context, idOrAlias string) (*GorevTemplate, error) {
+	return templateIDVeyaAliasIleGetir(ctx, vy, idOrAlias)
+}
+
+// TemplatedenGorevOlustur template kullanarak görev oluşturur
+func (vy *MemoryVeriYonetici) TemplatedenGorevOlustur(ctx context.Context, templateID string, degerler map[string]string) (*Gorev, error) {
+	return templatedenGorevOlustur(ctx, vy, templateID, degerler)
+}
+
+// VarsayilanTemplateleriOlustur varsayılan template'leri TR/EN çifti olarak oluşturur
+func (vy *MemoryVeriYonetici) VarsayilanTemplateleriOlustur(ctx context.Context) error {
+	return varsayilanTemplateleriOlustur(ctx, vy)
+}
+
+// cloneTemplate returns a deep copy of a template
+func cloneTemplate(t *GorevTemplate) *GorevTemplate {
+	kopya := *t
+	if t.Fields != nil {
+		kopya.Fields = make([]TemplateAlan, len(t.Fields))
+		for i, alan := range t.Fields {
+			kopya.Fields[i] = alan
+			if alan.Options != nil {
+				kopya.Fields[i].Options = append([]string(nil), alan.Options...)
+			}
+		}
+	}
+	if t.SampleValues != nil {
+		kopya.SampleValues = make(map[string]string, len(t.SampleValues))
+		for k, v := range t.SampleValues {
+			kopya.SampleValues[k] = v
+		}
+	}
+	if t.BaseTemplateID != nil {
+		baseID := *t.BaseTemplateID
+		kopya.BaseTemplateID = &baseID
+	}
+	return &kopya
+}
+
+// Hiyerarşi
+
+// AltGorevleriGetir belirtilen görevin doğrudan alt görevlerini getirir
+func (vy *MemoryVeriYonetici) AltGorevleriGetir(ctx context.Context, parentID string) ([]*Gorev, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	var gorevler []*Gorev
+	for _, kayit := range vy.dogrudanAltlar(parentID) {
+		gorev := vy.gorevKopyasi(kayit)
+		gorev.WorkspaceID = ""
+		gorevler = append(gorevler, gorev)
+	}
+	return gorevler, nil
+}
+
+// AltGorevleriTopluGetir verilen görevlerin doğrudan alt görevlerini parent ID'ye göre gruplar
+func (vy *MemoryVeriYonetici) AltGorevleriTopluGetir(ctx context.Context, parentIDs []string) (map[string][]*Gorev, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	sonuc := make(map[string][]*Gorev)
+	for _, parentID := range parentIDs {
+		if parentID == "" {
+			continue
+		}
+		if _, islendi := sonuc[parentID]; islendi {
+			continue
+		}
+		for _, kayit := range vy.dogrudanAltlar(parentID) {
+			gorev := vy.gorevKopyasi(kayit)
+			gorev.WorkspaceID = ""
+			sonuc[parentID] = append(sonuc[parentID], gorev)
+		}
+	}
+	return sonuc, nil
+}
+
+// dogrudanAltlar returns the direct subtasks of parentID ordered by creation time
+func (vy *MemoryVeriYonetici) dogrudanAltlar(parentID string) []*memoryGorev {
+	var altlar []*memoryGorev
+	for _, kayit := range vy.gorevler {
+		if kayit.gorev.ParentID == parentID && parentID != "" {
+			altlar = append(altlar, kayit)
+		}
+	}
+	sort.Slice(altlar, func(i, j int) bool {
+		a, b := altlar[i], altlar[j]
+		if !a.gorev.CreatedAt.Equal(b.gorev.CreatedAt) {
+			return a.gorev.CreatedAt.Before(b.gorev.CreatedAt)
+		}
+		return a.sira < b.sira
+	})
+	return altlar
+}
+
+// altAgac returns the subtasks of gorevID level by level together with their depth
+func (vy *MemoryVeriYonetici) altAgac(gorevID string) ([]*memoryGorev, []int) {
+	var gorevler []*memoryGorev
+	var derinlikler []int
+	seviye := []string{gorevID}
+	ziyaret := map[string]bool{gorevID: true}
+	for derinlik := 1; len(seviye) > 0; derinlik++ {
+		var kayitlar []*memoryGorev
+		for _, id := range seviye {
+			for _, kayit := range vy.dogrudanAltlar(id) {
+				if !ziyaret[kayit.gorev.ID] {
+					ziyaret[kayit.gorev.ID] = true
+					kayitlar = append(kayitlar, kayit)
+				}
+			}
+		}
+		sort.SliceStable(kayitlar, func(i, j int) bool {
+			return kayitlar[i].gorev.CreatedAt.Before(kayitlar[j].gorev.CreatedAt)
+		})
+		seviye = seviye[:0]
+		for _, kayit := range kayitlar {
+			gorevler = append(gorevler, kayit)
+			derinlikler = append(derinlikler, derinlik)
+			seviye = append(seviye, kayit.gorev.ID)
+		}
+	}
+	return gorevler, derinlikler
+}
+
+// altAgactaMi reports whether hedefID is gorevID itself or one of its subtasks
+func (vy *MemoryVeriYonetici) altAgactaMi(gorevID, hedefID string) bool {
+	if gorevID == hedefID {
+		return true
+	}
+	ziyaret := make(map[string]bool)
+	for id := hedefID; id != "" && !ziyaret[id]; {
+		ziyaret[id] = true
+		kayit, ok := vy.gorevler[id]
+		if !ok {
+			return false
+		}
+		if kayit.gorev.ParentID == gorevID {
+			return true
+		}
+		id = kayit.gorev.ParentID
+	}
+	return false
+}
+
+// TumAltGorevleriGetir belirtilen görevin tüm alt görev hiyerarşisini getirir; Level
+// görevin parentID'ye uzaklığıdır
+func (vy *MemoryVeriYonetici) TumAltGorevleriGetir(ctx context.Context, parentID string) ([]*Gorev, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	kayitlar, derinlikler := vy.altAgac(parentID)
+	gorevler := make([]*Gorev, 0, len(kayitlar))
+	for i, kayit := range kayitlar {
+		gorev := vy.gorevKopyasi(kayit)
+		gorev.WorkspaceID = ""
+		gorev.Level = derinlikler[i]
+		gorevler = append(gorevler, gorev)
+	}
+	return gorevler, nil
+}
+
+// UstGorevleriGetir belirtilen görevin tüm üst görev hiyerarşisini en yakın üst görevden başlayarak getirir
+func (vy *MemoryVeriYonetici) UstGorevleriGetir(ctx context.Context, gorevID string) ([]*Gorev, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	var gorevler []*Gorev
+	ziyaret := map[string]bool{gorevID: true}
+	kayit, ok := vy.gorevler[gorevID]
+	for ok && kayit.gorev.ParentID != "" && !ziyaret[kayit.gorev.ParentID] {
+		ziyaret[kayit.gorev.ParentID] = true
+		kayit, ok = vy.gorevler[kayit.gorev.ParentID]
+		if ok {
+			gorevler = append(gorevler, sadeGorevKopyasi(kayit))
+		}
+	}
+	return gorevler, nil
+}
+
+// GorevHiyerarsiGetir bir görevin tam hiyerarşi bilgilerini getirir
+func (vy *MemoryVeriYonetici) GorevHiyerarsiGetir(ctx context.Context, gorevID string) (*GorevHiyerarsi, error) {
+	gorev, err := vy.GorevGetir(ctx, gorevID)
+	if err != nil {
+		return nil, err
+	}
+
+	ustGorevler, err := vy.UstGorevleriGetir(ctx, gorevID)
+	if err != nil {
+		return nil, err
+	}
+
+	vy.mu.RLock()
+	altlar, _ := vy.altAgac(gorevID)
+	var tamamlanan, devamEden, beklemede int
+	for _, kayit := range altlar {
+		switch kayit.gorev.Status {
+		case constants.TaskStatusCompleted:
+			tamamlanan++
+		case constants.TaskStatusInProgress:
+			devamEden++
+		case constants.TaskStatusPending:
+			beklemede++
+		}
+	}
+	vy.mu.RUnlock()
+
+	toplam := len(altlar)
+	var ilerlemeYuzdesi float64
+	if toplam > 0 {
+		ilerlemeYuzdesi = (float64(tamamlanan) / float64(toplam)) * 100
+	} else if gorev.Status == constants.TaskStatusCompleted {
+		ilerlemeYuzdesi = 100
+	}
+
+	return &GorevHiyerarsi{
+		Gorev:              gorev,
+		ParentTasks:        ustGorevler,
+		TotalSubtasks:      toplam,
+		CompletedSubtasks:  tamamlanan,
+		InProgressSubtasks: devamEden,
+		PendingSubtasks:    beklemede,
+		ProgressPercentage: ilerlemeYuzdesi,
+	}, nil
+}
+
+// ParentIDGuncelle bir görevin parent_id'sini günceller; görev alt ağacıyla birlikte taşınır
+func (vy *MemoryVeriYonetici) ParentIDGuncelle(ctx context.Context, gorevID, yeniParentID string) error {
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	kayit, ok := vy.gorevler[gorevID]
+	if yeniParentID != "" {
+		if vy.altAgactaMi(gorevID, yeniParentID) {
+			return fmt.Errorf(i18n.T("error.circularDependency"))
+		}
+		if !ok {
+			return nil
+		}
+		if err := vy.referanslariKontrolEt(ctx, "", yeniParentID); err != nil {
+			return err
+		}
+	}
+	if ok {
+		kayit.gorev.ParentID = yeniParentID
+		kayit.gorev.UpdatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// DaireBagimliligiKontrolEt bir görevin belirtilen parent'a taşınması durumunda dairesel bağımlılık oluşup oluşmayacağını kontrol eder
+func (vy *MemoryVeriYonetici) DaireBagimliligiKontrolEt(ctx context.Context, gorevID, hedefParentID string) (bool, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+	return vy.altAgactaMi(gorevID, hedefParentID), nil
+}
+
+// AltAgacProjesiniGuncelle bir görevi ve tüm alt görevlerini başka bir projeye taşır
+func (vy *MemoryVeriYonetici) AltAgacProjesiniGuncelle(ctx context.Context, gorevID, projeID string) error {
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	kok, ok := vy.gorevler[gorevID]
+	if !ok {
+		return nil
+	}
+	if err := vy.referanslariKontrolEt(ctx, projeID, ""); err != nil {
+		return err
+	}
+
+	altlar, _ := vy.altAgac(gorevID)
+	simdi := time.Now().UTC()
+	for _, kayit := range append([]*memoryGorev{kok}, altlar...) {
+		kayit.gorev.ProjeID = projeID
+		kayit.gorev.UpdatedAt = simdi
+	}
+	return nil
+}
+
+// AltGorevOlustur creates a subtask under a parent task
+func (vy *MemoryVeriYonetici) AltGorevOlustur(ctx context.Context, parentID, baslik, aciklama, oncelik, sonTarihStr string, etiketIsimleri []string) (*Gorev, error) {
+	return altGorevOlustur(ctx, vy, parentID, baslik, aciklama, oncelik, sonTarihStr, etiketIsimleri)
+}
+
+// AI Context Management Methods
+
+// AIContextGetir retrieves the current AI context, creating an empty one on first use
+func (vy *MemoryVeriYonetici) AIContextGetir() (*AIContext, error) {
+	vy.mu.RLock()
+	kayit := vy.aiContext
+	vy.mu.RUnlock()
+
+	if kayit == nil {
+		defaultContext := &AIContext{
+			RecentTasks: []string{},
+			SessionData: make(map[string]interface{}),
+			LastUpdated: time.Now(),
+		}
+		if err := vy.AIContextKaydet(defaultContext); err != nil {
+			return nil, fmt.Errorf(i18n.T("error.contextInitializationFailed", map[string]interface{}{"Error": err}))
+		}
+		return defaultContext, nil
+	}
+
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	var recentTasks []string
+	if err := json.Unmarshal([]byte(vy.aiContext.recentTasks), &recentTasks); err != nil {
+		recentTasks = []string{}
+	}
+	var sessionData map[string]interface{}
+	if err := json.Unmarshal([]byte(vy.aiContext.sessionData), &sessionData); err != nil {
+		sessionData = make(map[string]interface{})
+	}
+
+	return &AIContext{
+		ActiveTaskID: vy.aiContext.activeTaskID,
+		RecentTasks:  recentTasks,
+		SessionData:  sessionData,
+		LastUpdated:  vy.aiContext.lastUpdated,
+	}, nil
+}
+
+// AIContextKaydet saves the AI context
+func (vy *MemoryVeriYonetici) AIContextKaydet(aiContext *AIContext) error {
+	recentTasksJSON, err := json.Marshal(aiContext.RecentTasks)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.jsonMarshalFailed", map[string]interface{}{"Field": "recent_tasks", "Error": err}))
+	}
+	sessionDataJSON, err := json.Marshal(aiContext.SessionData)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.jsonMarshalFailed", map[string]interface{}{"Field": "session_data", "Error": err}))
+	}
+
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	if aiContext.ActiveTaskID != "" {
+		if _, ok := vy.gorevler[aiContext.ActiveTaskID]; !ok {
+			return fmt.Errorf(i18n.T("error.contextSaveFailed", map[string]interface{}{
+				"Error": i18n.TEntityNotFoundByID(i18n.GetCurrentLanguage(), "task", aiContext.ActiveTaskID)}))
+		}
+	}
+
+	vy.aiContext = &memoryAIContext{
+		activeTaskID: aiContext.ActiveTaskID,
+		recentTasks:  string(recentTasksJSON),
+		sessionData:  string(sessionDataJSON),
+		lastUpdated:  time.Now(),
+	}
+	return nil
+}
+
+// AIInteractionKaydet records an AI interaction
+func (vy *MemoryVeriYonetici) AIInteractionKaydet(interaction *AIInteraction) error {
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	if !gecerliEtkilesimTipleri[interaction.ActionType] {
+		return fmt.Errorf(i18n.T("error.interactionSaveFailed", map[string]interface{}{
+			"Error": i18n.T("error.invalidInteractionType", map[string]interface{}{"Type": interaction.ActionType})}))
+	}
+	if _, ok := vy.gorevler[interaction.GorevID]; !ok {
+		return fmt.Errorf(i18n.T("error.interactionSaveFailed", map[string]interface{}{
+			"Error": i18n.TEntityNotFoundByID(i18n.GetCurrentLanguage(), "task", interaction.GorevID)}))
+	}
+
+	vy.etkilesimSira++
+	vy.etkilesimler = append(vy.etkilesimler, &AIInteraction{
+		ID:         strconv.FormatInt(vy.etkilesimSira, 10),
+		GorevID:    interaction.GorevID,
+		ActionType: interaction.ActionType,
+		Context:    interaction.Context,
+		Timestamp:  time.Now(),
+	})
+	return nil
+}
+
+// AIInteractionlariGetir retrieves recent AI interactions
+func (vy *MemoryVeriYonetici) AIInteractionlariGetir(limit int) ([]*AIInteraction, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	etkilesimler := vy.etkilesimleriSec(func(*AIInteraction) bool { return true })
+	if len(etkilesimler) > limit {
+		etkilesimler = etkilesimler[:limit]
+	}
+	return etkilesimler, nil
+}
+
+// AITodayInteractionlariGetir retrieves today's AI interactions
+func (vy *MemoryVeriYonetici) AITodayInteractionlariGetir() ([]*AIInteraction, error) {
+	today := time.Now().Truncate(24 * time.Hour)
+	tomorrow := today.Add(24 * time.Hour)
+	return vy.etkilesimleriSec(func(e *AIInteraction) bool {
+		return !e.Timestamp.Before(today) && e.Timestamp.Before(tomorrow)
+	}), nil
+}
+
+// etkilesimleriSec returns copies of the matching interactions, newest first
+func (vy *MemoryVeriYonetici) etkilesimleriSec(sec func(*AIInteraction) bool) []*AIInteraction {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	var etkilesimler []*AIInteraction
+	for i := len(vy.etkilesimler) - 1; i >= 0; i-- {
+		if sec(vy.etkilesimler[i]) {
+			kopya := *vy.etkilesimler[i]
+			etkilesimler = append(etkilesimler, &kopya)
+		}
+	}
+	sort.SliceStable(etkilesimler, func(i, j int) bool {
+		return etkilesimler[i].Timestamp.After(etkilesimler[j].Timestamp)
+	})
+	return etkilesimler
+}
+
+// AILastInteractionGuncelle updates the last AI interaction timestamp for a task
+func (vy *MemoryVeriYonetici) AILastInteractionGuncelle(taskID string, timestamp time.Time) error {
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	if kayit, ok := vy.gorevler[taskID]; ok {
+		kayit.ekstra["last_ai_interaction"] = timestamp
+	}
+	return nil
+}
+
+// AIEtkilemasimKaydet saves an AI interaction record
+func (vy *MemoryVeriYonetici) AIEtkilemasimKaydet(taskID string, interactionType, data, sessionID string) error {
+	return vy.AIInteractionKaydet(&AIInteraction{
+		GorevID:    taskID,
+		ActionType: interactionType,
+		Context:    data,
+		Timestamp:  time.Now(),
+	})
+}
+
+// GorevSonAIEtkilesiminiGuncelle updates the last AI interaction timestamp for a task
+func (vy *MemoryVeriYonetici) GorevSonAIEtkilesiminiGuncelle(taskID string, timestamp time.Time) error {
+	return vy.AILastInteractionGuncelle(taskID, timestamp)
+}
+
+// Dosya yolları
+
+// GorevDosyaYoluEkle adds a file path to a task
+func (vy *MemoryVeriYonetici) GorevDosyaYoluEkle(taskID string, path string) error {
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	if _, ok := vy.gorevler[taskID]; !ok {
+		return fmt.Errorf(i18n.T("error.filePathAddFailed", map[string]interface{}{
+			"Error": i18n.TEntityNotFoundByID(i18n.GetCurrentLanguage(), "task", taskID)}))
+	}
+	for _, y := range vy.dosyaYollari {
+		if y.taskID == taskID && y.path == path {
+			return nil
+		}
+	}
+	vy.dosyaYollari = append(vy.dosyaYollari, memoryDosyaYolu{taskID: taskID, path: path})
+	return nil
+}
+
+// GorevDosyaYoluSil removes a file path from a task
+func (vy *MemoryVeriYonetici) GorevDosyaYoluSil(taskID string, path string) error {
+	vy.mu.Lock()
+	defer vy.mu.Unlock()
+
+	for i, y := range vy.dosyaYollari {
+		if y.taskID == taskID && y.path == path {
+			vy.dosyaYollari = append(vy.dosyaYollari[:i], vy.dosyaYollari[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf(i18n.T("error.filePathNotFound"))
+}
+
+// GorevDosyaYollariGetir gets all file paths for a task
+func (vy *MemoryVeriYonetici) GorevDosyaYollariGetir(taskID string) ([]string, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	var paths []string
+	for _, y := range vy.dosyaYollari {
+		if y.taskID == taskID {
+			paths = append(paths, y.path)
+		}
+	}
+	return paths, nil
+}
+
+// DosyaYoluGorevleriGetir gets all tasks associated with a file path
+func (vy *MemoryVeriYonetici) DosyaYoluGorevleriGetir(path string) ([]string, error) {
+	vy.mu.RLock()
+	defer vy.mu.RUnlock()
+
+	var taskIDs []string
+	for _, y := range vy.dosyaYollari {
+		if y.path == path {
+			taskIDs = append(taskIDs, y.taskID)
+		}
+	}
+	return taskIDs, nil
+}
+
+// Veritabanı erişimi
+
+// GetDB returns no database; callers that need SQL must check for nil
+func (vy *MemoryVeriYonetici) GetDB() (*sql.DB, error) {
+	return nil, nil
+}
+
+// GetReadDB returns no database; callers that need SQL must check for nil
+func (vy *MemoryVeriYonetici) GetReadDB() (*sql.DB, error) {
+	return nil, nil
+}
+
+// YazmaIslemi is not available without a database
+func (vy *MemoryVeriYonetici) YazmaIslemi(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return fmt.Errorf(i18n.T("error.memoryBackendSQLUnavailable", map[string]interface{}{"Operation": "transaction"}))
+}
+
+// YardimciYazmaIslemi is not available without a database
+func (vy *MemoryVeriYonetici) YardimciYazmaIslemi(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return vy.YazmaIslemi(ctx, fn)
+}
+
+// Kapat releases nothing; the data is dropped with the value
+func (vy *MemoryVeriYonetici) Kapat() error {
+	return nil
+}
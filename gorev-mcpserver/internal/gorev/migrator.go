@@ -0,0 +1,337 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// migrationFilePattern matches migration files such as 000001_initial_schema.up.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is a schema version with its up and down scripts
+type Migration struct {
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+	UpSQL   string `json:"-"`
+	DownSQL string `json:"-"`
+}
+
+// MigrationState is the state of a single migration in a database
+type MigrationState struct {
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+	Applied bool   `json:"applied"`
+	Dirty   bool   `json:"dirty"`
+	Known   bool   `json:"known"` // false for versions recorded in the database but not shipped with this binary
+}
+
+// SchemaStatus summarizes the migration state of a database
+type SchemaStatus struct {
+	Current    int              `json:"current"` // Highest applied version, 0 for an empty database
+	Latest     int              `json:"latest"`  // Highest version known to this binary
+	Dirty      bool             `json:"dirty"`
+	DirtyAt    int              `json:"dirty_version,omitempty"`
+	Migrations []MigrationState `json:"migrations"`
+}
+
+// Pending returns the known migrations that are not applied yet
+func (s *SchemaStatus) Pending() []MigrationState {
+	pending := []MigrationState{}
+	for _, m := range s.Migrations {
+		if m.Known && !m.Applied {
+			pending = append(pending, m)
+		}
+	}
+	return pending
+}
+
+// Unknown returns the applied versions that this binary does not know,
+// i.e. the database was migrated by a newer release
+func (s *SchemaStatus) Unknown() []int {
+	unknown := []int{}
+	for _, m := range s.Migrations {
+		if m.Applied && !m.Known {
+			unknown = append(unknown, m.Version)
+		}
+	}
+	return unknown
+}
+
+// Migrator applies and rolls back schema migrations recorded in schema_migrations.
+// A migration is recorded as dirty before its script runs and marked clean afterwards,
+// so a failed migration leaves a dirty version behind that must be fixed with Force.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// LoadMigrations reads the migration scripts at the root of fsys, ordered by version.
+// A directory that cannot be read, including a missing one, is an error.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.migrationsReadFailed", map[string]interface{}{"Error": err}))
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, path.Clean(entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("error.migrationFileReadFailed", map[string]interface{}{"File": entry.Name(), "Error": err}))
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if match[3] == "up" {
+			m.UpSQL = string(content)
+		} else {
+			m.DownSQL = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.UpSQL == "" {
+			continue // A down script alone cannot be applied
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// NewMigrator creates a migrator for db with the migrations found in fsys
+func NewMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			dirty INTEGER NOT NULL DEFAULT 0
+		)
+	`); err != nil {
+		return nil, fmt.Errorf(i18n.T("error.migrationTableFailed", map[string]interface{}{"Error": err}))
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Migrations returns the migrations known to the migrator
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Status reports applied, pending and unknown versions and the dirty state
+func (m *Migrator) Status(ctx context.Context) (*SchemaStatus, error) {
+	rows, err := m.db.QueryContext(ctx, "SELECT version, dirty FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.queryFailed", map[string]interface{}{"Error": err}))
+	}
+	defer func() { _ = rows.Close() }()
+
+	applied := make(map[int]bool) // version -> dirty
+	for rows.Next() {
+		var version, dirty int
+		if err := rows.Scan(&version, &dirty); err != nil {
+			return nil, fmt.Errorf(i18n.T("error.queryFailed", map[string]interface{}{"Error": err}))
+		}
+		applied[version] = dirty != 0
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(i18n.T("error.rowIterationError", map[string]interface{}{"Error": err}))
+	}
+
+	status := &SchemaStatus{Migrations: []MigrationState{}}
+	known := make(map[int]bool)
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+		dirty, ok := applied[migration.Version]
+		status.Migrations = append(status.Migrations, MigrationState{
+			Version: migration.Version,
+			Name:    migration.Name,
+			Applied: ok,
+			Dirty:   dirty,
+			Known:   true,
+		})
+		status.Latest = migration.Version
+	}
+	for version, dirty := range applied {
+		if !known[version] {
+			status.Migrations = append(status.Migrations, MigrationState{Version: version, Applied: true, Dirty: dirty})
+		}
+	}
+	sort.Slice(status.Migrations, func(i, j int) bool { return status.Migrations[i].Version < status.Migrations[j].Version })
+
+	for _, state := range status.Migrations {
+		if !state.Applied {
+			continue
+		}
+		status.Current = state.Version
+		if state.Dirty {
+			status.Dirty = true
+			status.DirtyAt = state.Version
+		}
+	}
+	return status, nil
+}
+
+// CheckCompatible refuses databases that are dirty or were migrated by a newer binary
+func (m *Migrator) CheckCompatible(ctx context.Context) (*SchemaStatus, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if status.Dirty {
+		return status, fmt.Errorf(i18n.T("error.migrationDirty", map[string]interface{}{"Version": status.DirtyAt}))
+	}
+	// Without any known migrations (e.g. a wrong migrations path) there is nothing to compare against
+	if unknown := status.Unknown(); len(m.migrations) > 0 && len(unknown) > 0 && unknown[len(unknown)-1] > status.Latest {
+		return status, fmt.Errorf(i18n.T("error.schemaTooNew", map[string]interface{}{"Version": status.Current, "Latest": status.Latest}))
+	}
+	return status, nil
+}
+
+// Up applies up to n pending migrations in version order; n <= 0 applies all of them.
+// It returns the applied versions.
+func (m *Migrator) Up(ctx context.Context, n int) ([]int, error) {
+	status, err := m.CheckCompatible(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make(map[int]bool)
+	for _, state := range status.Pending() {
+		pending[state.Version] = true
+	}
+
+	applied := []int{}
+	for _, migration := range m.migrations {
+		if n > 0 && len(applied) >= n {
+			break
+		}
+		if !pending[migration.Version] {
+			continue
+		}
+
+		log.Printf("DEBUG: Applying migration %d (%s)", migration.Version, migration.Name)
+		if _, err := m.db.ExecContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES (?, 1)", migration.Version); err != nil {
+			return applied, fmt.Errorf(i18n.T("error.migrationRecordFailed", map[string]interface{}{"Version": migration.Version, "Error": err}))
+		}
+		if _, err := m.db.ExecContext(ctx, migration.UpSQL); err != nil {
+			return applied, fmt.Errorf(i18n.T("error.migrationExecuteFailed", map[string]interface{}{"Version": migration.Version, "Error": err}))
+		}
+		if _, err := m.db.ExecContext(ctx, "UPDATE schema_migrations SET dirty = 0 WHERE version = ?", migration.Version); err != nil {
+			return applied, fmt.Errorf(i18n.T("error.migrationRecordFailed", map[string]interface{}{"Version": migration.Version, "Error": err}))
+		}
+		applied = append(applied, migration.Version)
+	}
+	return applied, nil
+}
+
+// Down rolls back the n most recently applied migrations (at least one) and returns the reverted versions
+func (m *Migrator) Down(ctx context.Context, n int) ([]int, error) {
+	if n <= 0 {
+		n = 1
+	}
+	status, err := m.CheckCompatible(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]Migration)
+	for _, migration := range m.migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	reverted := []int{}
+	for i := len(status.Migrations) - 1; i >= 0 && len(reverted) < n; i-- {
+		state := status.Migrations[i]
+		if !state.Applied {
+			continue
+		}
+		migration := byVersion[state.Version]
+		if migration.DownSQL == "" {
+			return reverted, fmt.Errorf(i18n.T("error.migrationNoDown", map[string]interface{}{"Version": state.Version}))
+		}
+
+		log.Printf("DEBUG: Reverting migration %d (%s)", migration.Version, migration.Name)
+		if _, err := m.db.ExecContext(ctx, "UPDATE schema_migrations SET dirty = 1 WHERE version = ?", migration.Version); err != nil {
+			return reverted, fmt.Errorf(i18n.T("error.migrationRecordFailed", map[string]interface{}{"Version": migration.Version, "Error": err}))
+		}
+		if _, err := m.db.ExecContext(ctx, migration.DownSQL); err != nil {
+			return reverted, fmt.Errorf(i18n.T("error.migrationExecuteFailed", map[string]interface{}{"Version": migration.Version, "Error": err}))
+		}
+		if _, err := m.db.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", migration.Version); err != nil {
+			return reverted, fmt.Errorf(i18n.T("error.migrationRecordFailed", map[string]interface{}{"Version": migration.Version, "Error": err}))
+		}
+		reverted = append(reverted, migration.Version)
+	}
+	return reverted, nil
+}
+
+// Force records the database as cleanly migrated to version without running any script:
+// known versions up to version are marked applied, later ones are removed and the dirty
+// flag is cleared. Version 0 marks an empty schema.
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	known := version == 0
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			known = true
+		}
+	}
+	if version < 0 || !known {
+		return fmt.Errorf(i18n.T("error.migrationUnknownVersion", map[string]interface{}{"Version": version}))
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.transactionFailed", map[string]interface{}{"Error": err}))
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version > ?", version); err != nil {
+		return fmt.Errorf(i18n.T("error.migrationRecordFailed", map[string]interface{}{"Version": version, "Error": err}))
+	}
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			break
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO schema_migrations (version) VALUES (?)", migration.Version); err != nil {
+			return fmt.Errorf(i18n.T("error.migrationRecordFailed", map[string]interface{}{"Version": migration.Version, "Error": err}))
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE schema_migrations SET dirty = 0"); err != nil {
+		return fmt.Errorf(i18n.T("error.migrationRecordFailed", map[string]interface{}{"Version": version, "Error": err}))
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(i18n.T("error.transactionCommitFailed", map[string]interface{}{"Error": err}))
+	}
+	return nil
+}
+
+// RepairState records the migrations of databases created before migration tracking,
+// so that Up does not try to recreate existing tables
+func (m *Migrator) RepairState() error {
+	return (&VeriYonetici{db: m.db}).repairMigrationStateIfNeeded()
+}
@@ -0,0 +1,178 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// openMigratorTestDB opens an empty file database; file databases share state across pool connections
+func openMigratorTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "gorev.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_second.up.sql":   {Data: []byte("CREATE TABLE b (id TEXT);")},
+		"000002_second.down.sql": {Data: []byte("DROP TABLE b;")},
+		"000001_first.up.sql":    {Data: []byte("CREATE TABLE a (id TEXT);")},
+		"000003_orphan.down.sql": {Data: []byte("DROP TABLE c;")},
+		"README.md":              {Data: []byte("not a migration")},
+	}
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		t.Fatalf("LoadMigrations failed: %v", err)
+	}
+	if len(migrations) != 2 || migrations[0].Version != 1 || migrations[1].Version != 2 {
+		t.Fatalf("expected versions 1 and 2 in order, got %+v", migrations)
+	}
+	if migrations[1].Name != "second" || migrations[1].DownSQL == "" || migrations[0].DownSQL != "" {
+		t.Errorf("unexpected migration contents: %+v", migrations)
+	}
+
+	// A wrong path must not look like a database without migrations
+	if missing, err := LoadMigrations(os.DirFS(filepath.Join(t.TempDir(), "missing"))); err == nil {
+		t.Errorf("missing directory should be an error, got %v", missing)
+	}
+}
+
+func TestYeniVeriYonetici_WrongMigrationsPath(t *testing.T) {
+	setupTestI18n()
+	missing := filepath.Join(t.TempDir(), "missing")
+	if _, err := YeniVeriYonetici(":memory:", "file://"+missing); err == nil || !strings.Contains(err.Error(), missing) {
+		t.Errorf("a wrong migrations path should fail with the path, got %v", err)
+	}
+}
+
+func TestMigratorUpDownRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratorTestDB(t)
+	m, err := NewMigrator(db, os.DirFS("../../internal/veri/migrations"))
+	if err != nil {
+		t.Fatalf("NewMigrator failed: %v", err)
+	}
+	latest := m.Migrations()[len(m.Migrations())-1].Version
+
+	applied, err := m.Up(ctx, 2)
+	if err != nil || len(applied) != 2 {
+		t.Fatalf("Up(2) should apply two migrations, got %v (%v)", applied, err)
+	}
+	if _, err := m.Up(ctx, 0); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	status, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status.Current != latest || len(status.Pending()) != 0 || status.Dirty {
+		t.Fatalf("expected clean schema at %d, got %+v", latest, status)
+	}
+
+	// Roll back everything down to 000005, whose parent_id column SQLite cannot drop
+	reverted, err := m.Down(ctx, latest-5)
+	if err != nil {
+		t.Fatalf("Down failed after %v: %v", reverted, err)
+	}
+	if reverted[0] != latest || len(reverted) != latest-5 {
+		t.Errorf("unexpected reverted versions: %v", reverted)
+	}
+	if status, _ = m.Status(ctx); status.Current != 5 {
+		t.Errorf("expected version 5 after rollback, got %d", status.Current)
+	}
+
+	if _, err := m.Up(ctx, 0); err != nil {
+		t.Fatalf("re-applying migrations failed: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO gorevler (id, title, created_at, updated_at, last_ai_interaction) VALUES ('t', 'Task', datetime('now'), datetime('now'), NULL)"); err != nil {
+		t.Errorf("schema should be usable after the round trip: %v", err)
+	}
+}
+
+func TestMigratorDirtyAndForce(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratorTestDB(t)
+	fsys := fstest.MapFS{
+		"000001_first.up.sql":    {Data: []byte("CREATE TABLE a (id TEXT);")},
+		"000001_first.down.sql":  {Data: []byte("DROP TABLE a;")},
+		"000002_broken.up.sql":   {Data: []byte("CREATE TABLE b (id TEXT); THIS IS NOT SQL;")},
+		"000002_broken.down.sql": {Data: []byte("DROP TABLE IF EXISTS b;")},
+	}
+	m, err := NewMigrator(db, fsys)
+	if err != nil {
+		t.Fatalf("NewMigrator failed: %v", err)
+	}
+
+	applied, err := m.Up(ctx, 0)
+	if err == nil || len(applied) != 1 {
+		t.Fatalf("broken migration should fail after applying 1, got %v (%v)", applied, err)
+	}
+	status, _ := m.Status(ctx)
+	if !status.Dirty || status.DirtyAt != 2 {
+		t.Fatalf("failed migration should be recorded dirty, got %+v", status)
+	}
+	if applied, err := m.Up(ctx, 0); err == nil || len(applied) != 0 {
+		t.Errorf("dirty database should be refused, got %v (%v)", applied, err)
+	}
+	if _, err := m.Down(ctx, 1); err == nil {
+		t.Error("down should refuse a dirty database")
+	}
+
+	if err := m.Force(ctx, 1); err != nil {
+		t.Fatalf("Force failed: %v", err)
+	}
+	status, _ = m.Status(ctx)
+	if status.Dirty || status.Current != 1 || len(status.Pending()) != 1 {
+		t.Errorf("force should leave a clean schema at version 1 with one pending, got %+v", status)
+	}
+	if err := m.Force(ctx, 7); err == nil {
+		t.Error("forcing an unknown version should fail")
+	}
+	if err := m.Force(ctx, 0); err != nil {
+		t.Fatalf("Force(0) failed: %v", err)
+	}
+	if status, _ = m.Status(ctx); status.Current != 0 {
+		t.Errorf("Force(0) should clear all versions, got %d", status.Current)
+	}
+}
+
+func TestMigratorRefusesNewerSchema(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "gorev.db")
+	vy, err := YeniVeriYonetici(dbPath, "file://../../internal/veri/migrations")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	// Simulate a migration shipped by a newer release
+	if _, err := vy.db.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (999, 0)"); err != nil {
+		t.Fatalf("Failed to record version: %v", err)
+	}
+	_ = vy.Kapat()
+
+	if _, err := YeniVeriYonetici(dbPath, "file://../../internal/veri/migrations"); err == nil {
+		t.Error("opening a database migrated by a newer binary should fail")
+	}
+
+	db := openMigratorTestDB(t)
+	if _, err := db.Exec("CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, dirty INTEGER NOT NULL DEFAULT 0); INSERT INTO schema_migrations VALUES (1, 0), (999, 0)"); err != nil {
+		t.Fatalf("Failed to record versions: %v", err)
+	}
+	m, err := NewMigrator(db, os.DirFS("../../internal/veri/migrations"))
+	if err != nil {
+		t.Fatalf("NewMigrator failed: %v", err)
+	}
+	status, err := m.CheckCompatible(context.Background())
+	if err == nil {
+		t.Error("expected an error for a schema newer than the migrations")
+	}
+	if status == nil || len(status.Unknown()) != 1 || status.Current != 999 {
+		t.Errorf("status should report the unknown version, got %+v", status)
+	}
+}
@@ -16,6 +16,7 @@ type Gorev struct {
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	DueDate     *time.Time `json:"due_date,omitempty"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"` // Set for tasks read from the archive
 	Tags        []*Etiket  `json:"tags,omitempty"`
 	Subtasks    []*Gorev   `json:"subtasks,omitempty"`
 	Level       int        `json:"level,omitempty"`
@@ -110,6 +111,14 @@ type GorevHiyerarsi struct {
 	ProgressPercentage float64  `json:"progress_percentage"`
 }
 
+// GorevSayfasi is one page of a task list: the root tasks of the page followed by their
+// descendants that match the same filters
+type GorevSayfasi struct {
+	Gorevler     []*Gorev `json:"gorevler"`
+	KokSayisi    int      `json:"kok_sayisi"`    // matching root tasks on all pages
+	ToplamSayisi int      `json:"toplam_sayisi"` // matching tasks on all pages, subtasks included
+}
+
 // Note: AIInteraction, AIContext, and FilterProfile structs are defined in their respective manager files
 // to avoid circular dependencies and maintain clear ownership
 
@@ -0,0 +1,907 @@
+package gorev
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// NDJSON record types. Every line of an NDJSON export is {"type": "<type>", "data": {...}}.
+// Records are written in dependency order (header, projects, tags, templates, tasks, links)
+// and tasks are written parent-first, so an import never has to look ahead.
+const (
+	ndjsonRecordHeader   = "header"
+	ndjsonRecordProject  = "project"
+	ndjsonRecordTag      = "tag"
+	ndjsonRecordTemplate = "template"
+	ndjsonRecordTask     = "task"
+	ndjsonRecordLink     = "link"
+)
+
+// DefaultImportChunkSize is the number of tasks or links committed per transaction by NDJSON imports
+const DefaultImportChunkSize = 500
+
+// ndjsonProgressInterval is the number of records between export progress callbacks
+const ndjsonProgressInterval = 1000
+
+// StreamProgress reports how far a streaming export or import has got
+type StreamProgress struct {
+	Records   int  `json:"records"` // Records written or read so far
+	Projects  int  `json:"projects"`
+	Tags      int  `json:"tags"`
+	Templates int  `json:"templates"`
+	Tasks     int  `json:"tasks"`
+	Links     int  `json:"links"`
+	Done      bool `json:"done"`
+}
+
+// StreamProgressFunc receives progress updates of a streaming export or import
+type StreamProgressFunc func(StreamProgress)
+
+// ndjsonRecord is a single NDJSON line
+type ndjsonRecord struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// ndjsonHeader is the first record of an NDJSON export
+type ndjsonHeader struct {
+	Version  string         `json:"version"`
+	Metadata ExportMetadata `json:"metadata"`
+}
+
+// ndjsonTask is a task record; its tags reference the IDs of earlier tag records
+type ndjsonTask struct {
+	*Gorev
+	TagIDs []string `json:"tag_ids,omitempty"`
+}
+
+// ndjsonWriter writes NDJSON records and counts them for progress reporting
+type ndjsonWriter struct {
+	bw       *bufio.Writer
+	encoder  *json.Encoder
+	progress StreamProgressFunc
+	stats    StreamProgress
+}
+
+// newNDJSONWriter creates a buffered NDJSON writer
+func newNDJSONWriter(w io.Writer, progress StreamProgressFunc) *ndjsonWriter {
+	bw := bufio.NewWriter(w)
+	return &ndjsonWriter{bw: bw, encoder: json.NewEncoder(bw), progress: progress}
+}
+
+// write encodes one record as a single line
+func (nw *ndjsonWriter) write(recordType string, data interface{}) error {
+	record := struct {
+		Type string      `json:"type"`
+		Data interface{} `json:"data"`
+	}{recordType, data}
+	if err := nw.encoder.Encode(record); err != nil {
+		return err
+	}
+
+	nw.stats.Records++
+	switch recordType {
+	case ndjsonRecordProject:
+		nw.stats.Projects++
+	case ndjsonRecordTag:
+		nw.stats.Tags++
+	case ndjsonRecordTemplate:
+		nw.stats.Templates++
+	case ndjsonRecordTask:
+		nw.stats.Tasks++
+	case ndjsonRecordLink:
+		nw.stats.Links++
+	}
+	if nw.progress != nil && nw.stats.Records%ndjsonProgressInterval == 0 {
+		nw.progress(nw.stats)
+	}
+	return nil
+}
+
+// finish flushes the buffer and reports the final progress
+func (nw *ndjsonWriter) finish() error {
+	if err := nw.bw.Flush(); err != nil {
+		return err
+	}
+	nw.stats.Done = true
+	if nw.progress != nil {
+		nw.progress(nw.stats)
+	}
+	return nil
+}
+
+// newNDJSONHeader returns the header record of a new export
+func newNDJSONHeader() ndjsonHeader {
+	return ndjsonHeader{
+		Version: "v0.11.1",
+		Metadata: ExportMetadata{
+			ExportDate:      time.Now(),
+			GorevVersion:    "v0.11.1",
+			DatabaseVersion: "1.9",
+			ExportedBy:      "gorev_export",
+			Description:     i18n.T("export.generatedDescription", nil),
+		},
+	}
+}
+
+// WriteNDJSON writes already loaded export data as NDJSON records
+func WriteNDJSON(w io.Writer, exportData *ExportFormat, progress StreamProgressFunc) error {
+	nw := newNDJSONWriter(w, progress)
+
+	if err := nw.write(ndjsonRecordHeader, ndjsonHeader{Version: exportData.Version, Metadata: exportData.Metadata}); err != nil {
+		return err
+	}
+	for _, project := range exportData.Projects {
+		if err := nw.write(ndjsonRecordProject, project); err != nil {
+			return err
+		}
+	}
+	for _, tag := range exportData.Tags {
+		if err := nw.write(ndjsonRecordTag, tag); err != nil {
+			return err
+		}
+	}
+	for _, template := range exportData.Templates {
+		if err := nw.write(ndjsonRecordTemplate, template); err != nil {
+			return err
+		}
+	}
+
+	tagIDs := make(map[string][]string)
+	for _, taskTag := range exportData.TaskTags {
+		tagIDs[taskTag.TaskID] = append(tagIDs[taskTag.TaskID], taskTag.TagID)
+	}
+	for _, task := range orderTasksParentFirst(exportData.Tasks) {
+		record := *task
+		record.Tags = nil
+		if err := nw.write(ndjsonRecordTask, ndjsonTask{Gorev: &record, TagIDs: tagIDs[task.ID]}); err != nil {
+			return err
+		}
+	}
+	for _, dep := range exportData.Dependencies {
+		if err := nw.write(ndjsonRecordLink, dep); err != nil {
+			return err
+		}
+	}
+
+	return nw.finish()
+}
+
+// saveAsNDJSON saves already loaded export data as an NDJSON file
+func (iy *IsYonetici) saveAsNDJSON(exportData *ExportFormat, outputPath string) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.failedToCreateFile", map[string]interface{}{"Path": outputPath, "Error": err}))
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			fmt.Printf("Warning: failed to close file %s: %v\n", outputPath, cerr)
+		}
+	}()
+
+	if err := WriteNDJSON(file, exportData, nil); err != nil {
+		return fmt.Errorf(i18n.T("error.failedToWriteFile", map[string]interface{}{"Error": err}))
+	}
+
+	return nil
+}
+
+// StreamExportToFile exports directly from the database to an NDJSON file without
+// building the export in memory. Only options.Format "ndjson" is supported.
+func (iy *IsYonetici) StreamExportToFile(ctx context.Context, options ExportOptions, progress StreamProgressFunc) (StreamProgress, error) {
+	if err := iy.validateExportFileOptions(options); err != nil {
+		return StreamProgress{}, fmt.Errorf(i18n.T("error.invalidExportOptions", map[string]interface{}{"Error": err}))
+	}
+	if options.Format != "ndjson" {
+		return StreamProgress{}, fmt.Errorf(i18n.T("error.unsupportedExportFormat", map[string]interface{}{"Format": options.Format}))
+	}
+
+	outputPath, err := NormalizePath(options.OutputPath)
+	if err != nil {
+		return StreamProgress{}, fmt.Errorf(i18n.T("error.invalidOutputPath", map[string]interface{}{"Error": err}))
+	}
+	outputDir := filepath.Dir(outputPath)
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return StreamProgress{}, fmt.Errorf(i18n.T("error.failedToCreateDirectory", map[string]interface{}{"Path": outputDir, "Error": err}))
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return StreamProgress{}, fmt.Errorf(i18n.T("error.failedToCreateFile", map[string]interface{}{"Path": outputPath, "Error": err}))
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			fmt.Printf("Warning: failed to close file %s: %v\n", outputPath, cerr)
+		}
+	}()
+
+	return iy.ExportNDJSON(ctx, file, options, progress)
+}
+
+// ExportNDJSON streams projects, tags, templates, tasks and links to w, one record per line.
+// Tasks and links are read row by row, so memory use does not grow with the number of tasks
+// (apart from the set of exported task IDs, which is needed to filter links).
+func (iy *IsYonetici) ExportNDJSON(ctx context.Context, w io.Writer, options ExportOptions, progress StreamProgressFunc) (StreamProgress, error) {
+	if iy.veriYonetici == nil {
+		return StreamProgress{}, fmt.Errorf(i18n.T("error.dataManagerNotInitialized", nil))
+	}
+	if err := iy.validateExportDataOptions(options); err != nil {
+		return StreamProgress{}, fmt.Errorf(i18n.T("error.invalidExportOptions", map[string]interface{}{"Error": err}))
+	}
+	// Streaming reads use the read pool, so the export does not block writers
+	db, err := iy.veriYonetici.GetReadDB()
+	if err != nil {
+		return StreamProgress{}, err
+	}
+	if db == nil {
+		return StreamProgress{}, fmt.Errorf(i18n.T("error.memoryBackendSQLUnavailable", map[string]interface{}{"Operation": "export"}))
+	}
+
+	nw := newNDJSONWriter(w, progress)
+	if err := nw.write(ndjsonRecordHeader, newNDJSONHeader()); err != nil {
+		return nw.stats, fmt.Errorf(i18n.T("error.failedToWriteFile", map[string]interface{}{"Error": err}))
+	}
+
+	projects, err := iy.ProjeListele(ctx)
+	if err != nil {
+		return nw.stats, fmt.Errorf(i18n.T("error.failedToExportProjects", map[string]interface{}{"Error": err}))
+	}
+	projectFilter := make(map[string]bool, len(options.ProjectFilter))
+	for _, pid := range options.ProjectFilter {
+		projectFilter[pid] = true
+	}
+	for _, project := range projects {
+		if len(projectFilter) > 0 && !projectFilter[project.ID] {
+			continue
+		}
+		if err := nw.write(ndjsonRecordProject, project); err != nil {
+			return nw.stats, fmt.Errorf(i18n.T("error.failedToWriteFile", map[string]interface{}{"Error": err}))
+		}
+	}
+
+	where, args := exportTaskFilter(options, "g")
+	sources := []ndjsonTaskSource{ndjsonLiveTasks}
+	if options.IncludeArchived {
+		sources = append(sources, ndjsonArchivedTasks)
+	}
+
+	if err := iy.streamNDJSONTags(ctx, db, nw, sources, where, args); err != nil {
+		return nw.stats, fmt.Errorf(i18n.T("error.failedToExportTaskTags", map[string]interface{}{"Error": err}))
+	}
+
+	if options.IncludeTemplates {
+		templates, err := iy.veriYonetici.TemplateListele(ctx, "")
+		if err != nil {
+			return nw.stats, fmt.Errorf(i18n.T("error.failedToExportTemplates", map[string]interface{}{"Error": err}))
+		}
+		for _, template := range templates {
+			if err := nw.write(ndjsonRecordTemplate, template); err != nil {
+				return nw.stats, fmt.Errorf(i18n.T("error.failedToWriteFile", map[string]interface{}{"Error": err}))
+			}
+		}
+	}
+
+	// Archived tasks follow the live ones, so parents are still written before their subtasks
+	exported := make(map[string]bool)
+	for _, source := range sources {
+		if err := iy.streamNDJSONTasks(ctx, db, nw, options, source, where, args, exported); err != nil {
+			return nw.stats, fmt.Errorf(i18n.T("error.failedToExportTasks", map[string]interface{}{"Error": err}))
+		}
+	}
+
+	if options.IncludeDependencies {
+		if err := iy.streamNDJSONLinks(ctx, db, nw, sources, exported); err != nil {
+			return nw.stats, fmt.Errorf(i18n.T("error.failedToExportDependencies", map[string]interface{}{"Error": err}))
+		}
+	}
+
+	if err := nw.finish(); err != nil {
+		return nw.stats, fmt.Errorf(i18n.T("error.failedToWriteFile", map[string]interface{}{"Error": err}))
+	}
+	return nw.stats, nil
+}
+
+// exportTaskFilter translates the project and status export options into a WHERE clause on
+// the task table aliased as alias. The date range is applied while scanning because dates
+// are stored as text.
+func exportTaskFilter(options ExportOptions, alias string) (string, []interface{}) {
+	clauses := []string{}
+	args := []interface{}{}
+
+	if len(options.ProjectFilter) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(options.ProjectFilter)), ",")
+		clauses = append(clauses, alias+".project_id IN ("+placeholders+")")
+		for _, pid := range options.ProjectFilter {
+			args = append(args, pid)
+		}
+	}
+	if !options.IncludeCompleted {
+		clauses = append(clauses, alias+".status != ?")
+		args = append(args, constants.TaskStatusCompleted)
+	}
+
+	if len(clauses) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(clauses, " AND "), args
+}
+
+// ndjsonTaskSource names the tables a task stream reads
+type ndjsonTaskSource struct {
+	tasks, taskTags, links string
+	archivedAt             string // Column expression for Gorev.ArchivedAt
+}
+
+var (
+	ndjsonLiveTasks     = ndjsonTaskSource{"gorevler", "gorev_etiketleri", "baglantilar", "NULL"}
+	ndjsonArchivedTasks = ndjsonTaskSource{"gorevler_arsiv", "gorev_etiketleri_arsiv", "baglantilar_arsiv", "g.archived_at"}
+)
+
+// streamNDJSONTags writes the tags used by the exported tasks
+func (iy *IsYonetici) streamNDJSONTags(ctx context.Context, db *sql.DB, nw *ndjsonWriter, sources []ndjsonTaskSource, where string, args []interface{}) error {
+	var parts []string
+	var allArgs []interface{}
+	for _, source := range sources {
+		parts = append(parts, `SELECT e.id, e.name FROM etiketler e
+	          JOIN `+source.taskTags+` ge ON ge.tag_id = e.id
+	          JOIN `+source.tasks+` g ON g.id = ge.task_id`+where)
+		allArgs = append(allArgs, args...)
+	}
+	rows, err := db.QueryContext(ctx, strings.Join(parts, " UNION ")+` ORDER BY 2`, allArgs...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		tag := &Etiket{}
+		if err := rows.Scan(&tag.ID, &tag.Name); err != nil {
+			return err
+		}
+		if err := nw.write(ndjsonRecordTag, tag); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// streamNDJSONTasks writes the tasks of source parent-first (by hierarchy depth) and adds their IDs to exported
+func (iy *IsYonetici) streamNDJSONTasks(ctx context.Context, db *sql.DB, nw *ndjsonWriter, options ExportOptions, source ndjsonTaskSource, where string, args []interface{}, exported map[string]bool) error {
+	sorgu := `WITH RECURSIVE agac(id, derinlik) AS (
+	              SELECT id, 0 FROM ` + source.tasks + `
+	              WHERE parent_id IS NULL OR parent_id = '' OR parent_id NOT IN (SELECT id FROM ` + source.tasks + `)
+	              UNION ALL
+	              SELECT c.id, a.derinlik + 1 FROM ` + source.tasks + ` c JOIN agac a ON c.parent_id = a.id
+	          )
+	          SELECT g.id, g.title, g.description, g.status, g.priority, g.project_id, g.parent_id, g.workspace_id,
+	                 g.created_at, g.updated_at, g.due_date, ` + source.archivedAt + `,
+	                 (SELECT GROUP_CONCAT(ge.tag_id) FROM ` + source.taskTags + ` ge WHERE ge.task_id = g.id)
+	          FROM ` + source.tasks + ` g JOIN agac a ON a.id = g.id` + where + `
+	          ORDER BY a.derinlik, g.created_at, g.id`
+
+	rows, err := db.QueryContext(ctx, sorgu, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		task := &Gorev{}
+		var projeID, parentID, wsID, tagIDs sql.NullString
+		var archivedAt sql.NullTime
+		if err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.Priority,
+			&projeID, &parentID, &wsID, &task.CreatedAt, &task.UpdatedAt, &task.DueDate, &archivedAt, &tagIDs); err != nil {
+			return err
+		}
+		if archivedAt.Valid {
+			task.ArchivedAt = &archivedAt.Time
+		}
+
+		if options.DateRange != nil {
+			if options.DateRange.From != nil && task.CreatedAt.Before(*options.DateRange.From) {
+				continue
+			}
+			if options.DateRange.To != nil && task.CreatedAt.After(*options.DateRange.To) {
+				continue
+			}
+		}
+
+		task.ProjeID = projeID.String
+		task.ParentID = parentID.String
+		task.WorkspaceID = wsID.String
+		record := ndjsonTask{Gorev: task}
+		if tagIDs.String != "" {
+			record.TagIDs = strings.Split(tagIDs.String, ",")
+		}
+
+		if err := nw.write(ndjsonRecordTask, record); err != nil {
+			return err
+		}
+		exported[task.ID] = true
+	}
+	return rows.Err()
+}
+
+// streamNDJSONLinks writes the dependencies between exported tasks
+func (iy *IsYonetici) streamNDJSONLinks(ctx context.Context, db *sql.DB, nw *ndjsonWriter, sources []ndjsonTaskSource, exported map[string]bool) error {
+	var parts []string
+	for _, source := range sources {
+		parts = append(parts, `SELECT id, source_id, target_id, connection_type FROM `+source.links)
+	}
+	rows, err := db.QueryContext(ctx, strings.Join(parts, " UNION ")+` ORDER BY 1`)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		link := &Baglanti{}
+		if err := rows.Scan(&link.ID, &link.SourceID, &link.TargetID, &link.ConnectionType); err != nil {
+			return err
+		}
+		if !exported[link.SourceID] || !exported[link.TargetID] {
+			continue
+		}
+		if err := nw.write(ndjsonRecordLink, link); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ndjsonImport holds the state of a streaming import. Only ID mappings are kept for the
+// whole run; tasks and links are buffered until a chunk is full and then committed together.
+type ndjsonImport struct {
+	iy        *IsYonetici
+	options   ImportOptions
+	chunkSize int
+	progress  StreamProgressFunc
+	result    *ImportResult
+	stats     StreamProgress
+	ids       *importIDMap
+	tagIDs    map[string]*Etiket // tag record ID -> database tag
+	tasks     []*ndjsonTask
+	links     []*Baglanti
+}
+
+// importNDJSONFile opens an NDJSON file and imports it with ImportNDJSON
+func (iy *IsYonetici) importNDJSONFile(ctx context.Context, options ImportOptions) (*ImportResult, error) {
+	normalizedPath, err := NormalizePath(options.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.invalidFilePath", map[string]interface{}{"Error": err}))
+	}
+
+	file, err := os.Open(normalizedPath)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.failedToOpenFile", map[string]interface{}{"Path": normalizedPath, "Error": err}))
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			fmt.Printf("Warning: failed to close file %s: %v\n", normalizedPath, cerr)
+		}
+	}()
+
+	return iy.ImportNDJSON(ctx, file, options)
+}
+
+// ImportNDJSON reads NDJSON records line by line. Projects, tags and templates are imported
+// as they arrive; tasks and links are committed in transactions of options.ChunkSize records.
+// A failing chunk is rolled back and reported, chunks committed before it are kept.
+func (iy *IsYonetici) ImportNDJSON(ctx context.Context, r io.Reader, options ImportOptions) (*ImportResult, error) {
+	if iy.veriYonetici == nil {
+		return nil, fmt.Errorf(i18n.T("error.dataManagerNotInitialized", nil))
+	}
+	s := &ndjsonImport{
+		iy:        iy,
+		options:   options,
+		chunkSize: options.ChunkSize,
+		progress:  options.Progress,
+		result: &ImportResult{
+			Success:   true,
+			Conflicts: []ConflictResolution{},
+			Errors:    []string{},
+			Warnings:  []string{},
+		},
+		ids:    newImportIDMap(),
+		tagIDs: make(map[string]*Etiket),
+	}
+	if s.chunkSize <= 0 {
+		s.chunkSize = DefaultImportChunkSize
+	}
+
+	reader := bufio.NewReaderSize(r, 64*1024)
+	lineNo := 0
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return s.result, fmt.Errorf(i18n.T("error.ndjsonReadFailed", map[string]interface{}{"Line": lineNo + 1, "Error": readErr}))
+		}
+		lineNo++
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if err := s.handleLine(ctx, lineNo, line); err != nil {
+				return s.result, err
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return s.result, err
+		}
+	}
+
+	if s.stats.Records == 0 {
+		return nil, fmt.Errorf(i18n.T("error.missingVersion", nil))
+	}
+	if err := s.flush(ctx); err != nil {
+		return s.result, err
+	}
+
+	s.stats.Done = true
+	s.report()
+	return s.result, nil
+}
+
+// handleLine decodes and applies a single record
+func (s *ndjsonImport) handleLine(ctx context.Context, lineNo int, line []byte) error {
+	var record ndjsonRecord
+	if err := json.Unmarshal(line, &record); err != nil {
+		return fmt.Errorf(i18n.T("error.ndjsonInvalidRecord", map[string]interface{}{"Line": lineNo, "Error": err}))
+	}
+
+	// The header carries the version and has to come first, like the version field of a JSON export
+	if s.stats.Records == 0 {
+		var header ndjsonHeader
+		if record.Type != ndjsonRecordHeader || json.Unmarshal(record.Data, &header) != nil || header.Version == "" {
+			return fmt.Errorf(i18n.T("error.missingVersion", nil))
+		}
+		s.stats.Records++
+		return nil
+	}
+	s.stats.Records++
+
+	decode := func(v interface{}) error {
+		if err := json.Unmarshal(record.Data, v); err != nil {
+			return fmt.Errorf(i18n.T("error.ndjsonInvalidRecord", map[string]interface{}{"Line": lineNo, "Error": err}))
+		}
+		return nil
+	}
+
+	switch record.Type {
+	case ndjsonRecordProject:
+		var project Proje
+		if err := decode(&project); err != nil {
+			return err
+		}
+		if err := s.flush(ctx); err != nil {
+			return err
+		}
+		s.stats.Projects++
+		s.importProject(ctx, &project)
+	case ndjsonRecordTag:
+		var tag Etiket
+		if err := decode(&tag); err != nil {
+			return err
+		}
+		s.stats.Tags++
+		s.importTag(ctx, &tag)
+	case ndjsonRecordTemplate:
+		var template GorevTemplate
+		if err := decode(&template); err != nil {
+			return err
+		}
+		s.stats.Templates++
+		s.importTemplate(ctx, &template)
+	case ndjsonRecordTask:
+		task := &ndjsonTask{Gorev: &Gorev{}}
+		if err := decode(task); err != nil {
+			return err
+		}
+		// Links refer to tasks, so pending links are committed before more tasks arrive
+		if len(s.links) > 0 {
+			if err := s.flush(ctx); err != nil {
+				return err
+			}
+		}
+		s.stats.Tasks++
+		s.tasks = append(s.tasks, task)
+		if len(s.tasks) >= s.chunkSize {
+			return s.flush(ctx)
+		}
+	case ndjsonRecordLink:
+		var link Baglanti
+		if err := decode(&link); err != nil {
+			return err
+		}
+		if len(s.tasks) > 0 {
+			if err := s.flush(ctx); err != nil {
+				return err
+			}
+		}
+		s.stats.Links++
+		s.links = append(s.links, &link)
+		if len(s.links) >= s.chunkSize {
+			return s.flush(ctx)
+		}
+	default:
+		s.result.Warnings = append(s.result.Warnings, i18n.T("import.ndjsonUnknownRecord", map[string]interface{}{"Line": lineNo, "Type": record.Type}))
+	}
+
+	return nil
+}
+
+// importProject imports a project record right away; projects are few and referenced by tasks
+func (s *ndjsonImport) importProject(ctx context.Context, project *Proje) {
+	if s.options.DryRun {
+		if existing, err := s.iy.veriYonetici.ProjeGetir(ctx, project.ID); err == nil && existing != nil {
+			s.result.Conflicts = append(s.result.Conflicts, ConflictResolution{
+				Type:       "project",
+				Existing:   existing,
+				Incoming:   project,
+				Resolution: s.options.ConflictResolution,
+			})
+		}
+		s.ids.projects[project.ID] = project.ID
+		return
+	}
+
+	imported, conflicts, err := s.iy.importProjects(ctx, []*Proje{project}, s.options, s.ids)
+	if err != nil {
+		s.result.Errors = append(s.result.Errors, fmt.Sprintf("Projects: %v", err))
+		s.result.Success = false
+		return
+	}
+	s.result.ImportedProjects += imported
+	s.result.Conflicts = append(s.result.Conflicts, conflicts...)
+}
+
+// importTag resolves a tag record to an existing or new tag with the same name
+func (s *ndjsonImport) importTag(ctx context.Context, tag *Etiket) {
+	if s.options.DryRun {
+		s.tagIDs[tag.ID] = tag
+		return
+	}
+
+	tags, err := s.iy.veriYonetici.EtiketleriGetirVeyaOlustur(ctx, []string{tag.Name})
+	if err != nil {
+		s.result.Errors = append(s.result.Errors, fmt.Sprintf("Tags: %v", err))
+		s.result.Success = false
+		return
+	}
+	if len(tags) > 0 {
+		s.tagIDs[tag.ID] = tags[0]
+		s.result.ImportedTags++
+	}
+}
+
+// importTemplate imports a template record right away
+func (s *ndjsonImport) importTemplate(ctx context.Context, template *GorevTemplate) {
+	if s.options.DryRun {
+		return
+	}
+
+	imported, conflicts, err := s.iy.importTemplates(ctx, []*GorevTemplate{template}, s.options)
+	if err != nil {
+		s.result.Errors = append(s.result.Errors, fmt.Sprintf("Templates: %v", err))
+		s.result.Success = false
+		return
+	}
+	s.result.ImportedTemplates += imported
+	s.result.Conflicts = append(s.result.Conflicts, conflicts...)
+}
+
+// flush commits the pending task and link chunks
+func (s *ndjsonImport) flush(ctx context.Context) error {
+	if len(s.tasks) > 0 {
+		if err := s.commitTasks(ctx); err != nil {
+			return fmt.Errorf(i18n.T("error.ndjsonChunkFailed", map[string]interface{}{"Type": ndjsonRecordTask, "Error": err}))
+		}
+		s.tasks = s.tasks[:0]
+		s.report()
+	}
+	if len(s.links) > 0 {
+		if err := s.commitLinks(ctx); err != nil {
+			return fmt.Errorf(i18n.T("error.ndjsonChunkFailed", map[string]interface{}{"Type": ndjsonRecordLink, "Error": err}))
+		}
+		s.links = s.links[:0]
+		s.report()
+	}
+	return nil
+}
+
+// report calls the progress callback, if any
+func (s *ndjsonImport) report() {
+	if s.progress != nil {
+		s.progress(s.stats)
+	}
+}
+
+// commitTasks writes the pending task chunk in a single transaction. Results are only
+// added to the import result once the transaction has been committed.
+func (s *ndjsonImport) commitTasks(ctx context.Context) error {
+	imported := 0
+	conflicts := []ConflictResolution{}
+	errs := []string{}
+	newIDs := make(map[string]string, len(s.tasks))
+
+	err := s.iy.veriYonetici.YazmaIslemi(ctx, func(tx *sql.Tx) error {
+		for _, record := range s.tasks {
+			task := record.Gorev
+			originalID := task.ID
+			taskID := task.ID
+			if !s.options.PreserveIDs || taskID == "" {
+				taskID = uuid.New().String()
+			}
+			newIDs[originalID] = taskID
+
+			// Map project ID: explicit project mapping wins, otherwise follow imported project IDs
+			if task.ProjeID != "" {
+				if newProjectID, exists := s.options.ProjectMapping[task.ProjeID]; exists {
+					task.ProjeID = newProjectID
+				} else if newProjectID, exists := s.ids.projects[task.ProjeID]; exists {
+					task.ProjeID = newProjectID
+				} else if !ndjsonRowExists(ctx, tx, "projeler", task.ProjeID) {
+					errs = append(errs, i18n.T("error.invalidTaskProjectReference", map[string]interface{}{"TaskID": originalID, "ProjectID": task.ProjeID}))
+					continue
+				}
+			}
+
+			// Parents are written before their subtasks, so they are either mapped already or pre-existing
+			if task.ParentID != "" {
+				if newParentID, exists := newIDs[task.ParentID]; exists {
+					task.ParentID = newParentID
+				} else if newParentID, exists := s.ids.tasks[task.ParentID]; exists {
+					task.ParentID = newParentID
+				} else if !ndjsonRowExists(ctx, tx, "gorevler", task.ParentID) {
+					task.ParentID = ""
+				}
+			}
+
+			if task.Status == "" {
+				task.Status = constants.TaskStatusPending
+			}
+			if task.Priority == "" {
+				task.Priority = constants.PriorityMedium
+			}
+			if task.CreatedAt.IsZero() {
+				task.CreatedAt = time.Now()
+			}
+			if task.UpdatedAt.IsZero() {
+				task.UpdatedAt = task.CreatedAt
+			}
+
+			if ndjsonRowExists(ctx, tx, "gorevler", taskID) {
+				conflict := ConflictResolution{Type: "task", Existing: &Gorev{ID: taskID}, Incoming: task, Resolution: s.options.ConflictResolution}
+				if s.options.DryRun || s.options.ConflictResolution != "overwrite" {
+					if conflict.Resolution == "" {
+						conflict.Resolution = "skip"
+					}
+					conflicts = append(conflicts, conflict)
+					continue
+				}
+				task.ID = taskID
+				if _, err := tx.ExecContext(ctx, `UPDATE gorevler SET title = ?, description = ?, status = ?, priority = ?,
+			          project_id = ?, parent_id = ?, due_date = ?, updated_at = ? WHERE id = ?`,
+					task.Title, task.Description, task.Status, task.Priority,
+					sql.NullString{String: task.ProjeID, Valid: task.ProjeID != ""},
+					sql.NullString{String: task.ParentID, Valid: task.ParentID != ""},
+					task.DueDate, time.Now(), taskID); err != nil {
+					return err
+				}
+				conflict.NewValue = task
+				conflicts = append(conflicts, conflict)
+			} else {
+				if s.options.DryRun {
+					continue
+				}
+				task.ID = taskID
+				workspaceID := task.WorkspaceID
+				if workspaceID == "" {
+					workspaceID = s.iy.workspaceID
+				}
+				if workspaceID == "" {
+					workspaceID = "default"
+				}
+				if _, err := tx.ExecContext(ctx, `INSERT INTO gorevler (id, title, description, status, priority, project_id, parent_id, workspace_id, created_at, updated_at, due_date)
+			          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+					task.ID, task.Title, task.Description, task.Status, task.Priority,
+					sql.NullString{String: task.ProjeID, Valid: task.ProjeID != ""},
+					sql.NullString{String: task.ParentID, Valid: task.ParentID != ""},
+					workspaceID, task.CreatedAt, task.UpdatedAt, task.DueDate); err != nil {
+					return err
+				}
+			}
+
+			if _, err := tx.ExecContext(ctx, "DELETE FROM gorev_etiketleri WHERE task_id = ?", taskID); err != nil {
+				return err
+			}
+			for _, tagID := range record.TagIDs {
+				tag, ok := s.tagIDs[tagID]
+				if !ok {
+					continue
+				}
+				if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO gorev_etiketleri (task_id, tag_id) VALUES (?, ?)", taskID, tag.ID); err != nil {
+					return err
+				}
+			}
+			imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Only remapped IDs are remembered; with preserve_ids the map stays empty
+	for originalID, taskID := range newIDs {
+		if originalID != taskID {
+			s.ids.tasks[originalID] = taskID
+		}
+	}
+	s.result.ImportedTasks += imported
+	s.result.Conflicts = append(s.result.Conflicts, conflicts...)
+	s.result.Errors = append(s.result.Errors, errs...)
+	if len(errs) > 0 {
+		s.result.Success = false
+	}
+	return nil
+}
+
+// commitLinks writes the pending link chunk in a single transaction, skipping links whose
+// tasks are missing and links that already exist
+func (s *ndjsonImport) commitLinks(ctx context.Context) error {
+	if s.options.DryRun {
+		return nil
+	}
+
+	return s.iy.veriYonetici.YazmaIslemi(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, `INSERT INTO baglantilar (id, source_id, target_id, connection_type)
+	          SELECT ?, ?, ?, ?
+	          WHERE EXISTS (SELECT 1 FROM gorevler WHERE id = ?)
+	            AND EXISTS (SELECT 1 FROM gorevler WHERE id = ?)
+	            AND NOT EXISTS (SELECT 1 FROM baglantilar WHERE source_id = ? AND target_id = ? AND connection_type = ?)`)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = stmt.Close() }()
+
+		for _, link := range s.links {
+			sourceID := link.SourceID
+			if newID, exists := s.ids.tasks[sourceID]; exists {
+				sourceID = newID
+			}
+			targetID := link.TargetID
+			if newID, exists := s.ids.tasks[targetID]; exists {
+				targetID = newID
+			}
+			if _, err := stmt.ExecContext(ctx, uuid.New().String(), sourceID, targetID, link.ConnectionType,
+				sourceID, targetID, sourceID, targetID, link.ConnectionType); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ndjsonRowExists reports whether a row with the given ID exists in table (a fixed, trusted name)
+func ndjsonRowExists(ctx context.Context, tx *sql.Tx, table, id string) bool {
+	var exists int
+	err := tx.QueryRowContext(ctx, "SELECT 1 FROM "+table+" WHERE id = ?", id).Scan(&exists)
+	return err == nil
+}
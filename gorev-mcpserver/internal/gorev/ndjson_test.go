@@ -0,0 +1,238 @@
+package gorev
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+// addNDJSONTestData adds a subtask, tags and a dependency on top of setupTestData
+func addNDJSONTestData(t *testing.T, vy *VeriYonetici) {
+	seedTestData(t, vy, testSeed{
+		tasks: []*Gorev{{
+			ID:        "test-task-3",
+			Title:     "Test Subtask",
+			Status:    constants.TaskStatusInProgress,
+			Priority:  constants.PriorityLow,
+			ProjeID:   "test-project-1",
+			ParentID:  "test-task-1",
+			CreatedAt: time.Now().Add(-time.Hour), // Older than its parent, still exported after it
+			UpdatedAt: time.Now(),
+		}},
+		tags:  map[string][]string{"test-task-1": {"backend", "urgent"}},
+		links: []*Baglanti{{ID: "link-1", SourceID: "test-task-2", TargetID: "test-task-1", ConnectionType: "onceki"}},
+	})
+}
+
+// readNDJSONRecords splits an NDJSON export into its records
+func readNDJSONRecords(t *testing.T, data []byte) []ndjsonRecord {
+	records := []ndjsonRecord{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var record ndjsonRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("line is not a JSON record: %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestExportNDJSON(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	addNDJSONTestData(t, vy)
+
+	var buf bytes.Buffer
+	var last StreamProgress
+	stats, err := iy.ExportNDJSON(context.Background(), &buf, ExportOptions{
+		IncludeCompleted:    true,
+		IncludeDependencies: true,
+	}, func(p StreamProgress) { last = p })
+	if err != nil {
+		t.Fatalf("ExportNDJSON failed: %v", err)
+	}
+	if !last.Done || last != stats {
+		t.Errorf("final progress should be reported with Done set, got %+v (stats %+v)", last, stats)
+	}
+	if stats.Projects != 1 || stats.Tags != 2 || stats.Tasks != 3 || stats.Links != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	records := readNDJSONRecords(t, buf.Bytes())
+	if len(records) != stats.Records || records[0].Type != ndjsonRecordHeader {
+		t.Fatalf("expected %d records starting with a header, got %d", stats.Records, len(records))
+	}
+
+	order := []string{}
+	position := map[string]int{}
+	for i, record := range records {
+		if len(order) == 0 || order[len(order)-1] != record.Type {
+			order = append(order, record.Type)
+		}
+		if record.Type == ndjsonRecordTask {
+			var task ndjsonTask
+			if err := json.Unmarshal(record.Data, &task); err != nil {
+				t.Fatalf("invalid task record: %v", err)
+			}
+			position[task.ID] = i
+			if task.ID == "test-task-1" && len(task.TagIDs) != 2 {
+				t.Errorf("task record should reference its tags, got %v", task.TagIDs)
+			}
+		}
+	}
+	if got := strings.Join(order, ","); got != "header,project,tag,task,link" {
+		t.Errorf("unexpected record order: %s", got)
+	}
+	if position["test-task-1"] > position["test-task-3"] {
+		t.Error("parent task should be written before its subtask")
+	}
+
+	// Filters are applied in the query
+	buf.Reset()
+	stats, err = iy.ExportNDJSON(context.Background(), &buf, ExportOptions{IncludeDependencies: true}, nil)
+	if err != nil {
+		t.Fatalf("ExportNDJSON failed: %v", err)
+	}
+	if stats.Tasks != 2 || stats.Links != 0 {
+		t.Errorf("completed task and its link should be excluded, got %+v", stats)
+	}
+}
+
+func TestImportNDJSONRoundTrip(t *testing.T) {
+	source, sourceVY := newImportTestManager(t)
+	addNDJSONTestData(t, sourceVY)
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "backup.ndjson")
+	if _, err := source.StreamExportToFile(ctx, ExportOptions{
+		Format:              "ndjson",
+		OutputPath:          path,
+		IncludeCompleted:    true,
+		IncludeDependencies: true,
+	}, nil); err != nil {
+		t.Fatalf("StreamExportToFile failed: %v", err)
+	}
+
+	targetVY, err := YeniVeriYonetici(":memory:", "file://../../internal/veri/migrations")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	t.Cleanup(func() { _ = targetVY.Kapat() })
+	target := YeniIsYonetici(targetVY)
+
+	progressCalls := 0
+	result, err := target.ImportData(ctx, ImportOptions{
+		FilePath:  path,
+		ChunkSize: 1,
+		Progress:  func(StreamProgress) { progressCalls++ },
+	})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if result.ImportedProjects != 1 || result.ImportedTags != 2 || result.ImportedTasks != 3 || len(result.Errors) != 0 {
+		t.Fatalf("unexpected import result: %+v", result)
+	}
+	// One callback per committed chunk (3 tasks, 1 link) plus the final one
+	if progressCalls != 5 {
+		t.Errorf("expected 5 progress callbacks, got %d", progressCalls)
+	}
+
+	parent := findTaskByTitle(t, target, "Test Task 1")
+	subtask := findTaskByTitle(t, target, "Test Subtask")
+	blocker := findTaskByTitle(t, target, "Test Task 2")
+	if parent.ID == "test-task-1" {
+		t.Error("task IDs should be regenerated without preserve_ids")
+	}
+	if subtask.ParentID != parent.ID || subtask.Status != constants.TaskStatusInProgress {
+		t.Errorf("subtask should point at the new parent ID, got parent %q status %s", subtask.ParentID, subtask.Status)
+	}
+	if len(parent.Tags) != 2 || parent.ProjeID == "" || parent.ProjeID == "test-project-1" {
+		t.Errorf("unexpected tags/project on imported task: %d/%q", len(parent.Tags), parent.ProjeID)
+	}
+
+	links, err := targetVY.BaglantilariGetir(ctx, parent.ID)
+	if err != nil {
+		t.Fatalf("BaglantilariGetir failed: %v", err)
+	}
+	if len(links) != 1 || links[0].SourceID != blocker.ID {
+		t.Errorf("dependency should be remapped to the new IDs, got %+v", links)
+	}
+}
+
+func TestImportNDJSONConflicts(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	addNDJSONTestData(t, vy)
+	ctx := context.Background()
+
+	exportData, err := iy.ExportData(ctx, ExportOptions{IncludeCompleted: true, IncludeDependencies: true})
+	if err != nil {
+		t.Fatalf("ExportData failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "backup.jsonl")
+	if err := iy.SaveExportToFile(ctx, exportData, ExportOptions{Format: "ndjson", OutputPath: path}); err != nil {
+		t.Fatalf("SaveExportToFile failed: %v", err)
+	}
+
+	result, err := iy.ImportData(ctx, ImportOptions{FilePath: path, PreserveIDs: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if result.ImportedTasks != 0 || len(result.Conflicts) != 4 {
+		t.Errorf("dry run should report 1 project and 3 task conflicts, got %d imported, %d conflicts", result.ImportedTasks, len(result.Conflicts))
+	}
+
+	result, err = iy.ImportData(ctx, ImportOptions{FilePath: path, PreserveIDs: true, ConflictResolution: "skip"})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if result.ImportedTasks != 0 || len(result.Conflicts) != 4 {
+		t.Errorf("existing tasks should be skipped, got %d imported, %d conflicts", result.ImportedTasks, len(result.Conflicts))
+	}
+	links, err := vy.BaglantilariGetir(ctx, "test-task-1")
+	if err != nil {
+		t.Fatalf("BaglantilariGetir failed: %v", err)
+	}
+	if len(links) != 1 {
+		t.Errorf("existing dependency should not be duplicated, got %d", len(links))
+	}
+}
+
+func TestImportNDJSONInvalid(t *testing.T) {
+	iy, _ := newImportTestManager(t)
+	ctx := context.Background()
+
+	noHeader := writeImportFile(t, "no-header.ndjson", `{"type":"task","data":{"id":"x","title":"Orphan"}}`+"\n")
+	if _, err := iy.ImportData(ctx, ImportOptions{FilePath: noHeader}); err == nil {
+		t.Error("expected an error for a file without header record")
+	}
+
+	broken := writeImportFile(t, "broken.ndjson", `{"type":"header","data":{"version":"v0.11.1"}}`+"\n{not json\n")
+	if _, err := iy.ImportData(ctx, ImportOptions{FilePath: broken}); err == nil {
+		t.Error("expected an error for an invalid line")
+	}
+
+	unknown := writeImportFile(t, "unknown.ndjson", strings.Join([]string{
+		`{"type":"header","data":{"version":"v0.11.1"}}`,
+		`{"type":"ai_context","data":{}}`,
+		``,
+		`{"type":"task","data":{"id":"new-task","title":"Streamed","proje_id":"test-project-1"}}`,
+		`{"type":"task","data":{"id":"bad-task","title":"Bad","proje_id":"missing-project"}}`,
+	}, "\n"))
+	result, err := iy.ImportData(ctx, ImportOptions{FilePath: unknown})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if len(result.Warnings) != 1 || result.ImportedTasks != 1 || len(result.Errors) != 1 || result.Success {
+		t.Errorf("expected 1 warning, 1 imported task and 1 error, got %+v", result)
+	}
+	if task := findTaskByTitle(t, iy, "Streamed"); task.ProjeID != "test-project-1" || task.Priority != constants.PriorityMedium {
+		t.Errorf("task should keep the existing project and get defaults, got %q/%s", task.ProjeID, task.Priority)
+	}
+}
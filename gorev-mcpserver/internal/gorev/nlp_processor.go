@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,18 +13,39 @@ import (
 	"github.com/msenol/gorev/internal/i18n"
 )
 
-// NLPProcessor handles natural language queries for task management
+// NLPProcessor handles natural language queries for task management. Its keywords come
+// from language packs (see LanguagePack).
 type NLPProcessor struct {
-	TimeZone *time.Location
+	TimeZone   *time.Location
+	now        func() time.Time // Clock of relative dates; time.Now when nil
+	vocabulary *nlpVocabulary   // Registered language packs when nil
 }
 
-// NewNLPProcessor creates a new NLP processor with the system timezone
+// NewNLPProcessor creates a new NLP processor with the system timezone and the
+// registered language packs
 func NewNLPProcessor() *NLPProcessor {
 	return &NLPProcessor{
 		TimeZone: time.Local,
 	}
 }
 
+// NewNLPProcessorWithLanguagePacks creates a new NLP processor with the system timezone
+// that only understands the given language packs
+func NewNLPProcessorWithLanguagePacks(packs ...*LanguagePack) *NLPProcessor {
+	return &NLPProcessor{
+		TimeZone:   time.Local,
+		vocabulary: newNLPVocabulary(packs),
+	}
+}
+
+// vocab returns the vocabulary of the processor's language packs
+func (nlp *NLPProcessor) vocab() *nlpVocabulary {
+	if nlp.vocabulary == nil {
+		return defaultNLPVocabulary()
+	}
+	return nlp.vocabulary
+}
+
 // QueryIntent represents the parsed intent from natural language
 type QueryIntent struct {
 	Action     string                 `json:"action"`
@@ -86,148 +108,18 @@ func (nlp *NLPProcessor) ProcessQuery(query string) (*QueryIntent, error) {
 	return intent, nil
 }
 
-// parseAction determines the main action from the query
+// parseAction determines the main action from the query; longer phrases are tried first
 func (nlp *NLPProcessor) parseAction(query string) string {
-	actionPatterns := map[string][]string{
-		"list": {
-			"görevleri göster", "listele", "görevler", "ne var", "neler var",
-			"show tasks", "list tasks", "what tasks", "tasks",
-		},
-		"create": {
-			"görev oluştur", "yeni görev", "ekle", "oluştur", "yap",
-			"create task", "new task", "add task", "make task",
-		},
-		"update": {
-			"güncelle", "değiştir", "düzenle", "revize et",
-			"update task", "modify", "edit", "change",
-		},
-		"complete": {
-			"tamamla", "bitir", "kapat", "hallettim", "bitti",
-			"complete", "finish", "close", "done",
-		},
-		"delete": {
-			"sil", "kaldır", "iptal et",
-			"delete", "remove", "cancel",
-		},
-		"search": {
-			"ara", "bul", "araştır",
-			"search", "find", "look for",
-		},
-		"status": {
-			"durum", "durumu", "nasıl", "ne durumda",
-			"status", "state", "how is",
-		},
-	}
-
-	// First check for more specific multi-word patterns
-	specificPatterns := []struct {
-		action  string
-		pattern string
-	}{
-		{"create", "create task"},
-		{"create", "new task"},
-		{"create", "add task"},
-		{"create", "make task"},
-		{"create", "görev oluştur"},
-		{"create", "yeni görev"},
-		{"update", "update task"},
-		{"list", "show tasks"},
-		{"list", "list tasks"},
-		{"list", "görevleri göster"},
-		{"complete", "complete task"},
-		{"delete", "delete task"},
-	}
-
-	// Check specific patterns first
-	for _, sp := range specificPatterns {
-		if strings.Contains(query, sp.pattern) {
-			return sp.action
-		}
-	}
-
-	// Then check single word patterns
-	for action, patterns := range actionPatterns {
-		for _, pattern := range patterns {
-			// Skip patterns that have already been checked as multi-word
-			if len(strings.Fields(pattern)) > 1 {
-				continue
-			}
-			if strings.Contains(query, pattern) {
-				return action
-			}
-		}
+	if action, ok := nlp.vocab().match(nlp.vocab().actions, query); ok {
+		return action
 	}
-
 	return "list" // Default action
 }
 
 // parseTimeExpressions extracts time-related information
 func (nlp *NLPProcessor) parseTimeExpressions(query string) *TimeRange {
-	now := time.Now().In(nlp.TimeZone)
-
-	// Turkish time expressions
-	turkishPatterns := map[string]func() *TimeRange{
-		"bugün": func() *TimeRange {
-			start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, nlp.TimeZone)
-			end := start.Add(24 * time.Hour).Add(-time.Nanosecond)
-			return &TimeRange{Start: &start, End: &end, Relative: "today"}
-		},
-		"yarın": func() *TimeRange {
-			tomorrow := now.Add(24 * time.Hour)
-			start := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 0, 0, 0, 0, nlp.TimeZone)
-			end := start.Add(24 * time.Hour).Add(-time.Nanosecond)
-			return &TimeRange{Start: &start, End: &end, Relative: "tomorrow"}
-		},
-		"dün": func() *TimeRange {
-			yesterday := now.Add(-24 * time.Hour)
-			start := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, nlp.TimeZone)
-			end := start.Add(24 * time.Hour).Add(-time.Nanosecond)
-			return &TimeRange{Start: &start, End: &end, Relative: "yesterday"}
-		},
-		"bu hafta": func() *TimeRange {
-			weekday := int(now.Weekday())
-			if weekday == 0 {
-				weekday = 7
-			} // Sunday = 7
-			start := now.Add(-time.Duration(weekday-1) * 24 * time.Hour)
-			start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, nlp.TimeZone)
-			end := start.Add(7 * 24 * time.Hour).Add(-time.Nanosecond)
-			return &TimeRange{Start: &start, End: &end, Relative: "this_week"}
-		},
-		"gelecek hafta": func() *TimeRange {
-			weekday := int(now.Weekday())
-			if weekday == 0 {
-				weekday = 7
-			}
-			nextWeekStart := now.Add(time.Duration(7-weekday+1) * 24 * time.Hour)
-			start := time.Date(nextWeekStart.Year(), nextWeekStart.Month(), nextWeekStart.Day(), 0, 0, 0, 0, nlp.TimeZone)
-			end := start.Add(7 * 24 * time.Hour).Add(-time.Nanosecond)
-			return &TimeRange{Start: &start, End: &end, Relative: "next_week"}
-		},
-	}
-
-	// English time expressions
-	englishPatterns := map[string]func() *TimeRange{
-		"today":     turkishPatterns["bugün"],
-		"tomorrow":  turkishPatterns["yarın"],
-		"yesterday": turkishPatterns["dün"],
-		"this week": turkishPatterns["bu hafta"],
-		"next week": turkishPatterns["gelecek hafta"],
-	}
-
-	// Merge patterns
-	allPatterns := make(map[string]func() *TimeRange)
-	for k, v := range turkishPatterns {
-		allPatterns[k] = v
-	}
-	for k, v := range englishPatterns {
-		allPatterns[k] = v
-	}
-
-	for pattern, timeFunc := range allPatterns {
-		if strings.Contains(query, pattern) {
-			return timeFunc()
-		}
+	if relative, ok := nlp.vocab().match(nlp.vocab().timeExpressions, query); ok {
+		return nlp.relativeTimeRange(relative)
 	}
 
 	// Parse specific dates (YYYY-MM-DD format)
@@ -244,69 +136,65 @@ func (nlp *NLPProcessor) parseTimeExpressions(query string) *TimeRange {
 	return nil
 }
 
+// relativeTimeRange returns the days a relative time expression of a language pack covers
+func (nlp *NLPProcessor) relativeTimeRange(relative string) *TimeRange {
+	today := nlp.today()
+	start, end := today, today.AddDate(0, 0, 1)
+	switch relative {
+	case relativeToday:
+	case relativeTomorrow:
+		start, end = today.AddDate(0, 0, 1), today.AddDate(0, 0, 2)
+	case relativeDayAfterTomorrow:
+		start, end = today.AddDate(0, 0, 2), today.AddDate(0, 0, 3)
+	case relativeYesterday:
+		start, end = today.AddDate(0, 0, -1), today
+	case relativeThisWeek:
+		start = startOfWeek(today)
+		end = start.AddDate(0, 0, 7)
+	case relativeNextWeek:
+		start = startOfWeek(today).AddDate(0, 0, 7)
+		end = start.AddDate(0, 0, 7)
+	case relativeNextMonth:
+		start = time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, nlp.TimeZone)
+		end = start.AddDate(0, 1, 0)
+	default:
+		return nil
+	}
+	end = end.Add(-time.Nanosecond)
+	return &TimeRange{Start: &start, End: &end, Relative: relative}
+}
+
 // parseFilters extracts filtering criteria
 func (nlp *NLPProcessor) parseFilters(query string) map[string]interface{} {
 	filters := make(map[string]interface{})
 
+	vocab := nlp.vocab()
+
 	// Tag filters
-	tagRegex := regexp.MustCompile(`(?:etiket:|tag:)(\w+)`)
-	if matches := tagRegex.FindAllStringSubmatch(query, -1); len(matches) > 0 {
-		var tags []string
-		for _, match := range matches {
-			if len(match) > 1 {
-				tags = append(tags, match[1])
+	if len(vocab.tagPrefixes) > 0 {
+		tagRegex := regexp.MustCompile(`(?:` + quotedAlternatives(vocab.tagPrefixes) + `)(\w+)`)
+		if matches := tagRegex.FindAllStringSubmatch(query, -1); len(matches) > 0 {
+			var tags []string
+			for _, match := range matches {
+				if len(match) > 1 {
+					tags = append(tags, match[1])
+				}
+			}
+			if len(tags) > 0 {
+				filters["tags"] = tags
 			}
-		}
-		if len(tags) > 0 {
-			filters["tags"] = tags
 		}
 	}
 
-	// Priority filters
-	priorityPatterns := map[string]string{
-		"yüksek öncelik": "high",
-		"high priority":  "high",
-		"acil":           "urgent",
-		"urgent":         "urgent",
-		"düşük öncelik":  "low",
-		"low priority":   "low",
+	// Priority, status and category filters
+	if priority, ok := vocab.match(vocab.priorities, query); ok {
+		filters["priority"] = priority
 	}
-
-	for pattern, priority := range priorityPatterns {
-		if strings.Contains(query, pattern) {
-			filters["priority"] = priority
-			break
-		}
+	if status, ok := vocab.match(vocab.statuses, query); ok {
+		filters["status"] = status
 	}
-
-	// Status filters
-	statusPatterns := map[string]string{
-		"açık":        "open",
-		"open":        "open",
-		"tamamlanan":  "completed",
-		"completed":   "completed",
-		"devam eden":  "in_progress",
-		"in progress": "in_progress",
-		"bekleyen":    "pending",
-		"pending":     "pending",
-	}
-
-	for pattern, status := range statusPatterns {
-		if strings.Contains(query, pattern) {
-			filters["status"] = status
-			break
-		}
-	}
-
-	// Category filters
-	if strings.Contains(query, "frontend") || strings.Contains(query, "ön yüz") {
-		filters["category"] = "frontend"
-	} else if strings.Contains(query, "backend") || strings.Contains(query, "arka plan") {
-		filters["category"] = "backend"
-	} else if strings.Contains(query, "bug") || strings.Contains(query, "hata") {
-		filters["category"] = "bug"
-	} else if strings.Contains(query, "feature") || strings.Contains(query, "özellik") {
-		filters["category"] = "feature"
+	if category, ok := vocab.match(vocab.categories, query); ok {
+		filters["category"] = category
 	}
 
 	return filters
@@ -317,7 +205,10 @@ func (nlp *NLPProcessor) parseTaskReferences(query string) []string {
 	var references []string
 
 	// Task ID references
-	idRegex := regexp.MustCompile(`(?:görev |task )?\#?(\d+)`)
+	idRegex := regexp.MustCompile(`\#?(\d+)`)
+	if taskWords := nlp.vocab().taskWords; len(taskWords) > 0 {
+		idRegex = regexp.MustCompile(`(?:(?:` + quotedAlternatives(taskWords) + `) )?\#?(\d+)`)
+	}
 	if matches := idRegex.FindAllStringSubmatch(query, -1); len(matches) > 0 {
 		for _, match := range matches {
 			if len(match) > 1 {
@@ -327,12 +218,7 @@ func (nlp *NLPProcessor) parseTaskReferences(query string) []string {
 	}
 
 	// Recent task references
-	recentPatterns := []string{
-		"son oluşturduğum", "son görev", "en son", "last task", "latest task",
-		"recent task", "son eklediğim",
-	}
-
-	for _, pattern := range recentPatterns {
+	for _, pattern := range nlp.vocab().recentTasks {
 		if strings.Contains(query, pattern) {
 			references = append(references, "recent:1")
 			break
@@ -472,8 +358,8 @@ func (nlp *NLPProcessor) ValidateIntent(intent *QueryIntent) error {
 	switch intent.Action {
 	case "create":
 		// Create actions should have some content indication
-		if !strings.Contains(strings.ToLower(intent.Raw), "görev") &&
-			!strings.Contains(strings.ToLower(intent.Raw), "task") {
+		raw := strings.ToLower(intent.Raw)
+		if !slices.ContainsFunc(nlp.vocab().taskWords, func(word string) bool { return strings.Contains(raw, word) }) {
 			return fmt.Errorf(i18n.T("error.createActionRequiresContent"))
 		}
 	case "update", "complete", "delete":
@@ -486,7 +372,9 @@ func (nlp *NLPProcessor) ValidateIntent(intent *QueryIntent) error {
 	return nil
 }
 
-// ExtractTaskContent extracts task content from natural language
+// ExtractTaskContent extracts task content from natural language. After the action
+// words, the text is read as quick-add syntax (see QuickAdd), so tags, priority,
+// project, parent, due date and estimate are returned as well.
 func (nlp *NLPProcessor) ExtractTaskContent(query string) map[string]interface{} {
 	content := make(map[string]interface{})
 
@@ -494,43 +382,53 @@ func (nlp *NLPProcessor) ExtractTaskContent(query string) map[string]interface{}
 	normalized := strings.ToLower(query)
 
 	// Remove action words to get the content
-	actionWords := []string{
-		"görev oluştur", "yeni görev", "ekle", "oluştur", "yap",
-		"create task", "new task", "add task", "make task",
-	}
-
-	title := query
-	for _, action := range actionWords {
+	text := query
+	for _, action := range nlp.vocab().createPhrases {
 		if strings.Contains(normalized, action) {
 			parts := strings.Split(query, action)
 			if len(parts) > 1 {
-				title = strings.TrimSpace(parts[1])
+				text = strings.TrimSpace(parts[1])
 				break
 			}
 		}
 	}
+	text = strings.TrimLeft(text, ":- ")
 
-	// Clean title
-	title = strings.Trim(title, `"'`)
-	if title != "" {
-		content["title"] = title
+	// Invalid tokens are left out; ParseQuickAdd reports them
+	quickAdd, _ := nlp.parseQuickAdd(text)
+	if quickAdd.Title != "" {
+		content["title"] = quickAdd.Title
 	}
-
-	// Extract description if separated by colon or dash
-	if strings.Contains(title, ":") {
-		parts := strings.SplitN(title, ":", 2)
-		content["title"] = strings.TrimSpace(parts[0])
-		content["description"] = strings.TrimSpace(parts[1])
-	} else if strings.Contains(title, " - ") {
-		parts := strings.SplitN(title, " - ", 2)
-		content["title"] = strings.TrimSpace(parts[0])
-		content["description"] = strings.TrimSpace(parts[1])
+	if quickAdd.Description != "" {
+		content["description"] = quickAdd.Description
 	}
-
-	// Extract due date from time expressions
-	if timeRange := nlp.parseTimeExpressions(normalized); timeRange != nil && timeRange.Start != nil {
-		content["due_date"] = timeRange.Start.Format("2006-01-02T15:04:05Z07:00")
+	if len(quickAdd.Tags) > 0 {
+		content["tags"] = quickAdd.Tags
+	}
+	if quickAdd.Priority != "" {
+		content["priority"] = quickAdd.Priority
+	}
+	if quickAdd.Project != "" {
+		content["project"] = quickAdd.Project
+	}
+	if quickAdd.ParentID != "" {
+		content["parent_id"] = quickAdd.ParentID
+	}
+	if quickAdd.EstimatedHours > 0 {
+		content["estimated_hours"] = quickAdd.EstimatedHours
+	}
+	if quickAdd.DueDate != nil {
+		content["due_date"] = quickAdd.DueDate.Format("2006-01-02T15:04:05Z07:00")
 	}
 
 	return content
 }
+
+// quotedAlternatives joins words into a regular expression alternation
+func quotedAlternatives(words []string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = regexp.QuoteMeta(word)
+	}
+	return strings.Join(quoted, "|")
+}
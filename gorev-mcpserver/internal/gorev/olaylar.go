@@ -0,0 +1,75 @@
+package gorev
+
+// olayYayinlayan is implemented by the data managers that emit change events
+type olayYayinlayan interface {
+	olayYayicisi() (EventEmitter, string)
+}
+
+// olayYayicisi returns the event emitter of the data manager and its workspace
+func (vy *VeriYonetici) olayYayicisi() (EventEmitter, string) {
+	return vy.eventEmitter, vy.workspaceID
+}
+
+// olayYayicisi returns the event emitter of the data manager and its workspace
+func (vy *MemoryVeriYonetici) olayYayicisi() (EventEmitter, string) {
+	return vy.eventEmitter, vy.workspaceID
+}
+
+// olayYayicisi returns the event emitter of the wrapped data manager
+func (c *CachedVeriYonetici) olayYayicisi() (EventEmitter, string) {
+	if yayinlayan, ok := c.VeriYoneticiInterface.(olayYayinlayan); ok {
+		return yayinlayan.olayYayicisi()
+	}
+	return nil, ""
+}
+
+// degisiklikOlaylari collects the changes of a write that bypasses the data manager
+// methods, such as SQL run in YazmaIslemi. They are emitted in order with
+// olaylariYayinla once the transaction has committed. Archived tasks leave the live
+// tables, so they are reported as deleted and restored ones as created.
+type degisiklikOlaylari struct {
+	olaylar []func(yayici EventEmitter, workspaceID string)
+}
+
+// gorevOlusturuldu records a created task
+func (o *degisiklikOlaylari) gorevOlusturuldu(id string, veri map[string]interface{}) {
+	o.olaylar = append(o.olaylar, func(yayici EventEmitter, workspaceID string) {
+		yayici.EmitTaskCreated(workspaceID, id, veri)
+	})
+}
+
+// gorevGuncellendi records a changed task
+func (o *degisiklikOlaylari) gorevGuncellendi(id string, veri map[string]interface{}) {
+	o.olaylar = append(o.olaylar, func(yayici EventEmitter, workspaceID string) {
+		yayici.EmitTaskUpdated(workspaceID, id, veri)
+	})
+}
+
+// gorevSilindi records a task that left the live tables
+func (o *degisiklikOlaylari) gorevSilindi(id string) {
+	o.olaylar = append(o.olaylar, func(yayici EventEmitter, workspaceID string) {
+		yayici.EmitTaskDeleted(workspaceID, id)
+	})
+}
+
+// projeOlusturuldu records a created project
+func (o *degisiklikOlaylari) projeOlusturuldu(proje *Proje) {
+	o.olaylar = append(o.olaylar, func(yayici EventEmitter, workspaceID string) {
+		yayici.EmitProjectCreated(workspaceID, proje.ID, map[string]interface{}{"name": proje.Name})
+	})
+}
+
+// olaylariYayinla emits the collected changes if the data manager has an event emitter
+func (iy *IsYonetici) olaylariYayinla(olaylar *degisiklikOlaylari) {
+	yayinlayan, ok := iy.veriYonetici.(olayYayinlayan)
+	if !ok {
+		return
+	}
+	yayici, workspaceID := yayinlayan.olayYayicisi()
+	if yayici == nil {
+		return
+	}
+	for _, olay := range olaylar.olaylar {
+		olay(yayici, workspaceID)
+	}
+}
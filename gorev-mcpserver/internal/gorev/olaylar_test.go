@@ -0,0 +1,175 @@
+package gorev
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// kayitYayici records the emitted events as "type:id"
+type kayitYayici struct {
+	mu      sync.Mutex
+	olaylar []string
+}
+
+func (k *kayitYayici) ekle(olay string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.olaylar = append(k.olaylar, olay)
+}
+
+// al returns the recorded events sorted and forgets them
+func (k *kayitYayici) al() string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	olaylar := k.olaylar
+	k.olaylar = nil
+	sort.Strings(olaylar)
+	return strings.Join(olaylar, ",")
+}
+
+func (k *kayitYayici) EmitTaskCreated(workspaceID, taskID string, data map[string]interface{}) {
+	k.ekle("created:" + taskID)
+}
+func (k *kayitYayici) EmitTaskUpdated(workspaceID, taskID string, data map[string]interface{}) {
+	k.ekle("updated:" + taskID)
+}
+func (k *kayitYayici) EmitTaskDeleted(workspaceID, taskID string) { k.ekle("deleted:" + taskID) }
+func (k *kayitYayici) EmitProjectCreated(workspaceID, projectID string, data map[string]interface{}) {
+	k.ekle("project_created:" + projectID)
+}
+func (k *kayitYayici) EmitProjectUpdated(workspaceID, projectID string, data map[string]interface{}) {
+	k.ekle("project_updated:" + projectID)
+}
+func (k *kayitYayici) EmitProjectDeleted(workspaceID, projectID string) {
+	k.ekle("project_deleted:" + projectID)
+}
+func (k *kayitYayici) EmitTemplateChanged(workspaceID string) { k.ekle("template_changed") }
+func (k *kayitYayici) EmitWorkspaceSync(workspaceID string)   { k.ekle("sync") }
+
+// yayiciTak attaches a recording event emitter to vy
+func yayiciTak(vy *VeriYonetici) *kayitYayici {
+	yayici := &kayitYayici{}
+	vy.eventEmitter = yayici
+	return yayici
+}
+
+func TestArsivOlaylari(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	addArchiveTestData(t, vy)
+	yayici := yayiciTak(vy)
+	ctx := context.Background()
+
+	// Archived tasks leave the live tables
+	if _, err := iy.GorevleriArsivle(ctx, 30); err != nil {
+		t.Fatalf("GorevleriArsivle failed: %v", err)
+	}
+	if got := yayici.al(); got != "deleted:old-child,deleted:old-done,deleted:old-parent" {
+		t.Errorf("unexpected archive events: %s", got)
+	}
+
+	if _, err := iy.ArsivdenGeriYukle(ctx, "old-child"); err != nil {
+		t.Fatalf("ArsivdenGeriYukle failed: %v", err)
+	}
+	if got := yayici.al(); got != "created:old-child,created:old-parent" {
+		t.Errorf("unexpected restore events: %s", got)
+	}
+
+	// Events go through the cache wrapper too
+	cached := YeniIsYonetici(NewCachedVeriYonetici(vy, CacheOptions{}))
+	if _, err := cached.ArsivdenGeriYukle(ctx, "old-done"); err != nil {
+		t.Fatalf("ArsivdenGeriYukle failed: %v", err)
+	}
+	if got := yayici.al(); got != "created:old-done" {
+		t.Errorf("unexpected restore events through the cache: %s", got)
+	}
+}
+
+func TestBirlestirmeOlaylari(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	addMergeTestData(t, vy)
+	yayici := yayiciTak(vy)
+	ctx := context.Background()
+
+	if _, err := iy.GorevBirlestir(ctx, "dup", "main", true); err != nil {
+		t.Fatalf("GorevBirlestir dry run failed: %v", err)
+	}
+	if got := yayici.al(); got != "" {
+		t.Errorf("a dry run should emit nothing, got %s", got)
+	}
+
+	// The subtree of the source moves into the target's project
+	if _, err := iy.GorevBirlestir(ctx, "dup", "main", false); err != nil {
+		t.Fatalf("GorevBirlestir failed: %v", err)
+	}
+	if got := yayici.al(); got != "deleted:dup,updated:dup-child,updated:dup-grandchild,updated:main" {
+		t.Errorf("unexpected merge events: %s", got)
+	}
+}
+
+func TestKlonlamaOlaylari(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	addCloneTestData(t, vy)
+	yayici := yayiciTak(vy)
+	ctx := context.Background()
+
+	// Each clone is reported once, after the transaction has committed
+	beklenen := func(sonuc *KlonlamaSonucu, proje string) string {
+		var olaylar []string
+		if proje != "" {
+			olaylar = append(olaylar, "project_created:"+proje)
+		}
+		for _, klonID := range sonuc.IDMap {
+			olaylar = append(olaylar, "created:"+klonID)
+		}
+		sort.Strings(olaylar)
+		return strings.Join(olaylar, ",")
+	}
+	sonuc, err := iy.GorevKlonla(ctx, "epic", KlonlamaSecenekleri{})
+	if err != nil {
+		t.Fatalf("GorevKlonla failed: %v", err)
+	}
+	if got, want := yayici.al(), beklenen(sonuc, ""); len(sonuc.IDMap) != 3 || got != want {
+		t.Errorf("unexpected clone events:\n got %s\nwant %s", got, want)
+	}
+
+	sonuc, err = iy.ProjeKlonla(ctx, "test-project-1", KlonlamaSecenekleri{})
+	if err != nil {
+		t.Fatalf("ProjeKlonla failed: %v", err)
+	}
+	if got, want := yayici.al(), beklenen(sonuc, sonuc.Project.ID); got != want {
+		t.Errorf("unexpected project clone events:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestProjeTemplateOlaylari(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	ctx := context.Background()
+	if err := iy.ProjeTemplateKaydet(ctx, releaseTemplate()); err != nil {
+		t.Fatalf("ProjeTemplateKaydet failed: %v", err)
+	}
+	yayici := yayiciTak(vy)
+
+	// Nothing is emitted for a failed apply
+	if _, err := iy.ProjeTemplateUygula(ctx, "release", ProjeTemplateSecenekleri{}); err == nil {
+		t.Fatal("applying without the required version should fail")
+	}
+	if got := yayici.al(); got != "" {
+		t.Errorf("a failed apply should emit nothing, got %s", got)
+	}
+
+	sonuc, err := iy.ProjeTemplateUygula(ctx, "release", ProjeTemplateSecenekleri{Values: map[string]string{"version": "2.4"}})
+	if err != nil {
+		t.Fatalf("ProjeTemplateUygula failed: %v", err)
+	}
+	want := []string{"project_created:" + sonuc.Project.ID}
+	for _, id := range sonuc.TaskIDs {
+		want = append(want, "created:"+id)
+	}
+	sort.Strings(want)
+	if got := yayici.al(); got != strings.Join(want, ",") {
+		t.Errorf("unexpected apply events:\n got %s\nwant %s", got, strings.Join(want, ","))
+	}
+}
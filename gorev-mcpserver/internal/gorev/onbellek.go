@@ -0,0 +1,432 @@
+package gorev
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCacheSize is the number of task and project lookups kept per database
+	DefaultCacheSize = 5000
+	// DefaultCacheTTL bounds how long a lookup is served from the cache. It also bounds
+	// staleness after writes that bypass VeriYonetici, e.g. from another process.
+	DefaultCacheTTL = 30 * time.Second
+)
+
+const (
+	cacheKeyTask          = "g:"
+	cacheKeyProject       = "p:"
+	cacheKeyActiveProject = "aktif"
+)
+
+// CacheOptions configures the read-through cache of CachedVeriYonetici
+type CacheOptions struct {
+	MaxEntries int           // Entries kept before the least recently used one is evicted
+	TTL        time.Duration // Lifetime of an entry
+}
+
+// CacheStats describes the lookups served by a CachedVeriYonetici
+type CacheStats struct {
+	Entries       int     `json:"entries"`
+	MaxEntries    int     `json:"max_entries"`
+	TTLSeconds    float64 `json:"ttl_seconds"`
+	Hits          int64   `json:"hits"`
+	Misses        int64   `json:"misses"`
+	HitRate       float64 `json:"hit_rate"`
+	Evictions     int64   `json:"evictions"`
+	Invalidations int64   `json:"invalidations"`
+}
+
+type cacheEntry struct {
+	key       string
+	value     interface{}
+	expiresAt time.Time
+}
+
+// CachedVeriYonetici wraps a VeriYoneticiInterface with an in-process read-through cache
+// for GorevGetir, ProjeGetir and AktifProjeGetir. Every mutation of the interface goes
+// through this wrapper and changes the Generation; those that change a cached row also
+// drop the affected entries. Reads are passed through unchanged. Cached tasks and
+// projects are copied on the way in and out, so callers may modify what they get.
+type CachedVeriYonetici struct {
+	VeriYoneticiInterface
+
+	options CacheOptions
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // Front is the most recently used entry
+	// generation changes on every invalidation, so that a lookup which started before
+	// a write does not store the row it read before the write
+	generation uint64
+
+	hits          int64
+	misses        int64
+	evictions     int64
+	invalidations int64
+}
+
+// NewCachedVeriYonetici wraps inner with a cache. Zero options use DefaultCacheSize and DefaultCacheTTL.
+func NewCachedVeriYonetici(inner VeriYoneticiInterface, options CacheOptions) *CachedVeriYonetici {
+	if options.MaxEntries <= 0 {
+		options.MaxEntries = DefaultCacheSize
+	}
+	if options.TTL <= 0 {
+		options.TTL = DefaultCacheTTL
+	}
+	return &CachedVeriYonetici{
+		VeriYoneticiInterface: inner,
+		options:               options,
+		entries:               make(map[string]*list.Element),
+		lru:                   list.New(),
+	}
+}
+
+// Inner returns the wrapped data manager
+func (c *CachedVeriYonetici) Inner() VeriYoneticiInterface {
+	return c.VeriYoneticiInterface
+}
+
+// get returns a live entry and the current generation
+func (c *CachedVeriYonetici) get(key string) (interface{}, bool, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*cacheEntry)
+		if time.Now().Before(entry.expiresAt) {
+			c.lru.MoveToFront(element)
+			c.hits++
+			return entry.value, true, c.generation
+		}
+		c.lru.Remove(element)
+		delete(c.entries, key)
+	}
+	c.misses++
+	return nil, false, c.generation
+}
+
+// put stores value unless an invalidation happened since the lookup that read it
+func (c *CachedVeriYonetici) put(key string, value interface{}, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	expiresAt := time.Now().Add(c.options.TTL)
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*cacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.lru.Len() > c.options.MaxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+		c.evictions++
+	}
+}
+
+// invalidate drops the given keys
+func (c *CachedVeriYonetici) invalidate(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.invalidations++
+	for _, key := range keys {
+		if element, ok := c.entries[key]; ok {
+			c.lru.Remove(element)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// invalidatePrefix drops every key starting with prefix
+func (c *CachedVeriYonetici) invalidatePrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.invalidations++
+	for key, element := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.lru.Remove(element)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// OnbellegiTemizle drops all entries. Callers that write through GetDB use it
+// after their changes.
+func (c *CachedVeriYonetici) OnbellegiTemizle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.invalidations++
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+}
+
+// Generation changes on every invalidation, i.e. after every write through the cache.
+// Derived in-memory views such as the CompletionIndex compare it to notice writes.
+func (c *CachedVeriYonetici) Generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// CacheStats returns hit rate and size of the cache
+func (c *CachedVeriYonetici) CacheStats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := CacheStats{
+		Entries:       c.lru.Len(),
+		MaxEntries:    c.options.MaxEntries,
+		TTLSeconds:    c.options.TTL.Seconds(),
+		Hits:          c.hits,
+		Misses:        c.misses,
+		Evictions:     c.evictions,
+		Invalidations: c.invalidations,
+	}
+	if total := c.hits + c.misses; total > 0 {
+		stats.HitRate = float64(c.hits) / float64(total)
+	}
+	return stats
+}
+
+// WriteQueueStats reports the write queue of the wrapped VeriYonetici
+func (c *CachedVeriYonetici) WriteQueueStats() WriteQueueStats {
+	if reporter, ok := c.VeriYoneticiInterface.(interface{ WriteQueueStats() WriteQueueStats }); ok {
+		return reporter.WriteQueueStats()
+	}
+	return WriteQueueStats{}
+}
+
+// Read-through lookups
+
+func (c *CachedVeriYonetici) GorevGetir(ctx context.Context, id string) (*Gorev, error) {
+	key := cacheKeyTask + id
+	value, ok, generation := c.get(key)
+	if ok {
+		return cloneGorev(value.(*Gorev)), nil
+	}
+
+	gorev, err := c.VeriYoneticiInterface.GorevGetir(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.put(key, cloneGorev(gorev), generation)
+	return gorev, nil
+}
+
+func (c *CachedVeriYonetici) ProjeGetir(ctx context.Context, id string) (*Proje, error) {
+	key := cacheKeyProject + id
+	value, ok, generation := c.get(key)
+	if ok {
+		proje := *value.(*Proje)
+		return &proje, nil
+	}
+
+	proje, err := c.VeriYoneticiInterface.ProjeGetir(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	cached := *proje
+	c.put(key, &cached, generation)
+	return proje, nil
+}
+
+func (c *CachedVeriYonetici) AktifProjeGetir(ctx context.Context) (string, error) {
+	value, ok, generation := c.get(cacheKeyActiveProject)
+	if ok {
+		return value.(string), nil
+	}
+
+	projeID, err := c.VeriYoneticiInterface.AktifProjeGetir(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.put(cacheKeyActiveProject, projeID, generation)
+	return projeID, nil
+}
+
+// Mutations that change cached rows. Entries are dropped after the write, whether it
+// failed or not, since a failed write may still have changed the row.
+
+func (c *CachedVeriYonetici) GorevKaydet(ctx context.Context, gorev *Gorev) error {
+	defer c.invalidate(cacheKeyTask + gorev.ID)
+	return c.VeriYoneticiInterface.GorevKaydet(ctx, gorev)
+}
+
+func (c *CachedVeriYonetici) GorevGuncelle(ctx context.Context, taskID string, params interface{}) error {
+	defer c.invalidate(cacheKeyTask + taskID)
+	return c.VeriYoneticiInterface.GorevGuncelle(ctx, taskID, params)
+}
+
+// GorevSil drops all cached tasks, since deleting a task also removes its subtasks
+func (c *CachedVeriYonetici) GorevSil(ctx context.Context, id string) error {
+	defer c.invalidatePrefix(cacheKeyTask)
+	return c.VeriYoneticiInterface.GorevSil(ctx, id)
+}
+
+func (c *CachedVeriYonetici) GorevEtiketleriniAyarla(ctx context.Context, gorevID string, etiketler []*Etiket) error {
+	defer c.invalidate(cacheKeyTask + gorevID)
+	return c.VeriYoneticiInterface.GorevEtiketleriniAyarla(ctx, gorevID, etiketler)
+}
+
+func (c *CachedVeriYonetici) ParentIDGuncelle(ctx context.Context, gorevID, yeniParentID string) error {
+	defer c.invalidate(cacheKeyTask + gorevID)
+	return c.VeriYoneticiInterface.ParentIDGuncelle(ctx, gorevID, yeniParentID)
+}
+
+// AltAgacProjesiniGuncelle drops all cached tasks, since the whole subtree moves
+func (c *CachedVeriYonetici) AltAgacProjesiniGuncelle(ctx context.Context, gorevID, projeID string) error {
+	defer c.invalidatePrefix(cacheKeyTask)
+	return c.VeriYoneticiInterface.AltAgacProjesiniGuncelle(ctx, gorevID, projeID)
+}
+
+func (c *CachedVeriYonetici) ProjeKaydet(ctx context.Context, proje *Proje) error {
+	defer c.invalidate(cacheKeyProject + proje.ID)
+	return c.VeriYoneticiInterface.ProjeKaydet(ctx, proje)
+}
+
+func (c *CachedVeriYonetici) AktifProjeAyarla(ctx context.Context, projeID string) error {
+	defer c.invalidate(cacheKeyActiveProject)
+	return c.VeriYoneticiInterface.AktifProjeAyarla(ctx, projeID)
+}
+
+func (c *CachedVeriYonetici) AktifProjeKaldir(ctx context.Context) error {
+	defer c.invalidate(cacheKeyActiveProject)
+	return c.VeriYoneticiInterface.AktifProjeKaldir(ctx)
+}
+
+func (c *CachedVeriYonetici) GorevSonAIEtkilesiminiGuncelle(taskID string, timestamp time.Time) error {
+	defer c.invalidate(cacheKeyTask + taskID)
+	return c.VeriYoneticiInterface.GorevSonAIEtkilesiminiGuncelle(taskID, timestamp)
+}
+
+func (c *CachedVeriYonetici) AILastInteractionGuncelle(taskID string, timestamp time.Time) error {
+	defer c.invalidate(cacheKeyTask + taskID)
+	return c.VeriYoneticiInterface.AILastInteractionGuncelle(taskID, timestamp)
+}
+
+// Mutations that only add rows or change rows the cache does not hold. They change
+// the Generation, so that derived views such as the CompletionIndex see them.
+
+func (c *CachedVeriYonetici) GorevOlustur(ctx context.Context, params map[string]interface{}) (string, error) {
+	defer c.invalidate()
+	return c.VeriYoneticiInterface.GorevOlustur(ctx, params)
+}
+
+func (c *CachedVeriYonetici) AltGorevOlustur(ctx context.Context, parentID, baslik, aciklama, oncelik, sonTarihStr string, etiketIsimleri []string) (*Gorev, error) {
+	defer c.invalidate()
+	return c.VeriYoneticiInterface.AltGorevOlustur(ctx, parentID, baslik, aciklama, oncelik, sonTarihStr, etiketIsimleri)
+}
+
+func (c *CachedVeriYonetici) TemplatedenGorevOlustur(ctx context.Context, templateID string, degerler map[string]string) (*Gorev, error) {
+	defer c.invalidate()
+	return c.VeriYoneticiInterface.TemplatedenGorevOlustur(ctx, templateID, degerler)
+}
+
+func (c *CachedVeriYonetici) TemplateOlustur(ctx context.Context, template *GorevTemplate) error {
+	defer c.invalidate()
+	return c.VeriYoneticiInterface.TemplateOlustur(ctx, template)
+}
+
+func (c *CachedVeriYonetici) VarsayilanTemplateleriOlustur(ctx context.Context) error {
+	defer c.invalidate()
+	return c.VeriYoneticiInterface.VarsayilanTemplateleriOlustur(ctx)
+}
+
+// EtiketleriGetirVeyaOlustur creates the tags that do not exist yet
+func (c *CachedVeriYonetici) EtiketleriGetirVeyaOlustur(ctx context.Context, isimler []string) ([]*Etiket, error) {
+	defer c.invalidate()
+	return c.VeriYoneticiInterface.EtiketleriGetirVeyaOlustur(ctx, isimler)
+}
+
+func (c *CachedVeriYonetici) BaglantiEkle(ctx context.Context, baglanti *Baglanti) error {
+	defer c.invalidate(cacheKeyTask+baglanti.SourceID, cacheKeyTask+baglanti.TargetID)
+	return c.VeriYoneticiInterface.BaglantiEkle(ctx, baglanti)
+}
+
+func (c *CachedVeriYonetici) BaglantiSil(ctx context.Context, kaynakID, hedefID string) error {
+	defer c.invalidate(cacheKeyTask+kaynakID, cacheKeyTask+hedefID)
+	return c.VeriYoneticiInterface.BaglantiSil(ctx, kaynakID, hedefID)
+}
+
+func (c *CachedVeriYonetici) GorevDosyaYoluEkle(taskID string, path string) error {
+	defer c.invalidate()
+	return c.VeriYoneticiInterface.GorevDosyaYoluEkle(taskID, path)
+}
+
+func (c *CachedVeriYonetici) GorevDosyaYoluSil(taskID string, path string) error {
+	defer c.invalidate()
+	return c.VeriYoneticiInterface.GorevDosyaYoluSil(taskID, path)
+}
+
+func (c *CachedVeriYonetici) AIContextKaydet(context *AIContext) error {
+	defer c.invalidate()
+	return c.VeriYoneticiInterface.AIContextKaydet(context)
+}
+
+func (c *CachedVeriYonetici) AIInteractionKaydet(interaction *AIInteraction) error {
+	defer c.invalidate()
+	return c.VeriYoneticiInterface.AIInteractionKaydet(interaction)
+}
+
+func (c *CachedVeriYonetici) AIEtkilemasimKaydet(taskID string, interactionType, data, sessionID string) error {
+	defer c.invalidate()
+	return c.VeriYoneticiInterface.AIEtkilemasimKaydet(taskID, interactionType, data, sessionID)
+}
+
+// YazmaIslemi may change any row, so the whole cache is dropped afterwards
+func (c *CachedVeriYonetici) YazmaIslemi(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	defer c.OnbellegiTemizle()
+	return c.VeriYoneticiInterface.YazmaIslemi(ctx, fn)
+}
+
+// YardimciYazmaIslemi does not invalidate the cache; fn writes no task or project data
+func (c *CachedVeriYonetici) YardimciYazmaIslemi(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return c.VeriYoneticiInterface.YardimciYazmaIslemi(ctx, fn)
+}
+
+// Kapat drops the cache and closes the wrapped data manager
+func (c *CachedVeriYonetici) Kapat() error {
+	c.OnbellegiTemizle()
+	return c.VeriYoneticiInterface.Kapat()
+}
+
+// cloneGorev copies a task together with its tags and due date
+func cloneGorev(gorev *Gorev) *Gorev {
+	clone := *gorev
+	if gorev.DueDate != nil {
+		dueDate := *gorev.DueDate
+		clone.DueDate = &dueDate
+	}
+	if gorev.Tags != nil {
+		clone.Tags = make([]*Etiket, len(gorev.Tags))
+		for i, etiket := range gorev.Tags {
+			tag := *etiket
+			clone.Tags[i] = &tag
+		}
+	}
+	if gorev.Subtasks != nil {
+		clone.Subtasks = append([]*Gorev(nil), gorev.Subtasks...)
+	}
+	if gorev.Bagimliliklar != nil {
+		clone.Bagimliliklar = append([]Bagimlilik(nil), gorev.Bagimliliklar...)
+	}
+	return &clone
+}
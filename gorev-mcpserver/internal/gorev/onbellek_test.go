@@ -0,0 +1,173 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCachedTestVeriYonetici(t *testing.T, options CacheOptions) (*CachedVeriYonetici, *VeriYonetici) {
+	t.Helper()
+	vy, err := YeniVeriYonetici(":memory:", "file://../../internal/veri/migrations")
+	require.NoError(t, err)
+	cache := NewCachedVeriYonetici(vy, options)
+	t.Cleanup(func() { _ = cache.Kapat() })
+	return cache, vy
+}
+
+func TestCachedVeriYonetici_ReadThroughAndInvalidation(t *testing.T) {
+	cache, _ := newCachedTestVeriYonetici(t, CacheOptions{})
+	ctx := context.Background()
+
+	require.NoError(t, cache.ProjeKaydet(ctx, &Proje{ID: "p1", Name: "Backend", CreatedAt: time.Now(), UpdatedAt: time.Now()}))
+	require.NoError(t, cache.GorevKaydet(ctx, &Gorev{ID: "g1", Title: "Eski", Status: "beklemede", Priority: "orta", ProjeID: "p1", CreatedAt: time.Now(), UpdatedAt: time.Now()}))
+
+	first, err := cache.GorevGetir(ctx, "g1")
+	require.NoError(t, err)
+	second, err := cache.GorevGetir(ctx, "g1")
+	require.NoError(t, err)
+	assert.Equal(t, first.Title, second.Title)
+	stats := cache.CacheStats()
+	assert.Equal(t, int64(1), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.InDelta(t, 0.5, stats.HitRate, 0.001)
+
+	// Callers get copies, not the cached row
+	second.Title = "Local change"
+	third, err := cache.GorevGetir(ctx, "g1")
+	require.NoError(t, err)
+	assert.Equal(t, "Eski", third.Title)
+
+	require.NoError(t, cache.GorevGuncelle(ctx, "g1", map[string]interface{}{"title": "Yeni"}))
+	updated, err := cache.GorevGetir(ctx, "g1")
+	require.NoError(t, err)
+	assert.Equal(t, "Yeni", updated.Title)
+
+	etiketler, err := cache.EtiketleriGetirVeyaOlustur(ctx, []string{"api"})
+	require.NoError(t, err)
+	require.NoError(t, cache.GorevEtiketleriniAyarla(ctx, "g1", etiketler))
+	tagged, err := cache.GorevGetir(ctx, "g1")
+	require.NoError(t, err)
+	require.Len(t, tagged.Tags, 1)
+	assert.Equal(t, "api", tagged.Tags[0].Name)
+
+	require.NoError(t, cache.AktifProjeAyarla(ctx, "p1"))
+	aktif, err := cache.AktifProjeGetir(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "p1", aktif)
+	require.NoError(t, cache.AktifProjeKaldir(ctx))
+	aktif, err = cache.AktifProjeGetir(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, aktif)
+
+	require.NoError(t, cache.GorevSil(ctx, "g1"))
+	_, err = cache.GorevGetir(ctx, "g1")
+	assert.Error(t, err)
+}
+
+func TestCachedVeriYonetici_TransactionsDropCache(t *testing.T) {
+	cache, _ := newCachedTestVeriYonetici(t, CacheOptions{})
+	ctx := context.Background()
+
+	require.NoError(t, cache.ProjeKaydet(ctx, &Proje{ID: "p1", Name: "Eski", CreatedAt: time.Now(), UpdatedAt: time.Now()}))
+	_, err := cache.ProjeGetir(ctx, "p1")
+	require.NoError(t, err)
+
+	require.NoError(t, cache.YazmaIslemi(ctx, func(tx *sql.Tx) error {
+		_, err := tx.Exec("UPDATE projeler SET name = 'Yeni' WHERE id = 'p1'")
+		return err
+	}))
+	proje, err := cache.ProjeGetir(ctx, "p1")
+	require.NoError(t, err)
+	assert.Equal(t, "Yeni", proje.Name)
+}
+
+func TestCachedVeriYonetici_EveryWriteChangesGeneration(t *testing.T) {
+	cache, _ := newCachedTestVeriYonetici(t, CacheOptions{})
+	ctx := context.Background()
+	require.NoError(t, cache.ProjeKaydet(ctx, &Proje{ID: "p1", Name: "Backend", CreatedAt: time.Now(), UpdatedAt: time.Now()}))
+	require.NoError(t, cache.GorevKaydet(ctx, &Gorev{ID: "g1", Title: "Parent", Status: "beklemede", Priority: "orta", ProjeID: "p1", CreatedAt: time.Now(), UpdatedAt: time.Now()}))
+	require.NoError(t, cache.GorevKaydet(ctx, &Gorev{ID: "g2", Title: "Other", Status: "beklemede", Priority: "orta", ProjeID: "p1", CreatedAt: time.Now(), UpdatedAt: time.Now()}))
+
+	// Failed writes count too, since they may still have changed rows
+	writes := map[string]func() error{
+		"GorevOlustur": func() error {
+			_, err := cache.GorevOlustur(ctx, map[string]interface{}{"title": "Created", "project_id": "p1"})
+			return err
+		},
+		"AltGorevOlustur": func() error {
+			_, err := cache.AltGorevOlustur(ctx, "g1", "Child", "", "orta", "", nil)
+			return err
+		},
+		"TemplatedenGorevOlustur": func() error {
+			_, err := cache.TemplatedenGorevOlustur(ctx, "missing", map[string]string{})
+			return err
+		},
+		"TemplateOlustur": func() error {
+			return cache.TemplateOlustur(ctx, &GorevTemplate{Name: "Custom", Category: "Test", DefaultTitle: "Custom"})
+		},
+		"VarsayilanTemplateleriOlustur": func() error { return cache.VarsayilanTemplateleriOlustur(ctx) },
+		"EtiketleriGetirVeyaOlustur": func() error {
+			_, err := cache.EtiketleriGetirVeyaOlustur(ctx, []string{"new-tag"})
+			return err
+		},
+		"BaglantiEkle": func() error {
+			return cache.BaglantiEkle(ctx, &Baglanti{ID: "b1", SourceID: "g1", TargetID: "g2", ConnectionType: "onceki"})
+		},
+		"BaglantiSil":        func() error { return cache.BaglantiSil(ctx, "g1", "g2") },
+		"GorevDosyaYoluEkle": func() error { return cache.GorevDosyaYoluEkle("g1", "main.go") },
+		"GorevDosyaYoluSil":  func() error { return cache.GorevDosyaYoluSil("g1", "main.go") },
+		"AIContextKaydet":    func() error { return cache.AIContextKaydet(&AIContext{ActiveTaskID: "g1"}) },
+		"AIInteractionKaydet": func() error {
+			return cache.AIInteractionKaydet(&AIInteraction{GorevID: "g1", ActionType: "viewed", Timestamp: time.Now()})
+		},
+		"AIEtkilemasimKaydet":            func() error { return cache.AIEtkilemasimKaydet("g1", "viewed", "", "s1") },
+		"AILastInteractionGuncelle":      func() error { return cache.AILastInteractionGuncelle("g1", time.Now()) },
+		"GorevSonAIEtkilesiminiGuncelle": func() error { return cache.GorevSonAIEtkilesiminiGuncelle("g1", time.Now()) },
+	}
+	for name, write := range writes {
+		before := cache.Generation()
+		_ = write()
+		assert.NotEqual(t, before, cache.Generation(), "%s should change the generation", name)
+	}
+}
+
+func TestCachedVeriYonetici_Limits(t *testing.T) {
+	cache, vy := newCachedTestVeriYonetici(t, CacheOptions{MaxEntries: 2, TTL: 50 * time.Millisecond})
+	ctx := context.Background()
+
+	for _, id := range []string{"p1", "p2", "p3"} {
+		require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: id, Name: id, CreatedAt: time.Now(), UpdatedAt: time.Now()}))
+		_, err := cache.ProjeGetir(ctx, id)
+		require.NoError(t, err)
+	}
+	stats := cache.CacheStats()
+	assert.Equal(t, 2, stats.Entries)
+	assert.Equal(t, int64(1), stats.Evictions)
+
+	// p1 was evicted, p3 is still cached until the TTL passes
+	_, err := cache.ProjeGetir(ctx, "p3")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), cache.CacheStats().Hits)
+
+	time.Sleep(60 * time.Millisecond)
+	_, err = cache.ProjeGetir(ctx, "p3")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), cache.CacheStats().Hits)
+}
+
+func TestCachedVeriYonetici_StaleReadIsNotStored(t *testing.T) {
+	cache, _ := newCachedTestVeriYonetici(t, CacheOptions{})
+
+	// A lookup that started before a write must not store the row it read
+	_, ok, generation := cache.get(cacheKeyProject + "p1")
+	require.False(t, ok)
+	cache.invalidate(cacheKeyProject + "p1")
+	cache.put(cacheKeyProject+"p1", &Proje{ID: "p1", Name: "Stale"}, generation)
+
+	assert.Equal(t, 0, cache.CacheStats().Entries)
+}
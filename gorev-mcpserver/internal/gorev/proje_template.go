@@ -0,0 +1,492 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// ProjeTemplate proje şablonu: parametreli bir görev ağacı (project blueprint). Applying
+// it creates all tasks with their subtasks, dependencies, tags and due dates at once.
+type ProjeTemplate struct {
+	ID         string         `json:"id"`
+	Name       string         `json:"name"` // Default name of created projects; may use {{field}}
+	Alias      string         `json:"alias,omitempty"`
+	Definition string         `json:"definition"`
+	Fields     []TemplateAlan `json:"fields,omitempty"` // Parameters substituted as {{name}} in all tasks
+	// DefaultTags are added to every task of the template
+	DefaultTags []string              `json:"default_tags,omitempty"`
+	Tasks       []ProjeTemplateGorevi `json:"tasks"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+// ProjeTemplateGorevi is a task node of a project template
+type ProjeTemplateGorevi struct {
+	Key         string   `json:"key,omitempty"` // Referenced by depends_on of other nodes
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Priority    string   `json:"priority,omitempty"` // Default: orta
+	Tags        []string `json:"tags,omitempty"`
+	// Due is the offset of the due date from the start date: "T+3d", "2w", "1m", "T-1d"
+	Due       string                `json:"due,omitempty"`
+	DependsOn []string              `json:"depends_on,omitempty"` // Keys of the nodes that must be completed first
+	Subtasks  []ProjeTemplateGorevi `json:"subtasks,omitempty"`
+}
+
+// ProjeTemplateSecenekleri controls how a project template is applied
+type ProjeTemplateSecenekleri struct {
+	ProjectID   string            `json:"project_id,omitempty"`   // Populate this project instead of creating one
+	ProjectName string            `json:"project_name,omitempty"` // Name of the created project; default the template name
+	StartDate   string            `json:"start_date,omitempty"`   // Base of the due offsets (YYYY-MM-DD); default today
+	Values      map[string]string `json:"values,omitempty"`       // Values of the template fields
+}
+
+// ProjeTemplateSonucu describes the tasks created from a project template
+type ProjeTemplateSonucu struct {
+	Project        *Proje            `json:"project"`
+	ProjectCreated bool              `json:"project_created"`
+	Tasks          int               `json:"tasks"`
+	Links          int               `json:"links"`
+	Tags           int               `json:"tags"`
+	TaskIDs        map[string]string `json:"task_ids"` // Node key → task ID
+}
+
+// templateOfsetiDeseni matches due offsets like "T+3d", "+2w", "1m" and "T-1d"
+var templateOfsetiDeseni = regexp.MustCompile(`(?i)^(?:T\s*)?([+-]?)\s*(\d+)\s*([dwm])$`)
+
+// templateOfseti parses a due offset into months and days
+func templateOfseti(ofset string) (ay, gun int, err error) {
+	ofset = strings.TrimSpace(ofset)
+	if strings.EqualFold(ofset, "T") {
+		return 0, 0, nil
+	}
+	eslesme := templateOfsetiDeseni.FindStringSubmatch(ofset)
+	if eslesme == nil {
+		return 0, 0, fmt.Errorf(i18n.T("error.projectTemplateInvalidDue", map[string]interface{}{"Due": ofset}))
+	}
+	sayi, err := strconv.Atoi(eslesme[2])
+	if err != nil {
+		return 0, 0, fmt.Errorf(i18n.T("error.projectTemplateInvalidDue", map[string]interface{}{"Due": ofset}))
+	}
+	if eslesme[1] == "-" {
+		sayi = -sayi
+	}
+	switch strings.ToLower(eslesme[3]) {
+	case "w":
+		return 0, sayi * 7, nil
+	case "m":
+		return sayi, 0, nil
+	}
+	return 0, sayi, nil
+}
+
+// projeTemplateGorevleri returns the nodes of a template in pre-order, parents before
+// their subtasks, together with the index of each node's parent (-1 for roots)
+func projeTemplateGorevleri(gorevler []ProjeTemplateGorevi) ([]*ProjeTemplateGorevi, []int) {
+	var dugumler []*ProjeTemplateGorevi
+	var ebeveynler []int
+	var gez func(gorevler []ProjeTemplateGorevi, ebeveyn int)
+	gez = func(gorevler []ProjeTemplateGorevi, ebeveyn int) {
+		for i := range gorevler {
+			dugumler = append(dugumler, &gorevler[i])
+			ebeveynler = append(ebeveynler, ebeveyn)
+			gez(gorevler[i].Subtasks, len(dugumler)-1)
+		}
+	}
+	gez(gorevler, -1)
+	return dugumler, ebeveynler
+}
+
+// projeTemplateDogrula checks titles, priorities, due offsets, keys and dependencies
+func projeTemplateDogrula(template *ProjeTemplate) error {
+	if strings.TrimSpace(template.Name) == "" {
+		return fmt.Errorf(i18n.T("error.projectTemplateNameRequired"))
+	}
+	dugumler, _ := projeTemplateGorevleri(template.Tasks)
+	if len(dugumler) == 0 {
+		return fmt.Errorf(i18n.T("error.projectTemplateNoTasks"))
+	}
+	for _, alan := range template.Fields {
+		if strings.TrimSpace(alan.Name) == "" {
+			return fmt.Errorf(i18n.T("error.projectTemplateFieldNameRequired"))
+		}
+	}
+
+	anahtarlar := make(map[string]*ProjeTemplateGorevi, len(dugumler))
+	for i, dugum := range dugumler {
+		if strings.TrimSpace(dugum.Title) == "" {
+			return fmt.Errorf(i18n.T("error.projectTemplateTaskTitleRequired", map[string]interface{}{"Index": i + 1}))
+		}
+		if dugum.Priority != "" && !constants.IsValidPriority(dugum.Priority) {
+			return fmt.Errorf(i18n.T("error.invalidPriorityBatch", map[string]interface{}{"Priority": dugum.Priority}))
+		}
+		if dugum.Due != "" {
+			if _, _, err := templateOfseti(dugum.Due); err != nil {
+				return err
+			}
+		}
+		if dugum.Key == "" {
+			continue
+		}
+		if _, mevcut := anahtarlar[dugum.Key]; mevcut {
+			return fmt.Errorf(i18n.T("error.projectTemplateDuplicateKey", map[string]interface{}{"Key": dugum.Key}))
+		}
+		anahtarlar[dugum.Key] = dugum
+	}
+
+	for _, dugum := range dugumler {
+		for _, anahtar := range dugum.DependsOn {
+			if _, mevcut := anahtarlar[anahtar]; !mevcut || anahtar == dugum.Key {
+				return fmt.Errorf(i18n.T("error.projectTemplateUnknownDependency", map[string]interface{}{"Task": dugum.Title, "Key": anahtar}))
+			}
+		}
+	}
+
+	// Dependencies must not form a cycle; depth-first search with three colors
+	durum := make(map[string]int, len(anahtarlar))
+	var ziyaret func(anahtar string) error
+	ziyaret = func(anahtar string) error {
+		switch durum[anahtar] {
+		case 1:
+			return fmt.Errorf(i18n.T("error.projectTemplateDependencyCycle", map[string]interface{}{"Key": anahtar}))
+		case 2:
+			return nil
+		}
+		durum[anahtar] = 1
+		for _, onceki := range anahtarlar[anahtar].DependsOn {
+			if err := ziyaret(onceki); err != nil {
+				return err
+			}
+		}
+		durum[anahtar] = 2
+		return nil
+	}
+	for anahtar := range anahtarlar {
+		if err := ziyaret(anahtar); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ProjeTemplateKaydet validates and stores a new project template
+func (iy *IsYonetici) ProjeTemplateKaydet(ctx context.Context, template *ProjeTemplate) error {
+	db, err := iy.sqlOkumaDB("project templates")
+	if err != nil {
+		return err
+	}
+	if err := projeTemplateDogrula(template); err != nil {
+		return err
+	}
+	if template.Alias != "" {
+		var mevcut bool
+		if err := db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM proje_templateleri WHERE alias = ?)`, template.Alias).Scan(&mevcut); err != nil {
+			return fmt.Errorf(i18n.TSaveFailed(i18n.FromContext(ctx), "template", err))
+		}
+		if mevcut {
+			return fmt.Errorf(i18n.T("error.projectTemplateAliasExists", map[string]interface{}{"Alias": template.Alias}))
+		}
+	}
+
+	alanlarJSON, err := json.Marshal(template.Fields)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.fieldsJsonFailed", map[string]interface{}{"Error": err}))
+	}
+	etiketlerJSON, err := json.Marshal(template.DefaultTags)
+	if err != nil {
+		return fmt.Errorf(i18n.TSaveFailed(i18n.FromContext(ctx), "template", err))
+	}
+	gorevlerJSON, err := json.Marshal(template.Tasks)
+	if err != nil {
+		return fmt.Errorf(i18n.TSaveFailed(i18n.FromContext(ctx), "template", err))
+	}
+
+	template.ID = uuid.New().String()
+	template.CreatedAt = time.Now()
+	template.UpdatedAt = template.CreatedAt
+	err = iy.veriYonetici.YazmaIslemi(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO proje_templateleri
+			(id, name, alias, definition, fields, default_tags, tasks, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			template.ID, template.Name, sql.NullString{String: template.Alias, Valid: template.Alias != ""},
+			template.Definition, string(alanlarJSON), string(etiketlerJSON), string(gorevlerJSON),
+			template.CreatedAt, template.UpdatedAt)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf(i18n.TCreateFailed(i18n.FromContext(ctx), "template", err))
+	}
+	return nil
+}
+
+// projeTemplateKolonlari are the columns read by projeTemplateTara
+const projeTemplateKolonlari = `id, name, alias, definition, fields, default_tags, tasks, created_at, updated_at`
+
+// projeTemplateTara reads a project template row
+func projeTemplateTara(satir interface{ Scan(...interface{}) error }) (*ProjeTemplate, error) {
+	template := &ProjeTemplate{}
+	var alias sql.NullString
+	var alanlarJSON, etiketlerJSON, gorevlerJSON string
+	if err := satir.Scan(&template.ID, &template.Name, &alias, &template.Definition,
+		&alanlarJSON, &etiketlerJSON, &gorevlerJSON, &template.CreatedAt, &template.UpdatedAt); err != nil {
+		return nil, err
+	}
+	template.Alias = alias.String
+	if err := json.Unmarshal([]byte(alanlarJSON), &template.Fields); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(etiketlerJSON), &template.DefaultTags); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(gorevlerJSON), &template.Tasks); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// ProjeTemplateListele lists the stored project templates by name
+func (iy *IsYonetici) ProjeTemplateListele(ctx context.Context) ([]*ProjeTemplate, error) {
+	db, err := iy.sqlOkumaDB("project templates")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, `SELECT `+projeTemplateKolonlari+` FROM proje_templateleri ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.TListFailed(i18n.FromContext(ctx), "template", err))
+	}
+	defer func() { _ = rows.Close() }()
+
+	templates := []*ProjeTemplate{}
+	for rows.Next() {
+		template, err := projeTemplateTara(rows)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("error.templateReadFailed", map[string]interface{}{"Error": err}))
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+// ProjeTemplateGetir returns a project template by ID or alias
+func (iy *IsYonetici) ProjeTemplateGetir(ctx context.Context, idVeyaAlias string) (*ProjeTemplate, error) {
+	db, err := iy.sqlOkumaDB("project templates")
+	if err != nil {
+		return nil, err
+	}
+	template, err := projeTemplateTara(db.QueryRowContext(ctx,
+		`SELECT `+projeTemplateKolonlari+` FROM proje_templateleri WHERE id = ? OR alias = ?`, idVeyaAlias, idVeyaAlias))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf(i18n.T("error.projectTemplateNotFound", map[string]interface{}{"ID": idVeyaAlias}))
+	}
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.templateReadFailed", map[string]interface{}{"Error": err}))
+	}
+	return template, nil
+}
+
+// ProjeTemplateSil deletes a project template by ID or alias; projects created from it stay
+func (iy *IsYonetici) ProjeTemplateSil(ctx context.Context, idVeyaAlias string) error {
+	template, err := iy.ProjeTemplateGetir(ctx, idVeyaAlias)
+	if err != nil {
+		return err
+	}
+	return iy.veriYonetici.YazmaIslemi(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM proje_templateleri WHERE id = ?`, template.ID)
+		return err
+	})
+}
+
+// projeTemplateDegerleri completes the given values with field defaults and checks
+// that every required field has a value
+func projeTemplateDegerleri(template *ProjeTemplate, degerler map[string]string) (map[string]string, error) {
+	sonuc := make(map[string]string, len(template.Fields)+len(degerler))
+	for anahtar, deger := range degerler {
+		sonuc[anahtar] = deger
+	}
+	for _, alan := range template.Fields {
+		if deger, ok := sonuc[alan.Name]; ok && deger != "" {
+			continue
+		}
+		if alan.Default != "" {
+			sonuc[alan.Name] = alan.Default
+			continue
+		}
+		if alan.Required {
+			return nil, fmt.Errorf(i18n.T("error.requiredFieldMissing", map[string]interface{}{"Field": alan.Name}))
+		}
+	}
+	return sonuc, nil
+}
+
+// templateDoldur replaces the {{field}} placeholders of metin
+func templateDoldur(metin string, degerler map[string]string) string {
+	for anahtar, deger := range degerler {
+		metin = strings.ReplaceAll(metin, "{{"+anahtar+"}}", deger)
+	}
+	return metin
+}
+
+// ProjeTemplateUygula creates the task tree of a project template in a new project or,
+// with secenekler.ProjectID, in an existing one. The project, tasks, tags and
+// dependencies are written in one transaction, so a failure leaves nothing behind.
+func (iy *IsYonetici) ProjeTemplateUygula(ctx context.Context, idVeyaAlias string, secenekler ProjeTemplateSecenekleri) (*ProjeTemplateSonucu, error) {
+	template, err := iy.ProjeTemplateGetir(ctx, idVeyaAlias)
+	if err != nil {
+		return nil, err
+	}
+	degerler, err := projeTemplateDegerleri(template, secenekler.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	baslangic := time.Now()
+	if secenekler.StartDate != "" {
+		if baslangic, err = time.ParseInLocation(constants.DateFormatISO, secenekler.StartDate, time.Local); err != nil {
+			return nil, fmt.Errorf(i18n.T("error.invalidDateFormat", map[string]interface{}{"Error": err}))
+		}
+	}
+	baslangic = time.Date(baslangic.Year(), baslangic.Month(), baslangic.Day(), 0, 0, 0, 0, baslangic.Location())
+
+	simdi := time.Now()
+	workspaceID := iy.workspaceID
+	if workspaceID == "" {
+		workspaceID = "default"
+	}
+	sonuc := &ProjeTemplateSonucu{TaskIDs: make(map[string]string)}
+	if secenekler.ProjectID != "" {
+		if sonuc.Project, err = iy.veriYonetici.ProjeGetir(ctx, secenekler.ProjectID); err != nil {
+			return nil, fmt.Errorf(i18n.T("error.projectNotFound", map[string]interface{}{"Error": err}))
+		}
+	} else {
+		isim := secenekler.ProjectName
+		if isim == "" {
+			isim = template.Name
+		}
+		sonuc.ProjectCreated = true
+		sonuc.Project = &Proje{
+			ID:          uuid.New().String(),
+			Name:        templateDoldur(isim, degerler),
+			Definition:  templateDoldur(template.Definition, degerler),
+			WorkspaceID: iy.workspaceID,
+			CreatedAt:   simdi,
+			UpdatedAt:   simdi,
+		}
+	}
+
+	dugumler, ebeveynler := projeTemplateGorevleri(template.Tasks)
+	gorevIDleri := make([]string, len(dugumler))
+	for i, dugum := range dugumler {
+		gorevIDleri[i] = uuid.New().String()
+		if dugum.Key != "" {
+			sonuc.TaskIDs[dugum.Key] = gorevIDleri[i]
+		}
+	}
+
+	olaylar := &degisiklikOlaylari{}
+	err = iy.veriYonetici.YazmaIslemi(ctx, func(tx *sql.Tx) error {
+		if sonuc.ProjectCreated {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO projeler (id, name, definition, workspace_id, created_at, updated_at)
+				VALUES (?, ?, ?, ?, ?, ?)`,
+				sonuc.Project.ID, sonuc.Project.Name, sonuc.Project.Definition, workspaceID, simdi, simdi); err != nil {
+				return err
+			}
+			olaylar.projeOlusturuldu(sonuc.Project)
+		}
+
+		etiketIDleri := make(map[string]string)
+		etiketID := func(isim string) (string, error) {
+			if id, ok := etiketIDleri[isim]; ok {
+				return id, nil
+			}
+			var id string
+			err := tx.QueryRowContext(ctx, `SELECT id FROM etiketler WHERE name = ?`, isim).Scan(&id)
+			if err == sql.ErrNoRows {
+				id = uuid.New().String()
+				_, err = tx.ExecContext(ctx, `INSERT INTO etiketler (id, name) VALUES (?, ?)`, id, isim)
+			}
+			if err != nil {
+				return "", err
+			}
+			etiketIDleri[isim] = id
+			return id, nil
+		}
+
+		for i, dugum := range dugumler {
+			oncelik := dugum.Priority
+			if oncelik == "" {
+				oncelik = constants.PriorityMedium
+			}
+			var sonTarih *time.Time
+			if dugum.Due != "" {
+				ay, gun, err := templateOfseti(dugum.Due)
+				if err != nil {
+					return err
+				}
+				tarih := baslangic.AddDate(0, ay, gun)
+				sonTarih = &tarih
+			}
+			parentID := sql.NullString{}
+			if ebeveynler[i] >= 0 {
+				parentID = sql.NullString{String: gorevIDleri[ebeveynler[i]], Valid: true}
+			}
+			baslik := templateDoldur(dugum.Title, degerler)
+			if _, err := tx.ExecContext(ctx, `INSERT INTO gorevler
+				(id, title, description, status, priority, project_id, parent_id, workspace_id, created_at, updated_at, due_date)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				gorevIDleri[i], baslik, templateDoldur(dugum.Description, degerler),
+				constants.TaskStatusPending, oncelik, sonuc.Project.ID, parentID, workspaceID, simdi, simdi, sonTarih); err != nil {
+				return err
+			}
+			sonuc.Tasks++
+			olaylar.gorevOlusturuldu(gorevIDleri[i], map[string]interface{}{
+				"title":    baslik,
+				"status":   constants.TaskStatusPending,
+				"priority": oncelik,
+			})
+
+			eklenen := make(map[string]bool)
+			for _, etiket := range append(append([]string{}, template.DefaultTags...), dugum.Tags...) {
+				etiket = strings.TrimSpace(templateDoldur(etiket, degerler))
+				if etiket == "" || eklenen[etiket] {
+					continue
+				}
+				eklenen[etiket] = true
+				id, err := etiketID(etiket)
+				if err != nil {
+					return err
+				}
+				if _, err := tx.ExecContext(ctx, `INSERT INTO gorev_etiketleri (task_id, tag_id) VALUES (?, ?)`, gorevIDleri[i], id); err != nil {
+					return err
+				}
+				sonuc.Tags++
+			}
+		}
+
+		// The node a task depends on is the source of an "onceki" link to it
+		for i, dugum := range dugumler {
+			for _, anahtar := range dugum.DependsOn {
+				if _, err := tx.ExecContext(ctx, `INSERT INTO baglantilar (id, source_id, target_id, connection_type) VALUES (?, ?, ?, ?)`,
+					uuid.New().String(), sonuc.TaskIDs[anahtar], gorevIDleri[i], "onceki"); err != nil {
+					return err
+				}
+				sonuc.Links++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.projectTemplateApplyFailed", map[string]interface{}{"Error": err}))
+	}
+	iy.olaylariYayinla(olaylar)
+	return sonuc, nil
+}
@@ -0,0 +1,169 @@
+package gorev
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+// releaseTemplate is a small release process: a parameterized freeze with a subtask,
+// and a publish step that depends on the freeze
+func releaseTemplate() *ProjeTemplate {
+	return &ProjeTemplate{
+		Name:        "Release {{version}}",
+		Alias:       "release",
+		Definition:  "Release process",
+		Fields:      []TemplateAlan{{Name: "version", Type: "text", Required: true}, {Name: "owner", Type: "text", Default: "team"}},
+		DefaultTags: []string{"release"},
+		Tasks: []ProjeTemplateGorevi{
+			{
+				Key:      "freeze",
+				Title:    "Code freeze for {{version}}",
+				Priority: constants.PriorityHigh,
+				Due:      "T+3d",
+				Subtasks: []ProjeTemplateGorevi{
+					{Key: "branch", Title: "Cut release-{{version}} branch", Description: "Owner: {{owner}}", Due: "T+2d"},
+				},
+			},
+			{Key: "publish", Title: "Publish {{version}}", Tags: []string{"ops", "release"}, Due: "1w", DependsOn: []string{"freeze", "branch"}},
+		},
+	}
+}
+
+func TestTemplateOfseti(t *testing.T) {
+	for ofset, want := range map[string][2]int{"T": {0, 0}, "T+3d": {0, 3}, "t-1D": {0, -1}, "2w": {0, 14}, "+1m": {1, 0}, "T + 10d": {0, 10}} {
+		ay, gun, err := templateOfseti(ofset)
+		if err != nil || ay != want[0] || gun != want[1] {
+			t.Errorf("templateOfseti(%q) = %d, %d, %v; want %v", ofset, ay, gun, err, want)
+		}
+	}
+	for _, ofset := range []string{"", "3", "T+3y", "tomorrow"} {
+		if _, _, err := templateOfseti(ofset); err == nil {
+			t.Errorf("templateOfseti(%q) should fail", ofset)
+		}
+	}
+}
+
+func TestProjeTemplateKaydet_Validation(t *testing.T) {
+	iy, _ := newImportTestManager(t)
+	ctx := context.Background()
+
+	invalid := map[string]func(*ProjeTemplate){
+		"no name":            func(p *ProjeTemplate) { p.Name = "" },
+		"no tasks":           func(p *ProjeTemplate) { p.Tasks = nil },
+		"untitled subtask":   func(p *ProjeTemplate) { p.Tasks[0].Subtasks[0].Title = " " },
+		"bad priority":       func(p *ProjeTemplate) { p.Tasks[0].Priority = "urgent" },
+		"bad due":            func(p *ProjeTemplate) { p.Tasks[1].Due = "next week" },
+		"duplicate key":      func(p *ProjeTemplate) { p.Tasks[1].Key = "branch" },
+		"unknown dependency": func(p *ProjeTemplate) { p.Tasks[1].DependsOn = []string{"qa"} },
+		"self dependency":    func(p *ProjeTemplate) { p.Tasks[1].DependsOn = []string{"publish"} },
+		"cycle":              func(p *ProjeTemplate) { p.Tasks[0].DependsOn = []string{"publish"} },
+	}
+	for name, change := range invalid {
+		template := releaseTemplate()
+		change(template)
+		if err := iy.ProjeTemplateKaydet(ctx, template); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+
+	if err := iy.ProjeTemplateKaydet(ctx, releaseTemplate()); err != nil {
+		t.Fatalf("ProjeTemplateKaydet failed: %v", err)
+	}
+	if err := iy.ProjeTemplateKaydet(ctx, releaseTemplate()); err == nil {
+		t.Error("a second template with the same alias should be rejected")
+	}
+}
+
+func TestProjeTemplateUygula(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	ctx := context.Background()
+
+	template := releaseTemplate()
+	if err := iy.ProjeTemplateKaydet(ctx, template); err != nil {
+		t.Fatalf("ProjeTemplateKaydet failed: %v", err)
+	}
+	stored, err := iy.ProjeTemplateGetir(ctx, "release")
+	if err != nil || stored.ID != template.ID || len(stored.Tasks[0].Subtasks) != 1 || stored.Fields[1].Default != "team" {
+		t.Fatalf("template not stored as a whole: %+v (%v)", stored, err)
+	}
+
+	if _, err := iy.ProjeTemplateUygula(ctx, "release", ProjeTemplateSecenekleri{}); err == nil {
+		t.Error("applying without the required version should fail")
+	}
+
+	result, err := iy.ProjeTemplateUygula(ctx, "release", ProjeTemplateSecenekleri{
+		StartDate: "2026-11-02",
+		Values:    map[string]string{"version": "2.4"},
+	})
+	if err != nil {
+		t.Fatalf("ProjeTemplateUygula failed: %v", err)
+	}
+	if !result.ProjectCreated || result.Project.Name != "Release 2.4" || result.Tasks != 3 || result.Links != 2 || result.Tags != 4 {
+		t.Errorf("unexpected result: %+v, project %+v", result, result.Project)
+	}
+	if _, err := vy.ProjeGetir(ctx, result.Project.ID); err != nil {
+		t.Errorf("project not created: %v", err)
+	}
+
+	start := time.Date(2026, 11, 2, 0, 0, 0, 0, time.Local)
+	branch, err := vy.GorevGetir(ctx, result.TaskIDs["branch"])
+	if err != nil {
+		t.Fatalf("GorevGetir failed: %v", err)
+	}
+	if branch.Title != "Cut release-2.4 branch" || branch.Description != "Owner: team" || branch.ParentID != result.TaskIDs["freeze"] ||
+		branch.ProjeID != result.Project.ID || branch.Status != constants.TaskStatusPending || branch.Priority != constants.PriorityMedium {
+		t.Errorf("unexpected subtask: %+v", branch)
+	}
+	if branch.DueDate == nil || !branch.DueDate.Equal(start.AddDate(0, 0, 2)) {
+		t.Errorf("expected the subtask due on T+2d, got %v", branch.DueDate)
+	}
+
+	publish, err := vy.GorevGetir(ctx, result.TaskIDs["publish"])
+	if err != nil {
+		t.Fatalf("GorevGetir failed: %v", err)
+	}
+	var tags []string
+	for _, tag := range publish.Tags {
+		tags = append(tags, tag.Name)
+	}
+	if len(tags) != 2 || publish.DueDate == nil || !publish.DueDate.Equal(start.AddDate(0, 0, 7)) {
+		t.Errorf("unexpected publish task: tags %v, due %v", tags, publish.DueDate)
+	}
+	ready, waiting, err := iy.GorevBagimliMi(ctx, publish.ID)
+	if err != nil || ready || strings.Join(waiting, ",") == "" {
+		t.Errorf("publish should wait for the freeze and the branch: %v %v (%v)", ready, waiting, err)
+	}
+
+	// An existing project is populated instead
+	again, err := iy.ProjeTemplateUygula(ctx, template.ID, ProjeTemplateSecenekleri{
+		ProjectID: "test-project-1",
+		Values:    map[string]string{"version": "2.5"},
+	})
+	if err != nil {
+		t.Fatalf("ProjeTemplateUygula into a project failed: %v", err)
+	}
+	if again.ProjectCreated || again.Project.ID != "test-project-1" {
+		t.Errorf("expected test-project-1 to be populated, got %+v", again.Project)
+	}
+	if _, err := iy.ProjeTemplateUygula(ctx, "release", ProjeTemplateSecenekleri{ProjectID: "missing", Values: map[string]string{"version": "1"}}); err == nil {
+		t.Error("applying into a missing project should fail")
+	}
+
+	// Deleting the template keeps the projects created from it
+	if err := iy.ProjeTemplateSil(ctx, "release"); err != nil {
+		t.Fatalf("ProjeTemplateSil failed: %v", err)
+	}
+	if _, err := iy.ProjeTemplateGetir(ctx, template.ID); err == nil {
+		t.Error("template should be deleted")
+	}
+	if templates, err := iy.ProjeTemplateListele(ctx); err != nil || len(templates) != 0 {
+		t.Errorf("expected no templates, got %d (%v)", len(templates), err)
+	}
+	if _, err := vy.ProjeGetir(ctx, result.Project.ID); err != nil {
+		t.Errorf("project should stay: %v", err)
+	}
+}
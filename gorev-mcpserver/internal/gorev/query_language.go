@@ -0,0 +1,904 @@
+package gorev
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// The query language of gorev_search, the REST task list and filter profiles:
+//
+//	status:devam_ediyor (priority:yuksek OR tag:urgent) -tag:wontfix due<2026-11-01 project:"Backend API" "free text"
+//
+// Terms next to each other must all match, OR binds weaker than that implicit AND and
+// AND may be written out. "-" or NOT negates the following term or group. Field terms
+// are field:value or field=value; dates and priorities also take <, <=, > and >=, and
+// dates take ranges such as due:2026-10-01..2026-10-31. Bare words and quoted phrases
+// are matched against title, description, tags and project name through the FTS index.
+
+// QuerySyntaxError reports an invalid query; Position is the 1-based character
+// position of the offending token
+type QuerySyntaxError struct {
+	Position int    `json:"position"`
+	Message  string `json:"message"`
+}
+
+func (e *QuerySyntaxError) Error() string {
+	return i18n.T("error.querySyntax", map[string]interface{}{"Position": e.Position, "Detail": e.Message})
+}
+
+// querySyntaxError builds a QuerySyntaxError with a translated message
+func querySyntaxError(pos int, key string, data map[string]interface{}) *QuerySyntaxError {
+	return &QuerySyntaxError{Position: pos, Message: i18n.T(key, data)}
+}
+
+// Query is a parsed search query
+type Query struct {
+	Input string
+	root  queryNode
+}
+
+// ParseQuery parses a query. An empty query is valid and matches every task.
+func ParseQuery(input string) (*Query, error) {
+	tokens, err := lexQuery(input)
+	if err != nil {
+		return nil, err
+	}
+	q := &Query{Input: input}
+	if len(tokens) == 1 {
+		return q, nil
+	}
+
+	p := &queryParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, querySyntaxError(tok.pos, "query.unexpectedToken", map[string]interface{}{"Token": tok.raw})
+	}
+	q.root = root
+	return q, nil
+}
+
+// IsEmpty reports whether the query has no terms
+func (q *Query) IsEmpty() bool {
+	return q == nil || q.root == nil
+}
+
+// IsPlainText reports whether the query consists of bare words only. Such queries keep
+// the free-text search, where a task matching any of the words is a result.
+func (q *Query) IsPlainText() bool {
+	if q.IsEmpty() {
+		return false
+	}
+	nodes := []queryNode{q.root}
+	if and, ok := q.root.(*queryAnd); ok {
+		nodes = and.nodes
+	}
+	for _, node := range nodes {
+		if text, ok := node.(*queryText); !ok || text.phrase {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether a task satisfies the query. Project names are compared with
+// task.ProjeName, so tasks should come from the list queries that fill it.
+func (q *Query) Matches(task *Gorev) bool {
+	return q.IsEmpty() || q.root.matches(task)
+}
+
+// SQL compiles the query into a condition on the gorevler table with the given alias
+func (q *Query) SQL(alias string) (string, []interface{}) {
+	if q.IsEmpty() {
+		return "1=1", nil
+	}
+	var args []interface{}
+	return q.root.sql(alias, &args), args
+}
+
+// TextTerms returns the words and phrases the matching tasks are ranked by; terms
+// under a negation only exclude tasks and are left out
+func (q *Query) TextTerms() []string {
+	var terms []string
+	var walk func(node queryNode, negated bool)
+	walk = func(node queryNode, negated bool) {
+		switch n := node.(type) {
+		case *queryAnd:
+			for _, child := range n.nodes {
+				walk(child, negated)
+			}
+		case *queryOr:
+			for _, child := range n.nodes {
+				walk(child, negated)
+			}
+		case *queryNot:
+			walk(n.node, !negated)
+		case *queryText:
+			if !negated {
+				terms = append(terms, n.text)
+			}
+		}
+	}
+	if !q.IsEmpty() {
+		walk(q.root, false)
+	}
+	return terms
+}
+
+// ============================================================================
+// Lexer
+// ============================================================================
+
+type queryTokenKind int
+
+const (
+	tokenEOF queryTokenKind = iota
+	tokenLParen
+	tokenRParen
+	tokenOr
+	tokenAnd
+	tokenNot
+	tokenWord
+	tokenPhrase
+	tokenField
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	pos  int
+	raw  string
+	// text is the word, the phrase or the value of a field term
+	text   string
+	field  string
+	op     string
+	quoted bool
+}
+
+// queryOperators are tried in order, so two-character operators win
+var queryOperators = []string{"<=", ">=", ":", "=", "<", ">"}
+
+// lexQuery splits a query into tokens and ends them with tokenEOF
+func lexQuery(input string) ([]queryToken, error) {
+	r := []rune(input)
+	var tokens []queryToken
+	i := 0
+	for i < len(r) {
+		c := r[i]
+		pos := i + 1
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, queryToken{kind: tokenLParen, pos: pos, raw: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, queryToken{kind: tokenRParen, pos: pos, raw: ")"})
+			i++
+		case c == '"':
+			text, next, err := readQuotedQueryValue(r, i)
+			if err != nil {
+				return nil, err
+			}
+			i = next
+			if strings.TrimSpace(text) != "" {
+				tokens = append(tokens, queryToken{kind: tokenPhrase, pos: pos, raw: string(r[pos-1 : i]), text: strings.ToLower(text)})
+			}
+		case c == '-' && i+1 < len(r) && !unicode.IsSpace(r[i+1]) && r[i+1] != ')' && r[i+1] != '-':
+			// A leading "-" negates the term that follows
+			tokens = append(tokens, queryToken{kind: tokenNot, pos: pos, raw: "-"})
+			i++
+		default:
+			start := i
+			for i < len(r) && !unicode.IsSpace(r[i]) && r[i] != '(' && r[i] != ')' && r[i] != '"' {
+				i++
+			}
+			word := string(r[start:i])
+			switch word {
+			case "OR":
+				tokens = append(tokens, queryToken{kind: tokenOr, pos: pos, raw: word})
+				continue
+			case "AND":
+				tokens = append(tokens, queryToken{kind: tokenAnd, pos: pos, raw: word})
+				continue
+			case "NOT":
+				tokens = append(tokens, queryToken{kind: tokenNot, pos: pos, raw: word})
+				continue
+			}
+
+			if field, op, value, ok := splitQueryFieldTerm(word); ok {
+				tok := queryToken{kind: tokenField, pos: pos, field: field, op: op, text: value}
+				if value == "" && i < len(r) && r[i] == '"' {
+					quoted, next, err := readQuotedQueryValue(r, i)
+					if err != nil {
+						return nil, err
+					}
+					i = next
+					tok.text, tok.quoted = quoted, true
+				}
+				if strings.TrimSpace(tok.text) == "" {
+					return nil, querySyntaxError(pos, "query.missingValue", map[string]interface{}{"Field": field})
+				}
+				tok.raw = string(r[start:i])
+				tokens = append(tokens, tok)
+				continue
+			}
+
+			// Words without letters or digits match nothing in the index
+			if strings.IndexFunc(word, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) }) >= 0 {
+				tokens = append(tokens, queryToken{kind: tokenWord, pos: pos, raw: word, text: strings.ToLower(word)})
+			}
+		}
+	}
+	return append(tokens, queryToken{kind: tokenEOF, pos: len(r) + 1}), nil
+}
+
+// readQuotedQueryValue reads a double-quoted string starting at r[start]; \" and \\
+// escape a quote and a backslash. It returns the text and the index after the quote.
+func readQuotedQueryValue(r []rune, start int) (string, int, error) {
+	var b strings.Builder
+	for i := start + 1; i < len(r); i++ {
+		switch r[i] {
+		case '\\':
+			if i+1 < len(r) && (r[i+1] == '"' || r[i+1] == '\\') {
+				i++
+			}
+			b.WriteRune(r[i])
+		case '"':
+			return b.String(), i + 1, nil
+		default:
+			b.WriteRune(r[i])
+		}
+	}
+	return "", 0, querySyntaxError(start+1, "query.unterminatedQuote", nil)
+}
+
+// splitQueryFieldTerm splits words such as status:done, due<=2026-11-01 or due:>today;
+// a field name starts with a letter and contains letters, digits and underscores
+func splitQueryFieldTerm(word string) (field, op, value string, ok bool) {
+	end := 0
+	for i, r := range word {
+		if unicode.IsLetter(r) || r == '_' || (i > 0 && unicode.IsDigit(r)) {
+			end = i + len(string(r))
+			continue
+		}
+		break
+	}
+	if end == 0 {
+		return "", "", "", false
+	}
+	rest := word[end:]
+	for _, candidate := range queryOperators {
+		if strings.HasPrefix(rest, candidate) {
+			field, op, value = word[:end], candidate, rest[len(candidate):]
+			if op == ":" {
+				// field:<value is the same as field<value
+				for _, cmp := range queryOperators {
+					if cmp != ":" && cmp != "=" && strings.HasPrefix(value, cmp) {
+						op, value = cmp, value[len(cmp):]
+						break
+					}
+				}
+			}
+			return field, op, value, true
+		}
+	}
+	return "", "", "", false
+}
+
+// ============================================================================
+// Parser
+// ============================================================================
+
+// queryParser is a recursive descent parser over:
+//
+//	or      = and { "OR" and }
+//	and     = unary { ["AND"] unary }
+//	unary   = ("-" | "NOT") unary | primary
+//	primary = "(" or ")" | word | phrase | field
+type queryParser struct {
+	tokens []queryToken
+	i      int
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.i]
+}
+
+func (p *queryParser) next() queryToken {
+	tok := p.tokens[p.i]
+	if tok.kind != tokenEOF {
+		p.i++
+	}
+	return tok
+}
+
+// endsOperand reports whether a token cannot start a term
+func (tok queryToken) endsOperand() bool {
+	return tok.kind == tokenEOF || tok.kind == tokenRParen || tok.kind == tokenOr || tok.kind == tokenAnd
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	nodes := []queryNode{first}
+	for p.peek().kind == tokenOr {
+		op := p.next()
+		if p.peek().endsOperand() {
+			return nil, querySyntaxError(op.pos, "query.missingOperand", map[string]interface{}{"Operator": op.raw})
+		}
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	if len(nodes) == 1 {
+		return first, nil
+	}
+	return &queryOr{nodes: nodes}, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	var nodes []queryNode
+	for {
+		tok := p.peek()
+		switch tok.kind {
+		case tokenEOF, tokenRParen, tokenOr:
+			if len(nodes) == 0 {
+				if tok.kind == tokenOr {
+					return nil, querySyntaxError(tok.pos, "query.missingOperand", map[string]interface{}{"Operator": tok.raw})
+				}
+				return nil, querySyntaxError(tok.pos, "query.unexpectedToken", map[string]interface{}{"Token": tok.raw})
+			}
+			if len(nodes) == 1 {
+				return nodes[0], nil
+			}
+			return &queryAnd{nodes: nodes}, nil
+		case tokenAnd:
+			p.next()
+			if len(nodes) == 0 || p.peek().endsOperand() {
+				return nil, querySyntaxError(tok.pos, "query.missingOperand", map[string]interface{}{"Operator": tok.raw})
+			}
+			continue
+		}
+
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if tok := p.peek(); tok.kind == tokenNot {
+		p.next()
+		if p.peek().endsOperand() {
+			return nil, querySyntaxError(tok.pos, "query.missingOperand", map[string]interface{}{"Operator": tok.raw})
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &queryNot{node: node}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenLParen:
+		if p.peek().kind == tokenRParen {
+			return nil, querySyntaxError(tok.pos, "query.emptyGroup", nil)
+		}
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenRParen {
+			return nil, querySyntaxError(tok.pos, "query.missingCloseParen", nil)
+		}
+		p.next()
+		return node, nil
+	case tokenWord:
+		return &queryText{text: tok.text}, nil
+	case tokenPhrase:
+		return &queryText{text: tok.text, phrase: true}, nil
+	case tokenField:
+		return newQueryFieldNode(tok)
+	}
+	return nil, querySyntaxError(tok.pos, "query.unexpectedToken", map[string]interface{}{"Token": tok.raw})
+}
+
+// ============================================================================
+// Fields
+// ============================================================================
+
+// Fields of the query language
+const (
+	queryFieldStatus      = "status"
+	queryFieldPriority    = "priority"
+	queryFieldProject     = "project"
+	queryFieldTag         = "tag"
+	queryFieldDue         = "due"
+	queryFieldCreated     = "created"
+	queryFieldUpdated     = "updated"
+	queryFieldTitle       = "title"
+	queryFieldDescription = "description"
+	queryFieldID          = "id"
+	queryFieldParent      = "parent"
+)
+
+// queryFieldAliases maps the accepted field names, English and Turkish, to the field
+var queryFieldAliases = map[string]string{
+	"status": queryFieldStatus, "durum": queryFieldStatus,
+	"priority": queryFieldPriority, "oncelik": queryFieldPriority, "öncelik": queryFieldPriority,
+	"project": queryFieldProject, "proje": queryFieldProject, "proje_id": queryFieldProject, "project_id": queryFieldProject,
+	"tag": queryFieldTag, "tags": queryFieldTag, "etiket": queryFieldTag,
+	"due": queryFieldDue, "son_tarih": queryFieldDue, "due_date": queryFieldDue,
+	"created": queryFieldCreated, "olusturma": queryFieldCreated, "created_at": queryFieldCreated,
+	"updated": queryFieldUpdated, "guncelleme": queryFieldUpdated, "updated_at": queryFieldUpdated,
+	"title": queryFieldTitle, "baslik": queryFieldTitle,
+	"description": queryFieldDescription, "aciklama": queryFieldDescription,
+	"id":     queryFieldID,
+	"parent": queryFieldParent, "parent_id": queryFieldParent,
+}
+
+// queryFieldNames lists the fields in error messages
+const queryFieldNames = "status, priority, project, tag, due, created, updated, title, description, id, parent"
+
+// queryDateColumns are the task columns of the date fields
+var queryDateColumns = map[string]string{
+	queryFieldDue:     "due_date",
+	queryFieldCreated: "created_at",
+	queryFieldUpdated: "updated_at",
+}
+
+var queryStatusValues = map[string]string{
+	constants.TaskStatusPending: constants.TaskStatusPending, "pending": constants.TaskStatusPending, "todo": constants.TaskStatusPending,
+	constants.TaskStatusInProgress: constants.TaskStatusInProgress, "in_progress": constants.TaskStatusInProgress,
+	constants.TaskStatusCompleted: constants.TaskStatusCompleted, "completed": constants.TaskStatusCompleted, "done": constants.TaskStatusCompleted,
+	constants.TaskStatusCancelled: constants.TaskStatusCancelled, "cancelled": constants.TaskStatusCancelled, "canceled": constants.TaskStatusCancelled,
+}
+
+var queryPriorityValues = map[string]string{
+	constants.PriorityLow: constants.PriorityLow, "düşük": constants.PriorityLow, "low": constants.PriorityLow,
+	constants.PriorityMedium: constants.PriorityMedium, "medium": constants.PriorityMedium,
+	constants.PriorityHigh: constants.PriorityHigh, "yüksek": constants.PriorityHigh, "high": constants.PriorityHigh,
+}
+
+// queryPriorityRanks orders priorities for comparisons
+var queryPriorityRanks = map[string]int{constants.PriorityLow: 1, constants.PriorityMedium: 2, constants.PriorityHigh: 3}
+
+// queryNone matches tasks without a due date, project, parent or tags
+const queryNone = "none"
+
+// queryRelativeDay matches day offsets from today such as +7d, -2w or 3d
+var queryRelativeDay = regexp.MustCompile(`^([+-]?)(\d+)([dw])$`)
+
+// newQueryFieldNode validates a field term and builds its node
+func newQueryFieldNode(tok queryToken) (queryNode, error) {
+	field, ok := queryFieldAliases[strings.ToLower(tok.field)]
+	if !ok {
+		return nil, querySyntaxError(tok.pos, "query.unknownField", map[string]interface{}{"Field": tok.field, "Fields": queryFieldNames})
+	}
+	value := tok.text
+	lower := strings.ToLower(value)
+	equality := tok.op == ":" || tok.op == "="
+	invalidOperator := func() error {
+		return querySyntaxError(tok.pos, "query.invalidOperator", map[string]interface{}{"Operator": tok.op, "Field": tok.field})
+	}
+	invalidValue := func(expected string) error {
+		return querySyntaxError(tok.pos, "query.invalidValue", map[string]interface{}{"Value": value, "Field": tok.field, "Expected": expected})
+	}
+
+	switch field {
+	case queryFieldStatus:
+		if !equality {
+			return nil, invalidOperator()
+		}
+		status, ok := queryStatusValues[lower]
+		if !ok {
+			return nil, invalidValue(strings.Join(constants.GetValidTaskStatuses(), ", "))
+		}
+		return &queryEquals{field: field, value: status}, nil
+
+	case queryFieldPriority:
+		priority, ok := queryPriorityValues[lower]
+		if !ok {
+			return nil, invalidValue(strings.Join([]string{constants.PriorityLow, constants.PriorityMedium, constants.PriorityHigh}, ", "))
+		}
+		rank := queryPriorityRanks[priority]
+		node := &queryPriority{min: rank, max: rank}
+		switch tok.op {
+		case "<":
+			node.min, node.max = 1, rank-1
+		case "<=":
+			node.min = 1
+		case ">":
+			node.min, node.max = rank+1, 3
+		case ">=":
+			node.max = 3
+		}
+		return node, nil
+
+	case queryFieldDue, queryFieldCreated, queryFieldUpdated:
+		return newQueryDateNode(tok, field, lower)
+
+	case queryFieldProject, queryFieldTag, queryFieldParent:
+		if !equality {
+			return nil, invalidOperator()
+		}
+		if lower == queryNone && !tok.quoted {
+			return &queryEquals{field: field, none: true}, nil
+		}
+		return &queryEquals{field: field, value: value}, nil
+
+	default:
+		if !equality {
+			return nil, invalidOperator()
+		}
+		return &queryEquals{field: field, value: value}, nil
+	}
+}
+
+// newQueryDateNode builds the day interval of a date term; days are calendar dates
+// at UTC midnight like the dates stored from YYYY-MM-DD input
+func newQueryDateNode(tok queryToken, field, lower string) (queryNode, error) {
+	node := &queryDate{field: field, column: queryDateColumns[field]}
+	equality := tok.op == ":" || tok.op == "="
+
+	if lower == queryNone && !tok.quoted {
+		if !equality {
+			return nil, querySyntaxError(tok.pos, "query.invalidOperator", map[string]interface{}{"Operator": tok.op, "Field": tok.field})
+		}
+		node.none = true
+		return node, nil
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	day := func(value string) (time.Time, error) {
+		if t, ok := parseQueryDay(value, today); ok {
+			return t, nil
+		}
+		return time.Time{}, querySyntaxError(tok.pos, "query.invalidDate", map[string]interface{}{"Value": value, "Field": tok.field})
+	}
+
+	if from, to, isRange := strings.Cut(tok.text, ".."); isRange {
+		if !equality || (from == "" && to == "") {
+			return nil, querySyntaxError(tok.pos, "query.invalidDate", map[string]interface{}{"Value": tok.text, "Field": tok.field})
+		}
+		if from != "" {
+			start, err := day(from)
+			if err != nil {
+				return nil, err
+			}
+			node.from = &start
+		}
+		if to != "" {
+			end, err := day(to)
+			if err != nil {
+				return nil, err
+			}
+			end = end.AddDate(0, 0, 1)
+			node.to = &end
+		}
+		return node, nil
+	}
+
+	start, err := day(tok.text)
+	if err != nil {
+		return nil, err
+	}
+	end := start.AddDate(0, 0, 1)
+	switch tok.op {
+	case "<":
+		node.to = &start
+	case "<=":
+		node.to = &end
+	case ">":
+		node.from = &end
+	case ">=":
+		node.from = &start
+	default:
+		node.from, node.to = &start, &end
+	}
+	return node, nil
+}
+
+// parseQueryDay reads YYYY-MM-DD, today/tomorrow/yesterday in English or Turkish, or a
+// day offset such as +7d or -2w
+func parseQueryDay(value string, today time.Time) (time.Time, bool) {
+	switch strings.ToLower(value) {
+	case "today", "bugun", "bugün":
+		return today, true
+	case "tomorrow", "yarin", "yarın":
+		return today.AddDate(0, 0, 1), true
+	case "yesterday", "dun", "dün":
+		return today.AddDate(0, 0, -1), true
+	}
+	if t, err := time.Parse(constants.DateFormatISO, value); err == nil {
+		return t, true
+	}
+	if m := queryRelativeDay.FindStringSubmatch(strings.ToLower(value)); m != nil {
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return time.Time{}, false
+		}
+		if m[3] == "w" {
+			n *= 7
+		}
+		if m[1] == "-" {
+			n = -n
+		}
+		return today.AddDate(0, 0, n), true
+	}
+	return time.Time{}, false
+}
+
+// ============================================================================
+// AST
+// ============================================================================
+
+// queryNode is a node of a parsed query. sql returns a condition that is never NULL,
+// so NOT keeps its meaning; matches evaluates the same condition on a loaded task.
+type queryNode interface {
+	sql(alias string, args *[]interface{}) string
+	matches(task *Gorev) bool
+}
+
+type queryAnd struct{ nodes []queryNode }
+
+func (n *queryAnd) sql(alias string, args *[]interface{}) string {
+	parts := make([]string, len(n.nodes))
+	for i, node := range n.nodes {
+		parts[i] = node.sql(alias, args)
+	}
+	return "(" + strings.Join(parts, " AND ") + ")"
+}
+
+func (n *queryAnd) matches(task *Gorev) bool {
+	for _, node := range n.nodes {
+		if !node.matches(task) {
+			return false
+		}
+	}
+	return true
+}
+
+type queryOr struct{ nodes []queryNode }
+
+func (n *queryOr) sql(alias string, args *[]interface{}) string {
+	parts := make([]string, len(n.nodes))
+	for i, node := range n.nodes {
+		parts[i] = node.sql(alias, args)
+	}
+	return "(" + strings.Join(parts, " OR ") + ")"
+}
+
+func (n *queryOr) matches(task *Gorev) bool {
+	for _, node := range n.nodes {
+		if node.matches(task) {
+			return true
+		}
+	}
+	return false
+}
+
+type queryNot struct{ node queryNode }
+
+func (n *queryNot) sql(alias string, args *[]interface{}) string {
+	return "NOT " + n.node.sql(alias, args)
+}
+
+func (n *queryNot) matches(task *Gorev) bool {
+	return !n.node.matches(task)
+}
+
+// queryText is a lower-case word, matched as a prefix of indexed words, or a phrase
+type queryText struct {
+	text   string
+	phrase bool
+}
+
+func (n *queryText) sql(alias string, args *[]interface{}) string {
+	*args = append(*args, n.ftsExpr())
+	return alias + ".id IN (SELECT task_id FROM gorevler_fts WHERE gorevler_fts MATCH ?)"
+}
+
+// ftsExpr quotes the term for FTS5 MATCH; words are prefix queries
+func (n *queryText) ftsExpr() string {
+	quoted := `"` + strings.ReplaceAll(n.text, `"`, `""`) + `"`
+	if n.phrase {
+		return quoted
+	}
+	return quoted + "*"
+}
+
+func (n *queryText) matches(task *Gorev) bool {
+	// Words with punctuation are phrases for the FTS tokenizer as well
+	phrase := n.phrase || strings.IndexFunc(n.text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) }) >= 0
+	for _, text := range queryTextFields(task) {
+		text = strings.ToLower(text)
+		if phrase {
+			if strings.Contains(text, n.text) {
+				return true
+			}
+			continue
+		}
+		for _, word := range strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) }) {
+			if strings.HasPrefix(word, n.text) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// queryTextFields returns the task fields the FTS index covers
+func queryTextFields(task *Gorev) []string {
+	fields := []string{task.Title, task.Description, task.ProjeName}
+	for _, tag := range task.Tags {
+		fields = append(fields, tag.Name)
+	}
+	return fields
+}
+
+// queryEquals compares a text column; none matches tasks without a value
+type queryEquals struct {
+	field string
+	value string
+	none  bool
+}
+
+func (n *queryEquals) sql(alias string, args *[]interface{}) string {
+	tagExists := "EXISTS (SELECT 1 FROM gorev_etiketleri ge JOIN etiketler e ON e.id = ge.tag_id WHERE ge.task_id = " + alias + ".id"
+	if n.none {
+		switch n.field {
+		case queryFieldTag:
+			return "NOT " + tagExists + ")"
+		case queryFieldProject:
+			return "COALESCE(" + alias + ".project_id, '') = ''"
+		default:
+			return "COALESCE(" + alias + ".parent_id, '') = ''"
+		}
+	}
+
+	switch n.field {
+	case queryFieldStatus:
+		*args = append(*args, n.value)
+		return alias + ".status = ?"
+	case queryFieldProject:
+		*args = append(*args, n.value, n.value)
+		return "COALESCE(" + alias + ".project_id, '') IN (SELECT id FROM projeler WHERE id = ? OR name = ? COLLATE NOCASE)"
+	case queryFieldTag:
+		*args = append(*args, n.value)
+		return tagExists + " AND e.name = ? COLLATE NOCASE)"
+	case queryFieldParent:
+		*args = append(*args, n.value)
+		return "COALESCE(" + alias + ".parent_id, '') = ?"
+	case queryFieldTitle:
+		*args = append(*args, queryLikePattern(n.value))
+		return alias + `.title LIKE ? ESCAPE '\'`
+	case queryFieldDescription:
+		*args = append(*args, queryLikePattern(n.value))
+		return "COALESCE(" + alias + `.description, '') LIKE ? ESCAPE '\'`
+	default:
+		*args = append(*args, n.value)
+		return alias + ".id = ?"
+	}
+}
+
+// queryLikePattern matches value anywhere, with LIKE wildcards in value escaped
+func queryLikePattern(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+	return "%" + escaped + "%"
+}
+
+func (n *queryEquals) matches(task *Gorev) bool {
+	switch n.field {
+	case queryFieldStatus:
+		return task.Status == n.value
+	case queryFieldProject:
+		if n.none {
+			return task.ProjeID == ""
+		}
+		return task.ProjeID != "" && (task.ProjeID == n.value || strings.EqualFold(task.ProjeName, n.value))
+	case queryFieldTag:
+		if n.none {
+			return len(task.Tags) == 0
+		}
+		for _, tag := range task.Tags {
+			if strings.EqualFold(tag.Name, n.value) {
+				return true
+			}
+		}
+		return false
+	case queryFieldParent:
+		if n.none {
+			return task.ParentID == ""
+		}
+		return task.ParentID == n.value
+	case queryFieldTitle:
+		return strings.Contains(strings.ToLower(task.Title), strings.ToLower(n.value))
+	case queryFieldDescription:
+		return strings.Contains(strings.ToLower(task.Description), strings.ToLower(n.value))
+	default:
+		return task.ID == n.value
+	}
+}
+
+// queryPriority matches priorities with a rank between min and max
+type queryPriority struct{ min, max int }
+
+func (n *queryPriority) sql(alias string, args *[]interface{}) string {
+	*args = append(*args, n.min, n.max)
+	return fmt.Sprintf("(CASE %s.priority WHEN '%s' THEN 1 WHEN '%s' THEN 2 WHEN '%s' THEN 3 ELSE 0 END) BETWEEN ? AND ?",
+		alias, constants.PriorityLow, constants.PriorityMedium, constants.PriorityHigh)
+}
+
+func (n *queryPriority) matches(task *Gorev) bool {
+	rank := queryPriorityRanks[task.Priority]
+	return rank > 0 && rank >= n.min && rank <= n.max
+}
+
+// queryDate matches dates in [from, to); a missing bound is open, none matches tasks
+// without the date
+type queryDate struct {
+	field    string
+	column   string
+	from, to *time.Time
+	none     bool
+}
+
+func (n *queryDate) sql(alias string, args *[]interface{}) string {
+	column := alias + "." + n.column
+	if n.none {
+		return column + " IS NULL"
+	}
+	conditions := []string{column + " IS NOT NULL"}
+	if n.from != nil {
+		conditions = append(conditions, column+" >= ?")
+		*args = append(*args, *n.from)
+	}
+	if n.to != nil {
+		conditions = append(conditions, column+" < ?")
+		*args = append(*args, *n.to)
+	}
+	return "(" + strings.Join(conditions, " AND ") + ")"
+}
+
+func (n *queryDate) matches(task *Gorev) bool {
+	var value *time.Time
+	switch n.field {
+	case queryFieldDue:
+		value = task.DueDate
+	case queryFieldCreated:
+		value = &task.CreatedAt
+	default:
+		value = &task.UpdatedAt
+	}
+	if n.none {
+		return value == nil
+	}
+	if value == nil {
+		return false
+	}
+	if n.from != nil && value.Before(*n.from) {
+		return false
+	}
+	return n.to == nil || value.Before(*n.to)
+}
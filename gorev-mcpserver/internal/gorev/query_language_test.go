@@ -0,0 +1,219 @@
+package gorev
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+func TestParseQuerySyntaxErrors(t *testing.T) {
+	// Messages are translated, so the cases check the reported position
+	tests := []struct {
+		query    string
+		position int
+	}{
+		{"status:done OR", 13},            // query.missingOperand
+		{"AND tag:x", 1},                  // query.missingOperand
+		{"(status:done", 1},               // query.missingCloseParen
+		{"status:done)", 12},              // query.unexpectedToken
+		{"()", 1},                         // query.emptyGroup
+		{`title:"open`, 7},                // query.unterminatedQuote
+		{"tag:", 1},                       // query.missingValue
+		{"api colour:red", 5},             // query.unknownField
+		{"status>done", 1},                // query.invalidOperator
+		{"priority:urgent", 1},            // query.invalidValue
+		{"due<someday", 1},                // query.invalidDate
+		{"due<2026-01-01..2026-02-01", 1}, // query.invalidDate
+		{"-", 0},                          // valid, matches everything
+	}
+	for _, tt := range tests {
+		_, err := ParseQuery(tt.query)
+		if tt.position == 0 {
+			if err != nil {
+				t.Errorf("%q should parse, got %v", tt.query, err)
+			}
+			continue
+		}
+		var syntaxErr *QuerySyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("%q: expected a syntax error, got %v", tt.query, err)
+			continue
+		}
+		if syntaxErr.Position != tt.position || syntaxErr.Message == "" {
+			t.Errorf("%q: expected an error at %d, got %q at %d", tt.query, tt.position, syntaxErr.Message, syntaxErr.Position)
+		}
+	}
+}
+
+func TestParseQueryPlainText(t *testing.T) {
+	tests := map[string]bool{
+		"":                     false,
+		"login bug":            true,
+		`"login bug"`:          false,
+		"login status:pending": false,
+		"login OR signup":      false,
+	}
+	for query, plain := range tests {
+		q, err := ParseQuery(query)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) failed: %v", query, err)
+		}
+		if q.IsPlainText() != plain {
+			t.Errorf("IsPlainText(%q) = %v, want %v", query, !plain, plain)
+		}
+	}
+
+	q, _ := ParseQuery(`login -spam (tag:x OR "sign up")`)
+	if terms := q.TextTerms(); len(terms) != 2 || terms[0] != "login" || terms[1] != "sign up" {
+		t.Errorf("negated terms should not rank results, got %v", terms)
+	}
+}
+
+// addQueryTestData adds tasks covering every field of the query language
+func addQueryTestData(t *testing.T, vy VeriYoneticiInterface) {
+	ctx := context.Background()
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	soon := today.AddDate(0, 0, 3)
+	late := today.AddDate(0, 0, 30)
+
+	if err := vy.ProjeKaydet(ctx, &Proje{ID: "q-project", Name: "Backend API", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	tasks := []*Gorev{
+		{ID: "q-login", Title: "Fix login bug", Description: "Users cannot sign in", Status: constants.TaskStatusPending, Priority: constants.PriorityHigh, ProjeID: "q-project", DueDate: &soon},
+		{ID: "q-docs", Title: "Write docs", Description: "100% coverage of the API", Status: constants.TaskStatusInProgress, Priority: constants.PriorityMedium, ProjeID: "q-project", DueDate: &late},
+		{ID: "q-clean", Title: "Clean up logs", Status: constants.TaskStatusCompleted, Priority: constants.PriorityLow},
+		{ID: "q-child", Title: "Login form tests", Status: constants.TaskStatusPending, Priority: constants.PriorityLow, ParentID: "q-login", ProjeID: "q-project"},
+	}
+	for _, task := range tasks {
+		task.CreatedAt = now
+		task.UpdatedAt = now
+		if err := vy.GorevKaydet(ctx, task); err != nil {
+			t.Fatalf("Failed to create %s: %v", task.ID, err)
+		}
+	}
+	tags, err := vy.EtiketleriGetirVeyaOlustur(ctx, []string{"urgent", "frontend"})
+	if err != nil {
+		t.Fatalf("Failed to create tags: %v", err)
+	}
+	if err := vy.GorevEtiketleriniAyarla(ctx, "q-login", tags); err != nil {
+		t.Fatalf("Failed to set tags: %v", err)
+	}
+	if err := vy.GorevEtiketleriniAyarla(ctx, "q-child", tags[1:]); err != nil {
+		t.Fatalf("Failed to set tags: %v", err)
+	}
+}
+
+// queryTestCases are evaluated on both backends; the SQL of the SQLite backend and the
+// in-memory matching must agree
+var queryTestCases = map[string]string{
+	"status:pending":                      "q-child,q-login",
+	"durum:devam_ediyor":                  "q-docs",
+	"priority>=orta":                      "q-docs,q-login",
+	"oncelik:<yuksek -status:done":        "q-child,q-docs",
+	`project:"backend api" tag:none`:      "q-docs",
+	"proje:none":                          "q-clean",
+	"tag:URGENT OR title:docs":            "q-docs,q-login",
+	"etiket:frontend -parent:none":        "q-child",
+	"due<+7d":                             "q-login",
+	"due:today..+60d":                     "q-docs,q-login",
+	"due:none priority:low":               "q-child,q-clean",
+	"created:today updated>=-1d id:q-doc": "",
+	"created:today id:q-docs":             "q-docs",
+	"description:100%":                    "q-docs",
+	"login":                               "q-child,q-login",
+	`"sign in" OR (logs -status:pending)`: "q-clean,q-login",
+	"NOT (status:pending OR status:done)": "q-docs",
+}
+
+func runQueryTestCases(t *testing.T, iy *IsYonetici) {
+	for input, want := range queryTestCases {
+		query, err := ParseQuery(input)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) failed: %v", input, err)
+		}
+		tasks, err := iy.GorevListele(context.Background(), map[string]interface{}{"query": query})
+		if err != nil {
+			t.Fatalf("GorevListele(%q) failed: %v", input, err)
+		}
+		var ids []string
+		for _, task := range tasks {
+			if strings.HasPrefix(task.ID, "q-") {
+				ids = append(ids, task.ID)
+			}
+		}
+		sort.Strings(ids)
+		if got := strings.Join(ids, ","); got != want {
+			t.Errorf("%q: expected [%s], got [%s]", input, want, got)
+		}
+	}
+}
+
+func TestQueryGorevListeleSQLite(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	addQueryTestData(t, vy)
+	runQueryTestCases(t, iy)
+}
+
+func TestQueryGorevListeleMemory(t *testing.T) {
+	vy := NewMemoryVeriYonetici()
+	addQueryTestData(t, vy)
+	runQueryTestCases(t, YeniIsYonetici(vy))
+}
+
+func TestSearchStructuredQuery(t *testing.T) {
+	_, vy := newImportTestManager(t)
+	addQueryTestData(t, vy)
+	db, _ := vy.GetDB()
+	engine := NewSearchEngine(vy, db)
+
+	response, err := engine.Search(SearchOptions{Query: "login status:pending", Filters: map[string]interface{}{}})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(response.Results) != 2 || response.Results[0].MatchType != "query" {
+		t.Fatalf("expected 2 query results, got %+v", response.Results)
+	}
+	// The task with the word in its title ranks first
+	if response.Results[0].Task.ID != "q-login" && response.Results[0].Task.ID != "q-child" {
+		t.Errorf("unexpected first result %s", response.Results[0].Task.ID)
+	}
+
+	_, err = engine.Search(SearchOptions{Query: "status:", Filters: map[string]interface{}{}})
+	var syntaxErr *QuerySyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("expected a syntax error, got %v", err)
+	}
+}
+
+func TestFilterProfileQuery(t *testing.T) {
+	profile := &FilterProfile{
+		Filters:     SearchFilters{Status: []string{constants.TaskStatusPending}, Priority: []string{constants.PriorityLow, constants.PriorityMedium}, Tags: []string{"frontend"}},
+		SearchQuery: "login OR docs",
+	}
+	query, err := profile.Query()
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if query.IsPlainText() {
+		t.Error("a profile with filters should build a structured query")
+	}
+
+	_, vy := newImportTestManager(t)
+	addQueryTestData(t, vy)
+	tasks, err := vy.GorevListele(context.Background(), map[string]interface{}{"query": query})
+	if err != nil || len(tasks) != 1 || tasks[0].ID != "q-child" {
+		t.Errorf("expected q-child, got %v (%v)", tasks, err)
+	}
+
+	profile.SearchQuery = "status:"
+	if _, err := profile.Query(); err == nil {
+		t.Error("an invalid search query should be rejected")
+	}
+}
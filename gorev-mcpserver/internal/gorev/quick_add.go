@@ -0,0 +1,450 @@
+package gorev
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// QuickAdd is a task written in quick-add syntax, e.g.
+//
+//	Fix login timeout #auth #bug !yuksek @BackendAPI due:friday ^<parent-id> est:3h
+//
+// Words starting with # are tags, ! a priority, @ a project name or ID and ^ a parent
+// task ID; due: (son:) takes a date and est: (tahmin:) an estimate. Relative dates such
+// as "tomorrow", "gelecek cuma", "next friday" or "in 3 days" are also recognized in the
+// text itself, in any registered language pack. What remains is the title; ": " or " - " starts the description.
+type QuickAdd struct {
+	Title          string     `json:"title"`
+	Description    string     `json:"description,omitempty"`
+	Tags           []string   `json:"tags,omitempty"`
+	Priority       string     `json:"priority,omitempty"`
+	Project        string     `json:"project,omitempty"`
+	ParentID       string     `json:"parent_id,omitempty"`
+	DueDate        *time.Time `json:"due_date,omitempty"`
+	EstimatedHours float64    `json:"estimated_hours,omitempty"`
+}
+
+// quickAddWorkdayHours is the length of a day in estimates such as est:2d
+const quickAddWorkdayHours = 8
+
+// quickAddMaxDateWords bounds the length of date phrases such as "in 3 days"
+const quickAddMaxDateWords = 3
+
+// quickAddEstimateUnits maps folded estimate units to hours
+var quickAddEstimateUnits = map[string]float64{
+	"": 1, "h": 1, "hr": 1, "hrs": 1, "hour": 1, "hours": 1, "s": 1, "sa": 1, "saat": 1,
+	"m": 1.0 / 60, "min": 1.0 / 60, "mins": 1.0 / 60, "dk": 1.0 / 60, "dakika": 1.0 / 60,
+	"d": quickAddWorkdayHours, "day": quickAddWorkdayHours, "days": quickAddWorkdayHours,
+	"g": quickAddWorkdayHours, "gun": quickAddWorkdayHours,
+}
+
+var (
+	quickAddEstimatePart = regexp.MustCompile(`(\d+(?:[.,]\d+)?)([a-z]*)`)
+	quickAddDottedDate   = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})(?:\.(\d{4}))?$`)
+)
+
+// ParseQuickAdd parses one line of quick-add syntax. Unknown priorities, dates and
+// estimates are errors rather than being left in the title.
+func (nlp *NLPProcessor) ParseQuickAdd(text string) (*QuickAdd, error) {
+	quickAdd, errs := nlp.parseQuickAdd(text)
+	if len(errs) > 0 {
+		return quickAdd, errs[0]
+	}
+	return quickAdd, nil
+}
+
+// parseQuickAdd parses what it can and returns the errors of the rest
+func (nlp *NLPProcessor) parseQuickAdd(text string) (*QuickAdd, []error) {
+	quickAdd := &QuickAdd{}
+	var errs []error
+	today := nlp.today()
+
+	words := strings.Fields(text)
+	var rest []string
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		field, value, isField := strings.Cut(word, ":")
+		field = foldCompletionText(field)
+
+		switch {
+		case len(word) > 1 && word[0] == '#':
+			tag := strings.TrimRight(word[1:], ",;")
+			if !slices.Contains(quickAdd.Tags, tag) {
+				quickAdd.Tags = append(quickAdd.Tags, tag)
+			}
+
+		case len(word) > 1 && word[0] == '!':
+			priority, ok := nlp.vocab().priorityWords[foldCompletionText(strings.TrimRight(word[1:], ",;"))]
+			if !ok {
+				errs = append(errs, fmt.Errorf(i18n.T("error.quickAddInvalidPriority", map[string]interface{}{"Value": word[1:]})))
+				continue
+			}
+			quickAdd.Priority = priority
+
+		case len(word) > 1 && word[0] == '@':
+			var project string
+			project, i = quickAddValue(words, i, word[1:])
+			quickAdd.Project = project
+
+		case len(word) > 1 && word[0] == '^':
+			quickAdd.ParentID = word[1:]
+
+		case isField && (field == "due" || field == "son"):
+			due, next, ok := nlp.quickAddDueValue(words, i, value, today)
+			if !ok {
+				errs = append(errs, fmt.Errorf(i18n.T("error.quickAddInvalidDate", map[string]interface{}{"Value": strings.Trim(value, `"`)})))
+				continue
+			}
+			quickAdd.DueDate = &due
+			i = next
+
+		case isField && (field == "est" || field == "tahmin"):
+			hours, ok := parseQuickAddEstimate(value)
+			if !ok {
+				errs = append(errs, fmt.Errorf(i18n.T("error.quickAddInvalidEstimate", map[string]interface{}{"Value": value})))
+				continue
+			}
+			quickAdd.EstimatedHours = hours
+
+		default:
+			rest = append(rest, word)
+		}
+	}
+
+	// Relative dates in the text, unless due: gave one
+	if quickAdd.DueDate == nil {
+		for i := 0; i < len(rest) && quickAdd.DueDate == nil; i++ {
+			for n := quickAddMaxDateWords; n > 0; n-- {
+				if i+n > len(rest) {
+					continue
+				}
+				phrase := rest[i : i+n]
+				if !nlp.isExplicitDatePhrase(phrase) {
+					continue
+				}
+				if due, ok := nlp.parseDueDate(phrase, today); ok {
+					quickAdd.DueDate = &due
+					rest = append(rest[:i:i], rest[i+n:]...)
+					break
+				}
+			}
+		}
+	}
+
+	title := strings.Join(rest, " ")
+	for _, separator := range []string{": ", " - "} {
+		if before, after, found := strings.Cut(title, separator); found {
+			title = before
+			quickAdd.Description = strings.TrimSpace(after)
+			break
+		}
+	}
+	quickAdd.Title = strings.TrimSpace(strings.Trim(strings.TrimSpace(title), `"'`))
+	return quickAdd, errs
+}
+
+// quickAddValue returns a token value, joining the following words of a "quoted value".
+// It returns the index of the last word used.
+func quickAddValue(words []string, i int, value string) (string, int) {
+	if !strings.HasPrefix(value, `"`) {
+		return value, i
+	}
+	for (len(value) < 2 || !strings.HasSuffix(value, `"`)) && i+1 < len(words) {
+		i++
+		value += " " + words[i]
+	}
+	return strings.Trim(value, `"`), i
+}
+
+// quickAddDueValue reads the date after due:, which may be quoted or span up to
+// quickAddMaxDateWords words ("due:next friday"). It returns the index of the last word used.
+func (nlp *NLPProcessor) quickAddDueValue(words []string, i int, value string, today time.Time) (time.Time, int, bool) {
+	if strings.HasPrefix(value, `"`) {
+		quoted, last := quickAddValue(words, i, value)
+		due, ok := nlp.parseDueDate(strings.Fields(quoted), today)
+		return due, last, ok
+	}
+
+	phrase := []string{}
+	if value != "" {
+		phrase = append(phrase, value)
+	}
+	start := i + 1
+	for n := quickAddMaxDateWords - len(phrase); n >= 0; n-- {
+		if start+n > len(words) {
+			continue
+		}
+		candidate := append(append([]string{}, phrase...), words[start:start+n]...)
+		if len(candidate) == 0 {
+			continue
+		}
+		if due, ok := nlp.parseDueDate(candidate, today); ok {
+			return due, i + n, true
+		}
+	}
+	return time.Time{}, i, false
+}
+
+// isExplicitDatePhrase reports whether words in running text clearly name a date, so
+// that titles such as "Friday standup notes" keep their words
+func (nlp *NLPProcessor) isExplicitDatePhrase(words []string) bool {
+	vocab := nlp.vocab()
+	first := foldCompletionText(strings.TrimRight(words[0], ",.;"))
+	if len(words) == 1 {
+		if _, ok := vocab.dateExpressions[first]; ok {
+			return true
+		}
+		_, err := time.Parse(constants.DateFormatISO, first)
+		return err == nil
+	}
+	if vocab.dateStarters[first] {
+		return true
+	}
+	switch vocab.dateModifiers[first] {
+	case dateModifierNext, dateModifierThis, dateModifierIn:
+		return true
+	}
+	last := foldCompletionText(strings.TrimRight(words[len(words)-1], ",.;"))
+	return vocab.dateModifiers[last] == dateModifierLater
+}
+
+// parseDueDate reads a date phrase relative to today: the time expressions of the
+// language packs, everything parseQueryDay accepts, DD.MM[.YYYY], weekday names (the
+// next such day after today), "next/gelecek/haftaya <weekday>" (that day of next week),
+// "this/bu <weekday>", and "in N days/weeks/months" or "N gün/hafta/ay sonra", where N
+// may be a number word
+func (nlp *NLPProcessor) parseDueDate(words []string, today time.Time) (time.Time, bool) {
+	vocab := nlp.vocab()
+	folded := make([]string, len(words))
+	for i, word := range words {
+		folded[i] = foldCompletionText(strings.TrimRight(word, ",.;"))
+	}
+
+	if relative, ok := vocab.dateExpressions[strings.Join(folded, " ")]; ok {
+		switch relative {
+		case relativeToday:
+			return today, true
+		case relativeTomorrow:
+			return today.AddDate(0, 0, 1), true
+		case relativeDayAfterTomorrow:
+			return today.AddDate(0, 0, 2), true
+		case relativeYesterday:
+			return today.AddDate(0, 0, -1), true
+		case relativeNextWeek:
+			return startOfWeek(today).AddDate(0, 0, 7), true
+		case relativeNextMonth:
+			return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, nlp.TimeZone), true
+		}
+	}
+
+	switch len(folded) {
+	case 1:
+		word := folded[0]
+		if day, ok := parseQueryDay(word, today); ok {
+			return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, nlp.TimeZone), true
+		}
+		if weekday, ok := vocab.weekdays[word]; ok {
+			days := (int(weekday) - int(today.Weekday()) + 7) % 7
+			if days == 0 {
+				days = 7
+			}
+			return today.AddDate(0, 0, days), true
+		}
+		if m := quickAddDottedDate.FindStringSubmatch(word); m != nil {
+			day, _ := strconv.Atoi(m[1])
+			month, _ := strconv.Atoi(m[2])
+			year := today.Year()
+			if m[3] != "" {
+				year, _ = strconv.Atoi(m[3])
+			}
+			date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, nlp.TimeZone)
+			if date.Day() != day || date.Month() != time.Month(month) {
+				return time.Time{}, false
+			}
+			if m[3] == "" && date.Before(today) {
+				date = date.AddDate(1, 0, 0)
+			}
+			return date, true
+		}
+
+	case 2:
+		weekday, ok := vocab.weekdays[folded[1]]
+		if !ok {
+			break
+		}
+		offset := (int(weekday) + 6) % 7 // Days since Monday
+		switch vocab.dateModifiers[folded[0]] {
+		case dateModifierNext:
+			return startOfWeek(today).AddDate(0, 0, 7+offset), true
+		case dateModifierThis:
+			return startOfWeek(today).AddDate(0, 0, offset), true
+		}
+
+	case 3:
+		count, unit := "", ""
+		switch {
+		case vocab.dateModifiers[folded[0]] == dateModifierIn:
+			count, unit = folded[1], folded[2]
+		case vocab.dateModifiers[folded[2]] == dateModifierLater:
+			count, unit = folded[0], folded[1]
+		}
+		n, isNumber := vocab.number(count)
+		step, ok := vocab.dateUnits[unit]
+		if !isNumber || !ok || n < 0 {
+			break
+		}
+		return today.AddDate(0, n*step.months, n*step.days), true
+	}
+	return time.Time{}, false
+}
+
+// startOfWeek returns the Monday of the week of day
+func startOfWeek(day time.Time) time.Time {
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// parseQuickAddEstimate reads estimates such as 3h, 45m, 1h30m, 1.5 or 2d (8-hour days)
+// in hours
+func parseQuickAddEstimate(value string) (float64, bool) {
+	value = foldCompletionText(value)
+	matches := quickAddEstimatePart.FindAllStringSubmatchIndex(value, -1)
+	if len(matches) == 0 {
+		return 0, false
+	}
+
+	hours, end := 0.0, 0
+	for _, m := range matches {
+		if m[0] != end {
+			return 0, false
+		}
+		end = m[1]
+		number, err := strconv.ParseFloat(strings.Replace(value[m[2]:m[3]], ",", ".", 1), 64)
+		factor, ok := quickAddEstimateUnits[value[m[4]:m[5]]]
+		if err != nil || !ok {
+			return 0, false
+		}
+		hours += number * factor
+	}
+	if end != len(value) || hours <= 0 {
+		return 0, false
+	}
+	return math.Round(hours*100) / 100, true
+}
+
+// today returns the start of the current day in the processor's time zone
+func (nlp *NLPProcessor) today() time.Time {
+	now := time.Now().In(nlp.TimeZone)
+	if nlp.now != nil {
+		now = nlp.now().In(nlp.TimeZone)
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, nlp.TimeZone)
+}
+
+// compactName folds a name and drops everything but letters and digits, so that
+// @BackendAPI finds the project "Backend API"
+func compactName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return r
+		}
+		return -1
+	}, foldCompletionText(name))
+}
+
+// HizliGorevEkle creates the task of a quick-add line parsed by HizliEkleAyristir
+func (iy *IsYonetici) HizliGorevEkle(ctx context.Context, quickAdd *QuickAdd) (*Gorev, error) {
+	sonTarih := ""
+	if quickAdd.DueDate != nil {
+		sonTarih = quickAdd.DueDate.Format(constants.DateFormatISO)
+	}
+	oncelik := quickAdd.Priority
+	if oncelik == "" {
+		oncelik = constants.PriorityMedium
+	}
+
+	var gorev *Gorev
+	var err error
+	if quickAdd.ParentID != "" {
+		gorev, err = iy.AltGorevOlustur(ctx, quickAdd.ParentID, quickAdd.Title, quickAdd.Description, oncelik, sonTarih, quickAdd.Tags)
+	} else {
+		gorev, err = iy.GorevOlustur(ctx, quickAdd.Title, quickAdd.Description, oncelik, quickAdd.Project, sonTarih, quickAdd.Tags)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if quickAdd.EstimatedHours > 0 {
+		if err := iy.veriYonetici.GorevGuncelle(ctx, gorev.ID, map[string]interface{}{"estimated_hours": quickAdd.EstimatedHours}); err != nil {
+			return gorev, fmt.Errorf(i18n.TSaveFailed(i18n.FromContext(ctx), "task", err))
+		}
+	}
+	return gorev, nil
+}
+
+// HizliEkleAyristir parses one line of quick-add syntax and resolves its project and
+// parent; Project of the result holds the project ID. The project is looked up by ID
+// or by name, ignoring case, accents and spaces; without one the task goes to the
+// parent's project or the active project.
+func (iy *IsYonetici) HizliEkleAyristir(ctx context.Context, metin string) (*QuickAdd, error) {
+	quickAdd, err := NewNLPProcessor().ParseQuickAdd(metin)
+	if err != nil {
+		return quickAdd, err
+	}
+	if quickAdd.Title == "" {
+		return quickAdd, fmt.Errorf(i18n.T("error.taskTitleRequired"))
+	}
+
+	if quickAdd.Project != "" {
+		projeID, err := iy.projeBul(ctx, quickAdd.Project)
+		if err != nil {
+			return quickAdd, err
+		}
+		quickAdd.Project = projeID
+	}
+
+	if quickAdd.ParentID != "" {
+		parent, err := iy.veriYonetici.GorevGetir(ctx, quickAdd.ParentID)
+		if err != nil {
+			return quickAdd, fmt.Errorf(i18n.T("error.parentTaskNotFound"))
+		}
+		if quickAdd.Project != "" && quickAdd.Project != parent.ProjeID {
+			return quickAdd, fmt.Errorf(i18n.T("error.quickAddParentProjectMismatch", map[string]interface{}{"Parent": parent.Title}))
+		}
+		quickAdd.Project = parent.ProjeID
+	} else if quickAdd.Project == "" {
+		if projeID, err := iy.veriYonetici.AktifProjeGetir(ctx); err == nil {
+			quickAdd.Project = projeID
+		}
+	}
+	return quickAdd, nil
+}
+
+// projeBul finds a project by ID or by its compacted name
+func (iy *IsYonetici) projeBul(ctx context.Context, isim string) (string, error) {
+	projeler, err := iy.veriYonetici.ProjeleriGetir(ctx)
+	if err != nil {
+		return "", fmt.Errorf(i18n.T("error.projectListFailed", map[string]interface{}{"Error": err}))
+	}
+	aranan := compactName(isim)
+	for _, proje := range projeler {
+		if proje.ID == isim {
+			return proje.ID, nil
+		}
+	}
+	for _, proje := range projeler {
+		if compactName(proje.Name) == aranan {
+			return proje.ID, nil
+		}
+	}
+	return "", fmt.Errorf(i18n.T("error.quickAddProjectNotFound", map[string]interface{}{"Project": isim}))
+}
@@ -0,0 +1,160 @@
+package gorev
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newQuickAddTestProcessor returns a processor whose today is Wednesday, 2026-10-14
+func newQuickAddTestProcessor() *NLPProcessor {
+	nlp := &NLPProcessor{TimeZone: time.UTC}
+	nlp.now = func() time.Time { return time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC) }
+	return nlp
+}
+
+func TestNLPProcessor_ParseQuickAdd(t *testing.T) {
+	nlp := newQuickAddTestProcessor()
+
+	quickAdd, err := nlp.ParseQuickAdd("Fix login timeout #auth #bug !yuksek @BackendAPI due:friday ^parent-1 est:3h")
+	require.NoError(t, err)
+	due := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, &QuickAdd{
+		Title:          "Fix login timeout",
+		Tags:           []string{"auth", "bug"},
+		Priority:       constants.PriorityHigh,
+		Project:        "BackendAPI",
+		ParentID:       "parent-1",
+		DueDate:        &due,
+		EstimatedHours: 3,
+	}, quickAdd)
+
+	quickAdd, err = nlp.ParseQuickAdd(`Sürüm notları: değişiklikleri özetle @"Web Sitesi" !düşük #docs #docs`)
+	require.NoError(t, err)
+	assert.Equal(t, "Sürüm notları", quickAdd.Title)
+	assert.Equal(t, "değişiklikleri özetle", quickAdd.Description)
+	assert.Equal(t, "Web Sitesi", quickAdd.Project)
+	assert.Equal(t, constants.PriorityLow, quickAdd.Priority)
+	assert.Equal(t, []string{"docs"}, quickAdd.Tags)
+	assert.Nil(t, quickAdd.DueDate)
+
+	for _, text := range []string{"Fix it !whenever", "Fix it due:someday", "Fix it est:soon"} {
+		_, err := nlp.ParseQuickAdd(text)
+		assert.Error(t, err, text)
+	}
+}
+
+func TestNLPProcessor_ParseQuickAddDates(t *testing.T) {
+	nlp := newQuickAddTestProcessor()
+
+	tests := []struct {
+		text  string
+		title string
+		due   string
+	}{
+		{"Deploy due:2026-11-02", "Deploy", "2026-11-02"},
+		{"Deploy due:20.10", "Deploy", "2026-10-20"},
+		{"Deploy due:01.02", "Deploy", "2027-02-01"},
+		{"Deploy due:+7d", "Deploy", "2026-10-21"},
+		{"Deploy due:wednesday", "Deploy", "2026-10-21"},
+		{"Deploy due:yarın", "Deploy", "2026-10-15"},
+		{"Deploy due:next friday", "Deploy", "2026-10-23"},
+		{`Deploy due:"bu cuma" now`, "Deploy now", "2026-10-16"},
+		{"Deploy due: in 2 weeks", "Deploy", "2026-10-28"},
+		{"Raporu gönder gelecek cuma", "Raporu gönder", "2026-10-23"},
+		{"Raporu gönder haftaya salı", "Raporu gönder", "2026-10-20"},
+		{"Raporu 3 gün sonra gönder", "Raporu gönder", "2026-10-17"},
+		{"Call the bank in 3 days", "Call the bank", "2026-10-17"},
+		{"Plan offsite next week", "Plan offsite", "2026-10-19"},
+		{"Renew domain next month", "Renew domain", "2026-11-01"},
+		{"Review PRs tomorrow", "Review PRs", "2026-10-15"},
+		{"Backup day after tomorrow", "Backup", "2026-10-16"},
+		// Bare weekday names in the text stay part of the title
+		{"Friday standup notes", "Friday standup notes", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			quickAdd, err := nlp.ParseQuickAdd(tt.text)
+			require.NoError(t, err)
+			assert.Equal(t, tt.title, quickAdd.Title)
+			if tt.due == "" {
+				assert.Nil(t, quickAdd.DueDate)
+				return
+			}
+			require.NotNil(t, quickAdd.DueDate)
+			assert.Equal(t, tt.due, quickAdd.DueDate.Format(constants.DateFormatISO))
+		})
+	}
+}
+
+func TestParseQuickAddEstimate(t *testing.T) {
+	for value, hours := range map[string]float64{"3h": 3, "45m": 0.75, "1h30m": 1.5, "2d": 16, "1,5": 1.5, "90dk": 1.5, "2saat": 2} {
+		got, ok := parseQuickAddEstimate(value)
+		assert.True(t, ok, value)
+		assert.Equal(t, hours, got, value)
+	}
+	for _, value := range []string{"", "h", "3x", "0h", "1h-2h"} {
+		_, ok := parseQuickAddEstimate(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestNLPProcessor_ExtractTaskContentQuickAdd(t *testing.T) {
+	nlp := newQuickAddTestProcessor()
+
+	content := nlp.ExtractTaskContent("yeni görev oluştur: API entegrasyonu #backend !high est:2h yarın")
+	assert.Equal(t, "API entegrasyonu", content["title"])
+	assert.Equal(t, []string{"backend"}, content["tags"])
+	assert.Equal(t, constants.PriorityHigh, content["priority"])
+	assert.Equal(t, 2.0, content["estimated_hours"])
+	assert.Equal(t, "2026-10-15T00:00:00Z", content["due_date"])
+}
+
+func TestHizliGorevEkle(t *testing.T) {
+	ctx := context.Background()
+	vy := NewMemoryVeriYonetici()
+	iy := YeniIsYonetici(vy)
+
+	require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: "p-api", Name: "Backend API"}))
+	require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: "p-web", Name: "Web Sitesi"}))
+
+	quickAdd, err := iy.HizliEkleAyristir(ctx, "Fix login timeout #auth !yuksek @backendapi due:2026-12-01 est:3h")
+	require.NoError(t, err)
+	assert.Equal(t, "p-api", quickAdd.Project)
+
+	gorev, err := iy.HizliGorevEkle(ctx, quickAdd)
+	require.NoError(t, err)
+	stored, err := vy.GorevGetir(ctx, gorev.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Fix login timeout", stored.Title)
+	assert.Equal(t, "p-api", stored.ProjeID)
+	assert.Equal(t, constants.PriorityHigh, stored.Priority)
+	require.NotNil(t, stored.DueDate)
+	assert.Equal(t, "2026-12-01", stored.DueDate.Format(constants.DateFormatISO))
+	require.Len(t, stored.Tags, 1)
+	assert.Equal(t, "auth", stored.Tags[0].Name)
+
+	// Subtasks go to the parent's project; without a project the active one is used
+	quickAdd, err = iy.HizliEkleAyristir(ctx, "Write tests ^"+gorev.ID)
+	require.NoError(t, err)
+	subtask, err := iy.HizliGorevEkle(ctx, quickAdd)
+	require.NoError(t, err)
+	assert.Equal(t, gorev.ID, subtask.ParentID)
+	assert.Equal(t, "p-api", subtask.ProjeID)
+	assert.Equal(t, constants.PriorityMedium, subtask.Priority)
+
+	require.NoError(t, vy.AktifProjeAyarla(ctx, "p-web"))
+	quickAdd, err = iy.HizliEkleAyristir(ctx, "Update footer")
+	require.NoError(t, err)
+	assert.Equal(t, "p-web", quickAdd.Project)
+
+	for _, text := range []string{"#only-tags !high", "Deploy @Mobile", "Deploy ^missing", "Deploy @WebSitesi ^" + gorev.ID} {
+		_, err := iy.HizliEkleAyristir(ctx, text)
+		assert.Error(t, err, text)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/msenol/gorev/internal/i18n"
 )
@@ -22,7 +23,7 @@ type ProcessedQuery struct {
 	Confidence    float64           `json:"confidence"`
 }
 
-// SearchEngine handles advanced search functionality with SQL LIKE queries and fuzzy matching
+// SearchEngine handles advanced search functionality with the gorevler_fts FTS5 index and fuzzy matching
 type SearchEngine struct {
 	veriYonetici VeriYoneticiInterface
 	db           *sql.DB
@@ -39,6 +40,7 @@ type SearchOptions struct {
 	SortBy           string                 `json:"sort_by"`
 	SortDirection    string                 `json:"sort_direction"`
 	IncludeCompleted bool                   `json:"include_completed"`
+	IncludeArchived  bool                   `json:"include_archived"`
 	SearchFields     []string               `json:"search_fields"`
 }
 
@@ -48,17 +50,26 @@ type SearchResult struct {
 	RelevanceScore float64  `json:"relevance_score"`
 	MatchType      string   `json:"match_type"` // "exact", "fts", "fuzzy"
 	MatchedFields  []string `json:"matched_fields"`
+	// Highlights holds the matched fields with <mark> around the matching terms;
+	// long descriptions are cut to a snippet around the match
+	Highlights map[string]string `json:"highlights,omitempty"`
 }
 
-// SearchResponse contains search results with metadata
+// SearchResponse contains search results with metadata. TotalCount and Facets cover
+// all matching tasks, Results at most MaxResults of them.
 type SearchResponse struct {
 	Results     []SearchResult `json:"results"`
 	TotalCount  int            `json:"total_count"`
+	Facets      SearchFacets   `json:"facets"`
 	QueryTime   time.Duration  `json:"query_time"`
 	UsedFuzzy   bool           `json:"used_fuzzy"`
 	Suggestions []string       `json:"suggestions"`
 }
 
+// searchCandidateLimit caps the free-text matches a search ranks. TotalCount and Facets
+// are counted in SQL over all matches.
+const searchCandidateLimit = 1000
+
 // SearchHistoryEntry represents a search history record
 type SearchHistoryEntry struct {
 	ID              int       `json:"id"`
@@ -95,18 +106,17 @@ func (se *SearchEngine) PerformSearch(query string, filters SearchFilters) (*Sea
 		       g.parent_id, g.created_at, g.updated_at, g.due_date
 		FROM gorevler g
 		LEFT JOIN projeler p ON g.project_id = p.id
-		WHERE 1=1
 	`
 
 	var args []interface{}
-	argIndex := 0
 
-	// Add text search if query provided
-	if query != "" {
-		sqlQuery += " AND (g.title LIKE ? OR g.description LIKE ?)"
-		likeQuery := "%" + query + "%"
-		args = append(args, likeQuery, likeQuery)
-		argIndex += 2
+	// Add text search through the FTS index if query provided
+	ftsQuery := se.prepareFTSQuery(query)
+	if ftsQuery != "" {
+		sqlQuery += " JOIN gorevler_fts ON gorevler_fts.task_id = g.id WHERE gorevler_fts MATCH ?"
+		args = append(args, ftsQuery)
+	} else {
+		sqlQuery += " WHERE 1=1"
 	}
 
 	// Add status filter
@@ -160,20 +170,17 @@ func (se *SearchEngine) PerformSearch(query string, filters SearchFilters) (*Sea
 		args = append(args, filters.DueBefore)
 	}
 
-	// Order by relevance (title matches first, then description matches)
-	if query != "" {
-		sqlQuery += ` ORDER BY
-			CASE
-				WHEN g.title LIKE ? THEN 1
-				WHEN g.description LIKE ? THEN 2
-				ELSE 3
-			END, g.updated_at DESC`
-		likeQuery := "%" + query + "%"
-		args = append(args, likeQuery, likeQuery)
+	// Order by bm25 relevance, title matches weigh most
+	if ftsQuery != "" {
+		sqlQuery += " ORDER BY " + ftsRankExpr + ", g.updated_at DESC"
 	} else {
 		sqlQuery += " ORDER BY g.updated_at DESC"
 	}
 
+	if se.db == nil {
+		return nil, fmt.Errorf(i18n.T("error.memoryBackendSQLUnavailable", map[string]interface{}{"Operation": "search"}))
+	}
+
 	// Execute query
 	rows, err := se.db.Query(sqlQuery, args...)
 	if err != nil {
@@ -221,14 +228,18 @@ func (se *SearchEngine) PerformSearch(query string, filters SearchFilters) (*Sea
 
 	queryTime := time.Since(startTime)
 
-	// Convert tasks to search results
+	// Convert tasks to search results; the SQL already orders them by relevance
+	matchType := "filter"
+	if ftsQuery != "" {
+		matchType = "fts"
+	}
 	var results []SearchResult
 	for _, task := range tasks {
 		results = append(results, SearchResult{
 			Task:           task,
 			RelevanceScore: 1.0, // Simple scoring for now
-			MatchType:      "like",
-			MatchedFields:  []string{"baslik", "aciklama"},
+			MatchType:      matchType,
+			MatchedFields:  se.getMatchedFields(query, task),
 		})
 	}
 
@@ -262,9 +273,33 @@ func (se *SearchEngine) Search(options SearchOptions) (*SearchResponse, error) {
 	usedFuzzy := false
 	originalQuery := options.Query
 
+	// matchWhere selects the same active tasks as results from the gorevler table, so
+	// that the total and the facets are counted in SQL over all of them; results hold
+	// only the ranked candidates
+	var matchWhere []string
+	var matchArgs []interface{}
+	countInSQL := false
+
+	// Queries with fields, operators, phrases or groups select tasks with the query
+	// language; bare words keep the ranked free-text search below
+	parsed, err := ParseQuery(options.Query)
+	if err != nil {
+		return nil, err
+	}
+	structured := !parsed.IsEmpty() && !parsed.IsPlainText()
+	if structured {
+		results, err = se.performQuerySearch(options, parsed)
+		if err != nil {
+			return nil, err
+		}
+		cond, args := parsed.SQL("g")
+		matchWhere, matchArgs = append(matchWhere, cond), append(matchArgs, args...)
+		countInSQL = true
+	}
+
 	// Process query with NLP for enhanced filtering and suggestions
 	var nlpIntent *QueryIntent
-	if strings.TrimSpace(options.Query) != "" {
+	if !structured && strings.TrimSpace(options.Query) != "" {
 		if intent, err := se.nlpProcessor.ProcessQuery(options.Query); err == nil {
 			nlpIntent = intent
 
@@ -283,14 +318,34 @@ func (se *SearchEngine) Search(options SearchOptions) (*SearchResponse, error) {
 		}
 	}
 
-	// Try FTS5 search first if query is provided
-	if strings.TrimSpace(options.Query) != "" {
-		ftsResults, err := se.performFTSSearch(options)
+	// Try FTS5 search first if query is provided; all candidates are kept for the
+	// facet counts and cut to MaxResults at the end
+	candidates := options
+	if candidates.MaxResults < searchCandidateLimit {
+		candidates.MaxResults = searchCandidateLimit
+	}
+	if !structured && strings.TrimSpace(options.Query) != "" {
+		ftsResults, err := se.performFTSSearch(candidates)
 		if err != nil {
 			log.Printf("%s", i18n.T("error.ftsSearchFailed", map[string]interface{}{"Error": err}))
 		} else {
 			results = append(results, ftsResults...)
 		}
+		var textMatch []string
+		if ftsQuery := se.prepareFTSQuery(options.Query); ftsQuery != "" {
+			textMatch = append(textMatch, "g.id IN (SELECT task_id FROM gorevler_fts WHERE gorevler_fts MATCH ?)")
+			matchArgs = append(matchArgs, ftsQuery)
+			countInSQL = true
+		}
+
+		if options.IncludeArchived {
+			archiveResults, err := se.performArchiveSearch(candidates)
+			if err != nil {
+				log.Printf("%s", i18n.T("error.ftsSearchFailed", map[string]interface{}{"Error": err}))
+			} else {
+				results = append(results, archiveResults...)
+			}
+		}
 
 		// If FTS didn't return enough results and fuzzy is enabled, try fuzzy search
 		if len(results) < options.MaxResults/2 && options.UseFuzzySearch {
@@ -300,20 +355,40 @@ func (se *SearchEngine) Search(options SearchOptions) (*SearchResponse, error) {
 			} else {
 				results = append(results, fuzzyResults...)
 				usedFuzzy = true
+
+				ids := make([]string, len(fuzzyResults))
+				for i, result := range fuzzyResults {
+					ids[i] = result.Task.ID
+				}
+				idList, _ := json.Marshal(ids)
+				textMatch = append(textMatch, "g.id IN (SELECT value FROM json_each(?))")
+				matchArgs = append(matchArgs, string(idList))
+				countInSQL = true
 			}
 		}
+		if len(textMatch) > 0 {
+			matchWhere = append(matchWhere, "("+strings.Join(textMatch, " OR ")+")")
+		}
 	}
 
 	// Apply filters
 	if len(options.Filters) > 0 {
-		if len(results) == 0 {
+		if len(results) == 0 && !structured {
 			// No text search, just filter all tasks
-			allTasks, err := se.veriYonetici.GorevListele(context.Background(), map[string]interface{}{
-				"limit": 1000,
-			})
+			allTasks, err := se.veriYonetici.GorevListele(context.Background(), map[string]interface{}{})
 			if err != nil {
 				return nil, fmt.Errorf(i18n.T("error.tasksRetrieveFailed", map[string]interface{}{"Error": err}))
 			}
+			matchWhere, matchArgs = nil, nil
+			countInSQL = true
+
+			if options.IncludeArchived && se.db != nil {
+				archived, err := arsivGorevleriOku(context.Background(), se.db, " ORDER BY a.archived_at DESC")
+				if err != nil {
+					return nil, fmt.Errorf(i18n.T("error.tasksRetrieveFailed", map[string]interface{}{"Error": err}))
+				}
+				allTasks = append(allTasks, archived...)
+			}
 
 			for _, task := range allTasks {
 				results = append(results, SearchResult{
@@ -326,17 +401,42 @@ func (se *SearchEngine) Search(options SearchOptions) (*SearchResponse, error) {
 		}
 
 		results = se.applyFilters(results, options.Filters)
+		conds, args := searchFiltersSQL(options.Filters, "g", time.Now())
+		matchWhere, matchArgs = append(matchWhere, conds...), append(matchArgs, args...)
 	}
 
 	// Remove duplicates and sort by relevance
 	results = se.removeDuplicates(results)
 	se.sortResults(results, options.SortBy, options.SortDirection)
+	totalCount, facets := len(results), computeSearchFacets(results)
+	if countInSQL && se.db != nil {
+		if len(matchWhere) == 0 {
+			matchWhere = []string{"1 = 1"}
+		}
+		if total, counted, err := se.countMatches(results, strings.Join(matchWhere, " AND "), matchArgs); err != nil {
+			log.Printf("%s", i18n.T("error.searchCountFailed", map[string]interface{}{"Error": err}))
+		} else {
+			totalCount, facets = total, counted
+		}
+	}
 
 	// Limit results
 	if len(results) > options.MaxResults {
 		results = results[:options.MaxResults]
 	}
 
+	// Mark where the text terms matched
+	var terms []string
+	if structured {
+		terms = parsed.TextTerms()
+	} else {
+		terms = searchTerms(options.Query)
+	}
+	words := highlightWords(terms)
+	for i := range results {
+		addHighlights(&results[i], words)
+	}
+
 	// Record search history
 	se.recordSearchHistory(options, len(results), time.Since(startTime))
 
@@ -345,34 +445,57 @@ func (se *SearchEngine) Search(options SearchOptions) (*SearchResponse, error) {
 
 	return &SearchResponse{
 		Results:     results,
-		TotalCount:  len(results),
+		TotalCount:  totalCount,
+		Facets:      facets,
 		QueryTime:   time.Since(startTime),
 		UsedFuzzy:   usedFuzzy,
 		Suggestions: suggestions,
 	}, nil
 }
 
-// performFTSSearch executes FTS5 full-text search
+// ftsRankExpr ranks gorevler_fts rows with bm25; the weights follow the column
+// order task_id, title, description, tags, project_name
+const ftsRankExpr = "bm25(gorevler_fts, 0.0, 10.0, 4.0, 6.0, 2.0)"
+
+// Markers placed around matched terms in highlights
+const (
+	HighlightOpen  = "<mark>"
+	HighlightClose = "</mark>"
+)
+
+// performFTSSearch executes FTS5 full-text search ranked by bm25
 func (se *SearchEngine) performFTSSearch(options SearchOptions) ([]SearchResult, error) {
 	query := se.prepareFTSQuery(options.Query)
 	if query == "" {
 		return nil, nil
 	}
+	if se.db == nil {
+		// No FTS index without a database (in-memory storage)
+		return se.performTermSearch(options)
+	}
 
 	sqlQuery := `
-		SELECT g.id, g.title, g.description, g.status, g.priority, g.due_date,
-		       g.created_at, g.updated_at, g.project_id, g.parent_id,
-		       COALESCE(p.name, '') as proje_adi,
-		       fts.rank
-		FROM gorevler_fts fts
-		JOIN gorevler g ON fts.rowid = g.rowid
-		LEFT JOIN projeler p ON g.project_id = p.id
-		WHERE fts MATCH ?
-		ORDER BY fts.rank
+		SELECT g.id, g.title, COALESCE(g.description, ''), g.status, g.priority, g.due_date,
+		       g.created_at, g.updated_at, g.project_id, COALESCE(p.name, ''), g.parent_id,
+		       (SELECT GROUP_CONCAT(e.id || char(31) || e.name, char(30))
+		        FROM gorev_etiketleri ge JOIN etiketler e ON e.id = ge.tag_id WHERE ge.task_id = g.id),
+		       ` + ftsRankExpr + ` AS rank,
+		       highlight(gorevler_fts, 1, ?, ?),
+		       snippet(gorevler_fts, 2, ?, ?, '…', 16),
+		       highlight(gorevler_fts, 3, ?, ?)
+		FROM gorevler_fts
+		JOIN gorevler g ON g.id = gorevler_fts.task_id
+		LEFT JOIN projeler p ON p.id = g.project_id
+		WHERE gorevler_fts MATCH ?
+		ORDER BY rank
 		LIMIT ?
 	`
 
-	rows, err := se.db.Query(sqlQuery, query, options.MaxResults)
+	rows, err := se.db.Query(sqlQuery,
+		HighlightOpen, HighlightClose,
+		HighlightOpen, HighlightClose,
+		HighlightOpen, HighlightClose,
+		query, options.MaxResults)
 	if err != nil {
 		return nil, fmt.Errorf(i18n.T("error.ftsSearchFailed", map[string]interface{}{"Error": err}))
 	}
@@ -381,40 +504,242 @@ func (se *SearchEngine) performFTSSearch(options SearchOptions) ([]SearchResult,
 	var results []SearchResult
 	for rows.Next() {
 		var task Gorev
-		var projeAdi string
+		var projeID, parentID, etiketler sql.NullString
 		var rank float64
+		var titleHighlight, descriptionSnippet, tagsHighlight string
 
 		err := rows.Scan(
 			&task.ID, &task.Title, &task.Description, &task.Status, &task.Priority,
 			&task.DueDate, &task.CreatedAt, &task.UpdatedAt,
-			&task.ProjeID, &task.ParentID, &projeAdi, &rank,
+			&projeID, &task.ProjeName, &parentID, &etiketler, &rank,
+			&titleHighlight, &descriptionSnippet, &tagsHighlight,
 		)
 		if err != nil {
 			log.Printf("%s", i18n.T("error.scanResultFailed", map[string]interface{}{"Error": err}))
 			continue
 		}
+		task.ProjeID = projeID.String
+		task.ParentID = parentID.String
+		task.Tags = etiketleriAyristir(etiketler.String)
+
+		// The index knows which fields matched, including stemmed matches
+		highlights := map[string]string{}
+		var matchedFields []string
+		for _, field := range []struct{ name, text string }{
+			{"baslik", titleHighlight},
+			{"aciklama", descriptionSnippet},
+			{"etiketler", tagsHighlight},
+		} {
+			if strings.Contains(field.text, HighlightOpen) {
+				highlights[field.name] = field.text
+				matchedFields = append(matchedFields, field.name)
+			}
+		}
+		if len(matchedFields) == 0 {
+			// Only the project name matched
+			matchedFields = []string{"proje_adi"}
+		}
 
-		// Load tags - first get the task details which includes tags
-		taskDetail, err := se.veriYonetici.GorevDetay(context.Background(), task.ID)
-		if err == nil && taskDetail != nil {
-			task.Tags = taskDetail.Tags
+		results = append(results, SearchResult{
+			Task:           &task,
+			RelevanceScore: se.calculateFTSRelevance(rank, options.Query, &task),
+			MatchType:      "fts",
+			MatchedFields:  matchedFields,
+			Highlights:     highlights,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(i18n.T("error.ftsSearchFailed", map[string]interface{}{"Error": err}))
+	}
+
+	return results, nil
+}
+
+// performQuerySearch lists the tasks matching a structured query and ranks them by its
+// free-text terms with bm25, or by the weighted term score without the FTS index
+func (se *SearchEngine) performQuerySearch(options SearchOptions, query *Query) ([]SearchResult, error) {
+	ctx := context.Background()
+	tasks, err := se.veriYonetici.GorevListele(ctx, map[string]interface{}{"query": query})
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.searchQueryFailed", map[string]interface{}{"Error": err}))
+	}
+	if options.IncludeArchived && se.db != nil {
+		archived, err := arsivGorevleriOku(ctx, se.db, " ORDER BY a.archived_at DESC")
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("error.searchQueryFailed", map[string]interface{}{"Error": err}))
+		}
+		for _, task := range archived {
+			if query.Matches(task) {
+				tasks = append(tasks, task)
+			}
 		}
+	}
 
-		// Calculate relevance score based on FTS rank
-		relevanceScore := se.calculateFTSRelevance(rank, options.Query, &task)
+	terms := query.TextTerms()
+	ranks := make(map[string]float64)
+	if len(terms) > 0 && se.db != nil {
+		exprs := make([]string, len(terms))
+		for i, term := range terms {
+			exprs[i] = (&queryText{text: term, phrase: strings.Contains(term, " ")}).ftsExpr()
+		}
+		rows, err := se.db.Query(`SELECT task_id, `+ftsRankExpr+` FROM gorevler_fts WHERE gorevler_fts MATCH ?`,
+			strings.Join(exprs, " OR "))
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("error.ftsSearchFailed", map[string]interface{}{"Error": err}))
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id string
+			var rank float64
+			if err := rows.Scan(&id, &rank); err != nil {
+				return nil, fmt.Errorf(i18n.T("error.ftsSearchFailed", map[string]interface{}{"Error": err}))
+			}
+			ranks[id] = rank
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf(i18n.T("error.ftsSearchFailed", map[string]interface{}{"Error": err}))
+		}
+	}
 
-		// Determine matched fields
-		matchedFields := se.getMatchedFields(options.Query, &task)
+	textQuery := strings.Join(terms, " ")
+	var scored map[string]SearchResult
+	if len(terms) > 0 && se.db == nil {
+		scored = make(map[string]SearchResult)
+		for _, result := range se.scoreTerms(tasks, terms, SearchOptions{Query: textQuery, MaxResults: len(tasks)}) {
+			scored[result.Task.ID] = result
+		}
+	}
 
+	// Without free-text terms every match is equally relevant; with them, tasks that
+	// matched only through other terms rank last
+	baseScore := 1.0
+	if len(terms) > 0 {
+		baseScore = 0
+	}
+	results := make([]SearchResult, 0, len(tasks))
+	for _, task := range tasks {
+		result := SearchResult{Task: task, RelevanceScore: baseScore, MatchType: "query", MatchedFields: []string{}}
+		if rank, ok := ranks[task.ID]; ok && task.ArchivedAt == nil {
+			result.RelevanceScore = se.calculateFTSRelevance(rank, textQuery, task)
+			result.MatchedFields = se.getMatchedFields(textQuery, task)
+		} else if termResult, ok := scored[task.ID]; ok {
+			result.RelevanceScore = termResult.RelevanceScore
+			result.MatchedFields = termResult.MatchedFields
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// termFieldWeights follow the bm25 column weights of ftsRankExpr
+var termFieldWeights = map[string]float64{"baslik": 10.0, "aciklama": 4.0, "etiketler": 6.0, "proje_adi": 2.0}
+
+// searchTerms splits a query into lower-case words without FTS syntax characters
+func searchTerms(query string) []string {
+	var terms []string
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if cleaned := strings.Trim(word, `"'*()[]{}`); cleaned != "" {
+			terms = append(terms, cleaned)
+		}
+	}
+	return terms
+}
+
+// performTermSearch matches query words as prefixes of task words without the FTS
+// index, for data managers that have no database
+func (se *SearchEngine) performTermSearch(options SearchOptions) ([]SearchResult, error) {
+	terms := searchTerms(options.Query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	allTasks, err := se.veriYonetici.GorevListele(context.Background(), map[string]interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.ftsSearchFailed", map[string]interface{}{"Error": err}))
+	}
+	return se.scoreTerms(allTasks, terms, options), nil
+}
+
+// performArchiveSearch searches archived tasks, which are not in the FTS index. A LIKE
+// prefilter keeps the scan in SQL; the candidates are ranked like performTermSearch.
+func (se *SearchEngine) performArchiveSearch(options SearchOptions) ([]SearchResult, error) {
+	terms := searchTerms(options.Query)
+	if len(terms) == 0 || se.db == nil {
+		return nil, nil
+	}
+
+	var clauses []string
+	var args []interface{}
+	for _, term := range terms {
+		pattern := "%" + term + "%"
+		clauses = append(clauses, `(a.title LIKE ? OR a.description LIKE ? OR p.name LIKE ? OR EXISTS (
+			SELECT 1 FROM gorev_etiketleri_arsiv ge JOIN etiketler e ON e.id = ge.tag_id
+			WHERE ge.task_id = a.id AND e.name LIKE ?))`)
+		args = append(args, pattern, pattern, pattern, pattern)
+	}
+
+	tasks, err := arsivGorevleriOku(context.Background(), se.db, " WHERE "+strings.Join(clauses, " OR "), args...)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.ftsSearchFailed", map[string]interface{}{"Error": err}))
+	}
+	return se.scoreTerms(tasks, terms, options), nil
+}
+
+// scoreTerms ranks tasks by the weighted fields in which a word starts with one of the terms
+func (se *SearchEngine) scoreTerms(tasks []*Gorev, terms []string, options SearchOptions) []SearchResult {
+	var results []SearchResult
+	scores := make(map[string]float64)
+	for _, task := range tasks {
+		tagNames := make([]string, 0, len(task.Tags))
+		for _, tag := range task.Tags {
+			tagNames = append(tagNames, tag.Name)
+		}
+		fields := []struct{ name, text string }{
+			{"baslik", task.Title},
+			{"aciklama", task.Description},
+			{"etiketler", strings.Join(tagNames, " ")},
+			{"proje_adi", task.ProjeName},
+		}
+
+		var score float64
+		var matchedFields []string
+		for _, field := range fields {
+			words := strings.FieldsFunc(strings.ToLower(field.text), func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+			})
+			matched := false
+			for _, term := range terms {
+				for _, word := range words {
+					if strings.HasPrefix(word, term) {
+						score += termFieldWeights[field.name]
+						matched = true
+					}
+				}
+			}
+			if matched {
+				matchedFields = append(matchedFields, field.name)
+			}
+		}
+		if score == 0 {
+			continue
+		}
+
+		scores[task.ID] = score
 		results = append(results, SearchResult{
-			Task:           &task,
-			RelevanceScore: relevanceScore,
+			Task:           task,
+			RelevanceScore: se.calculateFTSRelevance(-score, options.Query, task),
 			MatchType:      "fts",
 			MatchedFields:  matchedFields,
 		})
 	}
 
-	return results, nil
+	sort.SliceStable(results, func(i, j int) bool {
+		return scores[results[i].Task.ID] > scores[results[j].Task.ID]
+	})
+	if len(results) > options.MaxResults {
+		results = results[:options.MaxResults]
+	}
+	return results
 }
 
 // performFuzzySearch executes fuzzy string matching
@@ -558,7 +883,8 @@ func min(a, b, c int) int {
 	return c
 }
 
-// prepareFTSQuery prepares the query for FTS5 search
+// prepareFTSQuery turns free text into an FTS5 MATCH expression. Every word becomes
+// a quoted prefix term, so punctuation such as "-" or ":" cannot break the FTS5 syntax.
 func (se *SearchEngine) prepareFTSQuery(query string) string {
 	query = strings.TrimSpace(query)
 	if query == "" {
@@ -574,18 +900,21 @@ func (se *SearchEngine) prepareFTSQuery(query string) string {
 		cleaned := strings.Trim(word, `"'*()[]{}`)
 		if len(cleaned) > 0 {
 			// Add wildcard for partial matching
-			ftsTerms = append(ftsTerms, cleaned+"*")
+			ftsTerms = append(ftsTerms, `"`+strings.ReplaceAll(cleaned, `"`, `""`)+`"*`)
 		}
 	}
 
-	// Join with OR for broad matching
+	// Join with OR for broad matching; bm25 ranks tasks matching more terms higher
 	return strings.Join(ftsTerms, " OR ")
 }
 
 // calculateFTSRelevance calculates relevance score for FTS results
 func (se *SearchEngine) calculateFTSRelevance(rank float64, query string, task *Gorev) float64 {
-	// FTS rank is negative (closer to 0 = better)
-	baseScore := 1.0 / (1.0 + (-rank))
+	// bm25 is negative, more negative is a better match; map it to 0..1
+	if rank > 0 {
+		rank = 0
+	}
+	baseScore := -rank / (1.0 - rank)
 
 	// Boost score for exact matches in title
 	queryLower := strings.ToLower(query)
@@ -612,6 +941,9 @@ func (se *SearchEngine) calculateFTSRelevance(rank float64, query string, task *
 func (se *SearchEngine) getMatchedFields(query string, task *Gorev) []string {
 	var matched []string
 	queryLower := strings.ToLower(query)
+	if queryLower == "" {
+		return matched
+	}
 
 	if strings.Contains(strings.ToLower(task.Title), queryLower) {
 		matched = append(matched, "baslik")
@@ -667,7 +999,7 @@ func (se *SearchEngine) matchesFilters(task *Gorev, filters map[string]interface
 			}
 		case "son_tarih":
 			if valueStr, ok := value.(string); ok {
-				if !se.matchesDateFilter(task.DueDate, valueStr) {
+				if !searchDateFilter(valueStr, time.Now()).matches(task) {
 					return false
 				}
 			}
@@ -690,32 +1022,76 @@ func (se *SearchEngine) matchesFilters(task *Gorev, filters map[string]interface
 	return true
 }
 
-// matchesDateFilter checks if a date matches the given filter
-func (se *SearchEngine) matchesDateFilter(taskDate *time.Time, filter string) bool {
-	if taskDate == nil {
-		return filter == "no_date"
-	}
-
-	now := time.Now()
+// searchDateFilter returns the condition of a son_tarih filter relative to the local
+// day of now; unknown filters match every task with a due date
+func searchDateFilter(filter string, now time.Time) *queryDate {
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-
+	day := func(days int) *time.Time {
+		t := today.AddDate(0, 0, days)
+		return &t
+	}
+	node := &queryDate{field: queryFieldDue, column: "due_date"}
 	switch filter {
+	case "no_date":
+		node.none = true
 	case "today":
-		return taskDate.Format("2006-01-02") == today.Format("2006-01-02")
+		node.from, node.to = day(0), day(1)
 	case "tomorrow":
-		tomorrow := today.AddDate(0, 0, 1)
-		return taskDate.Format("2006-01-02") == tomorrow.Format("2006-01-02")
+		node.from, node.to = day(1), day(2)
 	case "this_week":
-		weekStart := today.AddDate(0, 0, -int(today.Weekday()))
-		weekEnd := weekStart.AddDate(0, 0, 7)
-		return taskDate.After(weekStart) && taskDate.Before(weekEnd)
+		weekStart := -int(today.Weekday())
+		node.from, node.to = day(weekStart), day(weekStart+7)
 	case "overdue":
-		return taskDate.Before(today)
+		node.to = day(0)
 	case "upcoming":
-		return taskDate.After(today)
+		node.from = day(1)
 	}
+	return node
+}
 
-	return true
+// searchFiltersSQL returns the conditions of matchesFilters on the gorevler table
+func searchFiltersSQL(filters map[string]interface{}, alias string, now time.Time) ([]string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	for key, value := range filters {
+		valueStr, ok := value.(string)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "durum":
+			conds = append(conds, alias+".status = ?")
+			args = append(args, valueStr)
+		case "oncelik":
+			conds = append(conds, alias+".priority = ?")
+			args = append(args, valueStr)
+		case "proje_id":
+			conds = append(conds, "COALESCE("+alias+".project_id, '') = ?")
+			args = append(args, valueStr)
+		case "son_tarih":
+			conds = append(conds, searchDateFilter(valueStr, now).sql(alias, &args))
+		case "etiket":
+			conds = append(conds, "EXISTS (SELECT 1 FROM gorev_etiketleri ge JOIN etiketler e ON e.id = ge.tag_id WHERE ge.task_id = "+alias+".id AND e.name = ?)")
+			args = append(args, valueStr)
+		}
+	}
+	return conds, args
+}
+
+// countMatches counts the active tasks matching where in SQL and adds the archived
+// results, which are always loaded in full
+func (se *SearchEngine) countMatches(results []SearchResult, where string, args []interface{}) (int, SearchFacets, error) {
+	var counter searchFacetCounter
+	if err := counter.countSQL(context.Background(), se.db, where, args); err != nil {
+		return 0, SearchFacets{}, err
+	}
+	today := searchToday()
+	for _, result := range results {
+		if result.Task.ArchivedAt != nil {
+			counter.addTask(result.Task, today)
+		}
+	}
+	return counter.total, counter.facets(), nil
 }
 
 // sortResults sorts search results by the specified criteria
@@ -788,7 +1164,7 @@ func (se *SearchEngine) recordSearchHistory(options SearchOptions, resultCount i
 
 	filtersJSON, _ := json.Marshal(options.Filters)
 
-	_, err := se.db.Exec(`
+	_, err := yardimciExec(se.veriYonetici, se.db, `
 		INSERT INTO search_history (query, filters, result_count, execution_time_ms)
 		VALUES (?, ?, ?, ?)
 	`, options.Query, string(filtersJSON), resultCount, int(executionTime.Milliseconds()))
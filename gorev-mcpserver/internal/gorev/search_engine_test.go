@@ -2,7 +2,10 @@ package gorev
 
 import (
 	"context"
+	"database/sql"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/msenol/gorev/internal/i18n"
 	"github.com/stretchr/testify/assert"
@@ -73,3 +76,216 @@ func TestSearchEngine_PerformSearch(t *testing.T) {
 	}
 	assert.True(t, found, "Should find task1 in search results")
 }
+
+func TestSearchEngine_FTSIndex(t *testing.T) {
+	ctx := context.Background()
+	veriYonetici, err := YeniVeriYonetici(":memory:", "file://../../internal/veri/migrations")
+	require.NoError(t, err)
+	defer veriYonetici.Kapat()
+
+	db, err := veriYonetici.GetDB()
+	require.NoError(t, err)
+	searchEngine := NewSearchEngine(veriYonetici, db)
+
+	proje, err := veriYonetici.ProjeOlustur(ctx, "Backend API", "")
+	require.NoError(t, err)
+	deploy, err := veriYonetici.GorevOlusturBasit(ctx, "Deployment pipeline", "Automate the deployments to staging", proje.ID, "orta", "", "", "")
+	require.NoError(t, err)
+	_, err = veriYonetici.GorevOlusturBasit(ctx, "Write docs", "Mention the deployment once", "", "orta", "", "", "")
+	require.NoError(t, err)
+
+	search := func(query string) []SearchResult {
+		results, err := searchEngine.performFTSSearch(SearchOptions{Query: query, MaxResults: 10})
+		require.NoError(t, err)
+		return results
+	}
+
+	// Word stems match ("deployments" finds "Deployment"), title matches rank first and carry highlights
+	results := search("deployments")
+	require.Len(t, results, 2)
+	assert.Equal(t, deploy.ID, results[0].Task.ID)
+	assert.Greater(t, results[0].RelevanceScore, 0.0)
+	assert.Contains(t, results[0].Highlights["baslik"], "<mark>Deployment</mark>")
+	assert.Contains(t, results[0].MatchedFields, "aciklama")
+
+	// Punctuation must not break the FTS5 syntax
+	assert.Empty(t, search(`pipe-line: "x`))
+
+	// Tags and project names are kept in sync by triggers
+	etiketler, err := veriYonetici.EtiketleriGetirVeyaOlustur(ctx, []string{"infrastructure"})
+	require.NoError(t, err)
+	require.NoError(t, veriYonetici.GorevEtiketleriniAyarla(ctx, deploy.ID, etiketler))
+	results = search("infrastructure")
+	require.Len(t, results, 1)
+	assert.Equal(t, []string{"etiketler"}, results[0].MatchedFields)
+
+	require.NoError(t, veriYonetici.GorevEtiketleriniAyarla(ctx, deploy.ID, nil))
+	assert.Empty(t, search("infrastructure"))
+
+	_, err = db.Exec("UPDATE projeler SET name = 'Platform' WHERE id = ?", proje.ID)
+	require.NoError(t, err)
+	results = search("platform")
+	require.Len(t, results, 1)
+	assert.Equal(t, []string{"proje_adi"}, results[0].MatchedFields)
+
+	require.NoError(t, veriYonetici.GorevGuncelle(ctx, deploy.ID, map[string]interface{}{"title": "Release checklist"}))
+	assert.Len(t, search("checklist"), 1)
+
+	require.NoError(t, veriYonetici.GorevSil(ctx, deploy.ID))
+	assert.Empty(t, search("checklist"))
+}
+
+func TestSearchEngine_TermSearchWithoutDatabase(t *testing.T) {
+	ctx := context.Background()
+	veriYonetici := NewMemoryVeriYonetici()
+	searchEngine := NewSearchEngine(veriYonetici, nil)
+
+	require.NoError(t, veriYonetici.ProjeKaydet(ctx, &Proje{ID: "p1", Name: "Backend API"}))
+	deploy, err := veriYonetici.GorevOlustur(ctx, map[string]interface{}{"title": "Deployment pipeline", "description": "Automate staging", "proje_id": "p1"})
+	require.NoError(t, err)
+	docs, err := veriYonetici.GorevOlustur(ctx, map[string]interface{}{"title": "Write docs", "description": "Mention the deployment once"})
+	require.NoError(t, err)
+
+	// Without an FTS index words are matched as prefixes; title matches rank first
+	results, err := searchEngine.performFTSSearch(SearchOptions{Query: "deploy", MaxResults: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, deploy, results[0].Task.ID)
+	assert.Equal(t, []string{"baslik"}, results[0].MatchedFields)
+	assert.Equal(t, docs, results[1].Task.ID)
+	assert.Equal(t, []string{"aciklama"}, results[1].MatchedFields)
+
+	results, err = searchEngine.performFTSSearch(SearchOptions{Query: "backend", MaxResults: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, []string{"proje_adi"}, results[0].MatchedFields)
+
+	response, err := searchEngine.Search(SearchOptions{Query: "pipeline", Filters: map[string]interface{}{}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.TotalCount)
+}
+
+func TestSearchEngine_HighlightsAndFacets(t *testing.T) {
+	ctx := context.Background()
+	veriYonetici := NewMemoryVeriYonetici()
+	searchEngine := NewSearchEngine(veriYonetici, nil)
+
+	require.NoError(t, veriYonetici.ProjeKaydet(ctx, &Proje{ID: "p1", Name: "Backend API"}))
+	long := "One two three four five six seven eight nine ten eleven twelve deploy thirteen fourteen fifteen sixteen seventeen eighteen"
+	overdue := time.Now().AddDate(0, 0, -3)
+	for _, task := range []*Gorev{
+		{ID: "g1", Title: "Deployment pipeline", Description: long, ProjeID: "p1", Priority: "yuksek", DueDate: &overdue},
+		{ID: "g2", Title: "Deploy docs", ProjeID: "p1", Priority: "orta"},
+		{ID: "g3", Title: "Redeploy staging", Description: "deploy again", Priority: "orta"},
+	} {
+		task.Status = "beklemede"
+		require.NoError(t, veriYonetici.GorevKaydet(ctx, task))
+	}
+
+	response, err := searchEngine.Search(SearchOptions{Query: "deploy", Filters: map[string]interface{}{}, MaxResults: 1})
+	require.NoError(t, err)
+	require.Len(t, response.Results, 1)
+	assert.Equal(t, 3, response.TotalCount)
+
+	// Words starting with a term are marked; long descriptions become snippets
+	highlights := response.Results[0].Highlights
+	assert.Equal(t, "<mark>Deployment</mark> pipeline", highlights["baslik"])
+	assert.Equal(t, "…four five six seven eight nine ten eleven twelve <mark>deploy</mark> thirteen fourteen fifteen sixteen seventeen eighteen", highlights["aciklama"])
+
+	assert.Equal(t, []FacetCount{
+		{Value: "p1", Label: "Backend API", Count: 2, Query: "project:p1"},
+		{Value: queryNone, Count: 1, Query: "project:none"},
+	}, response.Facets.Project)
+	assert.Equal(t, []FacetCount{
+		{Value: DueBucketOverdue, Count: 1, Query: "due<today"},
+		{Value: DueBucketNone, Count: 2, Query: "due:none"},
+	}, response.Facets.Due)
+	assert.Equal(t, "priority:orta", response.Facets.Priority[0].Query)
+	assert.Equal(t, 2, response.Facets.Priority[0].Count)
+}
+
+func TestSearchEngine_CountsAllMatches(t *testing.T) {
+	ctx := context.Background()
+	veriYonetici, err := YeniVeriYonetici(":memory:", "file://../../internal/veri/migrations")
+	require.NoError(t, err)
+	defer veriYonetici.Kapat()
+	db, err := veriYonetici.GetDB()
+	require.NoError(t, err)
+	searchEngine := NewSearchEngine(veriYonetici, db)
+
+	// More matches than the candidates a search ranks: 1200 deploy tasks, half of them
+	// done, 1100 in the project, 300 tagged and 50 overdue
+	overdue := time.Now().AddDate(0, 0, -3)
+	require.NoError(t, veriYonetici.YazmaIslemi(ctx, func(tx *sql.Tx) error {
+		_, err := tx.Exec(`INSERT INTO projeler (id, name, definition, created_at, updated_at) VALUES ('p1', 'Backend API', '', datetime('now'), datetime('now'));
+			INSERT INTO etiketler (id, name) VALUES ('e1', 'release');
+			INSERT INTO gorevler (id, title, description, status, priority, created_at, updated_at)
+			VALUES ('other', 'Write changelog', '', 'beklemede', 'orta', datetime('now'), datetime('now'))`)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 1200)
+			INSERT INTO gorevler (id, title, description, status, priority, project_id, due_date, created_at, updated_at)
+			SELECT 'g' || i, 'Deploy step ' || i, '', CASE WHEN i % 2 = 0 THEN 'tamamlandi' ELSE 'beklemede' END, 'orta',
+			       CASE WHEN i <= 1100 THEN 'p1' END, CASE WHEN i <= 50 THEN ? END, datetime('now'), datetime('now')
+			FROM n`, overdue)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO gorev_etiketleri (task_id, tag_id) SELECT id, 'e1' FROM gorevler WHERE id != 'other' AND CAST(substr(id, 2) AS INTEGER) <= 300`)
+		return err
+	}))
+
+	response, err := searchEngine.Search(SearchOptions{Query: "deploy", Filters: map[string]interface{}{}, MaxResults: 10})
+	require.NoError(t, err)
+	assert.Len(t, response.Results, 10)
+	assert.Equal(t, 1200, response.TotalCount)
+	assert.Equal(t, []FacetCount{
+		{Value: "beklemede", Count: 600, Query: "status:beklemede"},
+		{Value: "tamamlandi", Count: 600, Query: "status:tamamlandi"},
+	}, response.Facets.Status)
+	assert.Equal(t, []FacetCount{
+		{Value: "p1", Label: "Backend API", Count: 1100, Query: "project:p1"},
+		{Value: queryNone, Count: 100, Query: "project:none"},
+	}, response.Facets.Project)
+	assert.Equal(t, []FacetCount{
+		{Value: queryNone, Count: 900, Query: "tag:none"},
+		{Value: "release", Count: 300, Query: "tag:release"},
+	}, response.Facets.Tag)
+	assert.Equal(t, []FacetCount{
+		{Value: DueBucketOverdue, Count: 50, Query: "due<today"},
+		{Value: DueBucketNone, Count: 1150, Query: "due:none"},
+	}, response.Facets.Due)
+
+	// Structured queries and filters without a query count in SQL as well
+	response, err = searchEngine.Search(SearchOptions{Query: "deploy status:beklemede", Filters: map[string]interface{}{}})
+	require.NoError(t, err)
+	assert.Equal(t, 600, response.TotalCount)
+
+	response, err = searchEngine.Search(SearchOptions{Filters: map[string]interface{}{"proje_id": "p1", "son_tarih": "no_date"}})
+	require.NoError(t, err)
+	assert.Len(t, response.Results, 50)
+	assert.Equal(t, 1050, response.TotalCount)
+	assert.Equal(t, []FacetCount{{Value: "p1", Label: "Backend API", Count: 1050, Query: "project:p1"}}, response.Facets.Project)
+}
+
+func TestSearchIndexMigrationBackfill(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratorTestDB(t)
+	m, err := NewMigrator(db, os.DirFS("../../internal/veri/migrations"))
+	require.NoError(t, err)
+
+	_, err = m.Up(ctx, 13)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO projeler (id, name, definition, created_at, updated_at) VALUES ('p1', 'Mobile', '', datetime('now'), datetime('now'));
+		INSERT INTO gorevler (id, title, description, status, priority, project_id, created_at, updated_at)
+		VALUES ('t1', 'Existing task', 'Created before the index', 'beklemede', 'orta', 'p1', datetime('now'), datetime('now'));`)
+	require.NoError(t, err)
+
+	_, err = m.Up(ctx, 0)
+	require.NoError(t, err)
+
+	var taskID string
+	require.NoError(t, db.QueryRow("SELECT task_id FROM gorevler_fts WHERE gorevler_fts MATCH 'project_name:mobile'").Scan(&taskID))
+	assert.Equal(t, "t1", taskID)
+}
@@ -0,0 +1,375 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// FacetCount is the number of search results with one value of a field. Query is the
+// term of the query language that narrows the search to the value.
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int    `json:"count"`
+	Query string `json:"query"`
+}
+
+// SearchFacets counts all results of a search, not only the returned page, by status,
+// priority, project, tag and due date bucket. Values are ordered by count; due buckets
+// keep their chronological order.
+type SearchFacets struct {
+	Status   []FacetCount `json:"status"`
+	Priority []FacetCount `json:"priority"`
+	Project  []FacetCount `json:"project"`
+	Tag      []FacetCount `json:"tag"`
+	Due      []FacetCount `json:"due"`
+}
+
+// Due date buckets of SearchFacets.Due
+const (
+	DueBucketOverdue = "overdue"
+	DueBucketToday   = "today"
+	DueBucketWeek    = "week"
+	DueBucketLater   = "later"
+	DueBucketNone    = queryNone
+)
+
+// dueBucketQueries are the query terms of the due buckets, in display order; days
+// follow the query language, so a bucket count equals the results of its query
+var dueBucketQueries = []struct{ bucket, query string }{
+	{DueBucketOverdue, "due<today"},
+	{DueBucketToday, "due:today"},
+	{DueBucketWeek, "due:tomorrow..+7d"},
+	{DueBucketLater, "due>+7d"},
+	{DueBucketNone, "due:none"},
+}
+
+// dueBucket returns the due bucket of a task relative to today (UTC midnight)
+func dueBucket(due *time.Time, today time.Time) string {
+	switch {
+	case due == nil:
+		return DueBucketNone
+	case due.Before(today):
+		return DueBucketOverdue
+	case due.Before(today.AddDate(0, 0, 1)):
+		return DueBucketToday
+	case due.Before(today.AddDate(0, 0, 8)):
+		return DueBucketWeek
+	default:
+		return DueBucketLater
+	}
+}
+
+// facetCounter collects the counts of one facet
+type facetCounter struct {
+	counts map[string]*FacetCount
+}
+
+func (fc *facetCounter) add(value, label, query string, n int) {
+	if fc.counts == nil {
+		fc.counts = make(map[string]*FacetCount)
+	}
+	if count, ok := fc.counts[value]; ok {
+		count.Count += n
+		return
+	}
+	fc.counts[value] = &FacetCount{Value: value, Label: label, Count: n, Query: query}
+}
+
+// sorted returns the counts, most frequent first and by value on ties
+func (fc *facetCounter) sorted() []FacetCount {
+	counts := make([]FacetCount, 0, len(fc.counts))
+	for _, count := range fc.counts {
+		counts = append(counts, *count)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Value < counts[j].Value
+	})
+	return counts
+}
+
+// searchFacetCounter collects the total and the facet counts of a search
+type searchFacetCounter struct {
+	total                          int
+	status, priority, project, tag facetCounter
+	due                            map[string]int
+}
+
+func (c *searchFacetCounter) addStatus(status string, n int) {
+	c.status.add(status, "", queryFieldStatus+":"+quoteQueryValue(status), n)
+}
+
+func (c *searchFacetCounter) addPriority(priority string, n int) {
+	c.priority.add(priority, "", queryFieldPriority+":"+quoteQueryValue(priority), n)
+}
+
+func (c *searchFacetCounter) addProject(projeID, projeName string, n int) {
+	if projeID == "" {
+		c.project.add(queryNone, "", queryFieldProject+":"+queryNone, n)
+		return
+	}
+	c.project.add(projeID, projeName, queryFieldProject+":"+quoteQueryValue(projeID), n)
+}
+
+func (c *searchFacetCounter) addTag(name string, n int) {
+	if name == "" {
+		c.tag.add(queryNone, "", queryFieldTag+":"+queryNone, n)
+		return
+	}
+	c.tag.add(name, "", queryFieldTag+":"+quoteQueryValue(name), n)
+}
+
+func (c *searchFacetCounter) addDue(bucket string, n int) {
+	if c.due == nil {
+		c.due = make(map[string]int)
+	}
+	c.due[bucket] += n
+}
+
+// addTask counts one task; today is the UTC midnight of the due buckets
+func (c *searchFacetCounter) addTask(task *Gorev, today time.Time) {
+	c.total++
+	c.addStatus(task.Status, 1)
+	c.addPriority(task.Priority, 1)
+	c.addProject(task.ProjeID, task.ProjeName, 1)
+	if len(task.Tags) == 0 {
+		c.addTag("", 1)
+	}
+	for _, etiket := range task.Tags {
+		c.addTag(etiket.Name, 1)
+	}
+	c.addDue(dueBucket(task.DueDate, today), 1)
+}
+
+// countSQL counts the tasks of the gorevler table (alias "g") matching where with
+// aggregate queries, so the counts cover every match however many there are
+func (c *searchFacetCounter) countSQL(ctx context.Context, db *sql.DB, where string, args []interface{}) error {
+	// One pass for the total, the tasks without tags and the due buckets; the bucket
+	// conditions are the compiled bucket queries
+	columns := []string{"COUNT(*)", "COALESCE(SUM(CASE WHEN NOT EXISTS (SELECT 1 FROM gorev_etiketleri ge WHERE ge.task_id = g.id) THEN 1 ELSE 0 END), 0)"}
+	var columnArgs []interface{}
+	for _, bucket := range dueBucketQueries {
+		query, err := ParseQuery(bucket.query)
+		if err != nil {
+			return err
+		}
+		cond, condArgs := query.SQL("g")
+		columns = append(columns, "COALESCE(SUM(CASE WHEN "+cond+" THEN 1 ELSE 0 END), 0)")
+		columnArgs = append(columnArgs, condArgs...)
+	}
+	var total, untagged int
+	dueCounts := make([]int, len(dueBucketQueries))
+	dest := []interface{}{&total, &untagged}
+	for i := range dueCounts {
+		dest = append(dest, &dueCounts[i])
+	}
+	err := db.QueryRowContext(ctx, "SELECT "+strings.Join(columns, ", ")+" FROM gorevler g WHERE "+where,
+		append(columnArgs, args...)...).Scan(dest...)
+	if err != nil {
+		return err
+	}
+	c.total += total
+	if untagged > 0 {
+		c.addTag("", untagged)
+	}
+	for i, bucket := range dueBucketQueries {
+		if dueCounts[i] > 0 {
+			c.addDue(bucket.bucket, dueCounts[i])
+		}
+	}
+
+	groups := []struct {
+		query string
+		add   func(value, label string, n int)
+	}{
+		{`SELECT g.status, '', COUNT(*) FROM gorevler g WHERE ` + where + ` GROUP BY g.status`,
+			func(value, _ string, n int) { c.addStatus(value, n) }},
+		{`SELECT g.priority, '', COUNT(*) FROM gorevler g WHERE ` + where + ` GROUP BY g.priority`,
+			func(value, _ string, n int) { c.addPriority(value, n) }},
+		{`SELECT COALESCE(g.project_id, ''), COALESCE(MAX(p.name), ''), COUNT(*)
+		  FROM gorevler g LEFT JOIN projeler p ON p.id = g.project_id
+		  WHERE ` + where + ` GROUP BY COALESCE(g.project_id, '')`,
+			c.addProject},
+		{`SELECT e.name, '', COUNT(*)
+		  FROM gorevler g JOIN gorev_etiketleri ge ON ge.task_id = g.id JOIN etiketler e ON e.id = ge.tag_id
+		  WHERE ` + where + ` GROUP BY e.name`,
+			func(value, _ string, n int) { c.addTag(value, n) }},
+	}
+	for _, group := range groups {
+		if err := countGroups(ctx, db, group.query, args, group.add); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countGroups reads the value, label and count rows of a GROUP BY query
+func countGroups(ctx context.Context, db *sql.DB, query string, args []interface{}, add func(value, label string, n int)) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var value, label string
+		var n int
+		if err := rows.Scan(&value, &label, &n); err != nil {
+			return err
+		}
+		add(value, label, n)
+	}
+	return rows.Err()
+}
+
+// facets returns the collected counts
+func (c *searchFacetCounter) facets() SearchFacets {
+	facets := SearchFacets{
+		Status:   c.status.sorted(),
+		Priority: c.priority.sorted(),
+		Project:  c.project.sorted(),
+		Tag:      c.tag.sorted(),
+		Due:      []FacetCount{},
+	}
+	for _, bucket := range dueBucketQueries {
+		if count := c.due[bucket.bucket]; count > 0 {
+			facets.Due = append(facets.Due, FacetCount{Value: bucket.bucket, Count: count, Query: bucket.query})
+		}
+	}
+	return facets
+}
+
+// searchToday returns the UTC midnight the due buckets count from
+func searchToday() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// computeSearchFacets counts the results by field
+func computeSearchFacets(results []SearchResult) SearchFacets {
+	var counter searchFacetCounter
+	today := searchToday()
+	for _, result := range results {
+		counter.addTask(result.Task, today)
+	}
+	return counter.facets()
+}
+
+// searchSnippetWords is the length of description snippets, as in the FTS snippet() call
+const searchSnippetWords = 16
+
+// highlightWords splits search terms and phrases into the lower-case words to highlight
+func highlightWords(terms []string) []string {
+	var words []string
+	for _, term := range terms {
+		words = append(words, strings.FieldsFunc(strings.ToLower(term), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		})...)
+	}
+	return words
+}
+
+// highlightText puts highlight marks around the words of text that start with one of
+// words, like the FTS highlight() function does for prefix queries. With snippetWords
+// > 0, longer texts are cut to that many words around the first match. It reports
+// whether anything matched.
+func highlightText(text string, words []string, snippetWords int) (string, bool) {
+	type segment struct {
+		text   string
+		marked bool
+	}
+	var segments []segment
+	var wordIndexes []int
+	firstMatch := -1
+	r := []rune(text)
+	for i := 0; i < len(r); {
+		j := i
+		isWord := unicode.IsLetter(r[i]) || unicode.IsNumber(r[i])
+		for j < len(r) && (unicode.IsLetter(r[j]) || unicode.IsNumber(r[j])) == isWord {
+			j++
+		}
+		seg := segment{text: string(r[i:j])}
+		if isWord {
+			lower := strings.ToLower(seg.text)
+			for _, word := range words {
+				if strings.HasPrefix(lower, word) {
+					seg.marked = true
+					break
+				}
+			}
+			if seg.marked && firstMatch < 0 {
+				firstMatch = len(wordIndexes)
+			}
+			wordIndexes = append(wordIndexes, len(segments))
+		}
+		segments = append(segments, seg)
+		i = j
+	}
+	if firstMatch < 0 {
+		return text, false
+	}
+
+	start, end := 0, len(segments)
+	prefix, suffix := "", ""
+	if snippetWords > 0 && len(wordIndexes) > snippetWords {
+		// Keep a little context before the first match
+		first := firstMatch - snippetWords/4
+		if first < 0 {
+			first = 0
+		}
+		if first+snippetWords > len(wordIndexes) {
+			first = len(wordIndexes) - snippetWords
+		}
+		last := first + snippetWords - 1
+		start, end = wordIndexes[first], wordIndexes[last]+1
+		if first > 0 {
+			prefix = "…"
+		}
+		if last < len(wordIndexes)-1 {
+			suffix = "…"
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	for _, seg := range segments[start:end] {
+		if seg.marked {
+			b.WriteString(HighlightOpen + seg.text + HighlightClose)
+		} else {
+			b.WriteString(seg.text)
+		}
+	}
+	b.WriteString(suffix)
+	return b.String(), true
+}
+
+// addHighlights fills the highlights of a result that has none from the index, such
+// as results of structured queries, archived tasks and the in-memory backend
+func addHighlights(result *SearchResult, words []string) {
+	if len(result.Highlights) > 0 || len(words) == 0 {
+		return
+	}
+	tagNames := make([]string, 0, len(result.Task.Tags))
+	for _, etiket := range result.Task.Tags {
+		tagNames = append(tagNames, etiket.Name)
+	}
+	highlights := make(map[string]string)
+	if text, ok := highlightText(result.Task.Title, words, 0); ok {
+		highlights["baslik"] = text
+	}
+	if text, ok := highlightText(result.Task.Description, words, searchSnippetWords); ok {
+		highlights["aciklama"] = text
+	}
+	if text, ok := highlightText(strings.Join(tagNames, " "), words, 0); ok {
+		highlights["etiketler"] = text
+	}
+	if len(highlights) > 0 {
+		result.Highlights = highlights
+	}
+}
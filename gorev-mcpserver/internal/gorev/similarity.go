@@ -0,0 +1,489 @@
+package gorev
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+const (
+	// DefaultSimilarLimit is the number of similar tasks returned when no limit is given
+	DefaultSimilarLimit = 5
+	// MaxSimilarLimit caps the number of similar tasks of one query
+	MaxSimilarLimit = 50
+	// DefaultSimilarMinScore is the lowest score of a related task
+	DefaultSimilarMinScore = 0.2
+	// DuplicateSimilarityThreshold is the lowest score of a possible duplicate
+	DuplicateSimilarityThreshold = 0.5
+	// similarityUntrackedTTL is the lifetime of an index over a data manager that does
+	// not report writes
+	similarityUntrackedTTL = time.Second
+	// similarityMinStemLength is the shortest stem left by suffix stripping
+	similarityMinStemLength = 3
+	// similarityTitleWeight counts title words more than description words
+	similarityTitleWeight = 2
+	// similarityMaxCommonTerms bounds the shared terms reported with a match
+	similarityMaxCommonTerms = 5
+	// similarityReloadRatio is the share of changed tasks above which a sync reloads
+	// the whole task list instead of the changed rows one by one
+	similarityReloadRatio = 4
+)
+
+// SimilarTask is a task similar to a query, with its cosine similarity (0-1) and the
+// stemmed terms that contributed most
+type SimilarTask struct {
+	Task        *Gorev   `json:"task"`
+	Score       float64  `json:"score"`
+	CommonTerms []string `json:"common_terms,omitempty"`
+}
+
+// SimilarityOptions selects similar tasks: those of the task TaskID, or of Text. Tasks
+// in ExcludeIDs are skipped, and so are completed and cancelled tasks with ActiveOnly.
+type SimilarityOptions struct {
+	TaskID     string
+	Text       string
+	Limit      int
+	MinScore   float64
+	ActiveOnly bool
+	ExcludeIDs []string
+}
+
+// similarityDoc is an indexed task
+type similarityDoc struct {
+	task      *Gorev
+	signature string             // Title and description the terms were computed from
+	terms     map[string]float64 // Term frequencies; title terms count similarityTitleWeight times
+	norm      float64            // Length of the TF-IDF vector; 0 when stale
+}
+
+// SimilarityIndex finds similar tasks by the cosine similarity of TF-IDF vectors of
+// their titles and descriptions. Words are folded, stopwords dropped and suffixes
+// stripped with the vocabulary of the language packs, so "görevleri" matches "görev"
+// and "tests" matches "test". It runs locally without any model.
+//
+// The index follows writes incrementally: when the generation of a
+// CachedVeriYonetici changes (or after similarityUntrackedTTL for other data
+// managers) only the IDs and update times of the tasks are read, and just the rows
+// whose update time changed are loaded and tokenized again; document frequencies are
+// adjusted by the difference. Data managers without a database reload the task list.
+type SimilarityIndex struct {
+	veriYonetici VeriYoneticiInterface
+	workspaceID  string
+	vocabulary   *nlpVocabulary // Registered language packs when nil
+
+	syncMu     sync.Mutex // Serializes syncs
+	mu         sync.RWMutex
+	docs       map[string]*similarityDoc
+	docFreq    map[string]int
+	built      bool
+	builtAt    time.Time
+	generation uint64
+}
+
+// NewSimilarityIndex creates an empty index over the tasks of workspaceID (all tasks
+// when empty); it is built by the first query
+func NewSimilarityIndex(veriYonetici VeriYoneticiInterface, workspaceID string) *SimilarityIndex {
+	return &SimilarityIndex{
+		veriYonetici: veriYonetici,
+		workspaceID:  workspaceID,
+		docs:         make(map[string]*similarityDoc),
+		docFreq:      make(map[string]int),
+	}
+}
+
+// vocab returns the vocabulary used for tokenizing
+func (si *SimilarityIndex) vocab() *nlpVocabulary {
+	if si.vocabulary == nil {
+		return defaultNLPVocabulary()
+	}
+	return si.vocabulary
+}
+
+// sync brings the index up to date with the data manager
+func (si *SimilarityIndex) sync(ctx context.Context) error {
+	si.syncMu.Lock()
+	defer si.syncMu.Unlock()
+
+	generation, tracked := uint64(0), false
+	if tracker, ok := si.veriYonetici.(interface{ Generation() uint64 }); ok {
+		generation, tracked = tracker.Generation(), true
+	}
+	si.mu.RLock()
+	fresh := si.built && (tracked && generation == si.generation || !tracked && time.Since(si.builtAt) <= similarityUntrackedTTL)
+	si.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+
+	gorevler, present, err := si.loadChanges(ctx)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.similarityIndexFailed", map[string]interface{}{"Error": err}))
+	}
+
+	// Tokenize outside the lock; only tasks whose text changed are tokenized
+	si.mu.RLock()
+	changed := make(map[string]*similarityDoc)
+	refreshed := make(map[string]*Gorev)
+	for _, gorev := range gorevler {
+		signature := gorev.Title + "\x00" + gorev.Description
+		if doc, ok := si.docs[gorev.ID]; ok && doc.signature == signature {
+			refreshed[gorev.ID] = gorev
+			continue
+		}
+		changed[gorev.ID] = &similarityDoc{task: gorev, signature: signature, terms: si.documentTerms(gorev)}
+	}
+	var removed []string
+	for id := range si.docs {
+		if !present[id] {
+			removed = append(removed, id)
+		}
+	}
+	si.mu.RUnlock()
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	for _, id := range removed {
+		si.removeDoc(id)
+	}
+	// Unchanged texts keep their terms; status and other fields are refreshed
+	for id, gorev := range refreshed {
+		if doc, ok := si.docs[id]; ok {
+			doc.task = gorev
+		}
+	}
+	for id, doc := range changed {
+		si.removeDoc(id)
+		si.docs[id] = doc
+		for term := range doc.terms {
+			si.docFreq[term]++
+		}
+	}
+	if len(removed) > 0 || len(changed) > 0 {
+		// Document frequencies changed, so every vector length is stale
+		for _, doc := range si.docs {
+			doc.norm = 0
+		}
+	}
+	si.built = true
+	si.builtAt = time.Now()
+	si.generation = generation
+	return nil
+}
+
+// loadChanges returns the tasks that are new or were updated since they were indexed,
+// and the IDs of all tasks present. The first build, data managers without a database
+// and large changes load the whole task list.
+func (si *SimilarityIndex) loadChanges(ctx context.Context) ([]*Gorev, map[string]bool, error) {
+	si.mu.RLock()
+	built := si.built
+	indexed := make(map[string]time.Time, len(si.docs))
+	for id, doc := range si.docs {
+		indexed[id] = doc.task.UpdatedAt
+	}
+	si.mu.RUnlock()
+
+	db, err := si.veriYonetici.GetReadDB()
+	if !built || err != nil || db == nil {
+		return si.loadAll(ctx)
+	}
+
+	query, args := `SELECT id, updated_at FROM gorevler`, []interface{}{}
+	if si.workspaceID != "" {
+		query += ` WHERE workspace_id = ?`
+		args = append(args, si.workspaceID)
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	present := make(map[string]bool, len(indexed))
+	var changedIDs []string
+	for rows.Next() {
+		var id string
+		var updatedAt time.Time
+		if err := rows.Scan(&id, &updatedAt); err != nil {
+			return nil, nil, err
+		}
+		present[id] = true
+		if at, ok := indexed[id]; !ok || !at.Equal(updatedAt) {
+			changedIDs = append(changedIDs, id)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(changedIDs) > len(indexed)/similarityReloadRatio+1 {
+		return si.loadAll(ctx)
+	}
+
+	gorevler := make([]*Gorev, 0, len(changedIDs))
+	for _, id := range changedIDs {
+		gorev, err := si.veriYonetici.GorevGetir(ctx, id)
+		if err != nil {
+			// Deleted since the scan
+			delete(present, id)
+			continue
+		}
+		gorevler = append(gorevler, gorev)
+	}
+	return gorevler, present, nil
+}
+
+// loadAll returns the whole task list of the workspace
+func (si *SimilarityIndex) loadAll(ctx context.Context) ([]*Gorev, map[string]bool, error) {
+	filters := map[string]interface{}{}
+	if si.workspaceID != "" {
+		filters["workspace_id"] = si.workspaceID
+	}
+	gorevler, err := si.veriYonetici.GorevListele(ctx, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+	present := make(map[string]bool, len(gorevler))
+	for _, gorev := range gorevler {
+		present[gorev.ID] = true
+	}
+	return gorevler, present, nil
+}
+
+// removeDoc drops a task and its document frequencies; the caller holds si.mu
+func (si *SimilarityIndex) removeDoc(id string) {
+	doc, ok := si.docs[id]
+	if !ok {
+		return
+	}
+	for term := range doc.terms {
+		if si.docFreq[term]--; si.docFreq[term] <= 0 {
+			delete(si.docFreq, term)
+		}
+	}
+	delete(si.docs, id)
+}
+
+// documentTerms returns the term frequencies of a task
+func (si *SimilarityIndex) documentTerms(gorev *Gorev) map[string]float64 {
+	terms := make(map[string]float64)
+	for _, term := range si.vocab().similarityTerms(gorev.Title) {
+		terms[term] += similarityTitleWeight
+	}
+	for _, term := range si.vocab().similarityTerms(gorev.Description) {
+		terms[term]++
+	}
+	return terms
+}
+
+// weight is the TF-IDF weight of a term: sublinear term frequency times smoothed
+// inverse document frequency; the caller holds si.mu
+func (si *SimilarityIndex) weight(term string, frequency float64) float64 {
+	idf := math.Log(float64(len(si.docs)+1)/float64(si.docFreq[term]+1)) + 1
+	return (1 + math.Log(frequency)) * idf
+}
+
+// vectorNorm returns the length of the TF-IDF vector of terms; the caller holds si.mu
+func (si *SimilarityIndex) vectorNorm(terms map[string]float64) float64 {
+	sum := 0.0
+	for term, frequency := range terms {
+		w := si.weight(term, frequency)
+		sum += w * w
+	}
+	return math.Sqrt(sum)
+}
+
+// Similar returns the tasks most similar to a task or a text, best first
+func (si *SimilarityIndex) Similar(ctx context.Context, options SimilarityOptions) ([]SimilarTask, error) {
+	if options.TaskID == "" && strings.TrimSpace(options.Text) == "" {
+		return nil, fmt.Errorf(i18n.T("error.similarQueryRequired"))
+	}
+	limit := options.Limit
+	if limit <= 0 {
+		limit = DefaultSimilarLimit
+	}
+	if limit > MaxSimilarLimit {
+		limit = MaxSimilarLimit
+	}
+	minScore := options.MinScore
+	if minScore <= 0 {
+		minScore = DefaultSimilarMinScore
+	}
+
+	if err := si.sync(ctx); err != nil {
+		return nil, err
+	}
+
+	// Norms are filled in lazily, so the whole query holds the write lock
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	var query map[string]float64
+	exclude := make(map[string]bool, len(options.ExcludeIDs)+1)
+	for _, id := range options.ExcludeIDs {
+		exclude[id] = true
+	}
+	if options.TaskID != "" {
+		exclude[options.TaskID] = true
+		if doc, ok := si.docs[options.TaskID]; ok {
+			query = doc.terms
+		} else {
+			// Tasks outside the index, e.g. archived ones, are tokenized on the fly
+			gorev, err := si.veriYonetici.GorevGetir(ctx, options.TaskID)
+			if err != nil {
+				return nil, err
+			}
+			query = si.documentTerms(gorev)
+		}
+	}
+	if text := strings.TrimSpace(options.Text); text != "" {
+		if query == nil {
+			query = make(map[string]float64)
+		}
+		for _, term := range si.vocab().similarityTerms(text) {
+			query[term]++
+		}
+	}
+
+	queryNorm := si.vectorNorm(query)
+	if queryNorm == 0 {
+		return []SimilarTask{}, nil
+	}
+	queryWeights := make(map[string]float64, len(query))
+	for term, frequency := range query {
+		queryWeights[term] = si.weight(term, frequency)
+	}
+
+	type termContribution struct {
+		term  string
+		value float64
+	}
+	var results []SimilarTask
+	for id, doc := range si.docs {
+		if exclude[id] || len(doc.terms) == 0 {
+			continue
+		}
+		if options.ActiveOnly && (doc.task.Status == constants.TaskStatusCompleted || doc.task.Status == constants.TaskStatusCancelled) {
+			continue
+		}
+
+		dot := 0.0
+		var contributions []termContribution
+		for term, queryWeight := range queryWeights {
+			frequency, ok := doc.terms[term]
+			if !ok {
+				continue
+			}
+			value := queryWeight * si.weight(term, frequency)
+			dot += value
+			contributions = append(contributions, termContribution{term, value})
+		}
+		if dot == 0 {
+			continue
+		}
+		if doc.norm == 0 {
+			doc.norm = si.vectorNorm(doc.terms)
+		}
+		score := dot / (queryNorm * doc.norm)
+		if score < minScore {
+			continue
+		}
+
+		sort.Slice(contributions, func(i, j int) bool {
+			if contributions[i].value != contributions[j].value {
+				return contributions[i].value > contributions[j].value
+			}
+			return contributions[i].term < contributions[j].term
+		})
+		var common []string
+		for i, c := range contributions {
+			if i == similarityMaxCommonTerms {
+				break
+			}
+			common = append(common, c.term)
+		}
+		results = append(results, SimilarTask{Task: doc.task, Score: math.Round(math.Min(score, 1)*1000) / 1000, CommonTerms: common})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Task.ID < results[j].Task.ID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	if results == nil {
+		results = []SimilarTask{}
+	}
+	return results, nil
+}
+
+// similarityTerms splits text into folded, stemmed words without stopwords
+func (v *nlpVocabulary) similarityTerms(text string) []string {
+	words := strings.FieldsFunc(foldCompletionText(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		if utf8.RuneCountInString(word) < constants.MinWordLength || v.stopwords[word] {
+			continue
+		}
+		terms = append(terms, v.stem(word))
+	}
+	return terms
+}
+
+// stem strips suffixes of the language packs, longest first, until none is left or
+// fewer than similarityMinStemLength letters would remain. It is deliberately crude:
+// "sayfası" and "sayfasındaki" both become "sayf", and the same word always yields
+// the same stem, which is all similarity needs.
+func (v *nlpVocabulary) stem(word string) string {
+	if strings.IndexFunc(word, unicode.IsLetter) < 0 {
+		return word
+	}
+	for stripped := true; stripped; {
+		stripped = false
+		for _, suffix := range v.stemSuffixes {
+			if strings.HasSuffix(word, suffix) && utf8.RuneCountInString(word)-utf8.RuneCountInString(suffix) >= similarityMinStemLength {
+				word = strings.TrimSuffix(word, suffix)
+				stripped = true
+				break
+			}
+		}
+	}
+	return word
+}
+
+// BenzerlikIndeksi returns the similarity index of the workspace, shared by similar
+// task queries, duplicate warnings and suggestions
+func (iy *IsYonetici) BenzerlikIndeksi() *SimilarityIndex {
+	iy.benzerlikOnce.Do(func() {
+		iy.benzerlikIndeks = NewSimilarityIndex(iy.veriYonetici, iy.workspaceID)
+	})
+	return iy.benzerlikIndeks
+}
+
+// BenzerGorevler returns the tasks most similar to a task or a text
+func (iy *IsYonetici) BenzerGorevler(ctx context.Context, options SimilarityOptions) ([]SimilarTask, error) {
+	return iy.BenzerlikIndeksi().Similar(ctx, options)
+}
+
+// OlasiKopyalar returns open tasks that are probably duplicates of a task with the
+// given title and description; haricID (the task itself, once created) is skipped
+func (iy *IsYonetici) OlasiKopyalar(ctx context.Context, baslik, aciklama, haricID string) ([]SimilarTask, error) {
+	return iy.BenzerlikIndeksi().Similar(ctx, SimilarityOptions{
+		Text:       strings.Repeat(baslik+" ", similarityTitleWeight) + aciklama,
+		Limit:      constants.MaxSuggestionsToShow,
+		MinScore:   DuplicateSimilarityThreshold,
+		ActiveOnly: true,
+		ExcludeIDs: []string{haricID},
+	})
+}
@@ -0,0 +1,191 @@
+package gorev
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSimilarityTestData adds tasks in English and Turkish, two pairs of which are
+// near-duplicates
+func newSimilarityTestData(t *testing.T) VeriYoneticiInterface {
+	ctx := context.Background()
+	vy := NewMemoryVeriYonetici()
+	for _, task := range []*Gorev{
+		{ID: "g-login", Title: "Fix login timeout on mobile", Description: "Users are logged out after 5 minutes", Status: constants.TaskStatusPending},
+		{ID: "g-login2", Title: "Login timeout fix", Status: constants.TaskStatusPending},
+		{ID: "g-giris", Title: "Giriş sayfasındaki hataları düzelt", Description: "Şifre alanı boş kalınca hata veriyor", Status: constants.TaskStatusPending},
+		{ID: "g-giris2", Title: "Giriş sayfası hatası", Status: constants.TaskStatusInProgress},
+		{ID: "g-readme", Title: "Update README documentation", Description: "Describe the new installation steps", Status: constants.TaskStatusPending},
+		{ID: "g-notes", Title: "Write release notes", Description: "Summarize the changes of the release", Status: constants.TaskStatusCompleted},
+		{ID: "g-dark", Title: "Add dark mode to settings", Status: constants.TaskStatusPending},
+	} {
+		task.Priority = constants.PriorityMedium
+		require.NoError(t, vy.GorevKaydet(ctx, task))
+	}
+	return vy
+}
+
+func similarIDs(similar []SimilarTask) []string {
+	ids := make([]string, 0, len(similar))
+	for _, s := range similar {
+		ids = append(ids, s.Task.ID)
+	}
+	return ids
+}
+
+func TestSimilarityIndex_Similar(t *testing.T) {
+	ctx := context.Background()
+	index := NewSimilarityIndex(newSimilarityTestData(t), "")
+
+	similar, err := index.Similar(ctx, SimilarityOptions{TaskID: "g-login"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g-login2"}, similarIDs(similar))
+	assert.Greater(t, similar[0].Score, DuplicateSimilarityThreshold)
+	assert.Contains(t, similar[0].CommonTerms, "timeout")
+
+	// Inflected Turkish words share their stems
+	similar, err = index.Similar(ctx, SimilarityOptions{TaskID: "g-giris2"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g-giris"}, similarIDs(similar))
+
+	similar, err = index.Similar(ctx, SimilarityOptions{Text: "release notes for v2"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g-notes"}, similarIDs(similar))
+
+	similar, err = index.Similar(ctx, SimilarityOptions{Text: "release notes for v2", ActiveOnly: true})
+	require.NoError(t, err)
+	assert.Empty(t, similar)
+
+	similar, err = index.Similar(ctx, SimilarityOptions{Text: "login timeout", ExcludeIDs: []string{"g-login2"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g-login"}, similarIDs(similar))
+
+	// Stopwords alone match nothing
+	similar, err = index.Similar(ctx, SimilarityOptions{Text: "the and ve ile"})
+	require.NoError(t, err)
+	assert.Empty(t, similar)
+
+	_, err = index.Similar(ctx, SimilarityOptions{})
+	assert.Error(t, err)
+	_, err = index.Similar(ctx, SimilarityOptions{TaskID: "missing"})
+	assert.Error(t, err)
+}
+
+func TestSimilarityIndex_IncrementalUpdates(t *testing.T) {
+	ctx := context.Background()
+	cached := NewCachedVeriYonetici(newSimilarityTestData(t), CacheOptions{})
+	index := NewSimilarityIndex(cached, "")
+
+	similar, err := index.Similar(ctx, SimilarityOptions{Text: "dark theme"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g-dark"}, similarIDs(similar))
+	readmeTerms := index.docs["g-readme"].terms
+
+	require.NoError(t, cached.GorevKaydet(ctx, &Gorev{ID: "g-theme", Title: "Dark theme for the editor", Status: constants.TaskStatusPending, Priority: constants.PriorityLow}))
+	require.NoError(t, cached.GorevGuncelle(ctx, "g-dark", map[string]interface{}{"title": "Add profile page"}))
+	similar, err = index.Similar(ctx, SimilarityOptions{Text: "dark theme"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g-theme"}, similarIDs(similar))
+
+	// Unchanged tasks are not tokenized again, but their status is current
+	require.NoError(t, cached.GorevGuncelle(ctx, "g-readme", map[string]interface{}{"status": constants.TaskStatusCompleted}))
+	similar, err = index.Similar(ctx, SimilarityOptions{Text: "README documentation"})
+	require.NoError(t, err)
+	require.Len(t, similar, 1)
+	assert.Equal(t, constants.TaskStatusCompleted, similar[0].Task.Status)
+	assert.Equal(t, readmeTerms, index.docs["g-readme"].terms)
+
+	require.NoError(t, cached.GorevSil(ctx, "g-theme"))
+	similar, err = index.Similar(ctx, SimilarityOptions{Text: "dark theme"})
+	require.NoError(t, err)
+	assert.Empty(t, similar)
+	assert.NotContains(t, index.docFreq, "theme")
+}
+
+// countingVeriYonetici counts the task loads of the similarity index
+type countingVeriYonetici struct {
+	VeriYoneticiInterface
+	listed, fetched int
+}
+
+func (c *countingVeriYonetici) GorevListele(ctx context.Context, filters map[string]interface{}) ([]*Gorev, error) {
+	c.listed++
+	return c.VeriYoneticiInterface.GorevListele(ctx, filters)
+}
+
+func (c *countingVeriYonetici) GorevGetir(ctx context.Context, id string) (*Gorev, error) {
+	c.fetched++
+	return c.VeriYoneticiInterface.GorevGetir(ctx, id)
+}
+
+func TestSimilarityIndex_LoadsOnlyChangedRows(t *testing.T) {
+	ctx := context.Background()
+	_, vy := newImportTestManager(t)
+	for i, title := range []string{"Fix login timeout on mobile", "Update README documentation", "Write release notes", "Add dark mode to settings", "Profile page layout"} {
+		require.NoError(t, vy.GorevKaydet(ctx, &Gorev{ID: fmt.Sprintf("s%d", i), Title: title, Status: constants.TaskStatusPending, Priority: constants.PriorityMedium}))
+	}
+	counter := &countingVeriYonetici{VeriYoneticiInterface: vy}
+	cached := NewCachedVeriYonetici(counter, CacheOptions{})
+	index := NewSimilarityIndex(cached, "")
+
+	_, err := index.Similar(ctx, SimilarityOptions{Text: "dark theme"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, counter.listed)
+
+	// After the first build only the edited row is loaded again
+	counter.listed, counter.fetched = 0, 0
+	require.NoError(t, cached.GorevGuncelle(ctx, "s3", map[string]interface{}{"title": "Dark theme for the editor", "updated_at": time.Now()}))
+	similar, err := index.Similar(ctx, SimilarityOptions{Text: "dark theme"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s3"}, similarIDs(similar))
+	assert.Equal(t, 0, counter.listed)
+	assert.Equal(t, 1, counter.fetched)
+	assert.Equal(t, "Dark theme for the editor", index.docs["s3"].task.Title)
+
+	require.NoError(t, cached.GorevSil(ctx, "s3"))
+	similar, err = index.Similar(ctx, SimilarityOptions{Text: "dark theme"})
+	require.NoError(t, err)
+	assert.Empty(t, similar)
+	assert.Equal(t, 0, counter.listed)
+	assert.NotContains(t, index.docs, "s3")
+}
+
+func TestNLPVocabulary_SimilarityTerms(t *testing.T) {
+	vocab := defaultNLPVocabulary()
+	assert.Equal(t, []string{"gir", "sayf", "hat", "duzelt"}, vocab.similarityTerms("Giriş sayfasındaki hataları düzelt"))
+	assert.Equal(t, vocab.similarityTerms("sayfası görevi"), vocab.similarityTerms("sayfasındaki görevler"))
+	assert.Equal(t, vocab.similarityTerms("fix test user"), vocab.similarityTerms("fixes tests users"))
+	assert.Equal(t, []string{"404"}, vocab.similarityTerms("the 404 a"))
+}
+
+func TestOlasiKopyalar(t *testing.T) {
+	ctx := context.Background()
+	iy := YeniIsYonetici(newSimilarityTestData(t))
+
+	kopyalar, err := iy.OlasiKopyalar(ctx, "Login timeout on mobile", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g-login", "g-login2"}, similarIDs(kopyalar))
+
+	// The new task itself and completed tasks are not duplicates
+	kopyalar, err = iy.OlasiKopyalar(ctx, "Fix login timeout on mobile", "", "g-login")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g-login2"}, similarIDs(kopyalar))
+
+	kopyalar, err = iy.OlasiKopyalar(ctx, "Write release notes", "", "")
+	require.NoError(t, err)
+	assert.Empty(t, kopyalar)
+
+	kopyalar, err = iy.OlasiKopyalar(ctx, "Add a profile page", "", "")
+	require.NoError(t, err)
+	assert.Empty(t, kopyalar)
+
+	benzerler, err := iy.BenzerGorevler(ctx, SimilarityOptions{TaskID: "g-giris", Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g-giris2"}, similarIDs(benzerler))
+}
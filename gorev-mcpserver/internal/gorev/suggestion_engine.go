@@ -15,6 +15,7 @@ import (
 type SuggestionEngine struct {
 	veriYonetici     VeriYoneticiInterface
 	aiContextManager *AIContextYonetici
+	similarityIndex  *SimilarityIndex
 }
 
 // NewSuggestionEngine creates a new suggestion engine
@@ -29,6 +30,20 @@ func (se *SuggestionEngine) SetAIContextManager(acm *AIContextYonetici) {
 	se.aiContextManager = acm
 }
 
+// SetSimilarityIndex shares a similarity index, e.g. IsYonetici.BenzerlikIndeksi, so
+// that similar tasks are not indexed again
+func (se *SuggestionEngine) SetSimilarityIndex(index *SimilarityIndex) {
+	se.similarityIndex = index
+}
+
+// similarity returns the similarity index, creating one over all tasks if none is set
+func (se *SuggestionEngine) similarity() *SimilarityIndex {
+	if se.similarityIndex == nil {
+		se.similarityIndex = NewSimilarityIndex(se.veriYonetici, "")
+	}
+	return se.similarityIndex
+}
+
 // Suggestion represents a suggested action
 type Suggestion struct {
 	Type        string                 `json:"type"`              // "next_action", "similar_task", "template", "deadline_risk"
@@ -217,40 +232,23 @@ func (se *SuggestionEngine) generateSimilarTaskSuggestions(ctx context.Context,
 		return suggestions, nil
 	}
 
-	// Get active task
-	activeTask, err := se.veriYonetici.GorevDetay(ctx, request.ActiveTaskID)
-	if err != nil {
-		return suggestions, err
-	}
-
 	// Find similar tasks based on title and description
-	allTasks, err := se.veriYonetici.GorevListele(ctx, map[string]interface{}{})
+	similarTasks, err := se.similarity().Similar(ctx, SimilarityOptions{
+		TaskID:   request.ActiveTaskID,
+		Limit:    MaxSimilarLimit,
+		MinScore: constants.SimilarityThreshold,
+	})
 	if err != nil {
 		return suggestions, err
 	}
 
-	var similarTasks []*Gorev
-	activeWords := extractKeywords(activeTask.Title + " " + activeTask.Description)
-
-	for _, task := range allTasks {
-		if task.ID == activeTask.ID {
-			continue
-		}
-
-		taskWords := extractKeywords(task.Title + " " + task.Description)
-		similarity := calculateSimilarity(activeWords, taskWords)
-
-		if similarity > constants.SimilarityThreshold { // Similarity threshold
-			similarTasks = append(similarTasks, task)
-		}
-	}
-
 	// Suggest reviewing similar completed tasks
-	for i, task := range similarTasks {
-		if i >= constants.MaxSuggestionsToShow { // Limit to top suggestions
+	for _, similar := range similarTasks {
+		if len(suggestions) >= constants.MaxSuggestionsToShow { // Limit to top suggestions
 			break
 		}
 
+		task := similar.Task
 		if task.Status == constants.TaskStatusCompleted {
 			suggestions = append(suggestions, Suggestion{
 				Type:        "similar_task",
@@ -262,8 +260,9 @@ func (se *SuggestionEngine) generateSimilarTaskSuggestions(ctx context.Context,
 					"similar_task_title": task.Title,
 					"similar_task_id":    task.ID,
 					"active_task_id":     request.ActiveTaskID,
+					"similarity":         similar.Score,
 				},
-				Confidence: constants.ConfidenceLow,
+				Confidence: similar.Score,
 				TaskID:     task.ID,
 			})
 		}
@@ -445,37 +444,6 @@ func extractKeywords(text string) []string {
 	return keywords
 }
 
-func calculateSimilarity(words1, words2 []string) float64 {
-	if len(words1) == 0 || len(words2) == 0 {
-		return 0.0
-	}
-
-	// Simple Jaccard similarity
-	set1 := make(map[string]bool)
-	for _, word := range words1 {
-		set1[word] = true
-	}
-
-	set2 := make(map[string]bool)
-	for _, word := range words2 {
-		set2[word] = true
-	}
-
-	intersection := 0
-	for word := range set1 {
-		if set2[word] {
-			intersection++
-		}
-	}
-
-	union := len(set1) + len(set2) - intersection
-	if union == 0 {
-		return 0.0
-	}
-
-	return float64(intersection) / float64(union)
-}
-
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
@@ -0,0 +1,282 @@
+package gorev
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// taskwarriorDateLayout is the date format used by `task export` / `task import`
+const taskwarriorDateLayout = "20060102T150405Z"
+
+// taskwarriorTask is a task as written by `task export`.
+// GorevParent is a user defined attribute that Taskwarrior keeps on import, so
+// subtask hierarchies survive a Gorev -> Taskwarrior -> Gorev round trip.
+type taskwarriorTask struct {
+	UUID        string                  `json:"uuid"`
+	Description string                  `json:"description"`
+	Status      string                  `json:"status"`
+	Entry       string                  `json:"entry,omitempty"`
+	Modified    string                  `json:"modified,omitempty"`
+	Start       string                  `json:"start,omitempty"`
+	End         string                  `json:"end,omitempty"`
+	Due         string                  `json:"due,omitempty"`
+	Project     string                  `json:"project,omitempty"`
+	Priority    string                  `json:"priority,omitempty"`
+	Tags        []string                `json:"tags,omitempty"`
+	Depends     taskwarriorDepends      `json:"depends,omitempty"`
+	Annotations []taskwarriorAnnotation `json:"annotations,omitempty"`
+	GorevParent string                  `json:"gorev_parent,omitempty"`
+}
+
+// taskwarriorAnnotation is a timestamped note attached to a Taskwarrior task
+type taskwarriorAnnotation struct {
+	Entry       string `json:"entry,omitempty"`
+	Description string `json:"description"`
+}
+
+// taskwarriorDepends accepts both the array form (Taskwarrior 2.6+) and the
+// comma separated string form (older versions) of the depends attribute
+type taskwarriorDepends []string
+
+// UnmarshalJSON decodes depends from either an array or a comma separated string
+func (d *taskwarriorDepends) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*d = list
+		return nil
+	}
+	var joined string
+	if err := json.Unmarshal(data, &joined); err != nil {
+		return err
+	}
+	*d = strings.FieldsFunc(joined, func(r rune) bool { return r == ',' })
+	return nil
+}
+
+// taskwarriorImporter imports the JSON array written by `task export`
+type taskwarriorImporter struct{}
+
+func init() {
+	registerImporter("taskwarrior", taskwarriorImporter{})
+}
+
+// load maps Taskwarrior tasks to Gorev tasks. Taskwarrior UUIDs are kept as task IDs,
+// so exporting from Gorev and importing back with preserve_ids reports conflicts.
+func (taskwarriorImporter) load(ctx context.Context, b *importBuilder, r io.Reader, options ImportOptions) error {
+	var twTasks []taskwarriorTask
+	if err := json.NewDecoder(r).Decode(&twTasks); err != nil {
+		return fmt.Errorf(i18n.T("error.taskwarriorInvalidJSON", map[string]interface{}{"Error": err}))
+	}
+
+	ids := make(map[string]string, len(twTasks))
+	for _, tw := range twTasks {
+		ids[tw.UUID] = taskwarriorTaskID(b, tw.UUID)
+	}
+
+	tasks := make(map[string]*Gorev, len(twTasks))
+	for _, tw := range twTasks {
+		// Recurring templates only generate other tasks; the generated instances are exported separately
+		if tw.Description == "" || tw.Status == "recurring" {
+			continue
+		}
+
+		task := &Gorev{
+			ID:       ids[tw.UUID],
+			Title:    tw.Description,
+			Status:   constants.TaskStatusPending,
+			Priority: constants.PriorityMedium,
+			ProjeID:  b.projectID(tw.Project, ""),
+		}
+
+		switch tw.Status {
+		case "pending", "waiting", "":
+			if tw.Start != "" {
+				task.Status = constants.TaskStatusInProgress
+			}
+		case "completed":
+			task.Status = constants.TaskStatusCompleted
+		case "deleted":
+			task.Status = constants.TaskStatusCancelled
+		default:
+			b.warn("import.unknownStatus", map[string]interface{}{"Item": tw.Description, "Value": tw.Status})
+		}
+
+		if tw.Priority != "" {
+			if priority, ok := normalizeImportPriority(tw.Priority); ok {
+				task.Priority = priority
+			} else {
+				b.warn("import.unknownPriority", map[string]interface{}{"Item": tw.Description, "Value": tw.Priority})
+			}
+		}
+
+		notes := make([]string, 0, len(tw.Annotations))
+		for _, annotation := range tw.Annotations {
+			notes = append(notes, annotation.Description)
+		}
+		task.Description = strings.Join(notes, "\n")
+
+		task.DueDate = parseTaskwarriorDate(b, tw.Description, tw.Due)
+		if entry := parseTaskwarriorDate(b, tw.Description, tw.Entry); entry != nil {
+			task.CreatedAt = *entry
+		}
+		if modified := parseTaskwarriorDate(b, tw.Description, firstNonEmpty(tw.Modified, tw.End)); modified != nil {
+			task.UpdatedAt = *modified
+		}
+
+		b.addTask(task, tw.Tags)
+		tasks[tw.UUID] = task
+	}
+
+	for _, tw := range twTasks {
+		task, ok := tasks[tw.UUID]
+		if !ok {
+			continue
+		}
+		// "A depends on B" means B has to be completed before A can start
+		for _, dep := range tw.Depends {
+			if blocker, ok := tasks[strings.TrimSpace(dep)]; ok {
+				b.addDependency(blocker.ID, task.ID)
+			}
+		}
+		if parent, ok := tasks[tw.GorevParent]; ok && parent != task {
+			task.ParentID = parent.ID
+		}
+	}
+
+	return nil
+}
+
+// taskwarriorTaskID keeps valid Taskwarrior UUIDs as task IDs
+func taskwarriorTaskID(b *importBuilder, twUUID string) string {
+	if _, err := uuid.Parse(twUUID); err == nil {
+		return strings.ToLower(twUUID)
+	}
+	return b.stableID("task", twUUID)
+}
+
+// parseTaskwarriorDate parses a Taskwarrior date, accepting the generic import formats as well
+func parseTaskwarriorDate(b *importBuilder, item, value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := parseImportDate(value)
+	if err != nil {
+		b.warn("import.invalidDate", map[string]interface{}{"Item": item, "Value": value})
+		return nil
+	}
+	return t
+}
+
+// WriteTaskwarrior writes tasks as a JSON array accepted by `task import`
+func WriteTaskwarrior(w io.Writer, exportData *ExportFormat) error {
+	idx := newExportIndex(exportData)
+
+	twTasks := make([]taskwarriorTask, 0, len(exportData.Tasks))
+	for _, task := range exportData.Tasks {
+		tw := taskwarriorTask{
+			UUID:        taskwarriorUUID(task.ID),
+			Description: task.Title,
+			Status:      "pending",
+			Entry:       taskwarriorDate(task.CreatedAt),
+			Modified:    taskwarriorDate(task.UpdatedAt),
+			Project:     idx.projectNames[task.ProjeID],
+			Priority:    taskwarriorPriority(task.Priority),
+			Tags:        taskwarriorTags(idx.tagNames[task.ID]),
+		}
+
+		switch task.Status {
+		case constants.TaskStatusInProgress:
+			tw.Start = tw.Modified
+		case constants.TaskStatusCompleted:
+			tw.Status = "completed"
+			tw.End = tw.Modified
+		case constants.TaskStatusCancelled:
+			tw.Status = "deleted"
+			tw.End = tw.Modified
+		}
+
+		if task.DueDate != nil {
+			tw.Due = taskwarriorDate(*task.DueDate)
+		}
+		if task.Description != "" {
+			tw.Annotations = []taskwarriorAnnotation{{Entry: tw.Entry, Description: task.Description}}
+		}
+		if task.ParentID != "" {
+			tw.GorevParent = taskwarriorUUID(task.ParentID)
+		}
+		for _, blockerID := range idx.blockers[task.ID] {
+			tw.Depends = append(tw.Depends, taskwarriorUUID(blockerID))
+		}
+
+		twTasks = append(twTasks, tw)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(twTasks)
+}
+
+// saveAsTaskwarrior saves export data as Taskwarrior JSON
+func (iy *IsYonetici) saveAsTaskwarrior(exportData *ExportFormat, outputPath string) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.failedToCreateFile", map[string]interface{}{"Path": outputPath, "Error": err}))
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			fmt.Printf("Warning: failed to close file %s: %v\n", outputPath, cerr)
+		}
+	}()
+
+	if err := WriteTaskwarrior(file, exportData); err != nil {
+		return fmt.Errorf(i18n.T("error.failedToWriteFile", map[string]interface{}{"Error": err}))
+	}
+
+	return nil
+}
+
+// taskwarriorUUID returns the task ID if it is a UUID, otherwise a UUID derived from it
+func taskwarriorUUID(id string) string {
+	if _, err := uuid.Parse(id); err == nil {
+		return id
+	}
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("gorev-task:"+id)).String()
+}
+
+// taskwarriorDate formats a time in Taskwarrior's UTC format
+func taskwarriorDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(taskwarriorDateLayout)
+}
+
+// taskwarriorPriority maps Gorev priorities to H/M/L
+func taskwarriorPriority(priority string) string {
+	switch priority {
+	case constants.PriorityHigh:
+		return "H"
+	case constants.PriorityLow:
+		return "L"
+	default:
+		return "M"
+	}
+}
+
+// taskwarriorTags replaces whitespace in tag names, which Taskwarrior does not allow
+func taskwarriorTags(names []string) []string {
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, strings.Join(strings.Fields(name), "_"))
+	}
+	return tags
+}
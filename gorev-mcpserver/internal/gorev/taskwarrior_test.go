@@ -0,0 +1,136 @@
+package gorev
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+func TestImportTaskwarrior(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	ctx := context.Background()
+
+	export := `[
+	  {"id": 1, "uuid": "0b5c4d6e-1f2a-4b3c-8d9e-0a1b2c3d4e5f", "description": "Write report", "status": "pending",
+	   "entry": "20250301T091500Z", "modified": "20250302T100000Z", "start": "20250302T100000Z",
+	   "due": "20250310T000000Z", "project": "Home.Office", "priority": "H", "tags": ["work", "writing"],
+	   "depends": "1c6d5e7f-2a3b-4c4d-9e0f-1b2c3d4e5f60",
+	   "annotations": [{"entry": "20250301T091600Z", "description": "Include Q1 numbers"}]},
+	  {"id": 2, "uuid": "1c6d5e7f-2a3b-4c4d-9e0f-1b2c3d4e5f60", "description": "Collect data", "status": "completed",
+	   "entry": "20250228T080000Z", "end": "20250301T080000Z", "priority": "L"},
+	  {"id": 0, "uuid": "2d7e6f80-3b4c-4d5e-8f10-2c3d4e5f6071", "description": "Old chore", "status": "deleted"},
+	  {"id": 3, "uuid": "3e8f7091-4c5d-4e6f-9021-3d4e5f607182", "description": "Weekly review", "status": "recurring"}
+	]`
+	path := writeImportFile(t, "tasks.json", export)
+
+	result, err := iy.ImportData(ctx, ImportOptions{FilePath: path, Format: "taskwarrior", PreserveIDs: true})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if result.ImportedTasks != 3 {
+		t.Fatalf("expected 3 imported tasks (recurring template skipped), got %d", result.ImportedTasks)
+	}
+
+	report, err := vy.GorevGetir(ctx, "0b5c4d6e-1f2a-4b3c-8d9e-0a1b2c3d4e5f")
+	if err != nil {
+		t.Fatalf("Taskwarrior UUID should be kept as task ID: %v", err)
+	}
+	if report.Status != constants.TaskStatusInProgress || report.Priority != constants.PriorityHigh {
+		t.Errorf("unexpected status/priority: %s/%s", report.Status, report.Priority)
+	}
+	if report.Description != "Include Q1 numbers" || len(report.Tags) != 2 {
+		t.Errorf("unexpected description/tags: %q/%d", report.Description, len(report.Tags))
+	}
+	if report.DueDate == nil || report.DueDate.Format(constants.DateFormatISO) != "2025-03-10" {
+		t.Errorf("unexpected due date: %v", report.DueDate)
+	}
+
+	links, err := vy.BaglantilariGetir(ctx, report.ID)
+	if err != nil {
+		t.Fatalf("BaglantilariGetir failed: %v", err)
+	}
+	if len(links) != 1 || links[0].SourceID != "1c6d5e7f-2a3b-4c4d-9e0f-1b2c3d4e5f60" || links[0].TargetID != report.ID {
+		t.Errorf("depends should become a dependency on the blocking task, got %+v", links)
+	}
+
+	if data, _ := vy.GorevGetir(ctx, "1c6d5e7f-2a3b-4c4d-9e0f-1b2c3d4e5f60"); data.Status != constants.TaskStatusCompleted || data.Priority != constants.PriorityLow {
+		t.Errorf("unexpected completed task: %s/%s", data.Status, data.Priority)
+	}
+	if chore, _ := vy.GorevGetir(ctx, "2d7e6f80-3b4c-4d5e-8f10-2c3d4e5f6071"); chore.Status != constants.TaskStatusCancelled {
+		t.Errorf("deleted task should be cancelled, got %s", chore.Status)
+	}
+}
+
+func TestWriteTaskwarrior(t *testing.T) {
+	due := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	data := &ExportFormat{
+		Projects: []*Proje{{ID: "p1", Name: "Backend"}},
+		Tasks: []*Gorev{
+			{ID: "0b5c4d6e-1f2a-4b3c-8d9e-0a1b2c3d4e5f", Title: "Parent", Status: constants.TaskStatusInProgress, Priority: constants.PriorityHigh, ProjeID: "p1", DueDate: &due, Description: "Notes", UpdatedAt: due},
+			{ID: "child", Title: "Child", Status: constants.TaskStatusCancelled, Priority: constants.PriorityLow, ParentID: "0b5c4d6e-1f2a-4b3c-8d9e-0a1b2c3d4e5f", UpdatedAt: due},
+		},
+		Tags:         []*Etiket{{ID: "t1", Name: "needs review"}},
+		TaskTags:     []TaskTagAssociation{{TaskID: "child", TagID: "t1"}},
+		Dependencies: []*Baglanti{{SourceID: "0b5c4d6e-1f2a-4b3c-8d9e-0a1b2c3d4e5f", TargetID: "child", ConnectionType: "onceki"}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTaskwarrior(&buf, data); err != nil {
+		t.Fatalf("WriteTaskwarrior failed: %v", err)
+	}
+	var out []taskwarriorTask
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if len(out) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(out))
+	}
+
+	parent, child := out[0], out[1]
+	if parent.Priority != "H" || parent.Project != "Backend" || parent.Start == "" || parent.Due != "20250401T000000Z" {
+		t.Errorf("unexpected parent: %+v", parent)
+	}
+	if len(parent.Annotations) != 1 || parent.Annotations[0].Description != "Notes" {
+		t.Errorf("description should be written as annotation: %+v", parent.Annotations)
+	}
+	if child.Status != "deleted" || child.Priority != "L" || child.GorevParent != parent.UUID {
+		t.Errorf("unexpected child: %+v", child)
+	}
+	if child.UUID == "child" || len(child.Depends) != 1 || child.Depends[0] != parent.UUID {
+		t.Errorf("non UUID IDs should be converted and dependencies kept: %+v", child)
+	}
+	if len(child.Tags) != 1 || child.Tags[0] != "needs_review" {
+		t.Errorf("tags must not contain spaces: %v", child.Tags)
+	}
+}
+
+func TestTaskwarriorRoundTrip(t *testing.T) {
+	iy, _ := newImportTestManager(t)
+	ctx := context.Background()
+
+	data, err := iy.ExportData(ctx, ExportOptions{IncludeCompleted: true, IncludeDependencies: true})
+	if err != nil {
+		t.Fatalf("ExportData failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	if err := iy.SaveExportToFile(ctx, data, ExportOptions{OutputPath: path, Format: "taskwarrior"}); err != nil {
+		t.Fatalf("SaveExportToFile failed: %v", err)
+	}
+
+	target, _ := newImportTestManager(t)
+	result, err := target.ImportData(ctx, ImportOptions{FilePath: path, Format: "taskwarrior"})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if result.ImportedTasks != len(data.Tasks) || len(result.Warnings) != 0 {
+		t.Errorf("expected %d tasks without warnings, got %d tasks, warnings %v", len(data.Tasks), result.ImportedTasks, result.Warnings)
+	}
+	if result.ImportedProjects != 0 {
+		t.Errorf("existing project should be matched by name, got %d new projects", result.ImportedProjects)
+	}
+}
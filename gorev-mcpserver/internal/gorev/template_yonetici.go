@@ -44,7 +44,7 @@ func (vy *VeriYonetici) TemplateOlustur(ctx context.Context, template *GorevTemp
 		baseTemplateID = &template.ID
 	}
 
-	_, err = vy.db.Exec(sorgu, template.ID, template.Name, template.Definition, template.Alias,
+	_, err = vy.yazici.exec(ctx, sorgu, template.ID, template.Name, template.Definition, template.Alias,
 		template.DefaultTitle, template.DescriptionTemplate,
 		string(alanlarJSON), string(ornekDegerlerJSON), template.Category, template.Active,
 		languageCode, baseTemplateID)
@@ -78,7 +78,7 @@ func (vy *VeriYonetici) TemplateListele(ctx context.Context, category string) ([
 		args = append(args, lang)
 	}
 
-	rows, err := vy.db.Query(sorgu, args...)
+	rows, err := vy.okuma.Query(sorgu, args...)
 	if err != nil {
 		return nil, fmt.Errorf(i18n.TListFailed(i18n.FromContext(ctx), "template", err))
 	}
@@ -126,7 +126,7 @@ func (vy *VeriYonetici) TemplateGetir(ctx context.Context, templateID string) (*
 			fields, sample_values, category, active, language_code, base_template_id
 			FROM gorev_templateleri WHERE id = ?`
 
-	err := vy.db.QueryRow(sorgu, templateID).Scan(
+	err := vy.okuma.QueryRow(sorgu, templateID).Scan(
 		&template.ID, &template.Name, &template.Definition, &template.Alias,
 		&template.DefaultTitle, &template.DescriptionTemplate,
 		&alanlarJSON, &ornekDegerlerJSON, &template.Category, &template.Active,
@@ -166,7 +166,7 @@ func (vy *VeriYonetici) TemplateAliasIleGetir(ctx context.Context, alias string)
 			fields, sample_values, category, active, language_code, base_template_id
 			FROM gorev_templateleri WHERE alias = ? AND active = 1 AND language_code = ?`
 
-	err := vy.db.QueryRow(sorgu, alias, lang).Scan(
+	err := vy.okuma.QueryRow(sorgu, alias, lang).Scan(
 		&template.ID, &template.Name, &template.Definition, &template.Alias,
 		&template.DefaultTitle, &template.DescriptionTemplate,
 		&alanlarJSON, &ornekDegerlerJSON, &template.Category, &template.Active,
@@ -194,6 +194,11 @@ func (vy *VeriYonetici) TemplateAliasIleGetir(ctx context.Context, alias string)
 
 // TemplateIDVeyaAliasIleGetir ID veya alias ile template getirir
 func (vy *VeriYonetici) TemplateIDVeyaAliasIleGetir(ctx context.Context, idOrAlias string) (*GorevTemplate, error) {
+	return templateIDVeyaAliasIleGetir(ctx, vy, idOrAlias)
+}
+
+// templateIDVeyaAliasIleGetir looks a template up by ID first, then by alias
+func templateIDVeyaAliasIleGetir(ctx context.Context, vy VeriYoneticiInterface, idOrAlias string) (*GorevTemplate, error) {
 	// Önce ID olarak dene
 	template, err := vy.TemplateGetir(ctx, idOrAlias)
 	if err == nil {
@@ -206,6 +211,11 @@ func (vy *VeriYonetici) TemplateIDVeyaAliasIleGetir(ctx context.Context, idOrAli
 
 // TemplatedenGorevOlustur template kullanarak görev oluşturur
 func (vy *VeriYonetici) TemplatedenGorevOlustur(ctx context.Context, templateID string, degerler map[string]string) (*Gorev, error) {
+	return templatedenGorevOlustur(ctx, vy, templateID, degerler)
+}
+
+// templatedenGorevOlustur fills a template with degerler and saves the task through vy
+func templatedenGorevOlustur(ctx context.Context, vy VeriYoneticiInterface, templateID string, degerler map[string]string) (*Gorev, error) {
 	// Template'i ID veya alias ile getir
 	template, err := vy.TemplateIDVeyaAliasIleGetir(ctx, templateID)
 	if err != nil {
@@ -318,6 +328,12 @@ func (vy *VeriYonetici) TemplatedenGorevOlustur(ctx context.Context, templateID
 
 // VarsayilanTemplateleriOlustur varsayılan template'leri TR/EN çifti olarak oluşturur
 func (vy *VeriYonetici) VarsayilanTemplateleriOlustur(ctx context.Context) error {
+	return varsayilanTemplateleriOlustur(ctx, vy)
+}
+
+// varsayilanTemplateleriOlustur creates the default templates through vy; templates whose
+// alias already exists in a language are skipped
+func varsayilanTemplateleriOlustur(ctx context.Context, vy VeriYoneticiInterface) error {
 	// Define all template groups with base IDs
 	templateGroups := []struct {
 		BaseTemplateID string
@@ -0,0 +1,111 @@
+package gorev
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+// setupTestData adds test-project-1 with the tasks test-task-1 and test-task-2
+func setupTestData(t *testing.T, vy *VeriYonetici) {
+	// Test projesi oluştur
+	proje := &Proje{
+		ID:         "test-project-1",
+		Name:       "Test Project",
+		Definition: "Test project description",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	err := vy.ProjeKaydet(context.Background(), proje)
+	if err != nil {
+		t.Fatalf("Failed to create test project: %v", err)
+	}
+
+	// Test görevleri oluştur
+	tasks := []*Gorev{
+		{
+			ID:          "test-task-1",
+			Title:       "Test Task 1",
+			Description: "Test task description",
+			Status:      constants.TaskStatusPending,
+			Priority:    constants.PriorityHigh,
+			ProjeID:     proje.ID,
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		},
+		{
+			ID:          "test-task-2",
+			Title:       "Test Task 2",
+			Description: "Another test task",
+			Status:      constants.TaskStatusCompleted,
+			Priority:    constants.PriorityMedium,
+			ProjeID:     proje.ID,
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		},
+	}
+
+	for _, task := range tasks {
+		err := vy.GorevKaydet(context.Background(), task)
+		if err != nil {
+			t.Fatalf("Failed to create test task: %v", err)
+		}
+	}
+
+	// Etiket test için şimdilik atlıyoruz - EtiketKaydet metodu yok
+}
+
+// testSeed is the data a test adds on top of setupTestData. Tasks without a status or a
+// priority are pending and of medium priority; tags, file paths and AI interactions are
+// keyed by task ID.
+type testSeed struct {
+	tasks          []*Gorev
+	tags           map[string][]string
+	links          []*Baglanti
+	files          map[string][]string
+	aiInteractions map[string]string // Action type
+}
+
+// seedTestData adds the tasks of seed with their tags, links, file paths and AI interactions
+func seedTestData(t *testing.T, vy *VeriYonetici, seed testSeed) {
+	ctx := context.Background()
+	for _, task := range seed.tasks {
+		if task.Status == "" {
+			task.Status = constants.TaskStatusPending
+		}
+		if task.Priority == "" {
+			task.Priority = constants.PriorityMedium
+		}
+		if err := vy.GorevKaydet(ctx, task); err != nil {
+			t.Fatalf("Failed to create %s: %v", task.ID, err)
+		}
+	}
+	for taskID, names := range seed.tags {
+		tags, err := vy.EtiketleriGetirVeyaOlustur(ctx, names)
+		if err != nil {
+			t.Fatalf("Failed to create tags: %v", err)
+		}
+		if err := vy.GorevEtiketleriniAyarla(ctx, taskID, tags); err != nil {
+			t.Fatalf("Failed to set tags of %s: %v", taskID, err)
+		}
+	}
+	for _, link := range seed.links {
+		if err := vy.BaglantiEkle(ctx, link); err != nil {
+			t.Fatalf("Failed to add link %s: %v", link.ID, err)
+		}
+	}
+	for taskID, paths := range seed.files {
+		for _, path := range paths {
+			if err := vy.GorevDosyaYoluEkle(taskID, path); err != nil {
+				t.Fatalf("Failed to add file path: %v", err)
+			}
+		}
+	}
+	for taskID, action := range seed.aiInteractions {
+		if _, err := vy.db.Exec(`INSERT INTO ai_interactions (task_id, action_type) VALUES (?, ?)`, taskID, action); err != nil {
+			t.Fatalf("Failed to add AI interaction: %v", err)
+		}
+	}
+}
@@ -0,0 +1,329 @@
+package gorev
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+// todoTxtPriority matches the "(A) " priority marker at the start of an incomplete task
+var todoTxtPriority = regexp.MustCompile(`^\(([A-Z])\)\s+`)
+
+// todoTxtDate matches a leading YYYY-MM-DD date
+var todoTxtDate = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})\s+`)
+
+// todoTxtKeyValue matches key:value extensions such as due:2025-01-31 (but not URLs)
+var todoTxtKeyValue = regexp.MustCompile(`^([A-Za-z_]+):([^\s:/]\S*)$`)
+
+// todoTxtLine is a parsed todo.txt line; the id/dep/parent extensions are resolved after all lines are read
+type todoTxtLine struct {
+	task   *Gorev
+	key    string
+	deps   []string
+	parent string
+}
+
+// todoTxtImporter imports todo.txt files (http://todotxt.org). Besides the standard
+// due: extension it understands id:, dep: and parent: for dependencies and subtasks,
+// and status: for Gorev statuses that todo.txt cannot express.
+type todoTxtImporter struct{}
+
+func init() {
+	registerImporter("todotxt", todoTxtImporter{})
+}
+
+// load maps todo.txt lines to tasks: (A)/(B)/(C) to high/medium/low priority,
+// the first +project to the project, other +projects and @contexts to tags
+func (todoTxtImporter) load(ctx context.Context, b *importBuilder, r io.Reader, options ImportOptions) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lines := []*todoTxtLine{}
+	byKey := make(map[string]*todoTxtLine)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		raw := strings.TrimSpace(scanner.Text())
+		if raw == "" {
+			continue
+		}
+
+		line, tags := parseTodoTxtLine(b, lineNo, raw)
+		if line.task.Title == "" {
+			b.warn("import.csvMissingTitle", map[string]interface{}{"Line": lineNo})
+			continue
+		}
+		line.task.ID = b.stableID("line", fmt.Sprintf("%d:%s", lineNo, raw))
+		if line.key != "" {
+			line.task.ID = b.stableID("id", line.key)
+			byKey[line.key] = line
+		}
+
+		b.addTask(line.task, tags)
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf(i18n.T("error.todoTxtReadFailed", map[string]interface{}{"Error": err}))
+	}
+
+	for _, line := range lines {
+		for _, dep := range line.deps {
+			if blocker, ok := byKey[dep]; ok {
+				b.addDependency(blocker.task.ID, line.task.ID)
+			}
+		}
+		if line.parent == "" {
+			continue
+		}
+		if parent, ok := byKey[line.parent]; ok && parent != line {
+			line.task.ParentID = parent.task.ID
+		} else {
+			b.warn("import.parentNotFound", map[string]interface{}{"Item": line.task.Title, "Value": line.parent})
+		}
+	}
+
+	return nil
+}
+
+// parseTodoTxtLine parses a single todo.txt line into a task and its tag names
+func parseTodoTxtLine(b *importBuilder, lineNo int, raw string) (*todoTxtLine, []string) {
+	line := &todoTxtLine{task: &Gorev{Status: constants.TaskStatusPending, Priority: constants.PriorityMedium}}
+	task := line.task
+	rest := raw
+
+	if strings.HasPrefix(rest, "x ") {
+		task.Status = constants.TaskStatusCompleted
+		rest = strings.TrimSpace(rest[2:])
+		if match := todoTxtDate.FindStringSubmatch(rest); match != nil {
+			if completed, err := time.Parse(constants.DateFormatISO, match[1]); err == nil {
+				task.UpdatedAt = completed
+			}
+			rest = rest[len(match[0]):]
+		}
+	}
+	if match := todoTxtPriority.FindStringSubmatch(rest); match != nil {
+		task.Priority = todoTxtLetterPriority(match[1])
+		rest = rest[len(match[0]):]
+	}
+	if match := todoTxtDate.FindStringSubmatch(rest); match != nil {
+		if created, err := time.Parse(constants.DateFormatISO, match[1]); err == nil {
+			task.CreatedAt = created
+		}
+		rest = rest[len(match[0]):]
+	}
+
+	var words, tags []string
+	projectSet := false
+	for _, word := range strings.Fields(rest) {
+		switch {
+		case len(word) > 1 && word[0] == '+':
+			name := word[1:]
+			if !projectSet {
+				task.ProjeID = b.projectID(todoTxtProjectName(b, name), "")
+				projectSet = true
+			} else {
+				tags = append(tags, name)
+			}
+		case len(word) > 1 && word[0] == '@':
+			tags = append(tags, word[1:])
+		case todoTxtKeyValue.MatchString(word):
+			match := todoTxtKeyValue.FindStringSubmatch(word)
+			if !applyTodoTxtKeyValue(b, lineNo, line, strings.ToLower(match[1]), match[2]) {
+				words = append(words, word)
+			}
+		default:
+			words = append(words, word)
+		}
+	}
+	task.Title = strings.Join(words, " ")
+
+	return line, tags
+}
+
+// applyTodoTxtKeyValue applies a known key:value extension and reports whether it was consumed
+func applyTodoTxtKeyValue(b *importBuilder, lineNo int, line *todoTxtLine, key, value string) bool {
+	switch key {
+	case "due":
+		due, err := parseImportDate(value)
+		if err != nil {
+			b.warn("import.csvInvalidDate", map[string]interface{}{"Line": lineNo, "Value": value})
+			return true
+		}
+		line.task.DueDate = due
+	case "pri":
+		// Completed tasks keep their priority as pri:A because "(A)" may not follow "x"
+		line.task.Priority = todoTxtLetterPriority(strings.ToUpper(value))
+	case "status":
+		if status, ok := normalizeImportStatus(value); ok {
+			line.task.Status = status
+		} else {
+			b.warn("import.csvUnknownStatus", map[string]interface{}{"Line": lineNo, "Value": value})
+		}
+	case "id":
+		line.key = value
+	case "dep":
+		line.deps = append(line.deps, strings.Split(value, ",")...)
+	case "parent":
+		line.parent = value
+	default:
+		return false
+	}
+	return true
+}
+
+// todoTxtProjectName matches "+My_Project" against an existing "My Project"
+func todoTxtProjectName(b *importBuilder, name string) string {
+	spaced := strings.ReplaceAll(name, "_", " ")
+	if _, ok := b.existingProjects[strings.ToLower(spaced)]; ok {
+		return spaced
+	}
+	return name
+}
+
+// todoTxtLetterPriority maps (A) to high, (B) to medium and (C) or lower to low
+func todoTxtLetterPriority(letter string) string {
+	switch letter {
+	case "A":
+		return constants.PriorityHigh
+	case "B":
+		return constants.PriorityMedium
+	default:
+		return constants.PriorityLow
+	}
+}
+
+// WriteTodoTxt writes tasks as todo.txt lines. Projects become +Project, tags @context,
+// and id:/dep:/parent: extensions are added only for tasks referenced by other tasks.
+func WriteTodoTxt(w io.Writer, exportData *ExportFormat) error {
+	idx := newExportIndex(exportData)
+
+	// Short keys for tasks that other tasks point at
+	referenced := make(map[string]bool)
+	for _, task := range exportData.Tasks {
+		if task.ParentID != "" {
+			referenced[task.ParentID] = true
+		}
+		for _, blockerID := range idx.blockers[task.ID] {
+			referenced[blockerID] = true
+		}
+	}
+	keys := todoTxtKeys(exportData.Tasks, referenced)
+
+	bw := bufio.NewWriter(w)
+	for _, task := range exportData.Tasks {
+		parts := []string{}
+		letter := map[string]string{
+			constants.PriorityHigh:   "A",
+			constants.PriorityMedium: "B",
+			constants.PriorityLow:    "C",
+		}[task.Priority]
+
+		done := task.Status == constants.TaskStatusCompleted || task.Status == constants.TaskStatusCancelled
+		if done {
+			parts = append(parts, "x", task.UpdatedAt.Format(constants.DateFormatISO))
+		} else if letter != "" {
+			parts = append(parts, "("+letter+")")
+		}
+		if !task.CreatedAt.IsZero() {
+			parts = append(parts, task.CreatedAt.Format(constants.DateFormatISO))
+		}
+
+		parts = append(parts, strings.Join(strings.Fields(task.Title), " "))
+		if name := idx.projectNames[task.ProjeID]; name != "" {
+			parts = append(parts, "+"+todoTxtWord(name))
+		}
+		for _, tag := range idx.tagNames[task.ID] {
+			parts = append(parts, "@"+todoTxtWord(tag))
+		}
+		if task.DueDate != nil {
+			parts = append(parts, "due:"+task.DueDate.Format(constants.DateFormatISO))
+		}
+		if done && letter != "" {
+			parts = append(parts, "pri:"+letter)
+		}
+		if task.Status == constants.TaskStatusInProgress || task.Status == constants.TaskStatusCancelled {
+			parts = append(parts, "status:"+task.Status)
+		}
+		if key, ok := keys[task.ID]; ok {
+			parts = append(parts, "id:"+key)
+		}
+		if key, ok := keys[task.ParentID]; ok {
+			parts = append(parts, "parent:"+key)
+		}
+		deps := []string{}
+		for _, blockerID := range idx.blockers[task.ID] {
+			if key, ok := keys[blockerID]; ok {
+				deps = append(deps, key)
+			}
+		}
+		if len(deps) > 0 {
+			parts = append(parts, "dep:"+strings.Join(deps, ","))
+		}
+
+		if _, err := bw.WriteString(strings.Join(parts, " ") + "\n"); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// saveAsTodoTxt saves export data as a todo.txt file
+func (iy *IsYonetici) saveAsTodoTxt(exportData *ExportFormat, outputPath string) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.failedToCreateFile", map[string]interface{}{"Path": outputPath, "Error": err}))
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			fmt.Printf("Warning: failed to close file %s: %v\n", outputPath, cerr)
+		}
+	}()
+
+	if err := WriteTodoTxt(file, exportData); err != nil {
+		return fmt.Errorf(i18n.T("error.failedToWriteFile", map[string]interface{}{"Error": err}))
+	}
+
+	return nil
+}
+
+// todoTxtKeys assigns short, unique keys (ID prefixes) to the referenced tasks
+func todoTxtKeys(tasks []*Gorev, referenced map[string]bool) map[string]string {
+	ids := make([]string, 0, len(referenced))
+	for _, task := range tasks {
+		if referenced[task.ID] {
+			ids = append(ids, task.ID)
+		}
+	}
+	sort.Strings(ids)
+
+	keys := make(map[string]string, len(ids))
+	used := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		word := todoTxtWord(id)
+		key := word
+		for length := 8; length <= len(word); length++ {
+			if !used[word[:length]] {
+				key = word[:length]
+				break
+			}
+		}
+		used[key] = true
+		keys[id] = key
+	}
+	return keys
+}
+
+// todoTxtWord replaces whitespace so that a value stays a single todo.txt token
+func todoTxtWord(value string) string {
+	return strings.Join(strings.Fields(value), "_")
+}
@@ -0,0 +1,127 @@
+package gorev
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+)
+
+func TestImportTodoTxt(t *testing.T) {
+	iy, vy := newImportTestManager(t)
+	ctx := context.Background()
+
+	todo := "(A) 2025-03-01 Call the bank +Test_Project @phone due:2025-03-05 id:bank\n" +
+		"\n" +
+		"x 2025-03-02 2025-03-01 Pay invoice +Finance +Q1 @office pri:C dep:bank\n" +
+		"(B) Review slides https://example.com/deck status:in_progress parent:bank\n" +
+		"x 2025-03-03 Dropped idea status:iptal\n" +
+		"(D) Someday maybe\n"
+	path := writeImportFile(t, "todo.txt", todo)
+
+	result, err := iy.ImportData(ctx, ImportOptions{FilePath: path})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if result.ImportedTasks != 5 {
+		t.Fatalf("expected 5 tasks, got %d", result.ImportedTasks)
+	}
+
+	bank := findTaskByTitle(t, iy, "Call the bank")
+	if bank.Priority != constants.PriorityHigh || bank.ProjeID != "test-project-1" {
+		t.Errorf("unexpected priority/project: %s/%s", bank.Priority, bank.ProjeID)
+	}
+	if bank.DueDate == nil || bank.DueDate.Format(constants.DateFormatISO) != "2025-03-05" || len(bank.Tags) != 1 {
+		t.Errorf("unexpected due/tags: %v/%d", bank.DueDate, len(bank.Tags))
+	}
+	if bank.CreatedAt.Format(constants.DateFormatISO) != "2025-03-01" {
+		t.Errorf("unexpected creation date: %v", bank.CreatedAt)
+	}
+
+	invoice := findTaskByTitle(t, iy, "Pay invoice")
+	if invoice.Status != constants.TaskStatusCompleted || invoice.Priority != constants.PriorityLow {
+		t.Errorf("unexpected status/priority: %s/%s", invoice.Status, invoice.Priority)
+	}
+	if len(invoice.Tags) != 2 {
+		t.Errorf("second project and context should become tags, got %d", len(invoice.Tags))
+	}
+	links, _ := vy.BaglantilariGetir(ctx, invoice.ID)
+	if len(links) != 1 || links[0].SourceID != bank.ID {
+		t.Errorf("dep: should create a dependency, got %+v", links)
+	}
+
+	slides := findTaskByTitle(t, iy, "Review slides https://example.com/deck")
+	if slides.Status != constants.TaskStatusInProgress || slides.ParentID != bank.ID {
+		t.Errorf("unexpected status/parent: %s/%s", slides.Status, slides.ParentID)
+	}
+	if dropped := findTaskByTitle(t, iy, "Dropped idea"); dropped.Status != constants.TaskStatusCancelled {
+		t.Errorf("status: extension should override completion, got %s", dropped.Status)
+	}
+	if someday := findTaskByTitle(t, iy, "Someday maybe"); someday.Priority != constants.PriorityLow {
+		t.Errorf("(D) should map to low priority, got %s", someday.Priority)
+	}
+}
+
+func TestWriteTodoTxt(t *testing.T) {
+	created := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	due := time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)
+	data := &ExportFormat{
+		Projects: []*Proje{{ID: "p1", Name: "My Project"}},
+		Tasks: []*Gorev{
+			{ID: "aaaaaaaa-1111", Title: "Parent task", Status: constants.TaskStatusPending, Priority: constants.PriorityHigh, ProjeID: "p1", DueDate: &due, CreatedAt: created},
+			{ID: "bbbbbbbb-2222", Title: "Done child", Status: constants.TaskStatusCompleted, Priority: constants.PriorityMedium, ParentID: "aaaaaaaa-1111", CreatedAt: created, UpdatedAt: due},
+		},
+		Tags:         []*Etiket{{ID: "t1", Name: "deep work"}},
+		TaskTags:     []TaskTagAssociation{{TaskID: "aaaaaaaa-1111", TagID: "t1"}},
+		Dependencies: []*Baglanti{{SourceID: "aaaaaaaa-1111", TargetID: "bbbbbbbb-2222"}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTodoTxt(&buf, data); err != nil {
+		t.Fatalf("WriteTodoTxt failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+
+	want := []string{
+		"(A) 2025-03-01 Parent task +My_Project @deep_work due:2025-03-09 id:aaaaaaaa",
+		"x 2025-03-09 2025-03-01 Done child pri:B parent:aaaaaaaa dep:aaaaaaaa",
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d:\n got  %q\n want %q", i+1, lines[i], want[i])
+		}
+	}
+}
+
+func TestTodoTxtRoundTrip(t *testing.T) {
+	iy, _ := newImportTestManager(t)
+	ctx := context.Background()
+
+	data, err := iy.ExportData(ctx, ExportOptions{IncludeCompleted: true, IncludeDependencies: true})
+	if err != nil {
+		t.Fatalf("ExportData failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "todo.txt")
+	if err := iy.SaveExportToFile(ctx, data, ExportOptions{OutputPath: path, Format: "todotxt"}); err != nil {
+		t.Fatalf("SaveExportToFile failed: %v", err)
+	}
+
+	target, _ := newImportTestManager(t)
+	result, err := target.ImportData(ctx, ImportOptions{FilePath: path})
+	if err != nil {
+		t.Fatalf("ImportData failed: %v", err)
+	}
+	if result.ImportedTasks != len(data.Tasks) || result.ImportedProjects != 0 || len(result.Warnings) != 0 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if task := findTaskByTitle(t, target, "Test Task 2"); task.Status != constants.TaskStatusCompleted {
+		t.Errorf("completion should survive the round trip, got %s", task.Status)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -34,66 +35,71 @@ type EventEmitter interface {
 }
 
 type VeriYonetici struct {
-	db           *sql.DB
+	db           *sql.DB     // Single read-write connection, used by the writer goroutine and migrations
+	okuma        *sql.DB     // Read-only connection pool
+	yazici       *writeQueue // Serializes all writes on db
 	eventEmitter EventEmitter
 	workspaceID  string // Workspace ID for event emission
 }
 
-// configureSQLiteForConcurrency configures SQLite for better concurrent access
+// configureSQLiteForConcurrency configures the read-write connection. It is limited to one
+// connection so that writes never compete for the SQLite write lock inside this process.
 func configureSQLiteForConcurrency(db *sql.DB) error {
-	// Enable WAL mode for better concurrent access
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+
+	// Enable WAL mode so that readers do not block the writer
 	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
 		return fmt.Errorf(i18n.T("error.walModeFailed", map[string]interface{}{"Error": err}))
 	}
 
-	// Set busy timeout to avoid immediate failures (10 seconds)
+	// Other processes (CLI commands, backups) may still hold the lock briefly
 	if _, err := db.Exec("PRAGMA busy_timeout=10000"); err != nil {
 		return fmt.Errorf(i18n.T("error.busyTimeoutFailed", map[string]interface{}{"Error": err}))
 	}
 
-	// Set connection pooling for better concurrency
-	// Lower max open connections to reduce contention
-	db.SetMaxOpenConns(5)
-	db.SetMaxIdleConns(2)
-	db.SetConnMaxLifetime(time.Hour)
-
 	return nil
 }
 
-// retryOnBusy retries a database operation if it fails with SQLITE_BUSY error
-func retryOnBusy(operation func() error, maxRetries int) error {
-	var err error
-	for i := 0; i <= maxRetries; i++ {
-		err = operation()
-		if err == nil {
-			return nil
-		}
+// memoryDatabaseSayaci names the shared in-memory databases opened by this process
+var memoryDatabaseSayaci atomic.Int64
 
-		// Check if error is SQLITE_BUSY
-		if strings.Contains(err.Error(), "database is locked") ||
-			strings.Contains(err.Error(), "SQLITE_BUSY") {
-			// Exponential backoff with cap: 10ms, 20ms, 40ms, 80ms, 160ms, 320ms, 640ms, 1000ms (capped)
-			backoff := time.Duration(10<<uint(i)) * time.Millisecond
-			if backoff > time.Second {
-				backoff = time.Second // Cap at 1 second
-			}
-			time.Sleep(backoff)
-			continue
-		}
+// isMemoryDatabase reports whether dbYolu names a private in-memory database
+func isMemoryDatabase(dbYolu string) bool {
+	return dbYolu == "" || dbYolu == ":memory:"
+}
 
-		// Not a busy error, return immediately
-		return err
+// openReadPool opens the read-only connection pool of dsn. Every connection runs
+// with query_only, so a write that bypasses the writer queue fails instead of
+// taking the write lock.
+func openReadPool(dsn string) (*sql.DB, error) {
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	params := "_pragma=busy_timeout(10000)&_pragma=query_only(1)"
+	db, err := sql.Open("sqlite", dsn+sep+params)
+	if err != nil {
+		return nil, err
 	}
-	return fmt.Errorf(i18n.T("error.operationRetryFailed", map[string]interface{}{"Retries": maxRetries, "Error": err}))
+	db.SetMaxOpenConns(runtime.NumCPU() + 1)
+	db.SetMaxIdleConns(2)
+	db.SetConnMaxLifetime(time.Hour)
+	return db, nil
 }
 
-func YeniVeriYonetici(dbYolu string, migrationsYolu string) (*VeriYonetici, error) {
-	return YeniVeriYoneticiWithEventEmitter(dbYolu, migrationsYolu, nil, "")
-}
+// openVeriYonetici opens the read-write connection, the read pool and the writer goroutine of dbYolu
+func openVeriYonetici(dbYolu string, eventEmitter EventEmitter, workspaceID string) (*VeriYonetici, error) {
+	dsn := dbYolu
+	if isMemoryDatabase(dbYolu) {
+		// A plain :memory: database is private to one connection. A named shared-cache
+		// database lets the read pool see it; readers take table locks, so they only see
+		// committed data and wait for an open write transaction to finish.
+		dsn = fmt.Sprintf("file:gorev-memdb-%d?mode=memory&cache=shared", memoryDatabaseSayaci.Add(1))
+	}
 
-// YeniVeriYoneticiWithEventEmitter creates a new VeriYonetici with optional event emitter
-func YeniVeriYoneticiWithEventEmitter(dbYolu string, migrationsYolu string, eventEmitter EventEmitter, workspaceID string) (*VeriYonetici, error) {
-	db, err := sql.Open("sqlite", dbYolu)
+	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		return nil, fmt.Errorf(i18n.T("error.dbOpenFailed", map[string]interface{}{"Error": err}))
 	}
@@ -104,12 +110,33 @@ func YeniVeriYoneticiWithEventEmitter(dbYolu string, migrationsYolu string, even
 		return nil, fmt.Errorf(i18n.T("error.dbConfigureFailed", map[string]interface{}{"Error": err}))
 	}
 
-	vy := &VeriYonetici{
+	okuma, err := openReadPool(dsn)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf(i18n.T("error.dbOpenFailed", map[string]interface{}{"Error": err}))
+	}
+
+	return &VeriYonetici{
 		db:           db,
+		okuma:        okuma,
+		yazici:       newWriteQueue(db, writeQueueCapacity),
 		eventEmitter: eventEmitter,
 		workspaceID:  workspaceID,
+	}, nil
+}
+
+func YeniVeriYonetici(dbYolu string, migrationsYolu string) (*VeriYonetici, error) {
+	return YeniVeriYoneticiWithEventEmitter(dbYolu, migrationsYolu, nil, "")
+}
+
+// YeniVeriYoneticiWithEventEmitter creates a new VeriYonetici with optional event emitter
+func YeniVeriYoneticiWithEventEmitter(dbYolu string, migrationsYolu string, eventEmitter EventEmitter, workspaceID string) (*VeriYonetici, error) {
+	vy, err := openVeriYonetici(dbYolu, eventEmitter, workspaceID)
+	if err != nil {
+		return nil, err
 	}
 	if err := vy.migrateDB(migrationsYolu); err != nil {
+		_ = vy.Kapat()
 		return nil, fmt.Errorf(i18n.T("error.migrationFailed", map[string]interface{}{"Error": err}))
 	}
 
@@ -123,23 +150,12 @@ func YeniVeriYoneticiWithEmbeddedMigrations(dbYolu string, migrationsFS fs.FS) (
 
 // YeniVeriYoneticiWithEmbeddedMigrationsAndEventEmitter creates a new VeriYonetici with embedded migrations and optional event emitter
 func YeniVeriYoneticiWithEmbeddedMigrationsAndEventEmitter(dbYolu string, migrationsFS fs.FS, eventEmitter EventEmitter, workspaceID string) (*VeriYonetici, error) {
-	db, err := sql.Open("sqlite", dbYolu)
+	vy, err := openVeriYonetici(dbYolu, eventEmitter, workspaceID)
 	if err != nil {
-		return nil, fmt.Errorf(i18n.T("error.dbOpenFailed", map[string]interface{}{"Error": err}))
-	}
-
-	// Configure SQLite for better concurrent access
-	if err := configureSQLiteForConcurrency(db); err != nil {
-		db.Close()
-		return nil, fmt.Errorf(i18n.T("error.dbConfigureFailed", map[string]interface{}{"Error": err}))
-	}
-
-	vy := &VeriYonetici{
-		db:           db,
-		eventEmitter: eventEmitter,
-		workspaceID:  workspaceID,
+		return nil, err
 	}
 	if err := vy.migrateDBWithFS(migrationsFS); err != nil {
+		_ = vy.Kapat()
 		return nil, fmt.Errorf(i18n.T("error.migrationFailed", map[string]interface{}{"Error": err}))
 	}
 
@@ -149,98 +165,49 @@ func YeniVeriYoneticiWithEmbeddedMigrationsAndEventEmitter(dbYolu string, migrat
 func (vy *VeriYonetici) migrateDB(migrationsYolu string) error {
 	log.Printf("DEBUG: migrateDB called with path: %s", migrationsYolu)
 
-	// Create schema_migrations table if it doesn't exist
-	_, err := vy.db.Exec(`
-		CREATE TABLE IF NOT EXISTS schema_migrations (
-			version INTEGER PRIMARY KEY,
-			dirty INTEGER NOT NULL DEFAULT 0
-		)
-	`)
-	if err != nil {
-		log.Printf("ERROR: Failed to create schema_migrations table: %v", err)
-		return fmt.Errorf(i18n.T("error.migrationTableFailed", map[string]interface{}{"Error": err}))
-	}
-	log.Printf("DEBUG: Schema migrations table ready")
-
-	// Check if database needs migration state repair
-	if err := vy.repairMigrationStateIfNeeded(); err != nil {
-		log.Printf("WARNING: Migration state repair failed: %v", err)
-		// Continue with normal migration process
-	}
-
 	// Parse migration path and remove file:// prefix if present
-	migrationsPath := migrationsYolu
-	if strings.HasPrefix(migrationsPath, "file://") {
-		migrationsPath = strings.TrimPrefix(migrationsPath, "file://")
-		// Make relative paths absolute
-		if !filepath.IsAbs(migrationsPath) {
-			if abs, err := filepath.Abs(migrationsPath); err == nil {
-				migrationsPath = abs
-			}
+	migrationsPath := strings.TrimPrefix(migrationsYolu, "file://")
+	if !filepath.IsAbs(migrationsPath) {
+		if abs, err := filepath.Abs(migrationsPath); err == nil {
+			migrationsPath = abs
 		}
 	}
 	log.Printf("DEBUG: Using filesystem path: %s", migrationsPath)
 
-	// Read migration files from directory
-	migrationFiles, err := filepath.Glob(filepath.Join(migrationsPath, "*.up.sql"))
-	if err != nil {
-		log.Printf("ERROR: Failed to read migration files from %s: %v", migrationsYolu, err)
-		return fmt.Errorf(i18n.T("error.migrationFileReadFailed", map[string]interface{}{"Error": err}))
+	if err := vy.runMigrations(os.DirFS(migrationsPath)); err != nil {
+		return fmt.Errorf(i18n.T("error.migrationsPathFailed", map[string]interface{}{"Path": migrationsPath, "Error": err}))
 	}
-	log.Printf("DEBUG: Found %d migration files", len(migrationFiles))
-
-	for _, migrationFile := range migrationFiles {
-		// Extract version from filename (e.g., 000001_initial_schema.up.sql -> 1)
-		filename := filepath.Base(migrationFile)
-		parts := strings.Split(filename, "_")
-		if len(parts) < 2 {
-			continue
-		}
-		versionStr := parts[0]
-		version := 0
-		if _, parseErr := fmt.Sscanf(versionStr, "%d", &version); parseErr != nil {
-			log.Printf("WARNING: Could not parse version from %s: %v", filename, parseErr)
-			continue
-		}
-
-		// Check if migration is already applied
-		var exists int
-		err = vy.db.QueryRow("SELECT COUNT(*) FROM schema_migrations WHERE version = ?", version).Scan(&exists)
-		if err != nil {
-			log.Printf("ERROR: Failed to check migration status for version %d: %v", version, err)
-			return fmt.Errorf(i18n.T("error.migrationCheckFailed", map[string]interface{}{"Version": version, "Error": err}))
-		}
-
-		if exists > 0 {
-			log.Printf("DEBUG: Migration %d already applied, skipping", version)
-			continue
-		}
-
-		// Read and execute migration file
-		migrationSQL, err := os.ReadFile(migrationFile)
-		if err != nil {
-			log.Printf("ERROR: Failed to read migration file %s: %v", migrationFile, err)
-			return fmt.Errorf(i18n.T("error.migrationFileReadFailed", map[string]interface{}{"File": migrationFile, "Error": err}))
-		}
+	return nil
+}
 
-		log.Printf("DEBUG: Applying migration %d from %s", version, filename)
-		_, err = vy.db.Exec(string(migrationSQL))
-		if err != nil {
-			log.Printf("ERROR: Failed to execute migration %d: %v", version, err)
-			return fmt.Errorf(i18n.T("error.migrationExecuteFailed", map[string]interface{}{"Version": version, "Error": err}))
-		}
+// migrateDBWithFS migrates database using embedded filesystem
+func (vy *VeriYonetici) migrateDBWithFS(migrationsFS fs.FS) error {
+	log.Printf("DEBUG: migrateDBWithFS called for platform: %s", runtime.GOOS)
+	return vy.runMigrations(migrationsFS)
+}
 
-		// Mark migration as applied
-		_, err = vy.db.Exec("INSERT INTO schema_migrations (version) VALUES (?)", version)
-		if err != nil {
-			log.Printf("ERROR: Failed to record migration %d: %v", version, err)
-			return fmt.Errorf(i18n.T("error.migrationRecordFailed", map[string]interface{}{"Version": version, "Error": err}))
-		}
+// runMigrations applies all pending migrations found in migrationsFS. Dirty databases and
+// databases migrated by a newer binary are refused instead of being modified.
+func (vy *VeriYonetici) runMigrations(migrationsFS fs.FS) error {
+	migrator, err := NewMigrator(vy.db, migrationsFS)
+	if err != nil {
+		log.Printf("ERROR: Failed to prepare migrations: %v", err)
+		return err
+	}
+	log.Printf("DEBUG: Found %d migrations", len(migrator.Migrations()))
 
-		log.Printf("DEBUG: Migration %d applied successfully", version)
+	// Check if database needs migration state repair
+	if err := vy.repairMigrationStateIfNeeded(); err != nil {
+		log.Printf("WARNING: Migration state repair failed: %v", err)
+		// Continue with normal migration process
 	}
 
-	log.Println("SUCCESS: Database migrated successfully")
+	applied, err := migrator.Up(context.Background(), 0)
+	if err != nil {
+		log.Printf("ERROR: Migration failed: %v", err)
+		return err
+	}
+	log.Printf("SUCCESS: Database migrated successfully (%d migrations applied)", len(applied))
 
 	// Varsayılan template'leri oluştur
 	if err := vy.VarsayilanTemplateleriOlustur(context.Background()); err != nil {
@@ -251,67 +218,129 @@ func (vy *VeriYonetici) migrateDB(migrationsYolu string) error {
 	return nil
 }
 
-// migrateDBWithFS migrates database using embedded filesystem
-func (vy *VeriYonetici) migrateDBWithFS(migrationsFS fs.FS) error {
-	log.Printf("DEBUG: migrateDBWithFS called for platform: %s", runtime.GOOS)
+// GorevListele retrieves tasks based on filters
+func (vy *VeriYonetici) GorevListele(ctx context.Context, filters map[string]interface{}) ([]*Gorev, error) {
+	whereClauses, args, siralama := gorevListesiKosullari(filters)
+	return vy.gorevListesiGetir(ctx, whereClauses, args, siralama)
+}
+
+// kokGorevKosulu selects the root tasks of the gorevler table ("g"). Unlike a parent_id
+// comparison it cannot use idx_gorev_parent, which keeps the planner on the list order
+// index for pages.
+const kokGorevKosulu = "COALESCE(g.parent_id, '') = ''"
+
+// GorevSayfasiGetir pages the root tasks with LIMIT/OFFSET and reads the subtrees of the
+// page through gorev_kapanis, so a page costs the same however long the list is
+func (vy *VeriYonetici) GorevSayfasiGetir(ctx context.Context, filters map[string]interface{}, limit, offset int) (*GorevSayfasi, error) {
+	whereClauses, args, siralama := gorevListesiKosullari(filters)
+	// Ties are broken by ID in the direction of the order, so pages neither overlap nor
+	// skip tasks and the list order indexes cover the whole ORDER BY
+	if strings.HasSuffix(siralama, " DESC") {
+		siralama += ", g.id DESC"
+	} else {
+		siralama += ", g.id"
+	}
 
-	// Extract embedded migrations to temporary directory
-	tempDir, err := os.MkdirTemp("", "gorev-migrations-*")
+	sayfa := &GorevSayfasi{Gorevler: []*Gorev{}}
+	where := ""
+	if len(whereClauses) > 0 {
+		where = " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+	err := vy.okuma.QueryRowContext(ctx, `SELECT COUNT(*), COALESCE(SUM(CASE WHEN `+kokGorevKosulu+` THEN 1 ELSE 0 END), 0)
+		FROM gorevler g`+where, args...).Scan(&sayfa.ToplamSayisi, &sayfa.KokSayisi)
 	if err != nil {
-		log.Printf("ERROR: Failed to create temp dir: %v", err)
-		return fmt.Errorf(i18n.T("error.migrationDirCreateFailed", map[string]interface{}{"Error": err}))
+		return nil, err
+	}
+	if limit <= 0 || offset >= sayfa.KokSayisi {
+		return sayfa, nil
 	}
-	log.Printf("DEBUG: Created temp dir: %s", tempDir)
-	defer os.RemoveAll(tempDir)
 
-	// Copy all migration files from embedded FS to temp directory
-	fileCount := 0
-	err = fs.WalkDir(migrationsFS, ".", func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			log.Printf("ERROR: WalkDir error for %s: %v", path, err)
-			return err
-		}
-		if d.IsDir() {
-			log.Printf("DEBUG: Skipping directory: %s", path)
-			return nil
+	kokKosullari := append(append([]string{}, whereClauses...), kokGorevKosulu)
+	rows, err := vy.okuma.QueryContext(ctx, `SELECT g.id FROM gorevler g WHERE `+strings.Join(kokKosullari, " AND ")+
+		` ORDER BY `+siralama+` LIMIT ? OFFSET ?`, append(append([]interface{}{}, args...), limit, offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	var kokIDler []interface{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
 		}
+		kokIDler = append(kokIDler, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(kokIDler) == 0 {
+		return sayfa, nil
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(kokIDler)), ",")
 
-		// Read file from embedded FS
-		content, err := fs.ReadFile(migrationsFS, path)
-		if err != nil {
-			log.Printf("ERROR: Failed to read embedded file %s: %v", path, err)
-			return err
-		}
+	kokler, err := vy.gorevListesiGetir(ctx, []string{"g.id IN (" + placeholders + ")"}, kokIDler, siralama)
+	if err != nil {
+		return nil, err
+	}
 
-		// Write to temp directory
-		destPath := filepath.Join(tempDir, path)
-		log.Printf("DEBUG: Writing file %s to %s", path, destPath)
-		if err := os.WriteFile(destPath, content, 0644); err != nil {
-			log.Printf("ERROR: Failed to write file %s: %v", destPath, err)
-			return fmt.Errorf(i18n.T("error.migrationFileWriteFailed", map[string]interface{}{"Error": err}))
-		}
-		fileCount++
-		return nil
-	})
+	// Descendants below a subtask that does not match are read as well; they are not
+	// reachable from the roots, as in the full list
+	altKosullar := append(append([]string{}, whereClauses...),
+		"g.id IN (SELECT descendant_id FROM gorev_kapanis WHERE depth > 0 AND ancestor_id IN ("+placeholders+"))")
+	altGorevler, err := vy.gorevListesiGetir(ctx, altKosullar, append(append([]interface{}{}, args...), kokIDler...), "g.created_at, g.id")
 	if err != nil {
-		log.Printf("ERROR: Failed to extract migrations: %v", err)
-		return fmt.Errorf(i18n.T("error.migrationExtractFailed", map[string]interface{}{"Error": err}))
+		return nil, err
 	}
-	log.Printf("DEBUG: Extracted %d migration files to %s", fileCount, tempDir)
 
-	// Now use regular file-based migration with the temp directory
-	log.Printf("DEBUG: Using temp directory for migrations: %s", tempDir)
-	return vy.migrateDB(tempDir)
+	sayfa.Gorevler = append(kokler, altGorevler...)
+	return sayfa, nil
 }
 
-// GorevListele retrieves tasks based on filters
-func (vy *VeriYonetici) GorevListele(ctx context.Context, filters map[string]interface{}) ([]*Gorev, error) {
-	// Convert filters to old format for compatibility
-	status := ""
-	sirala := ""
-	filtre := ""
-	workspaceID := ""
+// gorevListeleSorgusu returns the parsed query of the "query" filter, if any
+func gorevListeleSorgusu(filters map[string]interface{}) *Query {
+	sorgu, _ := filters["query"].(*Query)
+	return sorgu
+}
 
+// gorevSayfasiAyir cuts the page of GorevSayfasiGetir from a whole task list, for data
+// managers without SQL; only the descendants reachable from the page roots are kept
+func gorevSayfasiAyir(gorevler []*Gorev, limit, offset int) *GorevSayfasi {
+	sayfa := &GorevSayfasi{Gorevler: []*Gorev{}, ToplamSayisi: len(gorevler)}
+	var kokler []*Gorev
+	cocuklar := make(map[string][]*Gorev)
+	for _, gorev := range gorevler {
+		if gorev.ParentID == "" {
+			kokler = append(kokler, gorev)
+		} else {
+			cocuklar[gorev.ParentID] = append(cocuklar[gorev.ParentID], gorev)
+		}
+	}
+	sayfa.KokSayisi = len(kokler)
+	if limit <= 0 || offset >= len(kokler) {
+		return sayfa
+	}
+	son := offset + limit
+	if son > len(kokler) {
+		son = len(kokler)
+	}
+	sayfa.Gorevler = append(sayfa.Gorevler, kokler[offset:son]...)
+
+	var altlariEkle func(id string)
+	altlariEkle = func(id string) {
+		for _, cocuk := range cocuklar[id] {
+			sayfa.Gorevler = append(sayfa.Gorevler, cocuk)
+			altlariEkle(cocuk.ID)
+		}
+	}
+	for _, kok := range kokler[offset:son] {
+		altlariEkle(kok.ID)
+	}
+	return sayfa
+}
+
+// gorevListeleFiltreleri converts GorevListele filters to the arguments of GorevleriGetirWithWorkspace;
+// the proje_id and etiket filters are read separately
+func gorevListeleFiltreleri(filters map[string]interface{}) (status, sirala, filtre, workspaceID string) {
 	if v, ok := filters["status"]; ok {
 		if s, ok := v.(string); ok {
 			status = s
@@ -332,12 +361,21 @@ func (vy *VeriYonetici) GorevListele(ctx context.Context, filters map[string]int
 			workspaceID = s
 		}
 	}
-
-	return vy.GorevleriGetirWithWorkspace(ctx, status, sirala, filtre, workspaceID)
+	return status, sirala, filtre, workspaceID
 }
 
 // GorevOlustur creates a new task
 func (vy *VeriYonetici) GorevOlustur(ctx context.Context, params map[string]interface{}) (string, error) {
+	gorev := yeniGorevParametrelerden(params)
+	if err := vy.GorevKaydet(ctx, gorev); err != nil {
+		return "", err
+	}
+
+	return gorev.ID, nil
+}
+
+// yeniGorevParametrelerden builds a pending task from GorevOlustur parameters
+func yeniGorevParametrelerden(params map[string]interface{}) *Gorev {
 	gorev := &Gorev{
 		ID:        uuid.New().String(),
 		Status:    constants.TaskStatusPending,
@@ -370,12 +408,7 @@ func (vy *VeriYonetici) GorevOlustur(ctx context.Context, params map[string]inte
 			gorev.ParentID = s
 		}
 	}
-
-	if err := vy.GorevKaydet(ctx, gorev); err != nil {
-		return "", err
-	}
-
-	return gorev.ID, nil
+	return gorev
 }
 
 // GorevDetay retrieves detailed task information
@@ -385,6 +418,11 @@ func (vy *VeriYonetici) GorevDetay(ctx context.Context, taskID string) (*Gorev,
 
 // GorevBagimlilikGetir retrieves task dependencies
 func (vy *VeriYonetici) GorevBagimlilikGetir(ctx context.Context, taskID string) ([]*Gorev, error) {
+	return gorevBagimlilikGetir(ctx, vy, taskID)
+}
+
+// gorevBagimlilikGetir returns the tasks taskID depends on
+func gorevBagimlilikGetir(ctx context.Context, vy VeriYoneticiInterface, taskID string) ([]*Gorev, error) {
 	// Get all dependencies for the task
 	baglantilari, err := vy.BaglantilariGetir(ctx, taskID)
 	if err != nil {
@@ -407,6 +445,11 @@ func (vy *VeriYonetici) GorevBagimlilikGetir(ctx context.Context, taskID string)
 
 // AltGorevOlustur creates a subtask under a parent task
 func (vy *VeriYonetici) AltGorevOlustur(ctx context.Context, parentID, title, description, priority, sonTarihStr string, etiketIsimleri []string) (*Gorev, error) {
+	return altGorevOlustur(ctx, vy, parentID, title, description, priority, sonTarihStr, etiketIsimleri)
+}
+
+// altGorevOlustur saves a pending subtask of parentID with its tags through vy
+func altGorevOlustur(ctx context.Context, vy VeriYoneticiInterface, parentID, title, description, priority, sonTarihStr string, etiketIsimleri []string) (*Gorev, error) {
 	var sonTarih *time.Time
 	if sonTarihStr != "" {
 		t, err := time.Parse("2006-01-02", sonTarihStr)
@@ -446,11 +489,28 @@ func (vy *VeriYonetici) AltGorevOlustur(ctx context.Context, parentID, title, de
 	return gorev, nil
 }
 
+// Kapat runs the writes already queued and closes the database
 func (vy *VeriYonetici) Kapat() error {
+	if vy.yazici != nil {
+		vy.yazici.close()
+	}
+	if vy.okuma != nil {
+		_ = vy.okuma.Close()
+	}
 	return vy.db.Close()
 }
 
-// GetDB returns the underlying database connection for advanced operations
+// WriteQueueStats returns queue depth and wait time metrics of the writer goroutine
+func (vy *VeriYonetici) WriteQueueStats() WriteQueueStats {
+	if vy.yazici == nil {
+		return WriteQueueStats{}
+	}
+	return vy.yazici.stats()
+}
+
+// GetDB returns the read-write connection for advanced operations. It allows a single
+// connection, so writes made through it wait for the writer goroutine instead of
+// competing for the SQLite write lock.
 func (vy *VeriYonetici) GetDB() (*sql.DB, error) {
 	if vy.db == nil {
 		return nil, fmt.Errorf(i18n.T("error.dbConnectionClosed"))
@@ -458,6 +518,26 @@ func (vy *VeriYonetici) GetDB() (*sql.DB, error) {
 	return vy.db, nil
 }
 
+// GetReadDB returns the read-only connection pool. Long reads such as streaming
+// exports should use it, so that they do not hold the read-write connection.
+func (vy *VeriYonetici) GetReadDB() (*sql.DB, error) {
+	if vy.okuma == nil {
+		return nil, fmt.Errorf(i18n.T("error.dbConnectionClosed"))
+	}
+	return vy.okuma, nil
+}
+
+// YazmaIslemi runs fn in a transaction on the writer goroutine. fn must only use tx;
+// writes through VeriYonetici methods from inside fn would wait for fn itself.
+func (vy *VeriYonetici) YazmaIslemi(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return vy.yazici.tx(ctx, fn)
+}
+
+// YardimciYazmaIslemi is YazmaIslemi; only CachedVeriYonetici treats the two differently
+func (vy *VeriYonetici) YardimciYazmaIslemi(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return vy.YazmaIslemi(ctx, fn)
+}
+
 // ProjeOlustur creates a new project with minimal data for testing
 func (vy *VeriYonetici) ProjeOlustur(ctx context.Context, name, description string, etiketler ...string) (*Proje, error) {
 	proje := &Proje{
@@ -516,23 +596,19 @@ func (vy *VeriYonetici) GorevKaydet(ctx context.Context, gorev *Gorev) error {
 		workspaceID = "default"
 	}
 
-	// Use retry logic for better concurrent write handling
-	err := retryOnBusy(func() error {
-		_, err := vy.db.Exec(sorgu,
-			gorev.ID,
-			gorev.Title,
-			gorev.Description,
-			gorev.Status,
-			gorev.Priority,
-			sql.NullString{String: gorev.ProjeID, Valid: gorev.ProjeID != ""},
-			sql.NullString{String: gorev.ParentID, Valid: gorev.ParentID != ""},
-			workspaceID,
-			gorev.CreatedAt,
-			gorev.UpdatedAt,
-			gorev.DueDate,
-		)
-		return err
-	}, 10) // Retry up to 10 times with exponential backoff (capped at 1s)
+	_, err := vy.yazici.exec(ctx, sorgu,
+		gorev.ID,
+		gorev.Title,
+		gorev.Description,
+		gorev.Status,
+		gorev.Priority,
+		sql.NullString{String: gorev.ProjeID, Valid: gorev.ProjeID != ""},
+		sql.NullString{String: gorev.ParentID, Valid: gorev.ParentID != ""},
+		workspaceID,
+		gorev.CreatedAt,
+		gorev.UpdatedAt,
+		gorev.DueDate,
+	)
 
 	// Emit task created event if operation succeeded
 	if err == nil && vy.eventEmitter != nil {
@@ -553,7 +629,7 @@ func (vy *VeriYonetici) GorevGetir(ctx context.Context, id string) (*Gorev, erro
 	gorev := &Gorev{}
 	var projeID, parentID sql.NullString
 
-	err := vy.db.QueryRow(sorgu, id).Scan(
+	err := vy.okuma.QueryRow(sorgu, id).Scan(
 		&gorev.ID,
 		&gorev.Title,
 		&gorev.Description,
@@ -605,52 +681,95 @@ func (vy *VeriYonetici) GorevleriGetir(ctx context.Context, status, sirala, filt
 
 // GorevleriGetirWithWorkspace retrieves tasks with optional workspace filtering
 func (vy *VeriYonetici) GorevleriGetirWithWorkspace(ctx context.Context, status, sirala, filtre, workspaceID string) ([]*Gorev, error) {
-	sorgu := `SELECT id, title, description, status, priority, project_id, parent_id, workspace_id, created_at, updated_at, due_date
-	          FROM gorevler`
+	return vy.GorevListele(ctx, map[string]interface{}{
+		"status":       status,
+		"sirala":       sirala,
+		"filtre":       filtre,
+		"workspace_id": workspaceID,
+	})
+}
+
+// gorevListesiKosullari returns the conditions on the gorevler table ("g") and the order
+// of the task list selected by GorevListele filters
+func gorevListesiKosullari(filters map[string]interface{}) ([]string, []interface{}, string) {
+	status, sirala, filtre, workspaceID := gorevListeleFiltreleri(filters)
 	args := []interface{}{}
 	whereClauses := []string{}
 
 	// Add workspace filter if provided (centralized mode)
 	if workspaceID != "" {
-		whereClauses = append(whereClauses, "workspace_id = ?")
+		whereClauses = append(whereClauses, "g.workspace_id = ?")
 		args = append(args, workspaceID)
 	}
 
 	if status != "" {
-		whereClauses = append(whereClauses, "status = ?")
+		whereClauses = append(whereClauses, "g.status = ?")
 		args = append(args, status)
 	}
 
 	if filtre == "acil" {
-		whereClauses = append(whereClauses, "due_date IS NOT NULL AND due_date >= date('now') AND due_date < date('now', '+7 days')")
+		whereClauses = append(whereClauses, "g.due_date IS NOT NULL AND g.due_date >= date('now') AND g.due_date < date('now', '+7 days')")
 	} else if filtre == "gecmis" {
-		whereClauses = append(whereClauses, "due_date IS NOT NULL AND due_date < date('now')")
+		whereClauses = append(whereClauses, "g.due_date IS NOT NULL AND g.due_date < date('now')")
 	}
 
-	if len(whereClauses) > 0 {
-		sorgu += " WHERE " + strings.Join(whereClauses, " AND ")
+	if projeID, _ := filters["proje_id"].(string); projeID != "" {
+		whereClauses = append(whereClauses, "g.project_id = ?")
+		args = append(args, projeID)
+	}
+
+	if etiket, _ := filters["etiket"].(string); etiket != "" {
+		whereClauses = append(whereClauses, "EXISTS (SELECT 1 FROM gorev_etiketleri ge JOIN etiketler e ON e.id = ge.tag_id WHERE ge.task_id = g.id AND e.name = ?)")
+		args = append(args, etiket)
 	}
 
+	if sorgu := gorevListeleSorgusu(filters); !sorgu.IsEmpty() {
+		kosul, sorguArgs := sorgu.SQL("g")
+		whereClauses = append(whereClauses, kosul)
+		args = append(args, sorguArgs...)
+	}
+
+	siralama := "g.created_at DESC"
 	switch sirala {
 	case "son_tarih_asc":
-		sorgu += " ORDER BY due_date ASC"
+		siralama = "g.due_date ASC"
 	case "son_tarih_desc":
-		sorgu += " ORDER BY due_date DESC"
-	default:
-		sorgu += " ORDER BY created_at DESC"
+		siralama = "g.due_date DESC"
 	}
 
-	rows, err := vy.db.Query(sorgu, args...)
+	return whereClauses, args, siralama
+}
+
+// gorevListesiSorgusu reads tasks together with their project name and dependency
+// counts; the counts are indexed lookups for the returned rows only, so a page of a
+// long list does not aggregate all links
+const gorevListesiSorgusu = `SELECT g.id, g.title, g.description, g.status, g.priority, g.project_id, g.parent_id,
+	       g.workspace_id, g.created_at, g.updated_at, g.due_date,
+	       COALESCE(p.name, ''),
+	       (SELECT COUNT(*) FROM baglantilar b WHERE b.target_id = g.id),
+	       (SELECT COUNT(*) FROM baglantilar b JOIN gorevler kaynak ON kaynak.id = b.source_id
+	        WHERE b.target_id = g.id AND kaynak.status != 'tamamlandi'),
+	       (SELECT COUNT(*) FROM baglantilar b WHERE b.source_id = g.id)
+	FROM gorevler g
+	LEFT JOIN projeler p ON p.id = g.project_id`
+
+// gorevListesiGetir builds a task list from two queries regardless of its size: one for
+// the tasks with project names and dependency counts, one for the tags of all of them.
+// whereClauses refer to the gorevler table as "g".
+func (vy *VeriYonetici) gorevListesiGetir(ctx context.Context, whereClauses []string, args []interface{}, siralama string) ([]*Gorev, error) {
+	where := ""
+	if len(whereClauses) > 0 {
+		where = " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	rows, err := vy.okuma.QueryContext(ctx, gorevListesiSorgusu+where+" ORDER BY "+siralama, args...)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err := rows.Close(); err != nil {
-			// best-effort close
-		}
-	}()
+	defer func() { _ = rows.Close() }()
 
 	var gorevler []*Gorev
+	gorevMap := make(map[string]*Gorev)
 	for rows.Next() {
 		gorev := &Gorev{}
 		var projeID, parentID, wsID sql.NullString
@@ -667,50 +786,70 @@ func (vy *VeriYonetici) GorevleriGetirWithWorkspace(ctx context.Context, status,
 			&gorev.CreatedAt,
 			&gorev.UpdatedAt,
 			&gorev.DueDate,
+			&gorev.ProjeName,
+			&gorev.DependencyCount,
+			&gorev.UncompletedDependencyCount,
+			&gorev.DependentOnThisCount,
 		)
 		if err != nil {
 			return nil, err
 		}
+		gorev.ProjeID = projeID.String
+		gorev.ParentID = parentID.String
+		gorev.WorkspaceID = wsID.String
 
-		if projeID.Valid {
-			gorev.ProjeID = projeID.String
-		}
+		gorevler = append(gorevler, gorev)
+		gorevMap[gorev.ID] = gorev
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(gorevler) == 0 {
+		return gorevler, nil
+	}
 
-		if parentID.Valid {
-			gorev.ParentID = parentID.String
-		}
+	// Etiketleri tek sorguda getir; liste filtresi alt sorgu olarak tekrarlanır,
+	// böylece görev sayısı SQLite parametre sınırına takılmaz
+	etiketSorgusu := `SELECT ge.task_id, e.id, e.name FROM gorev_etiketleri ge
+	          JOIN etiketler e ON e.id = ge.tag_id`
+	if where != "" {
+		etiketSorgusu += " WHERE ge.task_id IN (SELECT g.id FROM gorevler g" + where + ")"
+	}
+	if err := vy.etiketleriDagit(ctx, etiketSorgusu, args, gorevMap); err != nil {
+		// Hata durumunda görevleri etiketsiz döndür
+		log.Printf("WARNING: %v", err)
+	}
 
-		if wsID.Valid {
-			gorev.WorkspaceID = wsID.String
-		}
+	return gorevler, nil
+}
 
-		// Etiketleri getir
-		etiketler, err := vy.gorevEtiketleriniGetir(gorev.ID)
-		if err != nil {
-			// Hata durumunda logla ve devam et, görevi etiketsiz döndür
-			//log.Printf("görev etiketleri getirilemedi: %v", err)
-		}
-		gorev.Tags = etiketler
+// etiketleriDagit runs a query returning (task_id, tag_id, tag_name) rows and appends
+// the tags to the matching tasks
+func (vy *VeriYonetici) etiketleriDagit(ctx context.Context, sorgu string, args []interface{}, gorevMap map[string]*Gorev) error {
+	rows, err := vy.okuma.QueryContext(ctx, sorgu, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
 
-		// Proje adını getir (Web UI ve VS Code için)
-		if gorev.ProjeID != "" {
-			proje, err := vy.ProjeGetir(ctx, gorev.ProjeID)
-			if err == nil && proje != nil {
-				gorev.ProjeName = proje.Name
-			}
+	for rows.Next() {
+		var gorevID string
+		e := &Etiket{}
+		if err := rows.Scan(&gorevID, &e.ID, &e.Name); err != nil {
+			return err
+		}
+		if gorev, ok := gorevMap[gorevID]; ok {
+			gorev.Tags = append(gorev.Tags, e)
 		}
-
-		gorevler = append(gorevler, gorev)
 	}
-
-	return gorevler, nil
+	return rows.Err()
 }
 
 func (vy *VeriYonetici) gorevEtiketleriniGetir(gorevID string) ([]*Etiket, error) {
 	sorgu := `SELECT e.id, e.name FROM etiketler e
 	          JOIN gorev_etiketleri ge ON e.id = ge.tag_id
 	          WHERE ge.task_id = ?`
-	rows, err := vy.db.Query(sorgu, gorevID)
+	rows, err := vy.okuma.Query(sorgu, gorevID)
 	if err != nil {
 		// Muhtemelen tablo yok, boş dön
 		return []*Etiket{}, nil
@@ -734,57 +873,46 @@ func (vy *VeriYonetici) gorevEtiketleriniGetir(gorevID string) ([]*Etiket, error
 
 func (vy *VeriYonetici) EtiketleriGetirVeyaOlustur(ctx context.Context, isimler []string) ([]*Etiket, error) {
 	etiketler := make([]*Etiket, 0, len(isimler))
-	tx, err := vy.db.Begin()
-	if err != nil {
-		return nil, fmt.Errorf(i18n.T("error.transactionFailed", map[string]interface{}{"Error": err}))
-	}
-	defer func() { _ = tx.Rollback() }() // Hata durumunda geri al
-
-	stmtSelect, err := tx.Prepare("SELECT id, name FROM etiketler WHERE name = ?")
-	if err != nil {
-		return nil, fmt.Errorf(i18n.T("error.selectPrepFailed", map[string]interface{}{"Error": err}))
-	}
-	defer func() { _ = stmtSelect.Close() }()
-
-	stmtInsert, err := tx.Prepare("INSERT INTO etiketler (id, name) VALUES (?, ?)")
-	if err != nil {
-		return nil, fmt.Errorf(i18n.T("error.insertPrepFailed", map[string]interface{}{"Error": err}))
-	}
-	defer func() { _ = stmtInsert.Close() }()
+	err := vy.yazici.tx(ctx, func(tx *sql.Tx) error {
+		stmtSelect, err := tx.Prepare("SELECT id, name FROM etiketler WHERE name = ?")
+		if err != nil {
+			return fmt.Errorf(i18n.T("error.selectPrepFailed", map[string]interface{}{"Error": err}))
+		}
+		defer func() { _ = stmtSelect.Close() }()
 
-	for _, name := range isimler {
-		if strings.TrimSpace(name) == "" {
-			continue
+		stmtInsert, err := tx.Prepare("INSERT INTO etiketler (id, name) VALUES (?, ?)")
+		if err != nil {
+			return fmt.Errorf(i18n.T("error.insertPrepFailed", map[string]interface{}{"Error": err}))
 		}
-		etiket := &Etiket{Name: strings.TrimSpace(name)}
-		err := stmtSelect.QueryRow(etiket.Name).Scan(&etiket.ID, &etiket.Name)
-		if err == sql.ErrNoRows {
-			// Etiket yok, oluştur
-			etiket.ID = uuid.New().String()
-			if _, err := stmtInsert.Exec(etiket.ID, etiket.Name); err != nil {
-				return nil, fmt.Errorf(i18n.T("error.tagCreateFailed", map[string]interface{}{"Tag": etiket.Name, "Error": err}))
+		defer func() { _ = stmtInsert.Close() }()
+
+		for _, name := range isimler {
+			if strings.TrimSpace(name) == "" {
+				continue
 			}
-		} else if err != nil {
-			return nil, fmt.Errorf(i18n.T("error.tagQueryFailed", map[string]interface{}{"Tag": etiket.Name, "Error": err}))
+			etiket := &Etiket{Name: strings.TrimSpace(name)}
+			err := stmtSelect.QueryRow(etiket.Name).Scan(&etiket.ID, &etiket.Name)
+			if err == sql.ErrNoRows {
+				// Etiket yok, oluştur
+				etiket.ID = uuid.New().String()
+				if _, err := stmtInsert.Exec(etiket.ID, etiket.Name); err != nil {
+					return fmt.Errorf(i18n.T("error.tagCreateFailed", map[string]interface{}{"Tag": etiket.Name, "Error": err}))
+				}
+			} else if err != nil {
+				return fmt.Errorf(i18n.T("error.tagQueryFailed", map[string]interface{}{"Tag": etiket.Name, "Error": err}))
+			}
+			etiketler = append(etiketler, etiket)
 		}
-		etiketler = append(etiketler, etiket)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return etiketler, tx.Commit()
+	return etiketler, nil
 }
 
 func (vy *VeriYonetici) GorevEtiketleriniAyarla(ctx context.Context, gorevID string, etiketler []*Etiket) error {
-	// Use retry logic for the entire transaction
-	return retryOnBusy(func() error {
-		tx, err := vy.db.Begin()
-		if err != nil {
-			return fmt.Errorf(i18n.T("error.transactionFailed", map[string]interface{}{"Error": err}))
-		}
-		defer func() {
-			// Silently ignore rollback errors as transaction may already be committed
-			_ = tx.Rollback()
-		}()
-
+	return vy.yazici.tx(ctx, func(tx *sql.Tx) error {
 		// Mevcut bağlantıları sil
 		if _, err := tx.Exec("DELETE FROM gorev_etiketleri WHERE task_id = ?", gorevID); err != nil {
 			return fmt.Errorf(i18n.T("error.currentTagsRemoveFailed", map[string]interface{}{"Error": err}))
@@ -804,9 +932,8 @@ func (vy *VeriYonetici) GorevEtiketleriniAyarla(ctx context.Context, gorevID str
 				return fmt.Errorf(i18n.T("error.taskTagAddFailed", map[string]interface{}{"Tag": etiket.Name, "Error": err}))
 			}
 		}
-
-		return tx.Commit()
-	}, 10) // Retry up to 10 times with exponential backoff (capped at 1s)
+		return nil
+	})
 }
 
 func (vy *VeriYonetici) GorevGuncelle(ctx context.Context, taskID string, params interface{}) error {
@@ -831,7 +958,7 @@ func (vy *VeriYonetici) GorevGuncelle(ctx context.Context, taskID string, params
 	sorgu := fmt.Sprintf("UPDATE gorevler SET %s WHERE id = ?", strings.Join(setParts, ", "))
 	args = append(args, taskID)
 
-	_, err := vy.db.Exec(sorgu, args...)
+	_, err := vy.yazici.exec(ctx, sorgu, args...)
 
 	// Emit task updated event if operation succeeded
 	if err == nil && vy.eventEmitter != nil {
@@ -851,7 +978,7 @@ func (vy *VeriYonetici) ProjeKaydet(ctx context.Context, proje *Proje) error {
 		workspaceID = "default"
 	}
 
-	_, err := vy.db.Exec(sorgu,
+	_, err := vy.yazici.exec(ctx, sorgu,
 		proje.ID,
 		proje.Name,
 		proje.Definition,
@@ -868,7 +995,7 @@ func (vy *VeriYonetici) ProjeGetir(ctx context.Context, id string) (*Proje, erro
 	          FROM projeler WHERE id = ?`
 
 	proje := &Proje{}
-	err := vy.db.QueryRow(sorgu, id).Scan(
+	err := vy.okuma.QueryRow(sorgu, id).Scan(
 		&proje.ID,
 		&proje.Name,
 		&proje.Definition,
@@ -891,7 +1018,7 @@ func (vy *VeriYonetici) ProjeleriGetir(ctx context.Context) ([]*Proje, error) {
 	          GROUP BY p.id, p.name, p.definition, p.created_at, p.updated_at
 	          ORDER BY p.created_at DESC`
 
-	rows, err := vy.db.Query(sorgu)
+	rows, err := vy.okuma.Query(sorgu)
 	if err != nil {
 		return nil, err
 	}
@@ -920,42 +1047,36 @@ func (vy *VeriYonetici) ProjeleriGetir(ctx context.Context) ([]*Proje, error) {
 }
 
 func (vy *VeriYonetici) GorevSil(ctx context.Context, id string) error {
-	// Start a transaction to ensure atomic deletion
-	tx, err := vy.db.Begin()
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	// First, delete all dependencies where this task is involved
-	// This prevents FK constraint violations
-	deleteDeps := `DELETE FROM baglantilar WHERE source_id = ? OR target_id = ?`
-	_, err = tx.Exec(deleteDeps, id, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete task dependencies: %w", err)
-	}
+	// Delete dependencies and the task in one transaction
+	err := vy.yazici.tx(ctx, func(tx *sql.Tx) error {
+		// First, delete all dependencies where this task is involved
+		// This prevents FK constraint violations
+		deleteDeps := `DELETE FROM baglantilar WHERE source_id = ? OR target_id = ?`
+		if _, err := tx.Exec(deleteDeps, id, id); err != nil {
+			return fmt.Errorf("failed to delete task dependencies: %w", err)
+		}
+
+		// Now delete the task itself
+		sorgu := `DELETE FROM gorevler WHERE id = ?`
+		result, err := tx.Exec(sorgu, id)
+		if err != nil {
+			return err
+		}
 
-	// Now delete the task itself
-	sorgu := `DELETE FROM gorevler WHERE id = ?`
-	result, err := tx.Exec(sorgu, id)
-	if err != nil {
-		return err
-	}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
 
-	rowsAffected, err := result.RowsAffected()
+		if rowsAffected == 0 {
+			return fmt.Errorf(i18n.TEntityNotFound(i18n.FromContext(ctx), "task", errors.New("not found")))
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf(i18n.TEntityNotFound(i18n.FromContext(ctx), "task", errors.New("not found")))
-	}
-
-	// Commit the transaction
-	if err := tx.Commit(); err != nil {
-		return err
-	}
-
 	// Emit task deleted event if operation succeeded
 	if vy.eventEmitter != nil {
 		vy.eventEmitter.EmitTaskDeleted(vy.workspaceID, id)
@@ -965,66 +1086,23 @@ func (vy *VeriYonetici) GorevSil(ctx context.Context, id string) error {
 }
 
 func (vy *VeriYonetici) ProjeGorevleriGetir(ctx context.Context, projeID string) ([]*Gorev, error) {
-	var sorgu string
-	var rows *sql.Rows
-	var err error
-
 	// Handle empty projeID as NULL search
 	if projeID == "" {
-		sorgu = `SELECT id, title, description, status, priority, project_id, created_at, updated_at
-		          FROM gorevler WHERE project_id IS NULL ORDER BY created_at DESC`
-		rows, err = vy.db.Query(sorgu)
-	} else {
-		sorgu = `SELECT id, title, description, status, priority, project_id, created_at, updated_at
-		          FROM gorevler WHERE project_id = ? ORDER BY created_at DESC`
-		rows, err = vy.db.Query(sorgu, projeID)
-	}
-	if err != nil {
-		return nil, err
+		return vy.gorevListesiGetir(ctx, []string{"g.project_id IS NULL"}, nil, "g.created_at DESC")
 	}
-	defer func() {
-		_ = rows.Close()
-	}()
-
-	var gorevler []*Gorev
-	for rows.Next() {
-		gorev := &Gorev{}
-		var pID sql.NullString
-
-		err := rows.Scan(
-			&gorev.ID,
-			&gorev.Title,
-			&gorev.Description,
-			&gorev.Status,
-			&gorev.Priority,
-			&pID,
-			&gorev.CreatedAt,
-			&gorev.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		if pID.Valid {
-			gorev.ProjeID = pID.String
-		}
-
-		gorevler = append(gorevler, gorev)
-	}
-
-	return gorevler, nil
+	return vy.gorevListesiGetir(ctx, []string{"g.project_id = ?"}, []interface{}{projeID}, "g.created_at DESC")
 }
 
 func (vy *VeriYonetici) BaglantiEkle(ctx context.Context, baglanti *Baglanti) error {
 	sorgu := `INSERT INTO baglantilar (id, source_id, target_id, connection_type) VALUES (?, ?, ?, ?)`
-	_, err := vy.db.Exec(sorgu, baglanti.ID, baglanti.SourceID, baglanti.TargetID, baglanti.ConnectionType)
+	_, err := vy.yazici.exec(ctx, sorgu, baglanti.ID, baglanti.SourceID, baglanti.TargetID, baglanti.ConnectionType)
 	return err
 }
 
 // BaglantiSil removes a dependency relationship between two tasks
 func (vy *VeriYonetici) BaglantiSil(ctx context.Context, kaynakID, hedefID string) error {
 	sorgu := `DELETE FROM baglantilar WHERE source_id = ? AND target_id = ?`
-	result, err := vy.db.Exec(sorgu, kaynakID, hedefID)
+	result, err := vy.yazici.exec(ctx, sorgu, kaynakID, hedefID)
 	if err != nil {
 		return err
 	}
@@ -1044,7 +1122,7 @@ func (vy *VeriYonetici) BaglantiSil(ctx context.Context, kaynakID, hedefID strin
 
 func (vy *VeriYonetici) BaglantilariGetir(ctx context.Context, gorevID string) ([]*Baglanti, error) {
 	sorgu := `SELECT id, source_id, target_id, connection_type FROM baglantilar WHERE source_id = ? OR target_id = ?`
-	rows, err := vy.db.Query(sorgu, gorevID, gorevID)
+	rows, err := vy.okuma.Query(sorgu, gorevID, gorevID)
 	if err != nil {
 		return nil, err
 	}
@@ -1068,7 +1146,7 @@ func (vy *VeriYonetici) AltGorevleriGetir(ctx context.Context, parentID string)
 	sorgu := `SELECT id, title, description, status, priority, project_id, parent_id, created_at, updated_at, due_date
 	          FROM gorevler WHERE parent_id = ? ORDER BY created_at`
 
-	rows, err := vy.db.Query(sorgu, parentID)
+	rows, err := vy.okuma.Query(sorgu, parentID)
 	if err != nil {
 		return nil, err
 	}
@@ -1117,38 +1195,47 @@ func (vy *VeriYonetici) AltGorevleriGetir(ctx context.Context, parentID string)
 	return gorevler, nil
 }
 
-// TumAltGorevleriGetir belirtilen görevin tüm alt görev hiyerarşisini getirir (recursive)
-func (vy *VeriYonetici) TumAltGorevleriGetir(ctx context.Context, parentID string) ([]*Gorev, error) {
-	sorgu := `
-		WITH RECURSIVE alt_gorevler AS (
-			SELECT id, title, description, status, priority, project_id, parent_id, 
-			       created_at, updated_at, due_date, 1 as level
-			FROM gorevler
-			WHERE parent_id = ?
-			
-			UNION ALL
-			
-			SELECT g.id, g.title, g.description, g.status, g.priority, g.project_id, g.parent_id,
-			       g.created_at, g.updated_at, g.due_date, ag.level + 1
-			FROM gorevler g
-			INNER JOIN alt_gorevler ag ON g.parent_id = ag.id
-		)
-		SELECT * FROM alt_gorevler ORDER BY level, created_at`
+// topluSorguLimiti is the largest ID list bound as IN (...) parameters; longer lists
+// read all rows instead and filter in Go, so the number of queries stays fixed
+const topluSorguLimiti = 500
+
+// AltGorevleriTopluGetir verilen görevlerin doğrudan alt görevlerini etiketleriyle birlikte
+// iki sorguda getirir; sonuç parent ID'ye göre gruplanır
+func (vy *VeriYonetici) AltGorevleriTopluGetir(ctx context.Context, parentIDs []string) (map[string][]*Gorev, error) {
+	sonuc := make(map[string][]*Gorev)
+	if len(parentIDs) == 0 {
+		return sonuc, nil
+	}
 
-	rows, err := vy.db.Query(sorgu, parentID)
+	istenen := make(map[string]bool, len(parentIDs))
+	for _, id := range parentIDs {
+		istenen[id] = true
+	}
+
+	whereClause := "g.parent_id IS NOT NULL AND g.parent_id != ''"
+	var args []interface{}
+	if len(parentIDs) <= topluSorguLimiti {
+		placeholders := make([]string, len(parentIDs))
+		for i, id := range parentIDs {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		whereClause = "g.parent_id IN (" + strings.Join(placeholders, ",") + ")"
+	}
+
+	rows, err := vy.okuma.QueryContext(ctx, `SELECT g.id, g.title, g.description, g.status, g.priority, g.project_id, g.parent_id,
+	          g.created_at, g.updated_at, g.due_date
+	          FROM gorevler g WHERE `+whereClause+` ORDER BY g.created_at`, args...)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		_ = rows.Close()
-	}()
+	defer func() { _ = rows.Close() }()
 
-	var gorevler []*Gorev
+	gorevMap := make(map[string]*Gorev)
 	for rows.Next() {
 		gorev := &Gorev{}
 		var projeID, parentID sql.NullString
-
-		err := rows.Scan(
+		if err := rows.Scan(
 			&gorev.ID,
 			&gorev.Title,
 			&gorev.Description,
@@ -1159,52 +1246,90 @@ func (vy *VeriYonetici) TumAltGorevleriGetir(ctx context.Context, parentID strin
 			&gorev.CreatedAt,
 			&gorev.UpdatedAt,
 			&gorev.DueDate,
-			&gorev.Level,
-		)
-		if err != nil {
+		); err != nil {
 			return nil, err
 		}
-
-		if projeID.Valid {
-			gorev.ProjeID = projeID.String
-		}
-		if parentID.Valid {
-			gorev.ParentID = parentID.String
+		if !istenen[parentID.String] {
+			continue
 		}
+		gorev.ProjeID = projeID.String
+		gorev.ParentID = parentID.String
+		sonuc[gorev.ParentID] = append(sonuc[gorev.ParentID], gorev)
+		gorevMap[gorev.ID] = gorev
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(gorevMap) == 0 {
+		return sonuc, nil
+	}
 
-		// Etiketleri getir
-		etiketler, err := vy.gorevEtiketleriniGetir(gorev.ID)
-		if err != nil && !errors.Is(err, sql.ErrNoRows) {
-			//log.Printf("Etiketler getirilemedi: %v", err)
-		}
-		gorev.Tags = etiketler
+	etiketSorgusu := `SELECT ge.task_id, e.id, e.name FROM gorev_etiketleri ge
+	          JOIN etiketler e ON e.id = ge.tag_id
+	          WHERE ge.task_id IN (SELECT g.id FROM gorevler g WHERE ` + whereClause + `)`
+	if err := vy.etiketleriDagit(ctx, etiketSorgusu, args, gorevMap); err != nil {
+		log.Printf("WARNING: %v", err)
+	}
 
-		gorevler = append(gorevler, gorev)
+	return sonuc, nil
+}
+
+// TumAltGorevleriGetir belirtilen görevin tüm alt görev hiyerarşisini getirir. Alt ağaç
+// gorev_kapanis tablosundan tek sorguda okunur; Level görevin parentID'ye uzaklığıdır.
+func (vy *VeriYonetici) TumAltGorevleriGetir(ctx context.Context, parentID string) ([]*Gorev, error) {
+	sorgu := `SELECT g.id, g.title, COALESCE(g.description, ''), g.status, g.priority, g.project_id, g.parent_id,
+	                 g.created_at, g.updated_at, g.due_date, k.depth
+	          FROM gorev_kapanis k
+	          JOIN gorevler g ON g.id = k.descendant_id
+	          WHERE k.ancestor_id = ? AND k.depth > 0
+	          ORDER BY k.depth, g.created_at`
+
+	gorevler, err := vy.hiyerarsiGorevleriniOku(ctx, sorgu, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(gorevler) == 0 {
+		return gorevler, nil
+	}
+
+	gorevMap := make(map[string]*Gorev, len(gorevler))
+	for _, gorev := range gorevler {
+		gorevMap[gorev.ID] = gorev
+	}
+	etiketSorgusu := `SELECT ge.task_id, e.id, e.name FROM gorev_etiketleri ge
+	          JOIN etiketler e ON e.id = ge.tag_id
+	          JOIN gorev_kapanis k ON k.descendant_id = ge.task_id
+	          WHERE k.ancestor_id = ? AND k.depth > 0`
+	if err := vy.etiketleriDagit(ctx, etiketSorgusu, []interface{}{parentID}, gorevMap); err != nil {
+		log.Printf("WARNING: %v", err)
 	}
 
 	return gorevler, nil
 }
 
-// UstGorevleriGetir belirtilen görevin tüm üst görev hiyerarşisini getirir
+// UstGorevleriGetir belirtilen görevin tüm üst görev hiyerarşisini en yakın üst görevden başlayarak getirir
 func (vy *VeriYonetici) UstGorevleriGetir(ctx context.Context, gorevID string) ([]*Gorev, error) {
-	sorgu := `
-		WITH RECURSIVE ust_gorevler AS (
-			SELECT g2.id, g2.title, g2.description, g2.status, g2.priority, g2.project_id, g2.parent_id,
-			       g2.created_at, g2.updated_at, g2.due_date
-			FROM gorevler g1
-			JOIN gorevler g2 ON g1.parent_id = g2.id
-			WHERE g1.id = ?
-			
-			UNION ALL
-			
-			SELECT g.id, g.title, g.description, g.status, g.priority, g.project_id, g.parent_id,
-			       g.created_at, g.updated_at, g.due_date
-			FROM gorevler g
-			INNER JOIN ust_gorevler ug ON ug.parent_id = g.id
-		)
-		SELECT * FROM ust_gorevler`
+	sorgu := `SELECT g.id, g.title, COALESCE(g.description, ''), g.status, g.priority, g.project_id, g.parent_id,
+	                 g.created_at, g.updated_at, g.due_date, k.depth
+	          FROM gorev_kapanis k
+	          JOIN gorevler g ON g.id = k.ancestor_id
+	          WHERE k.descendant_id = ? AND k.depth > 0
+	          ORDER BY k.depth`
+
+	gorevler, err := vy.hiyerarsiGorevleriniOku(ctx, sorgu, gorevID)
+	if err != nil {
+		return nil, err
+	}
+	// Level alt görevler için anlamlıdır; üst görevlerde boş bırakılır
+	for _, gorev := range gorevler {
+		gorev.Level = 0
+	}
+	return gorevler, nil
+}
 
-	rows, err := vy.db.Query(sorgu, gorevID)
+// hiyerarsiGorevleriniOku reads the task columns of a closure table query followed by the depth
+func (vy *VeriYonetici) hiyerarsiGorevleriniOku(ctx context.Context, sorgu string, args ...interface{}) ([]*Gorev, error) {
+	rows, err := vy.okuma.QueryContext(ctx, sorgu, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -1228,6 +1353,7 @@ func (vy *VeriYonetici) UstGorevleriGetir(ctx context.Context, gorevID string) (
 			&gorev.CreatedAt,
 			&gorev.UpdatedAt,
 			&gorev.DueDate,
+			&gorev.Level,
 		)
 		if err != nil {
 			return nil, err
@@ -1243,7 +1369,7 @@ func (vy *VeriYonetici) UstGorevleriGetir(ctx context.Context, gorevID string) (
 		gorevler = append(gorevler, gorev)
 	}
 
-	return gorevler, nil
+	return gorevler, rows.Err()
 }
 
 // GorevHiyerarsiGetir bir görevin tam hiyerarşi bilgilerini getirir
@@ -1261,27 +1387,17 @@ func (vy *VeriYonetici) GorevHiyerarsiGetir(ctx context.Context, gorevID string)
 	}
 
 	// Alt görev istatistiklerini hesapla
-	sorgu := `
-		WITH RECURSIVE alt_gorevler AS (
-			SELECT id, status
-			FROM gorevler
-			WHERE parent_id = ?
-			
-			UNION ALL
-			
-			SELECT g.id, g.status
-			FROM gorevler g
-			INNER JOIN alt_gorevler ag ON g.parent_id = ag.id
-		)
-		SELECT 
+	sorgu := `SELECT
 			COUNT(*) as toplam,
-			COALESCE(SUM(CASE WHEN status = 'tamamlandi' THEN 1 ELSE 0 END), 0) as tamamlanan,
-			COALESCE(SUM(CASE WHEN status = 'devam_ediyor' THEN 1 ELSE 0 END), 0) as devam_eden,
-			COALESCE(SUM(CASE WHEN status = 'beklemede' THEN 1 ELSE 0 END), 0) as beklemede
-		FROM alt_gorevler`
+			COALESCE(SUM(CASE WHEN g.status = 'tamamlandi' THEN 1 ELSE 0 END), 0) as tamamlanan,
+			COALESCE(SUM(CASE WHEN g.status = 'devam_ediyor' THEN 1 ELSE 0 END), 0) as devam_eden,
+			COALESCE(SUM(CASE WHEN g.status = 'beklemede' THEN 1 ELSE 0 END), 0) as beklemede
+		FROM gorev_kapanis k
+		JOIN gorevler g ON g.id = k.descendant_id
+		WHERE k.ancestor_id = ? AND k.depth > 0`
 
 	var toplam, tamamlanan, devamEden, beklemede int
-	err = vy.db.QueryRow(sorgu, gorevID).Scan(&toplam, &tamamlanan, &devamEden, &beklemede)
+	err = vy.okuma.QueryRow(sorgu, gorevID).Scan(&toplam, &tamamlanan, &devamEden, &beklemede)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return nil, err
 	}
@@ -1305,62 +1421,58 @@ func (vy *VeriYonetici) GorevHiyerarsiGetir(ctx context.Context, gorevID string)
 	}, nil
 }
 
-// ParentIDGuncelle bir görevin parent_id'sini günceller
+// ParentIDGuncelle bir görevin parent_id'sini günceller. Görev alt ağacıyla birlikte taşınır;
+// dairesel bağımlılık kontrolü ve güncelleme aynı transaction içinde yapılır.
 func (vy *VeriYonetici) ParentIDGuncelle(ctx context.Context, gorevID, yeniParentID string) error {
-	// Önce circular dependency kontrolü yap
-	if yeniParentID != "" {
-		daireVar, err := vy.DaireBagimliligiKontrolEt(ctx, gorevID, yeniParentID)
+	return vy.yazici.tx(ctx, func(tx *sql.Tx) error {
+		if yeniParentID == "" {
+			_, err := tx.ExecContext(ctx, `UPDATE gorevler SET parent_id = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = ?`, gorevID)
+			return err
+		}
+
+		daireVar, err := daireBagimliligiVar(ctx, tx, gorevID, yeniParentID)
 		if err != nil {
 			return err
 		}
 		if daireVar {
 			return fmt.Errorf(i18n.T("error.circularDependency"))
 		}
-	}
 
-	var sorgu string
-	var err error
-
-	if yeniParentID == "" {
-		sorgu = `UPDATE gorevler SET parent_id = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = ?`
-		_, err = vy.db.Exec(sorgu, gorevID)
-	} else {
-		sorgu = `UPDATE gorevler SET parent_id = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`
-		_, err = vy.db.Exec(sorgu, yeniParentID, gorevID)
-	}
+		_, err = tx.ExecContext(ctx, `UPDATE gorevler SET parent_id = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`, yeniParentID, gorevID)
+		return err
+	})
+}
 
+// AltAgacProjesiniGuncelle bir görevi ve tüm alt görevlerini tek sorguyla başka bir projeye taşır
+func (vy *VeriYonetici) AltAgacProjesiniGuncelle(ctx context.Context, gorevID, projeID string) error {
+	_, err := vy.yazici.exec(ctx, `UPDATE gorevler SET project_id = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (SELECT descendant_id FROM gorev_kapanis WHERE ancestor_id = ?)`,
+		sql.NullString{String: projeID, Valid: projeID != ""}, gorevID)
 	return err
 }
 
 // DaireBagimliligiKontrolEt bir görevin belirtilen parent'a taşınması durumunda dairesel bağımlılık oluşup oluşmayacağını kontrol eder
 func (vy *VeriYonetici) DaireBagimliligiKontrolEt(ctx context.Context, gorevID, hedefParentID string) (bool, error) {
+	return daireBagimliligiVar(ctx, vy.okuma, gorevID, hedefParentID)
+}
+
+// daireBagimliligiVar reports whether hedefParentID is gorevID itself or one of its subtasks
+func daireBagimliligiVar(ctx context.Context, q interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}, gorevID, hedefParentID string) (bool, error) {
 	// Kendisine parent olamaz
 	if gorevID == hedefParentID {
 		return true, nil
 	}
 
-	// Hedef parent'ın üst hiyerarşisinde gorevID var mı kontrol et
-	sorgu := `
-		WITH RECURSIVE ust_gorevler AS (
-			SELECT id, parent_id
-			FROM gorevler
-			WHERE id = ?
-			
-			UNION ALL
-			
-			SELECT g.id, g.parent_id
-			FROM gorevler g
-			INNER JOIN ust_gorevler ug ON g.id = ug.parent_id
-		)
-		SELECT COUNT(*) FROM ust_gorevler WHERE id = ?`
-
-	var count int
-	err := vy.db.QueryRow(sorgu, hedefParentID, gorevID).Scan(&count)
+	var varMi bool
+	err := q.QueryRowContext(ctx, `SELECT EXISTS (
+		SELECT 1 FROM gorev_kapanis WHERE ancestor_id = ? AND descendant_id = ?
+	)`, gorevID, hedefParentID).Scan(&varMi)
 	if err != nil {
 		return false, err
 	}
-
-	return count > 0, nil
+	return varMi, nil
 }
 
 // BulkBagimlilikSayilariGetir tüm görevlerin bağımlılık sayılarını tek sorguda hesaplar
@@ -1385,7 +1497,7 @@ func (vy *VeriYonetici) BulkBagimlilikSayilariGetir(gorevIDs []string) (map[stri
 		GROUP BY target_id
 	`, strings.Join(placeholders, ","))
 
-	rows, err := vy.db.Query(sorgu, args...)
+	rows, err := vy.okuma.Query(sorgu, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -1428,7 +1540,7 @@ func (vy *VeriYonetici) BulkTamamlanmamiaBagimlilikSayilariGetir(gorevIDs []stri
 		GROUP BY b.target_id
 	`, strings.Join(placeholders, ","))
 
-	rows, err := vy.db.Query(sorgu, args...)
+	rows, err := vy.okuma.Query(sorgu, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -1469,7 +1581,7 @@ func (vy *VeriYonetici) BulkBuGoreveBagimliSayilariGetir(gorevIDs []string) (map
 		GROUP BY b.source_id
 	`, strings.Join(placeholders, ","))
 
-	rows, err := vy.db.Query(sorgu, args...)
+	rows, err := vy.okuma.Query(sorgu, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -1499,7 +1611,7 @@ func (vy *VeriYonetici) AIContextGetir() (*AIContext, error) {
 	var lastUpdated time.Time
 
 	sorgu := `SELECT active_task_id, recent_tasks, session_data, last_updated FROM ai_context WHERE id = 1`
-	err := vy.db.QueryRow(sorgu).Scan(&activeTaskID, &recentTasksJSON, &sessionDataJSON, &lastUpdated)
+	err := vy.okuma.QueryRow(sorgu).Scan(&activeTaskID, &recentTasksJSON, &sessionDataJSON, &lastUpdated)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -1543,27 +1655,27 @@ func (vy *VeriYonetici) AIContextGetir() (*AIContext, error) {
 }
 
 // AIContextKaydet saves the AI context to the database
-func (vy *VeriYonetici) AIContextKaydet(context *AIContext) error {
-	recentTasksJSON, err := json.Marshal(context.RecentTasks)
+func (vy *VeriYonetici) AIContextKaydet(aiContext *AIContext) error {
+	recentTasksJSON, err := json.Marshal(aiContext.RecentTasks)
 	if err != nil {
 		return fmt.Errorf(i18n.T("error.jsonMarshalFailed", map[string]interface{}{"Field": "recent_tasks", "Error": err}))
 	}
 
-	sessionDataJSON, err := json.Marshal(context.SessionData)
+	sessionDataJSON, err := json.Marshal(aiContext.SessionData)
 	if err != nil {
 		return fmt.Errorf(i18n.T("error.jsonMarshalFailed", map[string]interface{}{"Field": "session_data", "Error": err}))
 	}
 
 	var activeTaskID *string
-	if context.ActiveTaskID != "" {
-		activeTaskID = &context.ActiveTaskID
+	if aiContext.ActiveTaskID != "" {
+		activeTaskID = &aiContext.ActiveTaskID
 	}
 
 	sorgu := `
 		INSERT OR REPLACE INTO ai_context (id, active_task_id, recent_tasks, session_data, last_updated)
 		VALUES (1, ?, ?, ?, ?)`
 
-	_, err = vy.db.Exec(sorgu, activeTaskID, string(recentTasksJSON), string(sessionDataJSON), time.Now())
+	_, err = vy.yazici.exec(context.Background(), sorgu, activeTaskID, string(recentTasksJSON), string(sessionDataJSON), time.Now())
 	if err != nil {
 		return fmt.Errorf(i18n.T("error.contextSaveFailed", map[string]interface{}{"Error": err}))
 	}
@@ -1582,7 +1694,7 @@ func (vy *VeriYonetici) AIInteractionKaydet(interaction *AIInteraction) error {
 		INSERT INTO ai_interactions (task_id, action_type, context, timestamp)
 		VALUES (?, ?, ?, ?)`
 
-	_, err := vy.db.Exec(sorgu, interaction.GorevID, interaction.ActionType, contextJSON, time.Now())
+	_, err := vy.yazici.exec(context.Background(), sorgu, interaction.GorevID, interaction.ActionType, contextJSON, time.Now())
 	if err != nil {
 		return fmt.Errorf(i18n.T("error.interactionSaveFailed", map[string]interface{}{"Error": err}))
 	}
@@ -1602,7 +1714,7 @@ func (vy *VeriYonetici) AIInteractionlariGetir(limit int) ([]*AIInteraction, err
 		ORDER BY timestamp DESC
 		LIMIT ?`
 
-	rows, err := vy.db.Query(sorgu, limit)
+	rows, err := vy.okuma.Query(sorgu, limit)
 	if err != nil {
 		return nil, fmt.Errorf(i18n.T("error.interactionQueryFailed", map[string]interface{}{"Error": err}))
 	}
@@ -1638,7 +1750,7 @@ func (vy *VeriYonetici) AITodayInteractionlariGetir() ([]*AIInteraction, error)
 		WHERE timestamp >= ? AND timestamp < ?
 		ORDER BY timestamp DESC`
 
-	rows, err := vy.db.Query(sorgu, today, tomorrow)
+	rows, err := vy.okuma.Query(sorgu, today, tomorrow)
 	if err != nil {
 		return nil, fmt.Errorf(i18n.T("error.todayInteractionQueryFailed", map[string]interface{}{"Error": err}))
 	}
@@ -1667,7 +1779,7 @@ func (vy *VeriYonetici) AITodayInteractionlariGetir() ([]*AIInteraction, error)
 func (vy *VeriYonetici) AILastInteractionGuncelle(taskID string, timestamp time.Time) error {
 	sorgu := `UPDATE gorevler SET last_ai_interaction = ? WHERE id = ?`
 
-	_, err := vy.db.Exec(sorgu, timestamp, taskID)
+	_, err := vy.yazici.exec(context.Background(), sorgu, timestamp, taskID)
 	if err != nil {
 		return fmt.Errorf(i18n.T("error.lastInteractionUpdateFailed", map[string]interface{}{"TaskID": taskID, "Error": err}))
 	}
@@ -1701,7 +1813,7 @@ func (vy *VeriYonetici) GorevDosyaYoluEkle(taskID string, path string) error {
 		VALUES (?, ?)
 		ON CONFLICT(task_id, file_path) DO UPDATE SET updated_at = CURRENT_TIMESTAMP
 	`
-	_, err := vy.db.Exec(query, taskID, path)
+	_, err := vy.yazici.exec(context.Background(), query, taskID, path)
 	if err != nil {
 		return fmt.Errorf(i18n.T("error.filePathAddFailed", map[string]interface{}{"Error": err}))
 	}
@@ -1715,7 +1827,7 @@ func (vy *VeriYonetici) GorevDosyaYoluSil(taskID string, path string) error {
 		DELETE FROM task_file_paths
 		WHERE task_id = ? AND file_path = ?
 	`
-	result, err := vy.db.Exec(query, taskID, path)
+	result, err := vy.yazici.exec(context.Background(), query, taskID, path)
 	if err != nil {
 		return fmt.Errorf(i18n.T("error.filePathRemoveFailed", map[string]interface{}{"Error": err}))
 	}
@@ -1738,7 +1850,7 @@ func (vy *VeriYonetici) GorevDosyaYollariGetir(taskID string) ([]string, error)
 		ORDER BY created_at ASC
 	`
 
-	rows, err := vy.db.Query(query, taskID)
+	rows, err := vy.okuma.Query(query, taskID)
 	if err != nil {
 		return nil, fmt.Errorf(i18n.T("error.filePathQueryFailed", map[string]interface{}{"Error": err}))
 	}
@@ -1770,7 +1882,7 @@ func (vy *VeriYonetici) DosyaYoluGorevleriGetir(path string) ([]string, error) {
 		ORDER BY created_at ASC
 	`
 
-	rows, err := vy.db.Query(query, path)
+	rows, err := vy.okuma.Query(query, path)
 	if err != nil {
 		return nil, fmt.Errorf(i18n.T("error.tasksQueryFailed", map[string]interface{}{"Error": err}))
 	}
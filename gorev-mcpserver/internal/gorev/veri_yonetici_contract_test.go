@@ -0,0 +1,576 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/msenol/gorev/internal/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVeriYoneticiContract_SQLite and TestVeriYoneticiContract_Memory run the same
+// behavioral suite against both VeriYoneticiInterface implementations
+
+func TestVeriYoneticiContract_SQLite(t *testing.T) {
+	runVeriYoneticiContract(t, func(t *testing.T) VeriYoneticiInterface {
+		vy, err := YeniVeriYonetici(":memory:", "file://../../internal/veri/migrations")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = vy.Kapat() })
+		return vy
+	})
+}
+
+func TestVeriYoneticiContract_Memory(t *testing.T) {
+	runVeriYoneticiContract(t, func(t *testing.T) VeriYoneticiInterface {
+		return NewMemoryVeriYonetici()
+	})
+}
+
+func runVeriYoneticiContract(t *testing.T, yeni func(t *testing.T) VeriYoneticiInterface) {
+	ctx := context.Background()
+	simdi := time.Now().UTC().Truncate(time.Second)
+
+	gorevEkle := func(t *testing.T, vy VeriYoneticiInterface, id, parentID, projeID string, olusturma time.Time) {
+		t.Helper()
+		require.NoError(t, vy.GorevKaydet(ctx, &Gorev{
+			ID: id, Title: "Görev " + id, Description: "açıklama " + id,
+			Status: constants.TaskStatusPending, Priority: constants.PriorityMedium,
+			ProjeID: projeID, ParentID: parentID, CreatedAt: olusturma, UpdatedAt: olusturma,
+		}))
+	}
+	idler := func(gorevler []*Gorev) []string {
+		ids := make([]string, len(gorevler))
+		for i, g := range gorevler {
+			ids[i] = g.ID
+		}
+		return ids
+	}
+	etiketIsimleri := func(etiketler []*Etiket) []string {
+		isimler := make([]string, 0, len(etiketler))
+		for _, e := range etiketler {
+			isimler = append(isimler, e.Name)
+		}
+		sort.Strings(isimler)
+		return isimler
+	}
+
+	t.Run("task roundtrip", func(t *testing.T) {
+		vy := yeni(t)
+		require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: "p1", Name: "Proje", CreatedAt: simdi, UpdatedAt: simdi}))
+		sonTarih := simdi.Add(48 * time.Hour)
+		require.NoError(t, vy.GorevKaydet(ctx, &Gorev{
+			ID: "g1", Title: "Başlık", Description: "Açıklama", Status: constants.TaskStatusPending,
+			Priority: constants.PriorityHigh, ProjeID: "p1", CreatedAt: simdi, UpdatedAt: simdi, DueDate: &sonTarih,
+		}))
+
+		gorev, err := vy.GorevGetir(ctx, "g1")
+		require.NoError(t, err)
+		assert.Equal(t, "Başlık", gorev.Title)
+		assert.Equal(t, "Açıklama", gorev.Description)
+		assert.Equal(t, constants.PriorityHigh, gorev.Priority)
+		assert.Equal(t, "p1", gorev.ProjeID)
+		assert.Equal(t, "Proje", gorev.ProjeName)
+		assert.Empty(t, gorev.ParentID)
+		assert.Empty(t, gorev.Tags)
+		require.NotNil(t, gorev.DueDate)
+		assert.True(t, sonTarih.Equal(*gorev.DueDate))
+		assert.True(t, simdi.Equal(gorev.CreatedAt))
+
+		_, err = vy.GorevGetir(ctx, "yok")
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+
+		assert.Error(t, vy.GorevKaydet(ctx, &Gorev{ID: "g1", Title: "tekrar", Status: constants.TaskStatusPending, CreatedAt: simdi, UpdatedAt: simdi}))
+		assert.Error(t, vy.GorevKaydet(ctx, &Gorev{ID: "g2", Title: "projesiz", Status: constants.TaskStatusPending, ProjeID: "yok", CreatedAt: simdi, UpdatedAt: simdi}))
+		assert.Error(t, vy.GorevKaydet(ctx, &Gorev{ID: "g3", Title: "öksüz", Status: constants.TaskStatusPending, ParentID: "yok", CreatedAt: simdi, UpdatedAt: simdi}))
+	})
+
+	t.Run("task update", func(t *testing.T) {
+		vy := yeni(t)
+		gorevEkle(t, vy, "g1", "", "", simdi)
+
+		yeniTarih := simdi.Add(72 * time.Hour)
+		require.NoError(t, vy.GorevGuncelle(ctx, "g1", map[string]interface{}{
+			"title":      "Yeni",
+			"status":     constants.TaskStatusInProgress,
+			"due_date":   yeniTarih,
+			"updated_at": time.Now(),
+		}))
+		gorev, err := vy.GorevGetir(ctx, "g1")
+		require.NoError(t, err)
+		assert.Equal(t, "Yeni", gorev.Title)
+		assert.Equal(t, constants.TaskStatusInProgress, gorev.Status)
+		require.NotNil(t, gorev.DueDate)
+		assert.True(t, yeniTarih.Equal(*gorev.DueDate))
+
+		require.NoError(t, vy.GorevGuncelle(ctx, "g1", map[string]interface{}{"due_date": nil}))
+		gorev, err = vy.GorevGetir(ctx, "g1")
+		require.NoError(t, err)
+		assert.Nil(t, gorev.DueDate)
+
+		assert.Error(t, vy.GorevGuncelle(ctx, "g1", "geçersiz"))
+		assert.Error(t, vy.GorevGuncelle(ctx, "g1", map[string]interface{}{"olmayan_kolon": 1}))
+		assert.NoError(t, vy.GorevGuncelle(ctx, "yok", map[string]interface{}{"title": "x"}))
+		assert.NoError(t, vy.GorevGuncelle(ctx, "g1", map[string]interface{}{}))
+	})
+
+	t.Run("task lists", func(t *testing.T) {
+		vy := yeni(t)
+		require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: "p1", Name: "Proje", CreatedAt: simdi, UpdatedAt: simdi}))
+		gorevEkle(t, vy, "eski", "", "p1", simdi.Add(-2*time.Hour))
+		gorevEkle(t, vy, "orta", "", "", simdi.Add(-time.Hour))
+		gorevEkle(t, vy, "yeni", "", "p1", simdi)
+
+		bugun := time.Now().UTC().Truncate(24 * time.Hour)
+		yakin := bugun.Add(36 * time.Hour)
+		gecmis := bugun.Add(-36 * time.Hour)
+		require.NoError(t, vy.GorevGuncelle(ctx, "eski", map[string]interface{}{"due_date": yakin}))
+		require.NoError(t, vy.GorevGuncelle(ctx, "orta", map[string]interface{}{"due_date": gecmis, "status": constants.TaskStatusCompleted}))
+
+		hepsi, err := vy.GorevleriGetir(ctx, "", "", "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"yeni", "orta", "eski"}, idler(hepsi))
+		assert.Equal(t, "Proje", hepsi[0].ProjeName)
+
+		tamamlanan, err := vy.GorevleriGetir(ctx, constants.TaskStatusCompleted, "", "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"orta"}, idler(tamamlanan))
+
+		acil, err := vy.GorevleriGetir(ctx, "", "", "acil")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"eski"}, idler(acil))
+
+		gecikmis, err := vy.GorevleriGetir(ctx, "", "", "gecmis")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"orta"}, idler(gecikmis))
+
+		artan, err := vy.GorevleriGetir(ctx, "", "son_tarih_asc", "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"yeni", "orta", "eski"}, idler(artan))
+
+		azalan, err := vy.GorevleriGetir(ctx, "", "son_tarih_desc", "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"eski", "orta", "yeni"}, idler(azalan))
+
+		projeGorevleri, err := vy.ProjeGorevleriGetir(ctx, "p1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"yeni", "eski"}, idler(projeGorevleri))
+
+		projesiz, err := vy.ProjeGorevleriGetir(ctx, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"orta"}, idler(projesiz))
+
+		listelenen, err := vy.GorevListele(ctx, map[string]interface{}{"status": constants.TaskStatusPending})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"yeni", "eski"}, idler(listelenen))
+
+		id, err := vy.GorevOlustur(ctx, map[string]interface{}{"title": "Parametreli", "priority": constants.PriorityLow, "proje_id": "p1"})
+		require.NoError(t, err)
+		olusturulan, err := vy.GorevDetay(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "Parametreli", olusturulan.Title)
+		assert.Equal(t, constants.TaskStatusPending, olusturulan.Status)
+	})
+
+	t.Run("task list pages", func(t *testing.T) {
+		vy := yeni(t)
+		require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: "p1", Name: "Proje", CreatedAt: simdi, UpdatedAt: simdi}))
+		// r1..r4 from oldest to newest; r2 -> a -> b, r3 -> c
+		gorevEkle(t, vy, "r1", "", "p1", simdi.Add(-4*time.Hour))
+		gorevEkle(t, vy, "r2", "", "p1", simdi.Add(-3*time.Hour))
+		gorevEkle(t, vy, "r3", "", "", simdi.Add(-2*time.Hour))
+		gorevEkle(t, vy, "r4", "", "", simdi.Add(-time.Hour))
+		gorevEkle(t, vy, "a", "r2", "p1", simdi)
+		gorevEkle(t, vy, "b", "a", "p1", simdi.Add(time.Minute))
+		gorevEkle(t, vy, "c", "r3", "", simdi)
+		etiketler, err := vy.EtiketleriGetirVeyaOlustur(ctx, []string{"ui"})
+		require.NoError(t, err)
+		require.NoError(t, vy.GorevEtiketleriniAyarla(ctx, "r3", etiketler))
+		require.NoError(t, vy.GorevEtiketleriniAyarla(ctx, "c", etiketler))
+
+		sayfa, err := vy.GorevSayfasiGetir(ctx, map[string]interface{}{}, 2, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 4, sayfa.KokSayisi)
+		assert.Equal(t, 7, sayfa.ToplamSayisi)
+		require.Len(t, sayfa.Gorevler, 3)
+		assert.Equal(t, []string{"r4", "r3", "c"}, idler(sayfa.Gorevler))
+
+		sayfa, err = vy.GorevSayfasiGetir(ctx, map[string]interface{}{}, 2, 2)
+		require.NoError(t, err)
+		require.Len(t, sayfa.Gorevler, 4)
+		assert.Equal(t, []string{"r2", "r1"}, idler(sayfa.Gorevler[:2]))
+		assert.ElementsMatch(t, []string{"a", "b"}, idler(sayfa.Gorevler[2:]))
+
+		sayfa, err = vy.GorevSayfasiGetir(ctx, map[string]interface{}{}, 2, 4)
+		require.NoError(t, err)
+		assert.Empty(t, sayfa.Gorevler)
+		assert.Equal(t, 4, sayfa.KokSayisi)
+
+		sayfa, err = vy.GorevSayfasiGetir(ctx, map[string]interface{}{"proje_id": "p1"}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, sayfa.KokSayisi)
+		assert.Equal(t, 4, sayfa.ToplamSayisi)
+		assert.Equal(t, []string{"r2", "r1"}, idler(sayfa.Gorevler[:2]))
+
+		sayfa, err = vy.GorevSayfasiGetir(ctx, map[string]interface{}{"etiket": "ui"}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sayfa.KokSayisi)
+		assert.Equal(t, 2, sayfa.ToplamSayisi)
+		assert.Equal(t, []string{"r3", "c"}, idler(sayfa.Gorevler))
+		assert.Equal(t, []string{"ui"}, etiketIsimleri(sayfa.Gorevler[1].Tags))
+	})
+
+	t.Run("projects", func(t *testing.T) {
+		vy := yeni(t)
+		require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: "p1", Name: "Birinci", Definition: "tanım", CreatedAt: simdi.Add(-time.Hour), UpdatedAt: simdi}))
+		require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: "p2", Name: "İkinci", CreatedAt: simdi, UpdatedAt: simdi}))
+		assert.Error(t, vy.ProjeKaydet(ctx, &Proje{ID: "p1", Name: "Tekrar", CreatedAt: simdi, UpdatedAt: simdi}))
+		gorevEkle(t, vy, "g1", "", "p1", simdi)
+		gorevEkle(t, vy, "g2", "", "p1", simdi)
+
+		proje, err := vy.ProjeGetir(ctx, "p1")
+		require.NoError(t, err)
+		assert.Equal(t, "Birinci", proje.Name)
+		assert.Equal(t, "tanım", proje.Definition)
+
+		_, err = vy.ProjeGetir(ctx, "yok")
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+
+		projeler, err := vy.ProjeleriGetir(ctx)
+		require.NoError(t, err)
+		require.Len(t, projeler, 2)
+		assert.Equal(t, "p2", projeler[0].ID)
+		assert.Equal(t, 0, projeler[0].TaskCount)
+		assert.Equal(t, 2, projeler[1].TaskCount)
+
+		aktif, err := vy.AktifProjeGetir(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, aktif)
+		assert.Error(t, vy.AktifProjeAyarla(ctx, "yok"))
+		require.NoError(t, vy.AktifProjeAyarla(ctx, "p2"))
+		aktif, err = vy.AktifProjeGetir(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "p2", aktif)
+		require.NoError(t, vy.AktifProjeKaldir(ctx))
+		aktif, err = vy.AktifProjeGetir(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, aktif)
+	})
+
+	t.Run("links", func(t *testing.T) {
+		vy := yeni(t)
+		gorevEkle(t, vy, "a", "", "", simdi)
+		gorevEkle(t, vy, "b", "", "", simdi.Add(time.Second))
+		gorevEkle(t, vy, "c", "", "", simdi.Add(2*time.Second))
+		require.NoError(t, vy.BaglantiEkle(ctx, &Baglanti{ID: "l1", SourceID: "a", TargetID: "c", ConnectionType: "onceki"}))
+		require.NoError(t, vy.BaglantiEkle(ctx, &Baglanti{ID: "l2", SourceID: "b", TargetID: "c", ConnectionType: "onceki"}))
+		assert.Error(t, vy.BaglantiEkle(ctx, &Baglanti{ID: "l3", SourceID: "a", TargetID: "yok", ConnectionType: "onceki"}))
+		require.NoError(t, vy.GorevGuncelle(ctx, "a", map[string]interface{}{"status": constants.TaskStatusCompleted}))
+
+		baglantilar, err := vy.BaglantilariGetir(ctx, "c")
+		require.NoError(t, err)
+		assert.Len(t, baglantilar, 2)
+
+		bagimliliklar, err := vy.GorevBagimlilikGetir(ctx, "c")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"a", "b"}, idler(bagimliliklar))
+
+		toplam, err := vy.BulkBagimlilikSayilariGetir([]string{"a", "b", "c"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"c": 2}, toplam)
+		tamamlanmamis, err := vy.BulkTamamlanmamiaBagimlilikSayilariGetir([]string{"a", "b", "c"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"c": 1}, tamamlanmamis)
+		bagimli, err := vy.BulkBuGoreveBagimliSayilariGetir([]string{"a", "b", "c"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"a": 1, "b": 1}, bagimli)
+		bos, err := vy.BulkBagimlilikSayilariGetir(nil)
+		require.NoError(t, err)
+		assert.Empty(t, bos)
+
+		liste, err := vy.GorevleriGetir(ctx, "", "", "")
+		require.NoError(t, err)
+		for _, g := range liste {
+			if g.ID == "c" {
+				assert.Equal(t, 2, g.DependencyCount)
+				assert.Equal(t, 1, g.UncompletedDependencyCount)
+			} else {
+				assert.Equal(t, 1, g.DependentOnThisCount)
+			}
+		}
+
+		require.NoError(t, vy.BaglantiSil(ctx, "a", "c"))
+		assert.Error(t, vy.BaglantiSil(ctx, "a", "c"))
+		baglantilar, err = vy.BaglantilariGetir(ctx, "a")
+		require.NoError(t, err)
+		assert.Empty(t, baglantilar)
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		vy := yeni(t)
+		gorevEkle(t, vy, "g1", "", "", simdi)
+
+		etiketler, err := vy.EtiketleriGetirVeyaOlustur(ctx, []string{" acil ", "", "backend"})
+		require.NoError(t, err)
+		require.Len(t, etiketler, 2)
+		assert.Equal(t, "acil", etiketler[0].Name)
+		tekrar, err := vy.EtiketleriGetirVeyaOlustur(ctx, []string{"acil"})
+		require.NoError(t, err)
+		assert.Equal(t, etiketler[0].ID, tekrar[0].ID)
+
+		require.NoError(t, vy.GorevEtiketleriniAyarla(ctx, "g1", etiketler))
+		gorev, err := vy.GorevGetir(ctx, "g1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"acil", "backend"}, etiketIsimleri(gorev.Tags))
+
+		liste, err := vy.GorevleriGetir(ctx, "", "", "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"acil", "backend"}, etiketIsimleri(liste[0].Tags))
+
+		assert.Error(t, vy.GorevEtiketleriniAyarla(ctx, "g1", []*Etiket{etiketler[0], etiketler[0]}))
+
+		require.NoError(t, vy.GorevEtiketleriniAyarla(ctx, "g1", etiketler[1:]))
+		gorev, err = vy.GorevGetir(ctx, "g1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend"}, etiketIsimleri(gorev.Tags))
+	})
+
+	t.Run("templates", func(t *testing.T) {
+		vy := yeni(t)
+
+		varsayilanlar, err := vy.TemplateListele(ctx, "")
+		require.NoError(t, err)
+		assert.NotEmpty(t, varsayilanlar)
+		for i := 1; i < len(varsayilanlar); i++ {
+			onceki, sonraki := varsayilanlar[i-1], varsayilanlar[i]
+			assert.True(t, onceki.Category < sonraki.Category || (onceki.Category == sonraki.Category && onceki.Name <= sonraki.Name))
+		}
+		require.NoError(t, vy.VarsayilanTemplateleriOlustur(ctx))
+		yeniden, err := vy.TemplateListele(ctx, "")
+		require.NoError(t, err)
+		assert.Len(t, yeniden, len(varsayilanlar))
+
+		bug, err := vy.TemplateAliasIleGetir(ctx, "bug")
+		require.NoError(t, err)
+		assert.Equal(t, "tr", bug.LanguageCode)
+		ayni, err := vy.TemplateIDVeyaAliasIleGetir(ctx, bug.ID)
+		require.NoError(t, err)
+		assert.Equal(t, bug.Name, ayni.Name)
+		_, err = vy.TemplateAliasIleGetir(ctx, "olmayan")
+		assert.Error(t, err)
+		_, err = vy.TemplateGetir(ctx, "olmayan")
+		assert.Error(t, err)
+
+		template := &GorevTemplate{
+			Name: "Deneme", Alias: "deneme", DefaultTitle: "Deneme: {{konu}}", DescriptionTemplate: "{{konu}} hakkında",
+			Fields:   []TemplateAlan{{Name: "konu", Type: "text", Required: true}},
+			Category: "Test", Active: true,
+		}
+		require.NoError(t, vy.TemplateOlustur(ctx, template))
+		assert.NotEmpty(t, template.ID)
+		assert.Error(t, vy.TemplateOlustur(ctx, &GorevTemplate{Name: "Deneme", Alias: "baska", Active: true}))
+
+		okunan, err := vy.TemplateGetir(ctx, template.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "tr", okunan.LanguageCode)
+		require.NotNil(t, okunan.BaseTemplateID)
+		assert.Equal(t, template.ID, *okunan.BaseTemplateID)
+		assert.Equal(t, template.Fields, okunan.Fields)
+
+		kategoride, err := vy.TemplateListele(ctx, "Test")
+		require.NoError(t, err)
+		require.Len(t, kategoride, 1)
+		assert.Equal(t, "Deneme", kategoride[0].Name)
+
+		_, err = vy.TemplatedenGorevOlustur(ctx, "deneme", map[string]string{"konu": "bellek"})
+		assert.Error(t, err, "template tasks need an active project")
+		require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: "p1", Name: "Proje", CreatedAt: simdi, UpdatedAt: simdi}))
+		require.NoError(t, vy.AktifProjeAyarla(ctx, "p1"))
+		gorev, err := vy.TemplatedenGorevOlustur(ctx, "deneme", map[string]string{"konu": "bellek"})
+		require.NoError(t, err)
+		assert.Equal(t, "Deneme: bellek", gorev.Title)
+		kayitli, err := vy.GorevGetir(ctx, gorev.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "bellek hakkında", kayitli.Description)
+		assert.Equal(t, "p1", kayitli.ProjeID)
+	})
+
+	t.Run("hierarchy", func(t *testing.T) {
+		vy := yeni(t)
+		require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: "p1", Name: "Proje", CreatedAt: simdi, UpdatedAt: simdi}))
+		gorevEkle(t, vy, "kok", "", "", simdi)
+		gorevEkle(t, vy, "a", "kok", "", simdi.Add(time.Second))
+		gorevEkle(t, vy, "b", "kok", "", simdi.Add(2*time.Second))
+		gorevEkle(t, vy, "a1", "a", "", simdi.Add(3*time.Second))
+		gorevEkle(t, vy, "a1x", "a1", "", simdi.Add(4*time.Second))
+		require.NoError(t, vy.GorevGuncelle(ctx, "a1", map[string]interface{}{"status": constants.TaskStatusCompleted}))
+		require.NoError(t, vy.GorevGuncelle(ctx, "b", map[string]interface{}{"status": constants.TaskStatusInProgress}))
+
+		altlar, err := vy.AltGorevleriGetir(ctx, "kok")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, idler(altlar))
+
+		toplu, err := vy.AltGorevleriTopluGetir(ctx, []string{"kok", "a", "b"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, idler(toplu["kok"]))
+		assert.Equal(t, []string{"a1"}, idler(toplu["a"]))
+		assert.Empty(t, toplu["b"])
+
+		tumu, err := vy.TumAltGorevleriGetir(ctx, "kok")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "a1", "a1x"}, idler(tumu))
+		seviyeler := make([]int, len(tumu))
+		for i, g := range tumu {
+			seviyeler[i] = g.Level
+		}
+		assert.Equal(t, []int{1, 1, 2, 3}, seviyeler)
+
+		ustler, err := vy.UstGorevleriGetir(ctx, "a1x")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a1", "a", "kok"}, idler(ustler))
+
+		hiyerarsi, err := vy.GorevHiyerarsiGetir(ctx, "kok")
+		require.NoError(t, err)
+		assert.Equal(t, 4, hiyerarsi.TotalSubtasks)
+		assert.Equal(t, 1, hiyerarsi.CompletedSubtasks)
+		assert.Equal(t, 1, hiyerarsi.InProgressSubtasks)
+		assert.Equal(t, 2, hiyerarsi.PendingSubtasks)
+		assert.InDelta(t, 25.0, hiyerarsi.ProgressPercentage, 0.001)
+
+		daire, err := vy.DaireBagimliligiKontrolEt(ctx, "a", "a1x")
+		require.NoError(t, err)
+		assert.True(t, daire)
+		daire, err = vy.DaireBagimliligiKontrolEt(ctx, "a", "a")
+		require.NoError(t, err)
+		assert.True(t, daire)
+		daire, err = vy.DaireBagimliligiKontrolEt(ctx, "a", "b")
+		require.NoError(t, err)
+		assert.False(t, daire)
+
+		assert.Error(t, vy.ParentIDGuncelle(ctx, "a", "a1x"))
+		require.NoError(t, vy.ParentIDGuncelle(ctx, "a", "b"))
+		tumu, err = vy.TumAltGorevleriGetir(ctx, "b")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "a1", "a1x"}, idler(tumu))
+		require.NoError(t, vy.ParentIDGuncelle(ctx, "a", ""))
+		ustler, err = vy.UstGorevleriGetir(ctx, "a1x")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a1", "a"}, idler(ustler))
+
+		require.NoError(t, vy.AltAgacProjesiniGuncelle(ctx, "a", "p1"))
+		projeGorevleri, err := vy.ProjeGorevleriGetir(ctx, "p1")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"a", "a1", "a1x"}, idler(projeGorevleri))
+
+		alt, err := vy.AltGorevOlustur(ctx, "kok", "Alt", "alt görev", constants.PriorityLow, "2030-01-02", []string{"alt"})
+		require.NoError(t, err)
+		kayitli, err := vy.GorevGetir(ctx, alt.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "kok", kayitli.ParentID)
+		assert.Equal(t, []string{"alt"}, etiketIsimleri(kayitli.Tags))
+		_, err = vy.AltGorevOlustur(ctx, "kok", "Hatalı", "", constants.PriorityLow, "02.01.2030", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		vy := yeni(t)
+		gorevEkle(t, vy, "ust", "", "", simdi)
+		gorevEkle(t, vy, "alt", "ust", "", simdi.Add(time.Second))
+		gorevEkle(t, vy, "diger", "", "", simdi.Add(2*time.Second))
+		require.NoError(t, vy.BaglantiEkle(ctx, &Baglanti{ID: "l1", SourceID: "alt", TargetID: "diger", ConnectionType: "onceki"}))
+		etiketler, err := vy.EtiketleriGetirVeyaOlustur(ctx, []string{"etiket"})
+		require.NoError(t, err)
+		require.NoError(t, vy.GorevEtiketleriniAyarla(ctx, "alt", etiketler))
+		require.NoError(t, vy.GorevDosyaYoluEkle("alt", "/src/main.go"))
+
+		assert.Error(t, vy.GorevSil(ctx, "ust"))
+		assert.Error(t, vy.GorevSil(ctx, "yok"))
+
+		require.NoError(t, vy.GorevSil(ctx, "alt"))
+		_, err = vy.GorevGetir(ctx, "alt")
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+		baglantilar, err := vy.BaglantilariGetir(ctx, "diger")
+		require.NoError(t, err)
+		assert.Empty(t, baglantilar)
+		gorevler, err := vy.DosyaYoluGorevleriGetir("/src/main.go")
+		require.NoError(t, err)
+		assert.Empty(t, gorevler)
+
+		require.NoError(t, vy.GorevSil(ctx, "ust"))
+	})
+
+	t.Run("ai context", func(t *testing.T) {
+		vy := yeni(t)
+		gorevEkle(t, vy, "g1", "", "", simdi)
+
+		aiContext, err := vy.AIContextGetir()
+		require.NoError(t, err)
+		assert.Empty(t, aiContext.ActiveTaskID)
+		assert.Empty(t, aiContext.RecentTasks)
+
+		require.NoError(t, vy.AIContextKaydet(&AIContext{
+			ActiveTaskID: "g1",
+			RecentTasks:  []string{"g1"},
+			SessionData:  map[string]interface{}{"sayac": 3},
+		}))
+		aiContext, err = vy.AIContextGetir()
+		require.NoError(t, err)
+		assert.Equal(t, "g1", aiContext.ActiveTaskID)
+		assert.Equal(t, []string{"g1"}, aiContext.RecentTasks)
+		assert.Equal(t, float64(3), aiContext.SessionData["sayac"])
+
+		require.NoError(t, vy.AIInteractionKaydet(&AIInteraction{GorevID: "g1", ActionType: "viewed"}))
+		require.NoError(t, vy.AIEtkilemasimKaydet("g1", "updated", `{"alan":"status"}`, "oturum"))
+		assert.Error(t, vy.AIInteractionKaydet(&AIInteraction{GorevID: "g1", ActionType: "gecersiz"}))
+
+		etkilesimler, err := vy.AIInteractionlariGetir(0)
+		require.NoError(t, err)
+		require.Len(t, etkilesimler, 2)
+		assert.NotEmpty(t, etkilesimler[0].ID)
+		tipler := []string{etkilesimler[0].ActionType, etkilesimler[1].ActionType}
+		assert.ElementsMatch(t, []string{"viewed", "updated"}, tipler)
+		sinirli, err := vy.AIInteractionlariGetir(1)
+		require.NoError(t, err)
+		assert.Len(t, sinirli, 1)
+
+		require.NoError(t, vy.AILastInteractionGuncelle("g1", time.Now()))
+		require.NoError(t, vy.GorevSonAIEtkilesiminiGuncelle("g1", time.Now()))
+
+		require.NoError(t, vy.GorevSil(ctx, "g1"))
+		aiContext, err = vy.AIContextGetir()
+		require.NoError(t, err)
+		assert.Empty(t, aiContext.ActiveTaskID)
+		etkilesimler, err = vy.AIInteractionlariGetir(10)
+		require.NoError(t, err)
+		assert.Empty(t, etkilesimler)
+	})
+
+	t.Run("file paths", func(t *testing.T) {
+		vy := yeni(t)
+		gorevEkle(t, vy, "g1", "", "", simdi)
+		gorevEkle(t, vy, "g2", "", "", simdi)
+
+		require.NoError(t, vy.GorevDosyaYoluEkle("g1", "/a.go"))
+		require.NoError(t, vy.GorevDosyaYoluEkle("g1", "/b.go"))
+		require.NoError(t, vy.GorevDosyaYoluEkle("g1", "/a.go"))
+		require.NoError(t, vy.GorevDosyaYoluEkle("g2", "/a.go"))
+		assert.Error(t, vy.GorevDosyaYoluEkle("yok", "/a.go"))
+
+		yollar, err := vy.GorevDosyaYollariGetir("g1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/a.go", "/b.go"}, yollar)
+		gorevler, err := vy.DosyaYoluGorevleriGetir("/a.go")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"g1", "g2"}, gorevler)
+
+		require.NoError(t, vy.GorevDosyaYoluSil("g1", "/a.go"))
+		assert.Error(t, vy.GorevDosyaYoluSil("g1", "/a.go"))
+		yollar, err = vy.GorevDosyaYollariGetir("g1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/b.go"}, yollar)
+	})
+}
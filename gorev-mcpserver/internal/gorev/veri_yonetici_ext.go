@@ -14,7 +14,7 @@ func (vy *VeriYonetici) AktifProjeAyarla(ctx context.Context, projeID string) er
 
 	// Önce projenin var olduğunu kontrol et
 	var count int
-	err := vy.db.QueryRow("SELECT COUNT(*) FROM projeler WHERE id = ?", projeID).Scan(&count)
+	err := vy.okuma.QueryRow("SELECT COUNT(*) FROM projeler WHERE id = ?", projeID).Scan(&count)
 	if err != nil {
 		return fmt.Errorf(i18n.TWithLang(lang, "error.check_failed", map[string]interface{}{"Entity": "proje", "Error": err}))
 	}
@@ -24,7 +24,7 @@ func (vy *VeriYonetici) AktifProjeAyarla(ctx context.Context, projeID string) er
 
 	// Aktif proje tablosunu güncelle (INSERT OR REPLACE)
 	sorgu := `INSERT OR REPLACE INTO aktif_proje (id, project_id) VALUES (1, ?)`
-	_, err = vy.db.Exec(sorgu, projeID)
+	_, err = vy.yazici.exec(ctx, sorgu, projeID)
 	if err != nil {
 		return fmt.Errorf(i18n.TWithLang(lang, "error.activeProjectSetFailed", map[string]interface{}{"Error": err}))
 	}
@@ -37,7 +37,7 @@ func (vy *VeriYonetici) AktifProjeGetir(ctx context.Context) (string, error) {
 	lang := i18n.FromContext(ctx)
 
 	var projeID string
-	err := vy.db.QueryRow("SELECT project_id FROM aktif_proje WHERE id = 1").Scan(&projeID)
+	err := vy.okuma.QueryRow("SELECT project_id FROM aktif_proje WHERE id = 1").Scan(&projeID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return "", nil // Aktif proje yok
@@ -51,7 +51,7 @@ func (vy *VeriYonetici) AktifProjeGetir(ctx context.Context) (string, error) {
 func (vy *VeriYonetici) AktifProjeKaldir(ctx context.Context) error {
 	lang := i18n.FromContext(ctx)
 
-	_, err := vy.db.Exec("DELETE FROM aktif_proje WHERE id = 1")
+	_, err := vy.yazici.exec(ctx, "DELETE FROM aktif_proje WHERE id = 1")
 	if err != nil {
 		return fmt.Errorf(i18n.TWithLang(lang, "error.activeProjectRemoveFailed", map[string]interface{}{"Error": err}))
 	}
@@ -36,11 +36,13 @@ type VeriYoneticiInterface interface {
 	TemplatedenGorevOlustur(ctx context.Context, templateID string, degerler map[string]string) (*Gorev, error)
 	VarsayilanTemplateleriOlustur(ctx context.Context) error
 	AltGorevleriGetir(ctx context.Context, parentID string) ([]*Gorev, error)
+	AltGorevleriTopluGetir(ctx context.Context, parentIDs []string) (map[string][]*Gorev, error)
 	TumAltGorevleriGetir(ctx context.Context, parentID string) ([]*Gorev, error)
 	UstGorevleriGetir(ctx context.Context, gorevID string) ([]*Gorev, error)
 	GorevHiyerarsiGetir(ctx context.Context, gorevID string) (*GorevHiyerarsi, error)
 	ParentIDGuncelle(ctx context.Context, gorevID, yeniParentID string) error
 	DaireBagimliligiKontrolEt(ctx context.Context, gorevID, hedefParentID string) (bool, error)
+	AltAgacProjesiniGuncelle(ctx context.Context, gorevID, projeID string) error
 	AltGorevOlustur(ctx context.Context, parentID, baslik, aciklama, oncelik, sonTarihStr string, etiketIsimleri []string) (*Gorev, error)
 
 	// AI Context Management methods
@@ -62,9 +64,18 @@ type VeriYoneticiInterface interface {
 	// Additional methods for NLP and auto state management
 	GorevDetay(ctx context.Context, taskID string) (*Gorev, error)
 	GorevListele(ctx context.Context, filters map[string]interface{}) ([]*Gorev, error)
+	// GorevSayfasiGetir returns the limit root tasks after offset of the GorevListele list
+	// with their matching descendants, and the number of matches on all pages
+	GorevSayfasiGetir(ctx context.Context, filters map[string]interface{}, limit, offset int) (*GorevSayfasi, error)
 	GorevOlustur(ctx context.Context, params map[string]interface{}) (string, error)
 	GorevBagimlilikGetir(ctx context.Context, taskID string) ([]*Gorev, error)
 	GetDB() (*sql.DB, error)
+	GetReadDB() (*sql.DB, error)
+	YazmaIslemi(ctx context.Context, fn func(tx *sql.Tx) error) error
+	// YardimciYazmaIslemi runs fn like YazmaIslemi but does not invalidate the lookup
+	// cache, so fn must only write tables that hold no task or project data, such as
+	// the search history and the filter profiles
+	YardimciYazmaIslemi(ctx context.Context, fn func(tx *sql.Tx) error) error
 
 	Kapat() error
 }
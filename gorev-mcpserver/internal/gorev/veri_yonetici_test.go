@@ -2,6 +2,7 @@ package gorev
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"os"
 	"testing"
@@ -1695,3 +1696,188 @@ func TestVeriYonetici_LongRunningConcurrency(t *testing.T) {
 			len(errors), float64(len(errors))/float64(totalOps)*100)
 	}
 }
+
+func TestVeriYonetici_GorevListesiTekSorgu(t *testing.T) {
+	vy, err := YeniVeriYonetici(":memory:", "file://../../internal/veri/migrations")
+	require.NoError(t, err)
+	defer vy.Kapat()
+	ctx := context.Background()
+
+	require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: "proje-1", Name: "Mobil", CreatedAt: time.Now(), UpdatedAt: time.Now()}))
+
+	simdi := time.Now()
+	gorevler := []*Gorev{
+		{ID: "kok-1", Title: "Kök 1", Status: "beklemede", ProjeID: "proje-1", CreatedAt: simdi, UpdatedAt: simdi},
+		{ID: "kok-2", Title: "Kök 2", Status: "tamamlandi", ProjeID: "proje-1", CreatedAt: simdi.Add(time.Second), UpdatedAt: simdi},
+		{ID: "alt-1", Title: "Alt 1", Status: "beklemede", ProjeID: "proje-1", ParentID: "kok-1", CreatedAt: simdi.Add(2 * time.Second), UpdatedAt: simdi},
+		{ID: "alt-2", Title: "Alt 2", Status: "beklemede", ProjeID: "proje-1", ParentID: "kok-1", CreatedAt: simdi.Add(3 * time.Second), UpdatedAt: simdi},
+		{ID: "yetim", Title: "Projesiz", Status: "beklemede", CreatedAt: simdi.Add(4 * time.Second), UpdatedAt: simdi},
+	}
+	for _, g := range gorevler {
+		require.NoError(t, vy.GorevKaydet(ctx, g))
+	}
+
+	etiketler, err := vy.EtiketleriGetirVeyaOlustur(ctx, []string{"backend", "acil"})
+	require.NoError(t, err)
+	require.NoError(t, vy.GorevEtiketleriniAyarla(ctx, "kok-1", etiketler))
+	require.NoError(t, vy.GorevEtiketleriniAyarla(ctx, "alt-2", etiketler[:1]))
+
+	// kok-1 waits for kok-2 (done) and yetim (open); alt-1 waits for kok-1
+	for i, b := range [][2]string{{"kok-2", "kok-1"}, {"yetim", "kok-1"}, {"kok-1", "alt-1"}} {
+		require.NoError(t, vy.BaglantiEkle(ctx, &Baglanti{ID: fmt.Sprintf("b-%d", i), SourceID: b[0], TargetID: b[1], ConnectionType: "onceki"}))
+	}
+
+	t.Run("GorevleriGetir fills tags, project and dependency counts", func(t *testing.T) {
+		liste, err := vy.GorevleriGetir(ctx, "", "", "")
+		require.NoError(t, err)
+		require.Len(t, liste, len(gorevler))
+
+		byID := make(map[string]*Gorev)
+		for _, g := range liste {
+			byID[g.ID] = g
+		}
+
+		kok := byID["kok-1"]
+		assert.Equal(t, "Mobil", kok.ProjeName)
+		assert.Len(t, kok.Tags, 2)
+		assert.Equal(t, 2, kok.DependencyCount)
+		assert.Equal(t, 1, kok.UncompletedDependencyCount)
+		assert.Equal(t, 1, kok.DependentOnThisCount)
+
+		assert.Len(t, byID["alt-2"].Tags, 1)
+		assert.Empty(t, byID["kok-2"].Tags)
+		assert.Equal(t, 1, byID["kok-2"].DependentOnThisCount)
+		assert.Equal(t, 1, byID["alt-1"].UncompletedDependencyCount)
+		assert.Equal(t, "", byID["yetim"].ProjeName)
+	})
+
+	t.Run("ProjeGorevleriGetir uses the same list query", func(t *testing.T) {
+		liste, err := vy.ProjeGorevleriGetir(ctx, "proje-1")
+		require.NoError(t, err)
+		require.Len(t, liste, 4)
+		for _, g := range liste {
+			assert.Equal(t, "Mobil", g.ProjeName)
+			if g.ID == "kok-1" {
+				assert.Len(t, g.Tags, 2)
+				assert.Equal(t, 2, g.DependencyCount)
+			}
+		}
+	})
+
+	t.Run("AltGorevleriTopluGetir groups subtasks by parent", func(t *testing.T) {
+		altlar, err := vy.AltGorevleriTopluGetir(ctx, []string{"kok-1", "kok-2"})
+		require.NoError(t, err)
+		require.Len(t, altlar["kok-1"], 2)
+		assert.Equal(t, "alt-1", altlar["kok-1"][0].ID)
+		assert.Equal(t, "alt-2", altlar["kok-1"][1].ID)
+		assert.Len(t, altlar["kok-1"][1].Tags, 1)
+		assert.Empty(t, altlar["kok-2"])
+
+		bos, err := vy.AltGorevleriTopluGetir(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, bos)
+	})
+}
+
+func TestVeriYonetici_HiyerarsiKapanis(t *testing.T) {
+	vy, err := YeniVeriYonetici(":memory:", "file://../../internal/veri/migrations")
+	require.NoError(t, err)
+	defer vy.Kapat()
+	ctx := context.Background()
+
+	require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: "proje-1", Name: "Web", CreatedAt: time.Now(), UpdatedAt: time.Now()}))
+	require.NoError(t, vy.ProjeKaydet(ctx, &Proje{ID: "proje-2", Name: "Mobil", CreatedAt: time.Now(), UpdatedAt: time.Now()}))
+
+	// kok -> a -> b -> c, kok -> d
+	simdi := time.Now()
+	for i, g := range []*Gorev{
+		{ID: "kok", Title: "Kök"},
+		{ID: "a", Title: "A", ParentID: "kok"},
+		{ID: "b", Title: "B", ParentID: "a"},
+		{ID: "c", Title: "C", ParentID: "b"},
+		{ID: "d", Title: "D", ParentID: "kok"},
+	} {
+		g.Status, g.Priority, g.ProjeID = "beklemede", "orta", "proje-1"
+		g.CreatedAt, g.UpdatedAt = simdi.Add(time.Duration(i)*time.Second), simdi
+		require.NoError(t, vy.GorevKaydet(ctx, g))
+	}
+	etiketler, err := vy.EtiketleriGetirVeyaOlustur(ctx, []string{"derin"})
+	require.NoError(t, err)
+	require.NoError(t, vy.GorevEtiketleriniAyarla(ctx, "c", etiketler))
+
+	idler := func(gorevler []*Gorev) []string {
+		ids := []string{}
+		for _, g := range gorevler {
+			ids = append(ids, g.ID)
+		}
+		return ids
+	}
+
+	altlar, err := vy.TumAltGorevleriGetir(ctx, "kok")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "d", "b", "c"}, idler(altlar))
+	assert.Equal(t, 3, altlar[3].Level)
+	require.Len(t, altlar[3].Tags, 1)
+	assert.Equal(t, "derin", altlar[3].Tags[0].Name)
+
+	ustler, err := vy.UstGorevleriGetir(ctx, "c")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a", "kok"}, idler(ustler))
+
+	// Cycle checks use the closure table; the trigger also guards direct updates
+	daire, err := vy.DaireBagimliligiKontrolEt(ctx, "a", "c")
+	require.NoError(t, err)
+	assert.True(t, daire)
+	daire, err = vy.DaireBagimliligiKontrolEt(ctx, "b", "d")
+	require.NoError(t, err)
+	assert.False(t, daire)
+	assert.Error(t, vy.ParentIDGuncelle(ctx, "a", "c"))
+	_, err = vy.yazici.exec(ctx, "UPDATE gorevler SET parent_id = 'c' WHERE id = 'a'")
+	assert.Error(t, err)
+
+	// Moving b takes c along
+	require.NoError(t, vy.ParentIDGuncelle(ctx, "b", "d"))
+	ustler, err = vy.UstGorevleriGetir(ctx, "c")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "d", "kok"}, idler(ustler))
+	altlar, err = vy.TumAltGorevleriGetir(ctx, "a")
+	require.NoError(t, err)
+	assert.Empty(t, altlar)
+
+	require.NoError(t, vy.GorevGuncelle(ctx, "c", map[string]interface{}{"status": "tamamlandi"}))
+	hiyerarsi, err := vy.GorevHiyerarsiGetir(ctx, "d")
+	require.NoError(t, err)
+	assert.Equal(t, 2, hiyerarsi.TotalSubtasks)
+	assert.Equal(t, 1, hiyerarsi.CompletedSubtasks)
+	assert.InDelta(t, 50.0, hiyerarsi.ProgressPercentage, 0.001)
+	assert.Equal(t, []string{"kok"}, idler(hiyerarsi.ParentTasks))
+
+	require.NoError(t, vy.AltAgacProjesiniGuncelle(ctx, "d", "proje-2"))
+	for id, proje := range map[string]string{"d": "proje-2", "b": "proje-2", "c": "proje-2", "a": "proje-1", "kok": "proje-1"} {
+		g, err := vy.GorevGetir(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, proje, g.ProjeID, id)
+	}
+
+	// Deleting c removes its rows; subtasks inserted before their parent are linked when it arrives
+	require.NoError(t, vy.GorevSil(ctx, "c"))
+	var kalan int
+	require.NoError(t, vy.okuma.QueryRow("SELECT COUNT(*) FROM gorev_kapanis WHERE ancestor_id = 'c' OR descendant_id = 'c'").Scan(&kalan))
+	assert.Equal(t, 0, kalan)
+
+	require.NoError(t, vy.YazmaIslemi(ctx, func(tx *sql.Tx) error {
+		for _, stmt := range []string{
+			`PRAGMA defer_foreign_keys = ON`,
+			`INSERT INTO gorevler (id, title, status, priority, parent_id, created_at, updated_at) VALUES ('torun', 'Torun', 'beklemede', 'orta', 'ara', datetime('now'), datetime('now'))`,
+			`INSERT INTO gorevler (id, title, status, priority, parent_id, created_at, updated_at) VALUES ('ara', 'Ara', 'beklemede', 'orta', 'kok', datetime('now'), datetime('now'))`,
+		} {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	ustler, err = vy.UstGorevleriGetir(ctx, "torun")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ara", "kok"}, idler(ustler))
+}
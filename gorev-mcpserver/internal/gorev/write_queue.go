@@ -0,0 +1,238 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/msenol/gorev/internal/i18n"
+)
+
+const (
+	// writeQueueCapacity is the number of writes that may wait for the writer goroutine
+	writeQueueCapacity = 256
+	// defaultWriteTimeout bounds queue wait and execution of writes whose context has no deadline
+	defaultWriteTimeout = 30 * time.Second
+)
+
+// WriteQueueStats describes the write queue of a database
+type WriteQueueStats struct {
+	Depth      int     `json:"depth"`        // Writes currently waiting
+	Capacity   int     `json:"capacity"`     // Maximum number of waiting writes
+	Writes     int64   `json:"writes"`       // Writes executed by the writer goroutine
+	Failed     int64   `json:"failed"`       // Executed writes that returned an error
+	TimedOut   int64   `json:"timed_out"`    // Writes dropped because their deadline passed while queued
+	AvgWaitMs  float64 `json:"avg_wait_ms"`  // Mean time between enqueue and execution
+	MaxWaitMs  float64 `json:"max_wait_ms"`  // Longest time between enqueue and execution
+	LastWaitMs float64 `json:"last_wait_ms"` // Wait time of the most recent write
+}
+
+// writeQueue runs all writes of a database on one goroutine that owns the only
+// read-write connection, so concurrent writers wait in a bounded queue instead of
+// failing with SQLITE_BUSY. The caller's context deadline bounds the wait and the write.
+type writeQueue struct {
+	db        *sql.DB
+	jobs      chan *writeJob
+	closing   chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+
+	mu        sync.Mutex
+	writes    int64
+	failed    int64
+	timedOut  int64
+	waited    int64 // Number of jobs taken from the queue, including expired ones
+	totalWait time.Duration
+	maxWait   time.Duration
+	lastWait  time.Duration
+}
+
+type writeJob struct {
+	ctx      context.Context
+	fn       func(ctx context.Context, db *sql.DB) error
+	queuedAt time.Time
+	result   chan error
+}
+
+// writerContextKey marks contexts of writes running on the writer goroutine
+type writerContextKey struct{}
+
+// newWriteQueue starts the writer goroutine for db. db should allow a single open connection.
+func newWriteQueue(db *sql.DB, capacity int) *writeQueue {
+	q := &writeQueue{
+		db:      db,
+		jobs:    make(chan *writeJob, capacity),
+		closing: make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+func (q *writeQueue) run() {
+	defer close(q.stopped)
+	for {
+		select {
+		case job := <-q.jobs:
+			q.execute(job)
+		case <-q.closing:
+			// Writes accepted before close still run
+			for {
+				select {
+				case job := <-q.jobs:
+					q.execute(job)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (q *writeQueue) execute(job *writeJob) {
+	wait := time.Since(job.queuedAt)
+
+	if err := job.ctx.Err(); err != nil {
+		q.record(wait, false, false, true)
+		job.result <- writeTimeoutError(wait, err)
+		return
+	}
+
+	err := job.fn(context.WithValue(job.ctx, writerContextKey{}, q), q.db)
+	q.record(wait, true, err != nil, false)
+	job.result <- err
+}
+
+func (q *writeQueue) record(wait time.Duration, executed, failed, timedOut bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.waited++
+	q.totalWait += wait
+	q.lastWait = wait
+	if wait > q.maxWait {
+		q.maxWait = wait
+	}
+	if executed {
+		q.writes++
+	}
+	if failed {
+		q.failed++
+	}
+	if timedOut {
+		q.timedOut++
+	}
+}
+
+// do runs fn on the writer goroutine and waits for its result. Writes issued from
+// inside fn run inline, since the writer is already held.
+func (q *writeQueue) do(ctx context.Context, fn func(ctx context.Context, db *sql.DB) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if ctx.Value(writerContextKey{}) == q {
+		return fn(ctx, q.db)
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultWriteTimeout)
+		defer cancel()
+	}
+
+	job := &writeJob{ctx: ctx, fn: fn, queuedAt: time.Now(), result: make(chan error, 1)}
+	select {
+	case <-q.closing:
+		return fmt.Errorf(i18n.T("error.writeQueueClosed"))
+	default:
+	}
+	select {
+	case q.jobs <- job:
+	case <-ctx.Done():
+		wait := time.Since(job.queuedAt)
+		q.record(wait, false, false, true)
+		return writeTimeoutError(wait, ctx.Err())
+	case <-q.closing:
+		return fmt.Errorf(i18n.T("error.writeQueueClosed"))
+	}
+
+	select {
+	case err := <-job.result:
+		return err
+	case <-ctx.Done():
+		// The write may have finished at the same moment; prefer its result
+		select {
+		case err := <-job.result:
+			return err
+		default:
+		}
+		return writeTimeoutError(time.Since(job.queuedAt), ctx.Err())
+	case <-q.stopped:
+		return fmt.Errorf(i18n.T("error.writeQueueClosed"))
+	}
+}
+
+// exec runs a single statement on the writer goroutine
+func (q *writeQueue) exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := q.do(ctx, func(ctx context.Context, db *sql.DB) error {
+		var err error
+		result, err = db.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+// tx runs fn in a transaction on the writer goroutine. The transaction is committed
+// when fn returns nil and rolled back otherwise.
+func (q *writeQueue) tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return q.do(ctx, func(ctx context.Context, db *sql.DB) error {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf(i18n.T("error.transactionFailed", map[string]interface{}{"Error": err}))
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// stats returns a snapshot of the queue metrics
+func (q *writeQueue) stats() WriteQueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	stats := WriteQueueStats{
+		Depth:      len(q.jobs),
+		Capacity:   cap(q.jobs),
+		Writes:     q.writes,
+		Failed:     q.failed,
+		TimedOut:   q.timedOut,
+		MaxWaitMs:  durationMs(q.maxWait),
+		LastWaitMs: durationMs(q.lastWait),
+	}
+	if q.waited > 0 {
+		stats.AvgWaitMs = durationMs(q.totalWait / time.Duration(q.waited))
+	}
+	return stats
+}
+
+// close stops accepting writes, runs the ones already queued and waits for the writer goroutine
+func (q *writeQueue) close() {
+	q.closeOnce.Do(func() { close(q.closing) })
+	<-q.stopped
+}
+
+func writeTimeoutError(wait time.Duration, err error) error {
+	return fmt.Errorf(i18n.T("error.writeQueueTimeout", map[string]interface{}{
+		"Wait":  wait.Round(time.Millisecond),
+		"Error": err,
+	}))
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
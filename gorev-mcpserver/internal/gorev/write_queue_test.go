@@ -0,0 +1,228 @@
+package gorev
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFileVeriYonetici(t *testing.T) *VeriYonetici {
+	t.Helper()
+	vy, err := YeniVeriYonetici(filepath.Join(t.TempDir(), "gorev.db"), "file://../../internal/veri/migrations")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = vy.Kapat() })
+	return vy
+}
+
+func TestWriteQueue_ConcurrentWriters(t *testing.T) {
+	vy := newFileVeriYonetici(t)
+	ctx := context.Background()
+
+	const writers, perWriter = 16, 25
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*perWriter)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				gorev := &Gorev{
+					ID:        fmt.Sprintf("w%d-%d", w, i),
+					Title:     "Concurrent",
+					Status:    "beklemede",
+					Priority:  "orta",
+					CreatedAt: time.Now(),
+					UpdatedAt: time.Now(),
+				}
+				if err := vy.GorevKaydet(ctx, gorev); err != nil {
+					errs <- err
+					continue
+				}
+				// Reads from the pool run alongside the writer
+				if _, err := vy.GorevGetir(ctx, gorev.ID); err != nil {
+					errs <- err
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	var count int
+	require.NoError(t, vy.okuma.QueryRow("SELECT COUNT(*) FROM gorevler").Scan(&count))
+	assert.Equal(t, writers*perWriter, count)
+
+	stats := vy.WriteQueueStats()
+	assert.GreaterOrEqual(t, stats.Writes, int64(writers*perWriter))
+	assert.Equal(t, 0, stats.Depth)
+	assert.Equal(t, writeQueueCapacity, stats.Capacity)
+	assert.GreaterOrEqual(t, stats.MaxWaitMs, stats.AvgWaitMs)
+}
+
+func TestWriteQueue_Deadline(t *testing.T) {
+	vy := newFileVeriYonetici(t)
+
+	// Hold the writer until the second write has given up
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		_ = vy.yazici.do(context.Background(), func(ctx context.Context, db *sql.DB) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := vy.ProjeKaydet(ctx, &Proje{ID: "p1", Name: "Late", CreatedAt: time.Now(), UpdatedAt: time.Now()})
+	assert.Error(t, err)
+	close(release)
+
+	// The expired write is dropped, not executed later
+	require.Eventually(t, func() bool { return vy.WriteQueueStats().TimedOut == 1 }, time.Second, 5*time.Millisecond)
+	proje, err := vy.ProjeGetir(context.Background(), "p1")
+	assert.Error(t, err)
+	assert.Nil(t, proje)
+}
+
+func TestWriteQueue_NestedWriteRunsInline(t *testing.T) {
+	vy := newFileVeriYonetici(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := vy.yazici.do(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := vy.yazici.exec(ctx, "INSERT INTO projeler (id, name, definition, created_at, updated_at) VALUES ('p1', 'Nested', '', ?, ?)", time.Now(), time.Now())
+		return err
+	})
+	require.NoError(t, err)
+
+	proje, err := vy.ProjeGetir(context.Background(), "p1")
+	require.NoError(t, err)
+	assert.Equal(t, "Nested", proje.Name)
+}
+
+func TestWriteQueue_ReadPoolIsQueryOnly(t *testing.T) {
+	vy := newFileVeriYonetici(t)
+
+	readDB, err := vy.GetReadDB()
+	require.NoError(t, err)
+	_, err = readDB.Exec("INSERT INTO etiketler (id, name) VALUES ('e1', 'bypass')")
+	assert.Error(t, err, "writes must go through the writer queue")
+
+	// In-memory databases are shared between the writer and the read pool
+	memory, err := YeniVeriYonetici(":memory:", "file://../../internal/veri/migrations")
+	require.NoError(t, err)
+	defer memory.Kapat()
+	require.NoError(t, memory.ProjeKaydet(context.Background(), &Proje{ID: "p1", Name: "Shared", CreatedAt: time.Now(), UpdatedAt: time.Now()}))
+	proje, err := memory.ProjeGetir(context.Background(), "p1")
+	require.NoError(t, err)
+	assert.Equal(t, "Shared", proje.Name)
+}
+
+func TestWriteQueue_MemoryReadsSeeOnlyCommittedData(t *testing.T) {
+	vy, err := YeniVeriYonetici(":memory:", "file://../../internal/veri/migrations")
+	require.NoError(t, err)
+	defer vy.Kapat()
+	ctx := context.Background()
+
+	read := make(chan error, 1)
+	require.NoError(t, vy.YazmaIslemi(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`INSERT INTO projeler (id, name, definition, created_at, updated_at) VALUES ('p1', 'Half done', '', ?, ?)`, time.Now(), time.Now()); err != nil {
+			return err
+		}
+		go func() {
+			_, err := vy.ProjeGetir(ctx, "p1")
+			read <- err
+		}()
+		// The read waits for the transaction instead of seeing its uncommitted row
+		select {
+		case err := <-read:
+			t.Errorf("read finished inside the write transaction: %v", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+		return nil
+	}))
+	select {
+	case err := <-read:
+		assert.NoError(t, err, "the committed row should be read")
+	case <-time.After(5 * time.Second):
+		t.Fatal("read did not finish after the commit")
+	}
+}
+
+func TestWriteQueue_CloseRunsQueuedWrites(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE t (n INTEGER)")
+	require.NoError(t, err)
+
+	q := newWriteQueue(db, 8)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := q.exec(context.Background(), "INSERT INTO t (n) VALUES (?)", i); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	// Writes accepted before close either run or report an error, none are lost silently
+	q.close()
+	wg.Wait()
+
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM t").Scan(&count))
+	assert.Equal(t, succeeded, count)
+
+	_, err = q.exec(context.Background(), "INSERT INTO t (n) VALUES (99)")
+	assert.Error(t, err)
+}
+
+func TestWriteQueue_FilterProfilesAndSearchHistory(t *testing.T) {
+	vy := newFileVeriYonetici(t)
+	cached := NewCachedVeriYonetici(vy, CacheOptions{})
+	readDB, err := vy.GetReadDB()
+	require.NoError(t, err)
+	generation := cached.Generation()
+	writes := vy.WriteQueueStats().Writes
+
+	// Profiles and history are read from the read pool and written through the queue
+	fpm := NewFilterProfileManager(cached, readDB)
+	profile := &FilterProfile{Name: "Urgent", SearchQuery: "priority:yuksek"}
+	require.NoError(t, fpm.SaveFilterProfile(profile))
+	id, err := strconv.Atoi(profile.ID)
+	require.NoError(t, err)
+	require.NoError(t, fpm.MarkProfileUsed(id))
+	stored, err := fpm.GetFilterProfile(id)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.UseCount)
+
+	_, err = NewSearchEngine(cached, readDB).Search(SearchOptions{Query: "release"})
+	require.NoError(t, err)
+	var history int
+	require.NoError(t, readDB.QueryRow("SELECT COUNT(*) FROM search_history").Scan(&history))
+	assert.Equal(t, 1, history)
+
+	assert.Equal(t, writes+3, vy.WriteQueueStats().Writes)
+	assert.Equal(t, generation, cached.Generation(), "writes without task data must not invalidate the cache")
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/gorev"
 	"github.com/msenol/gorev/internal/i18n"
 	testinghelpers "github.com/msenol/gorev/internal/testing"
 )
@@ -447,3 +448,85 @@ func BenchmarkRaceConditionDetection(b *testing.B) {
 		b.Errorf("Race conditions detected: %v errors, first: %v", len(collectedErrors), collectedErrors[0])
 	}
 }
+
+// seedListBenchmarkTasks inserts n tasks in one project directly with SQL: every fifth task is
+// a subtask of the task before it, every task has a tag and depends on its predecessor
+func seedListBenchmarkTasks(b *testing.B, vy *gorev.VeriYonetici, n int) {
+	db, err := vy.GetDB()
+	if err != nil {
+		b.Fatalf("GetDB failed: %v", err)
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		b.Fatalf("Begin failed: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`INSERT INTO projeler (id, name, definition, created_at, updated_at) VALUES ('bench-project', 'Benchmark', '', datetime('now'), datetime('now'));
+		INSERT INTO etiketler (id, name) VALUES ('tag-0', 'backend'), ('tag-1', 'frontend'), ('tag-2', 'bug');`); err != nil {
+		b.Fatalf("Failed to seed project: %v", err)
+	}
+	taskStmt, err := tx.Prepare(`INSERT INTO gorevler (id, title, description, status, priority, project_id, parent_id, created_at, updated_at)
+		VALUES (?, ?, 'Benchmark task', ?, 'orta', 'bench-project', ?, datetime('now', ?), datetime('now'))`)
+	if err != nil {
+		b.Fatalf("Prepare failed: %v", err)
+	}
+	tagStmt, err := tx.Prepare(`INSERT INTO gorev_etiketleri (task_id, tag_id) VALUES (?, ?)`)
+	if err != nil {
+		b.Fatalf("Prepare failed: %v", err)
+	}
+	linkStmt, err := tx.Prepare(`INSERT INTO baglantilar (id, source_id, target_id, connection_type) VALUES (?, ?, ?, 'onceki')`)
+	if err != nil {
+		b.Fatalf("Prepare failed: %v", err)
+	}
+
+	statuses := []string{constants.TaskStatusPending, constants.TaskStatusInProgress, constants.TaskStatusCompleted}
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("bench-task-%d", i)
+		var parentID interface{}
+		if i%5 == 4 {
+			parentID = fmt.Sprintf("bench-task-%d", i-1)
+		}
+		if _, err := taskStmt.Exec(id, fmt.Sprintf("Task %d", i), statuses[i%3], parentID, fmt.Sprintf("-%d seconds", n-i)); err != nil {
+			b.Fatalf("Failed to seed task: %v", err)
+		}
+		if _, err := tagStmt.Exec(id, fmt.Sprintf("tag-%d", i%3)); err != nil {
+			b.Fatalf("Failed to seed tag: %v", err)
+		}
+		if i > 0 {
+			if _, err := linkStmt.Exec("bench-link-"+id, fmt.Sprintf("bench-task-%d", i-1), id); err != nil {
+				b.Fatalf("Failed to seed dependency: %v", err)
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		b.Fatalf("Commit failed: %v", err)
+	}
+}
+
+// BenchmarkGorevListeleScaling lists the first page of tasks from databases of growing size.
+// Only the roots of the page and their subtrees are read, so the time per page stays about
+// flat; the COUNT of the matching tasks is the only part that grows with the task count.
+func BenchmarkGorevListeleScaling(b *testing.B) {
+	for _, n := range []int{1000, 10000, 50000} {
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			vy, err := gorev.YeniVeriYonetici(":memory:", constants.TestMigrationsPath)
+			if err != nil {
+				b.Fatalf("Failed to create database: %v", err)
+			}
+			defer func() { _ = vy.Kapat() }()
+			seedListBenchmarkTasks(b, vy, n)
+
+			handlers := YeniHandlers(gorev.YeniIsYonetici(vy))
+			params := map[string]interface{}{"all_projects": true, "limit": float64(50)}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				result, err := handlers.GorevListele(params)
+				if err != nil || result.IsError {
+					b.Fatalf("gorev_listele failed: %v %v", err, result)
+				}
+			}
+		})
+	}
+}
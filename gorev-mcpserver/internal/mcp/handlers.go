@@ -2,8 +2,13 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -264,8 +269,33 @@ func (h *Handlers) gorevHiyerarsiYazdirVeIsaretle(ctx context.Context, lang stri
 	return metin
 }
 
+// gorevCocuklari görevleri parent ID'ye göre gruplar; alt görevler oluşturulma sırasına göre dizilir
+func gorevCocuklari(gorevMap map[string]*gorev.Gorev) map[string][]*gorev.Gorev {
+	cocuklar := make(map[string][]*gorev.Gorev)
+	for _, g := range gorevMap {
+		if g.ParentID != "" {
+			cocuklar[g.ParentID] = append(cocuklar[g.ParentID], g)
+		}
+	}
+	for _, liste := range cocuklar {
+		sort.Slice(liste, func(i, j int) bool {
+			if liste[i].CreatedAt.Equal(liste[j].CreatedAt) {
+				return liste[i].ID < liste[j].ID
+			}
+			return liste[i].CreatedAt.Before(liste[j].CreatedAt)
+		})
+	}
+	return cocuklar
+}
+
 // gorevHiyerarsiYazdirInternal görev hiyerarşisini yazdırır ve gösterilenleri işaretler
 func (h *Handlers) gorevHiyerarsiYazdirInternal(ctx context.Context, lang string, gorev *gorev.Gorev, gorevMap map[string]*gorev.Gorev, seviye int, projeGoster bool, shownGorevIDs map[string]bool) string {
+	return h.gorevHiyerarsiYazdirCocuklarla(ctx, lang, gorev, gorevCocuklari(gorevMap), seviye, projeGoster, shownGorevIDs)
+}
+
+// gorevHiyerarsiYazdirCocuklarla görev hiyerarşisini önceden gruplanmış alt görevlerle yazdırır,
+// böylece her seviyede tüm görev listesi taranmaz
+func (h *Handlers) gorevHiyerarsiYazdirCocuklarla(ctx context.Context, lang string, gorev *gorev.Gorev, cocuklar map[string][]*gorev.Gorev, seviye int, projeGoster bool, shownGorevIDs map[string]bool) string {
 	indent := strings.Repeat("  ", seviye)
 	prefix := ""
 	if seviye > 0 {
@@ -295,9 +325,15 @@ func (h *Handlers) gorevHiyerarsiYazdirInternal(ctx context.Context, lang string
 	}
 
 	if projeGoster && gorev.ProjeID != "" {
-		proje, _ := h.isYonetici.ProjeGetir(ctx, gorev.ProjeID)
-		if proje != nil {
-			details = append(details, i18n.T("messages.projectLabel", map[string]interface{}{"Name": proje.Name}))
+		// Liste sorgusu proje adını zaten getirir
+		projeAdi := gorev.ProjeName
+		if projeAdi == "" {
+			if proje, _ := h.isYonetici.ProjeGetir(ctx, gorev.ProjeID); proje != nil {
+				projeAdi = proje.Name
+			}
+		}
+		if projeAdi != "" {
+			details = append(details, i18n.T("messages.projectLabel", map[string]interface{}{"Name": projeAdi}))
 		}
 	}
 
@@ -334,12 +370,10 @@ func (h *Handlers) gorevHiyerarsiYazdirInternal(ctx context.Context, lang string
 		metin += bagimlilikBilgisi
 	}
 
-	// Alt görevleri bul ve yazdır - TÜM alt görevler gösterilir
-	for _, g := range gorevMap {
-		if g.ParentID == gorev.ID {
-			shownGorevIDs[g.ID] = true
-			metin += h.gorevHiyerarsiYazdirInternal(ctx, lang, g, gorevMap, seviye+1, projeGoster, shownGorevIDs)
-		}
+	// Alt görevleri yazdır - TÜM alt görevler gösterilir
+	for _, g := range cocuklar[gorev.ID] {
+		shownGorevIDs[g.ID] = true
+		metin += h.gorevHiyerarsiYazdirCocuklarla(ctx, lang, g, cocuklar, seviye+1, projeGoster, shownGorevIDs)
 	}
 
 	if seviye == 0 {
@@ -415,26 +449,8 @@ func (h *Handlers) GorevListele(params map[string]interface{}) (*mcp.CallToolRes
 		filters[constants.ParamFilter] = filter
 	}
 
-	gorevler, err := h.isYonetici.GorevListele(ctx, filters)
-	if err != nil {
-		return mcp.NewToolResultError(i18n.TListFailed(lang, "task", err)), nil
-	}
-
-	// DEBUG: Log görev sayısı
-	// fmt.Fprintf(os.Stderr, "[GorevListele] Fetched %d tasks total\n", len(gorevler))
-
-	// Filter by tag
 	if tag != "" {
-		var filteredTasks []*gorev.Gorev
-		for _, g := range gorevler {
-			for _, e := range g.Tags {
-				if e.Name == tag {
-					filteredTasks = append(filteredTasks, g)
-					break
-				}
-			}
-		}
-		gorevler = filteredTasks
+		filters["etiket"] = tag
 	}
 
 	// If active project exists and all_projects is false, show only active project's tasks
@@ -442,21 +458,17 @@ func (h *Handlers) GorevListele(params map[string]interface{}) (*mcp.CallToolRes
 	if !allProjects {
 		aktifProje, _ = h.isYonetici.AktifProjeGetir(ctx)
 		if aktifProje != nil {
-			// Görevleri filtrele
-			var filtreliGorevler []*gorev.Gorev
-			for _, g := range gorevler {
-				if g.ProjeID == aktifProje.ID {
-					filtreliGorevler = append(filtreliGorevler, g)
-				}
-			}
-			gorevler = filtreliGorevler
+			filters["proje_id"] = aktifProje.ID
 		}
 	}
 
-	// Toplam görev sayısı
-	toplamGorevSayisi := len(gorevler)
+	// Sadece istenen sayfanın kök görevleri ve alt ağaçları okunur
+	sayfa, err := h.isYonetici.GorevSayfasiGetir(ctx, filters, limit, offset)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.TListFailed(lang, "task", err)), nil
+	}
 
-	if toplamGorevSayisi == 0 {
+	if sayfa.ToplamSayisi == 0 {
 		mesaj := i18n.T("messages.noTasks")
 		if aktifProje != nil {
 			// Debug logging for OpenCode.ai issue
@@ -471,14 +483,14 @@ func (h *Handlers) GorevListele(params map[string]interface{}) (*mcp.CallToolRes
 		return mcp.NewToolResultText(mesaj), nil
 	}
 
-	// Görevleri hiyerarşik olarak organize et
+	// Sayfanın görevlerini hiyerarşik olarak organize et
 	gorevMap := make(map[string]*gorev.Gorev)
-	kokGorevler := []*gorev.Gorev{}
+	paginatedKokGorevler := []*gorev.Gorev{}
 
-	for _, g := range gorevler {
+	for _, g := range sayfa.Gorevler {
 		gorevMap[g.ID] = g
 		if g.ParentID == "" {
-			kokGorevler = append(kokGorevler, g)
+			paginatedKokGorevler = append(paginatedKokGorevler, g)
 		}
 	}
 
@@ -486,7 +498,7 @@ func (h *Handlers) GorevListele(params map[string]interface{}) (*mcp.CallToolRes
 
 	// Kompakt başlık ve pagination bilgisi
 	// FIX: Tüm görev sayısını göster (root + subtasks)
-	toplamRootGorevSayisi := len(kokGorevler)
+	toplamRootGorevSayisi := sayfa.KokSayisi
 	if toplamRootGorevSayisi > limit || offset > 0 {
 		// Check if offset is beyond available data
 		if offset >= toplamRootGorevSayisi {
@@ -513,31 +525,19 @@ func (h *Handlers) GorevListele(params map[string]interface{}) (*mcp.CallToolRes
 	}
 	metin += "\n"
 
-	// Pagination uygula - SADECE ROOT görevlere
-	// Subtask'lar parent'larıyla birlikte gösterilecek
-	var paginatedKokGorevler []*gorev.Gorev
-	if offset < len(kokGorevler) {
-		end := offset + limit
-		if end > len(kokGorevler) {
-			end = len(kokGorevler)
-		}
-		paginatedKokGorevler = kokGorevler[offset:end]
-	} else {
-		paginatedKokGorevler = []*gorev.Gorev{}
-	}
-
 	// Response boyutunu tahmin et ve gerekirse daha az görev göster
 	estimatedSize := 0
 	maxResponseSize := constants.MaxResponseSize // ~20K karakter güvenli limit
 
+	// Alt görevleri bir kez grupla; boyut tahmini ve yazdırma tüm listeyi tekrar taramaz
+	cocuklar := gorevCocuklari(gorevMap)
+
 	gorevlerToShow := []*gorev.Gorev{}
 	for _, kokGorev := range paginatedKokGorevler {
 		gorevSize := h.gorevResponseSizeEstimate(kokGorev)
 		// Alt görevler için ek boyut tahmin et
-		for _, g := range gorevMap {
-			if g.ParentID == kokGorev.ID {
-				gorevSize += h.gorevResponseSizeEstimate(g)
-			}
+		for _, g := range cocuklar[kokGorev.ID] {
+			gorevSize += h.gorevResponseSizeEstimate(g)
 		}
 
 		if estimatedSize+gorevSize > maxResponseSize && len(gorevlerToShow) > 0 {
@@ -554,9 +554,10 @@ func (h *Handlers) GorevListele(params map[string]interface{}) (*mcp.CallToolRes
 	shownGorevIDs := make(map[string]bool)
 
 	// Kök görevlerden başlayarak hiyerarşiyi oluştur
-	// NOT: gorevMap tüm görevleri içerir, böylece paginated bir görevin TÜM alt görevleri gösterilir
+	// NOT: gorevMap sayfadaki kök görevlerin TÜM alt görevlerini içerir, hepsi gösterilir
 	for _, kokGorev := range gorevlerToShow {
-		metin += h.gorevHiyerarsiYazdirVeIsaretle(ctx, lang, kokGorev, gorevMap, 0, allProjects || aktifProje == nil, shownGorevIDs)
+		shownGorevIDs[kokGorev.ID] = true
+		metin += h.gorevHiyerarsiYazdirCocuklarla(ctx, lang, kokGorev, cocuklar, 0, allProjects || aktifProje == nil, shownGorevIDs)
 	}
 
 	// REMOVED: Orphan checking logic
@@ -755,33 +756,43 @@ func (h *Handlers) GorevDetay(params map[string]interface{}) (*mcp.CallToolResul
 
 	gorev, err := h.isYonetici.GorevGetir(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultError(i18n.TEntityNotFoundByID(lang, "task", id)), nil
+		// Archived tasks stay readable; they are not counted or recorded as viewed
+		arsivGorev, arsivErr := h.isYonetici.ArsivGorevGetir(ctx, id)
+		if arsivErr != nil {
+			return mcp.NewToolResultError(i18n.TEntityNotFoundByID(lang, "task", id)), nil
+		}
+		gorev = arsivGorev
 	}
 
-	// Bağımlılık sayılarını hesapla (VS Code extension için gerekli)
-	bagimliSayilari, _ := h.isYonetici.VeriYonetici().BulkBagimlilikSayilariGetir([]string{id})
-	if count, exists := bagimliSayilari[id]; exists {
-		gorev.DependencyCount = count
-	}
+	if gorev.ArchivedAt == nil {
+		// Bağımlılık sayılarını hesapla (VS Code extension için gerekli)
+		bagimliSayilari, _ := h.isYonetici.VeriYonetici().BulkBagimlilikSayilariGetir([]string{id})
+		if count, exists := bagimliSayilari[id]; exists {
+			gorev.DependencyCount = count
+		}
 
-	tamamlanmamisSayilari, _ := h.isYonetici.VeriYonetici().BulkTamamlanmamiaBagimlilikSayilariGetir([]string{id})
-	if count, exists := tamamlanmamisSayilari[id]; exists {
-		gorev.UncompletedDependencyCount = count
-	}
+		tamamlanmamisSayilari, _ := h.isYonetici.VeriYonetici().BulkTamamlanmamiaBagimlilikSayilariGetir([]string{id})
+		if count, exists := tamamlanmamisSayilari[id]; exists {
+			gorev.UncompletedDependencyCount = count
+		}
 
-	buGoreveBagimliSayilari, _ := h.isYonetici.VeriYonetici().BulkBuGoreveBagimliSayilariGetir([]string{id})
-	if count, exists := buGoreveBagimliSayilari[id]; exists {
-		gorev.DependentOnThisCount = count
-	}
+		buGoreveBagimliSayilari, _ := h.isYonetici.VeriYonetici().BulkBuGoreveBagimliSayilariGetir([]string{id})
+		if count, exists := buGoreveBagimliSayilari[id]; exists {
+			gorev.DependentOnThisCount = count
+		}
 
-	// Auto-state management: Record task view and potentially transition state
-	if err := h.aiContextYonetici.RecordTaskView(ctx, id); err != nil {
-		// Log but don't fail the request
-		// fmt.Printf("Görev görüntüleme kaydı hatası: %v\n", err)
+		// Auto-state management: Record task view and potentially transition state
+		if err := h.aiContextYonetici.RecordTaskView(ctx, id); err != nil {
+			// Log but don't fail the request
+			// fmt.Printf("Görev görüntüleme kaydı hatası: %v\n", err)
+		}
 	}
 
 	// Markdown formatında detaylı görev bilgisi
 	metin := fmt.Sprintf("# %s\n\n", gorev.Title)
+	if gorev.ArchivedAt != nil {
+		metin += i18n.T("archive.archivedNotice", map[string]interface{}{"Date": gorev.ArchivedAt.Format(constants.DateTimeFormatFull)}) + "\n\n"
+	}
 	metin += i18n.T("headers.generalInfo") + "\n"
 	metin += i18n.TListItem(lang, "id_field", gorev.ID) + "\n"
 	metin += i18n.TListItem(lang, "durum", gorev.Status) + "\n"
@@ -797,6 +808,9 @@ func (h *Handlers) GorevDetay(params map[string]interface{}) (*mcp.CallToolResul
 	}
 	if gorev.ParentID != "" {
 		parent, err := h.isYonetici.GorevGetir(ctx, gorev.ParentID)
+		if err != nil && gorev.ArchivedAt != nil {
+			parent, err = h.isYonetici.ArsivGorevGetir(ctx, gorev.ParentID)
+		}
 		if err == nil {
 			metin += "\n" + i18n.TListItem(lang, "ust_gorev", parent.Title)
 		}
@@ -829,7 +843,11 @@ func (h *Handlers) GorevDetay(params map[string]interface{}) (*mcp.CallToolResul
 	// Bağımlılıkları ekle - Her zaman göster
 	metin += "\n\n" + i18n.T("headers.dependencies") + "\n"
 
-	baglantilar, err := h.isYonetici.GorevBaglantilariGetir(ctx, id)
+	baglantiGetir := h.isYonetici.GorevBaglantilariGetir
+	if gorev.ArchivedAt != nil {
+		baglantiGetir = h.isYonetici.ArsivBaglantilariGetir
+	}
+	baglantilar, err := baglantiGetir(ctx, id)
 	if err != nil {
 		metin += i18n.T("messages.dependenciesNotAvailable") + "\n"
 	} else if len(baglantilar) == 0 {
@@ -887,6 +905,8 @@ func (h *Handlers) GorevDetay(params map[string]interface{}) (*mcp.CallToolResul
 		}
 	}
 
+	metin += h.ilgiliGorevlerBolumu(ctx, id)
+
 	metin += "\n\n---\n"
 	metin += "\n*" + i18n.T("messages.lastUpdate", map[string]interface{}{
 		"Date": gorev.UpdatedAt.Format(constants.DateFormatDisplay),
@@ -895,6 +915,40 @@ func (h *Handlers) GorevDetay(params map[string]interface{}) (*mcp.CallToolResul
 	return mcp.NewToolResultText(metin), nil
 }
 
+// benzerGorevSatirlari lists similar tasks with their scores
+func benzerGorevSatirlari(benzerler []gorev.SimilarTask) string {
+	var satirlar strings.Builder
+	for _, benzer := range benzerler {
+		satirlar.WriteString(i18n.T("similar.item", map[string]interface{}{
+			"Title":  benzer.Task.Title,
+			"ID":     benzer.Task.ID,
+			"Status": benzer.Task.Status,
+			"Score":  int(math.Round(benzer.Score * 100)),
+		}) + "\n")
+	}
+	return satirlar.String()
+}
+
+// ilgiliGorevlerBolumu renders the related tasks section of a task detail; index
+// errors must not break the detail view, so they leave the section out
+func (h *Handlers) ilgiliGorevlerBolumu(ctx context.Context, id string) string {
+	benzerler, err := h.isYonetici.BenzerGorevler(ctx, gorev.SimilarityOptions{TaskID: id})
+	if err != nil || len(benzerler) == 0 {
+		return ""
+	}
+	return "\n\n" + i18n.T("similar.related") + "\n" + benzerGorevSatirlari(benzerler)
+}
+
+// olasiKopyaUyarisi warns about open tasks that look like duplicates of a new task;
+// it is empty when there are none
+func (h *Handlers) olasiKopyaUyarisi(ctx context.Context, baslik, aciklama, haricID string) string {
+	kopyalar, err := h.isYonetici.OlasiKopyalar(ctx, baslik, aciklama, haricID)
+	if err != nil || len(kopyalar) == 0 {
+		return ""
+	}
+	return "\n\n" + i18n.T("similar.possibleDuplicates") + "\n" + benzerGorevSatirlari(kopyalar)
+}
+
 // GorevDuzenle görevi düzenler
 func (h *Handlers) GorevDuzenle(params map[string]interface{}) (*mcp.CallToolResult, error) {
 	lang := h.extractLanguage()
@@ -1207,6 +1261,7 @@ func (h *Handlers) GorevSuggestions(params map[string]interface{}) (*mcp.CallToo
 
 	// Create suggestion engine
 	suggestionEngine := gorev.NewSuggestionEngine(h.isYonetici.VeriYonetici())
+	suggestionEngine.SetSimilarityIndex(h.isYonetici.BenzerlikIndeksi())
 	if h.aiContextYonetici != nil {
 		suggestionEngine.SetAIContextManager(h.aiContextYonetici)
 	}
@@ -1312,6 +1367,7 @@ func (h *Handlers) GorevIntelligentCreate(params map[string]interface{}) (*mcp.C
 
 	// Create intelligent task creator
 	creator := gorev.NewIntelligentTaskCreator(h.isYonetici.VeriYonetici())
+	creator.SetSimilarityIndex(h.isYonetici.BenzerlikIndeksi())
 
 	// Prepare request
 	request := gorev.TaskCreationRequest{
@@ -1931,6 +1987,7 @@ func (h *Handlers) TemplatedenGorevOlustur(params map[string]interface{}) (*mcp.
 	successMsg += i18n.TListItem(lang, "id_field", gorev.ID) + "\n"
 	successMsg += i18n.TListItem(lang, "oncelik", gorev.Priority) + "\n\n"
 	successMsg += i18n.T("messages.detailsCommand", map[string]interface{}{"ID": gorev.ID})
+	successMsg += h.olasiKopyaUyarisi(ctx, gorev.Title, gorev.Description, gorev.ID)
 
 	return mcp.NewToolResultText(successMsg), nil
 }
@@ -1974,7 +2031,7 @@ func (h *Handlers) GorevAltGorevOlustur(params map[string]interface{}) (*mcp.Cal
 	return mcp.NewToolResultText(i18n.T("success.subtaskCreated", map[string]interface{}{
 		"Title": gorev.Title,
 		"Id":    gorev.ID,
-	})), nil
+	}) + h.olasiKopyaUyarisi(ctx, gorev.Title, gorev.Description, gorev.ID)), nil
 }
 
 // GorevUstDegistir bir görevin üst görevini değiştirir
@@ -2101,6 +2158,22 @@ func (h *Handlers) CallTool(toolName string, params map[string]interface{}) (*mc
 		return h.GorevExport(params)
 	case "gorev_import":
 		return h.GorevImport(params)
+	case "gorev_doctor":
+		return h.GorevDoctor(params)
+	case "gorev_arsiv":
+		return h.GorevArsiv(params)
+	case "gorev_complete":
+		return h.GorevComplete(params)
+	case "gorev_quick_add":
+		return h.GorevQuickAdd(params)
+	case "gorev_similar":
+		return h.GorevSimilar(params)
+	case "gorev_merge":
+		return h.GorevMerge(params)
+	case "gorev_clone":
+		return h.GorevClone(params)
+	case "gorev_project_template":
+		return h.GorevProjectTemplate(params)
 
 	// Unified tools - 8 tools replacing 27 individual tools (37% reduction)
 	case "aktif_proje": // replaces aktif_proje_ayarla, aktif_proje_goster, aktif_proje_kaldir
@@ -2606,6 +2679,11 @@ func (h *Handlers) GorevExport(params map[string]interface{}) (*mcp.CallToolResu
 		includeAIContext = val
 	}
 
+	includeArchived := true
+	if val, ok := params["include_archived"].(bool); ok {
+		includeArchived = val
+	}
+
 	// Parse project filter
 	var projectFilter []string
 	if val, ok := params["project_filter"]; ok {
@@ -2647,6 +2725,23 @@ func (h *Handlers) GorevExport(params map[string]interface{}) (*mcp.CallToolResu
 		IncludeMetadata:     true,
 		IncludeAIContext:    includeAIContext,
 		IncludeTemplates:    includeTemplates,
+		IncludeArchived:     includeArchived,
+	}
+
+	// NDJSON is streamed from the database straight to the file
+	if format == "ndjson" {
+		stats, err := h.isYonetici.StreamExportToFile(ctx, options, nil)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf(i18n.T("error.exportFailed", map[string]interface{}{"Error": err}))), nil
+		}
+		return mcp.NewToolResultText(i18n.T("export.success", map[string]interface{}{
+			"Format":    format,
+			"Path":      outputPath,
+			"Tasks":     stats.Tasks,
+			"Projects":  stats.Projects,
+			"Tags":      stats.Tags,
+			"Templates": stats.Templates,
+		})), nil
 	}
 
 	// Export data
@@ -2728,14 +2823,37 @@ func (h *Handlers) GorevImport(params map[string]interface{}) (*mcp.CallToolResu
 		}
 	}
 
+	// Parse CSV column mapping
+	var columnMapping map[string]string
+	if val, ok := params["column_mapping"]; ok {
+		if mappingInterface, ok := val.(map[string]interface{}); ok {
+			columnMapping = make(map[string]string)
+			for k, v := range mappingInterface {
+				if vStr, ok := v.(string); ok {
+					columnMapping[k] = vStr
+				}
+			}
+		}
+	}
+
+	format, _ := params["format"].(string)
+
+	chunkSize := 0
+	if val, ok := params["chunk_size"].(float64); ok && val > 0 {
+		chunkSize = int(val)
+	}
+
 	// Create import options
 	options := gorev.ImportOptions{
 		FilePath:           filePath,
+		Format:             format,
 		ImportMode:         importMode,
 		ConflictResolution: conflictResolution,
 		PreserveIDs:        preserveIDs,
 		ProjectMapping:     projectMapping,
+		ColumnMapping:      columnMapping,
 		DryRun:             dryRun,
+		ChunkSize:          chunkSize,
 	}
 
 	// Import data
@@ -2793,6 +2911,497 @@ func (h *Handlers) GorevImport(params map[string]interface{}) (*mcp.CallToolResu
 	return mcp.NewToolResultText(summary.String()), nil
 }
 
+// GorevDoctor checks the database for dangling references and optionally repairs them
+func (h *Handlers) GorevDoctor(params map[string]interface{}) (*mcp.CallToolResult, error) {
+	lang := h.extractLanguage()
+	ctx := i18n.WithLanguage(context.Background(), lang)
+
+	options := gorev.DoctorOptions{}
+	if val, ok := params["fix"].(bool); ok {
+		options.Fix = val
+	}
+	if val, ok := params["vacuum"].(bool); ok {
+		options.Vacuum = val
+	}
+
+	report, err := h.isYonetici.VeritabaniDenetle(ctx, options)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.T("error.doctorFailed", map[string]interface{}{"Error": err})), nil
+	}
+
+	var metin strings.Builder
+	metin.WriteString(i18n.T("doctor.title") + "\n\n")
+	if len(report.IntegrityProblems) == 0 {
+		metin.WriteString("- ✅ integrity_check\n")
+	} else {
+		metin.WriteString(fmt.Sprintf("- ❌ integrity_check: %s\n", strings.Join(report.IntegrityProblems, "; ")))
+	}
+	for _, issue := range report.Issues {
+		switch {
+		case issue.Count == 0:
+			metin.WriteString(fmt.Sprintf("- ✅ %s\n", issue.Check))
+		case issue.Fixed:
+			metin.WriteString(fmt.Sprintf("- 🔧 %s: %s\n", issue.Check, i18n.T("doctor.fixed", map[string]interface{}{"Count": issue.Count})))
+		default:
+			metin.WriteString(fmt.Sprintf("- ❌ %s: %s (%s)\n", issue.Check, i18n.T("doctor.found", map[string]interface{}{"Count": issue.Count}), strings.Join(issue.Samples, ", ")))
+		}
+	}
+	for _, check := range report.Skipped {
+		metin.WriteString(fmt.Sprintf("- ⏭️ %s: %s\n", check, i18n.T("doctor.skipped")))
+	}
+
+	metin.WriteString("\n")
+	if report.Healthy() {
+		metin.WriteString(i18n.T("doctor.healthy"))
+	} else {
+		metin.WriteString(i18n.T("doctor.unhealthy"))
+	}
+	return mcp.NewToolResultText(metin.String()), nil
+}
+
+// GorevArsiv archives old completed tasks, restores archived tasks and manages the workspace retention
+func (h *Handlers) GorevArsiv(params map[string]interface{}) (*mcp.CallToolResult, error) {
+	lang := h.extractLanguage()
+	ctx := i18n.WithLanguage(context.Background(), lang)
+
+	action, _ := params["action"].(string)
+	retentionDays := -1
+	if val, ok := params["retention_days"].(float64); ok {
+		retentionDays = int(val)
+	}
+
+	switch action {
+	case constants.ActionRun:
+		if retentionDays < 0 {
+			retentionDays = 0 // Workspace setting
+		}
+		sonuc, err := h.isYonetici.GorevleriArsivle(ctx, retentionDays)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if sonuc.RetentionDays == 0 {
+			return mcp.NewToolResultText(i18n.T("archive.disabled")), nil
+		}
+		if sonuc.Tasks == 0 {
+			return mcp.NewToolResultText(i18n.T("archive.nothingToArchive", map[string]interface{}{
+				"Cutoff": sonuc.Cutoff.Format(constants.DateFormatISO),
+			})), nil
+		}
+		return mcp.NewToolResultText(i18n.T("archive.archived", arsivSonucuVerisi(sonuc))), nil
+
+	case constants.ActionRestore:
+		id, result := h.toolHelpers.Validator.ValidateTaskID(params)
+		if result != nil {
+			return result, nil
+		}
+		sonuc, err := h.isYonetici.ArsivdenGeriYukle(ctx, id)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(i18n.T("archive.restored", arsivSonucuVerisi(sonuc))), nil
+
+	case constants.ActionList:
+		limit := 50
+		if val, ok := params["limit"].(float64); ok && val > 0 {
+			limit = int(val)
+		}
+		gorevler, err := h.isYonetici.ArsivGorevleriListele(ctx, limit)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if len(gorevler) == 0 {
+			return mcp.NewToolResultText(i18n.T("archive.listEmpty")), nil
+		}
+		var metin strings.Builder
+		metin.WriteString(i18n.T("archive.listTitle", map[string]interface{}{"Count": len(gorevler)}) + "\n\n")
+		for _, g := range gorevler {
+			metin.WriteString(fmt.Sprintf("- %s (`%s`) %s\n", g.Title, g.ID, g.ArchivedAt.Format(constants.DateFormatISO)))
+		}
+		return mcp.NewToolResultText(metin.String()), nil
+
+	case constants.ActionSettings:
+		var ayarlar *gorev.ArsivAyarlari
+		var err error
+		if retentionDays >= 0 {
+			ayarlar, err = h.isYonetici.ArsivAyarlariKaydet(ctx, retentionDays)
+		} else {
+			ayarlar, err = h.isYonetici.ArsivAyarlariGetir(ctx)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if ayarlar.RetentionDays == 0 {
+			return mcp.NewToolResultText(i18n.T("archive.settingsDisabled")), nil
+		}
+		return mcp.NewToolResultText(i18n.T("archive.settings", map[string]interface{}{"Days": ayarlar.RetentionDays})), nil
+
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("invalid action: %s (expected: %s)", action, strings.Join(constants.ValidArchiveActions, "|"))), nil
+	}
+}
+
+// arsivSonucuVerisi returns the template data of the archive and restore messages
+func arsivSonucuVerisi(sonuc *gorev.ArsivSonucu) map[string]interface{} {
+	return map[string]interface{}{
+		"Tasks":        sonuc.Tasks,
+		"Tags":         sonuc.Tags,
+		"Links":        sonuc.Links,
+		"Interactions": sonuc.Interactions,
+		"FilePaths":    sonuc.FilePaths,
+		"Cutoff":       sonuc.Cutoff.Format(constants.DateFormatISO),
+	}
+}
+
+// GorevComplete returns type-ahead completions for tasks, tags, projects and templates
+func (h *Handlers) GorevComplete(params map[string]interface{}) (*mcp.CallToolResult, error) {
+	lang := h.extractLanguage()
+	ctx := i18n.WithLanguage(context.Background(), lang)
+
+	options := gorev.CompletionOptions{
+		Query: h.toolHelpers.Validator.ValidateOptionalString(params, "query"),
+		Kind:  h.toolHelpers.Validator.ValidateOptionalString(params, "kind"),
+	}
+	if val, ok := params["limit"].(float64); ok {
+		options.Limit = int(val)
+	}
+
+	completions, err := h.isYonetici.OtomatikTamamla(ctx, options)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(completions) == 0 {
+		return mcp.NewToolResultText(i18n.T("complete.empty", map[string]interface{}{"Query": options.Query})), nil
+	}
+
+	var metin strings.Builder
+	metin.WriteString(i18n.T("complete.title", map[string]interface{}{"Query": options.Query, "Count": len(completions)}) + "\n\n")
+	for _, completion := range completions {
+		metin.WriteString(fmt.Sprintf("- %s `%s` (%s", completion.Label, completion.Value, completion.Kind))
+		if completion.Detail != "" {
+			metin.WriteString(", " + completion.Detail)
+		}
+		if completion.Count > 0 {
+			metin.WriteString(fmt.Sprintf(", %d", completion.Count))
+		}
+		metin.WriteString(")\n")
+	}
+	return mcp.NewToolResultText(metin.String()), nil
+}
+
+// GorevQuickAdd creates a task from one line of quick-add syntax
+func (h *Handlers) GorevQuickAdd(params map[string]interface{}) (*mcp.CallToolResult, error) {
+	lang := h.extractLanguage()
+	ctx := i18n.WithLanguage(context.Background(), lang)
+
+	text, ok := params["text"].(string)
+	if !ok || strings.TrimSpace(text) == "" {
+		return mcp.NewToolResultError(i18n.TRequiredParam(lang, "text")), nil
+	}
+
+	quickAdd, err := h.isYonetici.HizliEkleAyristir(ctx, text)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var gorevSonuc *gorev.Gorev
+	if !h.toolHelpers.Validator.ValidateBool(params, "dry_run") {
+		if gorevSonuc, err = h.isYonetici.HizliGorevEkle(ctx, quickAdd); err != nil {
+			return mcp.NewToolResultError(i18n.TCreateFailed(lang, "task", err)), nil
+		}
+	}
+
+	var metin strings.Builder
+	if gorevSonuc == nil {
+		metin.WriteString(i18n.T("quickAdd.preview", map[string]interface{}{"Title": quickAdd.Title}) + "\n\n")
+	} else {
+		metin.WriteString(i18n.T("quickAdd.created", map[string]interface{}{"Title": gorevSonuc.Title}) + "\n\n")
+		metin.WriteString(i18n.TListItem(lang, "id_field", gorevSonuc.ID) + "\n")
+	}
+	if quickAdd.Description != "" {
+		metin.WriteString(i18n.TListItem(lang, "aciklama", quickAdd.Description) + "\n")
+	}
+	priority := quickAdd.Priority
+	if priority == "" {
+		priority = constants.PriorityMedium
+	}
+	metin.WriteString(i18n.TListItem(lang, "oncelik", priority) + "\n")
+	if quickAdd.Project != "" {
+		metin.WriteString(i18n.TListItem(lang, "proje", quickAdd.Project) + "\n")
+	}
+	if quickAdd.ParentID != "" {
+		metin.WriteString(i18n.TListItem(lang, "ust_gorev", quickAdd.ParentID) + "\n")
+	}
+	if quickAdd.DueDate != nil {
+		metin.WriteString(i18n.TListItem(lang, "son_tarih", quickAdd.DueDate.Format(constants.DateFormatISO)) + "\n")
+	}
+	if len(quickAdd.Tags) > 0 {
+		metin.WriteString(i18n.TListItem(lang, "etiketler", strings.Join(quickAdd.Tags, ", ")) + "\n")
+	}
+	if quickAdd.EstimatedHours > 0 {
+		metin.WriteString(i18n.TListItem(lang, "tahmini_sure", fmt.Sprintf("%gh", quickAdd.EstimatedHours)) + "\n")
+	}
+	haricID := ""
+	if gorevSonuc != nil {
+		haricID = gorevSonuc.ID
+	}
+	metin.WriteString(h.olasiKopyaUyarisi(ctx, quickAdd.Title, quickAdd.Description, haricID))
+	return mcp.NewToolResultText(metin.String()), nil
+}
+
+// GorevMerge merges a duplicate task into another one, or previews the merge
+func (h *Handlers) GorevMerge(params map[string]interface{}) (*mcp.CallToolResult, error) {
+	lang := h.extractLanguage()
+	ctx := i18n.WithLanguage(context.Background(), lang)
+
+	kaynakID, result := h.toolHelpers.Validator.ValidateTaskIDField(params, "source_id")
+	if result != nil {
+		return result, nil
+	}
+	hedefID, result := h.toolHelpers.Validator.ValidateTaskIDField(params, "target_id")
+	if result != nil {
+		return result, nil
+	}
+	dryRun := h.toolHelpers.Validator.ValidateBool(params, "dry_run")
+
+	sonuc, err := h.isYonetici.GorevBirlestir(ctx, kaynakID, hedefID, dryRun)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	etiketler := "-"
+	if len(sonuc.AddedTags) > 0 {
+		etiketler = strings.Join(sonuc.AddedTags, ", ")
+	}
+	veri := map[string]interface{}{
+		"Source":       fmt.Sprintf("%s (`%s`)", sonuc.Source.Title, sonuc.Source.ID),
+		"Target":       fmt.Sprintf("%s (`%s`)", sonuc.Target.Title, sonuc.Target.ID),
+		"Tags":         etiketler,
+		"Subtasks":     len(sonuc.Subtasks),
+		"Links":        sonuc.Links,
+		"DroppedLinks": sonuc.DroppedLinks,
+		"FilePaths":    sonuc.FilePaths,
+		"Interactions": sonuc.Interactions,
+	}
+
+	var metin strings.Builder
+	if dryRun {
+		metin.WriteString(i18n.T("merge.preview", veri))
+	} else {
+		metin.WriteString(i18n.T("merge.merged", veri))
+	}
+	if sonuc.ProjectMoves > 0 {
+		metin.WriteString("\n" + i18n.T("merge.projectMoves", map[string]interface{}{"Count": sonuc.ProjectMoves}))
+	}
+	metin.WriteString("\n\n" + i18n.T("merge.combinedDescription") + "\n\n" + sonuc.Target.Description + "\n\n")
+	if dryRun {
+		metin.WriteString(i18n.T("merge.previewHint"))
+	} else {
+		metin.WriteString(i18n.T("merge.archivedHint", map[string]interface{}{"ID": sonuc.Source.ID}))
+	}
+	return mcp.NewToolResultText(metin.String()), nil
+}
+
+// GorevClone deep-clones a task with its subtasks, or a whole project
+func (h *Handlers) GorevClone(params map[string]interface{}) (*mcp.CallToolResult, error) {
+	lang := h.extractLanguage()
+	ctx := i18n.WithLanguage(context.Background(), lang)
+
+	taskID := h.toolHelpers.Validator.ValidateOptionalString(params, "task_id")
+	projectID := h.toolHelpers.Validator.ValidateOptionalString(params, "project_id")
+	if taskID == "" && projectID == "" {
+		return mcp.NewToolResultError(i18n.T("error.cloneSourceRequired")), nil
+	}
+	secenekler := gorev.KlonlamaSecenekleri{
+		ProjectID:        h.toolHelpers.Validator.ValidateOptionalString(params, "target_project_id"),
+		ProjectName:      h.toolHelpers.Validator.ValidateOptionalString(params, "project_name"),
+		ResetStatus:      h.toolHelpers.Validator.ValidateBool(params, "reset_status"),
+		IncludeFilePaths: h.toolHelpers.Validator.ValidateBool(params, "include_files"),
+	}
+	if val, ok := params["shift_days"].(float64); ok {
+		secenekler.DueDateShiftDays = int(val)
+	}
+	if val, ok := params["shift_months"].(float64); ok {
+		secenekler.DueDateShiftMonths = int(val)
+	}
+
+	if taskID != "" {
+		sonuc, err := h.isYonetici.GorevKlonla(ctx, taskID, secenekler)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(i18n.T("clone.task", klonlamaSonucuVerisi(sonuc, map[string]interface{}{
+			"Title": sonuc.Root.Title,
+			"ID":    sonuc.Root.ID,
+		}))), nil
+	}
+
+	kaynak, err := h.isYonetici.VeriYonetici().ProjeGetir(ctx, projectID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.T("error.projectNotFound", map[string]interface{}{"Error": err})), nil
+	}
+	sonuc, err := h.isYonetici.ProjeKlonla(ctx, projectID, secenekler)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(i18n.T("clone.project", klonlamaSonucuVerisi(sonuc, map[string]interface{}{
+		"Source": kaynak.Name,
+		"Name":   sonuc.Project.Name,
+		"ID":     sonuc.Project.ID,
+	}))), nil
+}
+
+// GorevProjectTemplate lists, shows, creates, deletes and applies project templates
+func (h *Handlers) GorevProjectTemplate(params map[string]interface{}) (*mcp.CallToolResult, error) {
+	lang := h.extractLanguage()
+	ctx := i18n.WithLanguage(context.Background(), lang)
+
+	action, _ := params["action"].(string)
+	templateID := h.toolHelpers.Validator.ValidateOptionalString(params, "template_id")
+	if action == constants.ActionShow || action == constants.ActionDelete || action == constants.ActionApply {
+		if templateID == "" {
+			return mcp.NewToolResultError(i18n.TRequiredParam(lang, "template_id")), nil
+		}
+	}
+
+	switch action {
+	case constants.ActionList:
+		templates, err := h.isYonetici.ProjeTemplateListele(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if len(templates) == 0 {
+			return mcp.NewToolResultText(i18n.T("projectTemplate.listEmpty")), nil
+		}
+		var metin strings.Builder
+		metin.WriteString(i18n.T("projectTemplate.listTitle", map[string]interface{}{"Count": len(templates)}) + "\n\n")
+		for _, t := range templates {
+			metin.WriteString(i18n.T("projectTemplate.listItem", projeTemplateVerisi(t)) + "\n")
+		}
+		return mcp.NewToolResultText(metin.String()), nil
+
+	case constants.ActionShow:
+		template, err := h.isYonetici.ProjeTemplateGetir(ctx, templateID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		veri := projeTemplateVerisi(template)
+		jsonVeri, _ := json.MarshalIndent(template, "", "  ")
+		veri["JSON"] = string(jsonVeri)
+		return mcp.NewToolResultText(i18n.T("projectTemplate.show", veri)), nil
+
+	case constants.ActionCreate:
+		var ham []byte
+		switch deger := params["template"].(type) {
+		case string:
+			ham = []byte(deger)
+		case map[string]interface{}:
+			ham, _ = json.Marshal(deger)
+		default:
+			return mcp.NewToolResultError(i18n.TRequiredParam(lang, "template")), nil
+		}
+		template := &gorev.ProjeTemplate{}
+		if err := json.Unmarshal(ham, template); err != nil {
+			return mcp.NewToolResultError(i18n.T("error.projectTemplateInvalidJSON", map[string]interface{}{"Error": err})), nil
+		}
+		if err := h.isYonetici.ProjeTemplateKaydet(ctx, template); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(i18n.T("projectTemplate.created", projeTemplateVerisi(template))), nil
+
+	case constants.ActionDelete:
+		if err := h.isYonetici.ProjeTemplateSil(ctx, templateID); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(i18n.T("projectTemplate.deleted", map[string]interface{}{"ID": templateID})), nil
+
+	case constants.ActionApply:
+		secenekler := gorev.ProjeTemplateSecenekleri{
+			ProjectID:   h.toolHelpers.Validator.ValidateOptionalString(params, "project_id"),
+			ProjectName: h.toolHelpers.Validator.ValidateOptionalString(params, "project_name"),
+			StartDate:   h.toolHelpers.Validator.ValidateOptionalString(params, "start_date"),
+			Values:      make(map[string]string),
+		}
+		if degerler, ok := params["values"].(map[string]interface{}); ok {
+			for anahtar, deger := range degerler {
+				secenekler.Values[anahtar] = fmt.Sprint(deger)
+			}
+		}
+		template, err := h.isYonetici.ProjeTemplateGetir(ctx, templateID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		sonuc, err := h.isYonetici.ProjeTemplateUygula(ctx, template.ID, secenekler)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(i18n.T("projectTemplate.applied", map[string]interface{}{
+			"Template": template.Name,
+			"Project":  sonuc.Project.Name,
+			"ID":       sonuc.Project.ID,
+			"Tasks":    sonuc.Tasks,
+			"Links":    sonuc.Links,
+			"Tags":     sonuc.Tags,
+		})), nil
+
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("invalid action: %s (expected: %s)", action, strings.Join(constants.ValidProjectTemplateActions, "|"))), nil
+	}
+}
+
+// projeTemplateVerisi returns the template data of the project template messages
+func projeTemplateVerisi(template *gorev.ProjeTemplate) map[string]interface{} {
+	gorevler := 0
+	var say func(dugumler []gorev.ProjeTemplateGorevi)
+	say = func(dugumler []gorev.ProjeTemplateGorevi) {
+		for _, dugum := range dugumler {
+			gorevler++
+			say(dugum.Subtasks)
+		}
+	}
+	say(template.Tasks)
+	return map[string]interface{}{
+		"Name":  template.Name,
+		"ID":    template.ID,
+		"Alias": template.Alias,
+		"Tasks": gorevler,
+	}
+}
+
+// klonlamaSonucuVerisi adds the counts of a clone to the template data
+func klonlamaSonucuVerisi(sonuc *gorev.KlonlamaSonucu, veri map[string]interface{}) map[string]interface{} {
+	veri["Tasks"] = sonuc.Tasks
+	veri["Links"] = sonuc.Links
+	veri["Tags"] = sonuc.Tags
+	veri["FilePaths"] = sonuc.FilePaths
+	return veri
+}
+
+// GorevSimilar lists the tasks most similar to a task or a text
+func (h *Handlers) GorevSimilar(params map[string]interface{}) (*mcp.CallToolResult, error) {
+	lang := h.extractLanguage()
+	ctx := i18n.WithLanguage(context.Background(), lang)
+
+	options := gorev.SimilarityOptions{
+		TaskID:     h.toolHelpers.Validator.ValidateOptionalString(params, "task_id"),
+		Text:       h.toolHelpers.Validator.ValidateOptionalString(params, "text"),
+		ActiveOnly: h.toolHelpers.Validator.ValidateBool(params, "active_only"),
+	}
+	if val, ok := params["limit"].(float64); ok {
+		options.Limit = int(val)
+	}
+	if val, ok := params["min_score"].(float64); ok {
+		options.MinScore = val
+	}
+
+	benzerler, err := h.isYonetici.BenzerGorevler(ctx, options)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(benzerler) == 0 {
+		return mcp.NewToolResultText(i18n.T("similar.empty")), nil
+	}
+	return mcp.NewToolResultText(i18n.T("similar.title", map[string]interface{}{"Count": len(benzerler)}) + "\n\n" + benzerGorevSatirlari(benzerler)), nil
+}
+
 // IDEDetect detects all installed IDEs on the system
 func (h *Handlers) IDEDetect(params map[string]interface{}) (*mcp.CallToolResult, error) {
 	detector := gorev.NewIDEDetector()
@@ -3158,6 +3767,11 @@ func (h *Handlers) GorevSearchAdvanced(params map[string]interface{}) (*mcp.Call
 		includeCompleted = val
 	}
 
+	includeArchived := false
+	if val, ok := params["include_archived"].(bool); ok {
+		includeArchived = val
+	}
+
 	// Parse search options
 	options := gorev.SearchOptions{
 		Query:            query,
@@ -3168,6 +3782,7 @@ func (h *Handlers) GorevSearchAdvanced(params map[string]interface{}) (*mcp.Call
 		SortBy:           sortBy,
 		SortDirection:    sortDirection,
 		IncludeCompleted: includeCompleted,
+		IncludeArchived:  includeArchived,
 	}
 
 	// Extract filters if provided
@@ -3177,17 +3792,6 @@ func (h *Handlers) GorevSearchAdvanced(params map[string]interface{}) (*mcp.Call
 		}
 	}
 
-	// Parse query string for "key:value" patterns and add to filters
-	// Supports queries like "durum:devam_ediyor oncelik:yuksek"
-	if query != "" && len(options.Filters) == 0 {
-		parsedFilters := parseQueryFilters(query)
-		if len(parsedFilters) > 0 {
-			options.Filters = parsedFilters
-			// Clear query if it was fully parsed into filters
-			options.Query = ""
-		}
-	}
-
 	// Extract search fields if provided
 	if fieldsParam, ok := params["search_fields"]; ok {
 		if fields, ok := fieldsParam.([]interface{}); ok {
@@ -3200,19 +3804,26 @@ func (h *Handlers) GorevSearchAdvanced(params map[string]interface{}) (*mcp.Call
 	}
 
 	// Create search engine and perform search
-	db, err := h.isYonetici.VeriYonetici().GetDB()
+	db, err := h.isYonetici.VeriYonetici().GetReadDB()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Database access failed: %v", err)), nil
 	}
 	searchEngine := gorev.NewSearchEngine(h.isYonetici.VeriYonetici(), db)
 	response, err := searchEngine.Search(options)
 	if err != nil {
+		var syntaxErr *gorev.QuerySyntaxError
+		if errors.As(err, &syntaxErr) {
+			return mcp.NewToolResultError(syntaxErr.Error()), nil
+		}
 		return mcp.NewToolResultError(fmt.Sprintf("Search failed: %v", err)), nil
 	}
 
-	// Format response as simple text for now
-	responseText := fmt.Sprintf("Advanced Search Results:\n")
-	responseText += fmt.Sprintf("Query: '%s'\n", query)
+	return mcp.NewToolResultText("Advanced Search Results:\n" + formatSearchResults(query, response)), nil
+}
+
+// formatSearchResults renders the summary and the top results of a search
+func formatSearchResults(query string, response *gorev.SearchResponse) string {
+	responseText := fmt.Sprintf("Query: '%s'\n", query)
 	responseText += fmt.Sprintf("Found %d results in %dms\n", response.TotalCount, response.QueryTime.Milliseconds())
 	responseText += fmt.Sprintf("Fuzzy search: %v\n", response.UsedFuzzy)
 
@@ -3222,54 +3833,177 @@ func (h *Handlers) GorevSearchAdvanced(params map[string]interface{}) (*mcp.Call
 			if i >= 5 { // Limit to top 5 for display
 				break
 			}
-			responseText += fmt.Sprintf("- %s (Score: %.2f)\n", result.Task.Title, result.RelevanceScore)
+			archived := ""
+			if result.Task.ArchivedAt != nil {
+				archived = " 🗃️"
+			}
+			title := result.Task.Title
+			if highlighted, ok := result.Highlights["baslik"]; ok {
+				title = searchHighlightMarkdown.Replace(highlighted)
+			}
+			responseText += fmt.Sprintf("- %s%s (Score: %.2f)\n", title, archived, result.RelevanceScore)
+			if snippet, ok := result.Highlights["aciklama"]; ok {
+				responseText += fmt.Sprintf("  %s\n", searchHighlightMarkdown.Replace(snippet))
+			}
 		}
 	}
 
-	return mcp.NewToolResultText(responseText), nil
+	facets := []struct {
+		name   string
+		counts []gorev.FacetCount
+	}{
+		{"Status", response.Facets.Status},
+		{"Priority", response.Facets.Priority},
+		{"Project", response.Facets.Project},
+		{"Tag", response.Facets.Tag},
+		{"Due", response.Facets.Due},
+	}
+	if response.TotalCount > 0 {
+		responseText += "\nFacets:\n"
+		for _, facet := range facets {
+			parts := make([]string, 0, len(facet.counts))
+			for _, count := range facet.counts {
+				label := count.Value
+				if count.Label != "" {
+					label = count.Label
+				}
+				parts = append(parts, fmt.Sprintf("%s (%d)", label, count.Count))
+			}
+			if len(parts) > 0 {
+				responseText += fmt.Sprintf("- %s: %s\n", facet.name, strings.Join(parts, ", "))
+			}
+		}
+	}
+	return responseText
 }
 
-// GorevFilterProfileSave saves a filter profile
-func (h *Handlers) GorevFilterProfileSave(params map[string]interface{}) (*mcp.CallToolResult, error) {
-	name, err := h.toolHelpers.Validator.ValidateRequiredString(params, "name")
+// searchHighlightMarkdown renders highlight marks as bold text
+var searchHighlightMarkdown = strings.NewReplacer(gorev.HighlightOpen, "**", gorev.HighlightClose, "**")
+
+// filterProfileManager returns the filter profile manager of the workspace database
+func (h *Handlers) filterProfileManager() (*gorev.FilterProfileManager, error) {
+	db, err := h.isYonetici.VeriYonetici().GetReadDB()
 	if err != nil {
-		return err, nil
+		return nil, err
 	}
+	if db == nil {
+		return nil, fmt.Errorf(i18n.T("error.memoryBackendSQLUnavailable", map[string]interface{}{"Operation": "filter profiles"}))
+	}
+	return gorev.NewFilterProfileManager(h.isYonetici.VeriYonetici(), db), nil
+}
 
-	// TODO: Extract and use description, searchQuery, isDefault, filters when implementing FilterProfileManager
-	// For now, just return success message
-	return mcp.NewToolResultText(fmt.Sprintf("Filter profile '%s' saved successfully", name)), nil
+// filterProfileIDParam reads profile_id given as a number or a numeric string
+func filterProfileIDParam(params map[string]interface{}) int {
+	switch val := params["profile_id"].(type) {
+	case int:
+		return val
+	case float64:
+		return int(val)
+	case string:
+		id, _ := strconv.Atoi(strings.TrimSpace(val))
+		return id
+	}
+	return 0
 }
 
-// GorevFilterProfileLoad loads a filter profile by ID or name
-func (h *Handlers) GorevFilterProfileLoad(params map[string]interface{}) (*mcp.CallToolResult, error) {
-	profileID := 0
-	if val, ok := params["profile_id"]; ok {
-		if intVal, ok := val.(int); ok {
-			profileID = intVal
-		} else if floatVal, ok := val.(float64); ok {
-			profileID = int(floatVal)
+// GorevFilterProfileSave saves a filter profile; profile_id updates an existing one
+func (h *Handlers) GorevFilterProfileSave(params map[string]interface{}) (*mcp.CallToolResult, error) {
+	name, errResult := h.toolHelpers.Validator.ValidateRequiredString(params, "name")
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	profile := &gorev.FilterProfile{
+		Name:        name,
+		Description: h.toolHelpers.Validator.ValidateOptionalString(params, "description"),
+		SearchQuery: h.toolHelpers.Validator.ValidateOptionalString(params, "search_query"),
+	}
+	if id := filterProfileIDParam(params); id > 0 {
+		profile.ID = strconv.Itoa(id)
+	}
+	if filters, ok := params["filters"].(map[string]interface{}); ok {
+		data, err := json.Marshal(filters)
+		if err == nil {
+			err = json.Unmarshal(data, &profile.Filters)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(i18n.T("error.filterProfileParseFailed", map[string]interface{}{"Error": err})), nil
 		}
 	}
 
+	fpm, err := h.filterProfileManager()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := fpm.SaveFilterProfile(profile); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Filter profile '%s' saved successfully (ID: %s)", profile.Name, profile.ID)), nil
+}
+
+// GorevFilterProfileLoad loads a filter profile by ID or name and runs its query
+func (h *Handlers) GorevFilterProfileLoad(params map[string]interface{}) (*mcp.CallToolResult, error) {
+	profileID := filterProfileIDParam(params)
 	profileName := h.toolHelpers.Validator.ValidateOptionalString(params, "profile_name")
+	if profileName == "" {
+		profileName = h.toolHelpers.Validator.ValidateOptionalString(params, "name")
+	}
 
 	if profileID == 0 && profileName == "" {
 		return mcp.NewToolResultError("Profile ID or name is required"), nil
 	}
 
-	// TODO: Implement actual profile loading
-	// For now, return mock data
-	profile := &gorev.FilterProfile{
-		ID:          fmt.Sprintf("%d", profileID),
-		Name:        profileName,
-		Description: "Mock filter profile",
-		Filters:     gorev.SearchFilters{Status: []string{"devam_ediyor"}},
-		SearchQuery: "",
-		IsDefault:   false,
+	fpm, err := h.filterProfileManager()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var profile *gorev.FilterProfile
+	if profileID > 0 {
+		profile, err = fpm.GetFilterProfile(profileID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	} else {
+		profiles, err := fpm.ListFilterProfiles(false)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		for _, candidate := range profiles {
+			if strings.EqualFold(candidate.Name, profileName) {
+				profile = candidate
+				break
+			}
+		}
+		if profile == nil {
+			return mcp.NewToolResultError(i18n.T("error.filter_profile_not_found", map[string]interface{}{"id": profileName})), nil
+		}
+	}
+
+	query, err := profile.Query()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if id, err := strconv.Atoi(profile.ID); err == nil {
+		if err := fpm.MarkProfileUsed(id); err != nil {
+			slog.Warn("Failed to mark filter profile used", "profile", profile.ID, "error", err)
+		}
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Loaded filter profile: %s", profile.Name)), nil
+	db, err := h.isYonetici.VeriYonetici().GetReadDB()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Database access failed: %v", err)), nil
+	}
+	response, err := gorev.NewSearchEngine(h.isYonetici.VeriYonetici(), db).Search(gorev.SearchOptions{
+		Query:   query.Input,
+		Filters: make(map[string]interface{}),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Search failed: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Loaded filter profile: %s\n", profile.Name) + formatSearchResults(query.Input, response)), nil
 }
 
 // GorevFilterProfileList lists all filter profiles
@@ -3279,36 +4013,13 @@ func (h *Handlers) GorevFilterProfileList(params map[string]interface{}) (*mcp.C
 		defaultsOnly = val
 	}
 
-	// TODO: Implement actual profile listing
-	// For now, return mock data
-	profiles := []*gorev.FilterProfile{
-		{
-			ID:          "1",
-			Name:        "Yüksek Öncelik",
-			Description: "Yüksek öncelikli görevler",
-			Filters:     gorev.SearchFilters{Priority: []string{"yuksek"}},
-			IsDefault:   true,
-			UseCount:    5,
-		},
-		{
-			ID:          "2",
-			Name:        "Devam Ediyor",
-			Description: "Şu anda üzerinde çalışılan görevler",
-			Filters:     gorev.SearchFilters{Status: []string{"devam_ediyor"}},
-			IsDefault:   true,
-			UseCount:    10,
-		},
-	}
-
-	// Filter for defaults only if requested
-	if defaultsOnly {
-		var defaultProfiles []*gorev.FilterProfile
-		for _, profile := range profiles {
-			if profile.IsDefault {
-				defaultProfiles = append(defaultProfiles, profile)
-			}
-		}
-		profiles = defaultProfiles
+	fpm, err := h.filterProfileManager()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	profiles, err := fpm.ListFilterProfiles(defaultsOnly)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	// Build detailed response with full profile information
@@ -3344,11 +4055,14 @@ func (h *Handlers) GorevFilterProfileList(params map[string]interface{}) (*mcp.C
 			if len(profile.Filters.ProjectIDs) > 0 {
 				response.WriteString(fmt.Sprintf("- **Proje ID'leri:** %v\n", profile.Filters.ProjectIDs))
 			}
+			if profile.SearchQuery != "" {
+				response.WriteString(fmt.Sprintf("- **Sorgu:** `%s`\n", profile.SearchQuery))
+			}
 
 			response.WriteString("\n")
 		}
 
-		response.WriteString("💡 **Kullanım:** `gorev_filter_profile_load` komutuyla profil ID veya ismiyle yükleyebilirsiniz.\n")
+		response.WriteString("💡 **Kullanım:** `gorev_filter_profile` aracının `load` eylemiyle profil ID veya ismiyle yükleyebilirsiniz.\n")
 	}
 
 	return mcp.NewToolResultText(response.String()), nil
@@ -3356,21 +4070,19 @@ func (h *Handlers) GorevFilterProfileList(params map[string]interface{}) (*mcp.C
 
 // GorevFilterProfileDelete deletes a filter profile
 func (h *Handlers) GorevFilterProfileDelete(params map[string]interface{}) (*mcp.CallToolResult, error) {
-	profileID := 0
-	if val, ok := params["profile_id"]; ok {
-		if intVal, ok := val.(int); ok {
-			profileID = intVal
-		} else if floatVal, ok := val.(float64); ok {
-			profileID = int(floatVal)
-		}
-	}
-
+	profileID := filterProfileIDParam(params)
 	if profileID == 0 {
 		return mcp.NewToolResultError("Profile ID is required"), nil
 	}
 
-	// TODO: Implement actual profile deletion
-	// For now, return success message
+	fpm, err := h.filterProfileManager()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := fpm.DeleteFilterProfile(profileID); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	return mcp.NewToolResultText(fmt.Sprintf("Filter profile %d deleted successfully", profileID)), nil
 }
 
@@ -3385,28 +4097,13 @@ func (h *Handlers) GorevSearchHistory(params map[string]interface{}) (*mcp.CallT
 		}
 	}
 
-	// TODO: Implement actual search history retrieval
-	// For now, return mock data
-	history := []*gorev.SearchHistoryEntry{
-		{
-			ID:              1,
-			Query:           "database görevleri",
-			Filters:         `{"durum": "devam_ediyor"}`,
-			ResultCount:     5,
-			ExecutionTimeMs: 150,
-		},
-		{
-			ID:              2,
-			Query:           "yüksek öncelikli",
-			Filters:         `{"oncelik": "yuksek"}`,
-			ResultCount:     8,
-			ExecutionTimeMs: 89,
-		},
+	fpm, err := h.filterProfileManager()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
-
-	// Apply limit
-	if len(history) > limit {
-		history = history[:limit]
+	history, err := fpm.GetSearchHistory(limit)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	responseText := fmt.Sprintf("Search History (last %d entries):\n", len(history))
@@ -3698,29 +4395,3 @@ func (h *Handlers) GorevSearch(params map[string]interface{}) (*mcp.CallToolResu
 // ============================================================================
 // HELPER FUNCTIONS
 // ============================================================================
-
-// parseQueryFilters parses query strings like "durum:devam_ediyor oncelik:yuksek"
-// into a filters map for advanced search
-func parseQueryFilters(query string) map[string]interface{} {
-	filters := make(map[string]interface{})
-
-	// Split by spaces, but preserve quoted strings
-	parts := strings.Fields(query)
-
-	for _, part := range parts {
-		// Look for "key:value" pattern
-		if strings.Contains(part, ":") {
-			kv := strings.SplitN(part, ":", 2)
-			if len(kv) == 2 {
-				key := strings.TrimSpace(kv[0])
-				value := strings.TrimSpace(kv[1])
-
-				if key != "" && value != "" {
-					filters[key] = value
-				}
-			}
-		}
-	}
-
-	return filters
-}
@@ -85,5 +85,13 @@ func ListTools() []Tool {
 		{Name: "gorev_file_watch_stats", Description: "Dosya izleme istatistikleri"},
 		{Name: "gorev_export", Description: "Görevleri, projeleri ve ilişkili verileri JSON veya CSV formatında dosyaya dışa aktarır"},
 		{Name: "gorev_import", Description: "Daha önce dışa aktarılan verileri sisteme geri yükler"},
+		{Name: "gorev_doctor", Description: "Veritabanındaki kopuk referansları bulur ve isteğe bağlı olarak onarır"},
+		{Name: "gorev_arsiv", Description: "Eski tamamlanmış görevleri arşivler, arşivi listeler ve geri yükler"},
+		{Name: "gorev_complete", Description: "Görev, etiket, proje ve şablonlar için yazarken tamamlama önerileri"},
+		{Name: "gorev_quick_add", Description: "Etiket, öncelik, proje, son tarih, üst görev ve tahmini süreyi tek satırdan okuyarak görev oluştur"},
+		{Name: "gorev_similar", Description: "Bir göreve veya metne benzeyen görevleri bul (olası kopyalar ve ilgili işler)"},
+		{Name: "gorev_merge", Description: "Kopya bir görevi diğerine birleştir; etiketler, alt görevler, bağımlılıklar ve dosya yolları taşınır, kaynak arşivlenir"},
+		{Name: "gorev_clone", Description: "Bir görevi alt görevleri, etiketleri ve iç bağımlılıklarıyla ya da tüm bir projeyi klonla"},
+		{Name: "gorev_project_template", Description: "Proje şablonlarını listele, oluştur, sil ve alt görevler, bağımlılıklar ve göreli son tarihlerle tüm görev ağacını tek işlemde uygula"},
 	}
 }
@@ -113,6 +113,14 @@ func TestListTools(t *testing.T) {
 		"gorev_file_watch_stats",
 		"gorev_export",
 		"gorev_import",
+		"gorev_doctor",
+		"gorev_arsiv",
+		"gorev_complete",
+		"gorev_quick_add",
+		"gorev_similar",
+		"gorev_merge",
+		"gorev_clone",
+		"gorev_project_template",
 	}
 
 	// Create a map for easier lookup
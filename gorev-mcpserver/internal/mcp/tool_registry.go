@@ -6,6 +6,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/msenol/gorev/internal/constants"
+	"github.com/msenol/gorev/internal/gorev"
 	"github.com/msenol/gorev/internal/i18n"
 )
 
@@ -421,7 +422,7 @@ func (tr *ToolRegistry) registerAdvancedTools(s *server.MCPServer) {
 				"format": map[string]interface{}{
 					"type":        "string",
 					"description": i18n.T("tools.params.export.format", nil),
-					"enum":        []string{"json", "csv"},
+					"enum":        []string{"json", "ndjson", "csv", "ics", "taskwarrior", "todotxt"},
 					"default":     "json",
 				},
 				"include_completed": map[string]interface{}{
@@ -444,6 +445,11 @@ func (tr *ToolRegistry) registerAdvancedTools(s *server.MCPServer) {
 					"description": i18n.T("tools.params.export.include_ai_context", nil),
 					"default":     false,
 				},
+				"include_archived": map[string]interface{}{
+					"type":        "boolean",
+					"description": i18n.T("tools.params.export.include_archived", nil),
+					"default":     true,
+				},
 				"project_filter": map[string]interface{}{
 					"type":        "array",
 					"description": i18n.T("tools.params.export.project_filter", nil),
@@ -515,11 +521,269 @@ func (tr *ToolRegistry) registerAdvancedTools(s *server.MCPServer) {
 						"type": "string",
 					},
 				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.import.format", nil),
+					"enum":        gorev.SupportedImportFormats(),
+				},
+				"column_mapping": map[string]interface{}{
+					"type":        "object",
+					"description": i18n.T("tools.params.import.column_mapping", nil),
+					"additionalProperties": map[string]interface{}{
+						"type": "string",
+					},
+				},
+				"chunk_size": map[string]interface{}{
+					"type":        "number",
+					"description": i18n.T("tools.params.import.chunk_size", nil),
+					"minimum":     1,
+				},
 			},
 			Required: []string{"file_path"},
 		},
 	}, tr.handlers.GorevImport)
 
+	// Gorev Doctor - Database integrity check and repair
+	s.AddTool(mcp.Tool{
+		Name:        "gorev_doctor",
+		Description: i18n.T("tools.descriptions.gorev_doctor", nil),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"fix": map[string]interface{}{
+					"type":        "boolean",
+					"description": i18n.T("tools.params.doctor.fix", nil),
+					"default":     false,
+				},
+				"vacuum": map[string]interface{}{
+					"type":        "boolean",
+					"description": i18n.T("tools.params.doctor.vacuum", nil),
+					"default":     false,
+				},
+			},
+		},
+	}, tr.handlers.GorevDoctor)
+
+	// Gorev Arsiv - Archive of old completed tasks
+	s.AddTool(mcp.Tool{
+		Name:        "gorev_arsiv",
+		Description: i18n.T("tools.descriptions.gorev_arsiv", nil),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"action": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.archive.action", nil),
+					"enum":        constants.ValidArchiveActions,
+				},
+				"task_id": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.archive.task_id", nil),
+				},
+				"retention_days": map[string]interface{}{
+					"type":        "number",
+					"description": i18n.T("tools.params.archive.retention_days", nil),
+					"minimum":     0,
+				},
+				"limit": map[string]interface{}{
+					"type":        "number",
+					"description": i18n.T("tools.params.archive.limit", nil),
+					"minimum":     1,
+				},
+			},
+			Required: []string{"action"},
+		},
+	}, tr.handlers.GorevArsiv)
+
+	// Gorev Complete - Type-ahead completion
+	s.AddTool(mcp.Tool{
+		Name:        "gorev_complete",
+		Description: i18n.T("tools.descriptions.gorev_complete", nil),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.complete.query", nil),
+				},
+				"kind": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.complete.kind", nil),
+					"enum":        constants.ValidCompletionKinds,
+				},
+				"limit": map[string]interface{}{
+					"type":        "number",
+					"description": i18n.T("tools.params.complete.limit", nil),
+					"minimum":     1,
+					"maximum":     gorev.MaxCompletionLimit,
+				},
+			},
+		},
+	}, tr.handlers.GorevComplete)
+
+	// Gorev Quick Add - One-line task creation
+	s.AddTool(mcp.Tool{
+		Name:        "gorev_quick_add",
+		Description: i18n.T("tools.descriptions.gorev_quick_add", nil),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"text": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.quick_add.text", nil),
+				},
+				"dry_run": map[string]interface{}{
+					"type":        "boolean",
+					"description": i18n.T("tools.params.quick_add.dry_run", nil),
+				},
+			},
+			Required: []string{"text"},
+		},
+	}, tr.handlers.GorevQuickAdd)
+
+	// Gorev Similar - TF-IDF similar tasks
+	s.AddTool(mcp.Tool{
+		Name:        "gorev_similar",
+		Description: i18n.T("tools.descriptions.gorev_similar", nil),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"task_id": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.similar.task_id", nil),
+				},
+				"text": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.similar.text", nil),
+				},
+				"limit": map[string]interface{}{
+					"type":        "number",
+					"description": i18n.T("tools.params.similar.limit", nil),
+					"minimum":     1,
+					"maximum":     gorev.MaxSimilarLimit,
+				},
+				"min_score": map[string]interface{}{
+					"type":        "number",
+					"description": i18n.T("tools.params.similar.min_score", nil),
+					"minimum":     0,
+					"maximum":     1,
+				},
+				"active_only": map[string]interface{}{
+					"type":        "boolean",
+					"description": i18n.T("tools.params.similar.active_only", nil),
+				},
+			},
+		},
+	}, tr.handlers.GorevSimilar)
+
+	// Gorev Merge - merge a duplicate task into another
+	s.AddTool(mcp.Tool{
+		Name:        "gorev_merge",
+		Description: i18n.T("tools.descriptions.gorev_merge", nil),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"source_id": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.merge.source_id", nil),
+				},
+				"target_id": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.merge.target_id", nil),
+				},
+				"dry_run": map[string]interface{}{
+					"type":        "boolean",
+					"description": i18n.T("tools.params.merge.dry_run", nil),
+				},
+			},
+			Required: []string{"source_id", "target_id"},
+		},
+	}, tr.handlers.GorevMerge)
+
+	// Gorev Clone - deep clone of tasks and projects
+	s.AddTool(mcp.Tool{
+		Name:        "gorev_clone",
+		Description: i18n.T("tools.descriptions.gorev_clone", nil),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"task_id": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.clone.task_id", nil),
+				},
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.clone.project_id", nil),
+				},
+				"target_project_id": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.clone.target_project_id", nil),
+				},
+				"project_name": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.clone.project_name", nil),
+				},
+				"reset_status": map[string]interface{}{
+					"type":        "boolean",
+					"description": i18n.T("tools.params.clone.reset_status", nil),
+				},
+				"shift_days": map[string]interface{}{
+					"type":        "number",
+					"description": i18n.T("tools.params.clone.shift_days", nil),
+				},
+				"shift_months": map[string]interface{}{
+					"type":        "number",
+					"description": i18n.T("tools.params.clone.shift_months", nil),
+				},
+				"include_files": map[string]interface{}{
+					"type":        "boolean",
+					"description": i18n.T("tools.params.clone.include_files", nil),
+				},
+			},
+		},
+	}, tr.handlers.GorevClone)
+
+	// Gorev Project Template - blueprints of whole task trees
+	s.AddTool(mcp.Tool{
+		Name:        "gorev_project_template",
+		Description: i18n.T("tools.descriptions.gorev_project_template", nil),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"action": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.projectTemplate.action", nil),
+					"enum":        constants.ValidProjectTemplateActions,
+				},
+				"template_id": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.projectTemplate.template_id", nil),
+				},
+				"template": map[string]interface{}{
+					"type":        "object",
+					"description": i18n.T("tools.params.projectTemplate.template", nil),
+				},
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.projectTemplate.project_id", nil),
+				},
+				"project_name": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.projectTemplate.project_name", nil),
+				},
+				"start_date": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.projectTemplate.start_date", nil),
+				},
+				"values": map[string]interface{}{
+					"type":        "object",
+					"description": i18n.T("tools.params.projectTemplate.values", nil),
+				},
+			},
+			Required: []string{"action"},
+		},
+	}, tr.handlers.GorevProjectTemplate)
+
 	// IDE Management tools replaced by unified "gorev_ide" tool with actions: detect|install|uninstall|status|update
 }
 
@@ -650,6 +914,10 @@ func (tr *ToolRegistry) registerUnifiedTools(s *server.MCPServer) {
 					"type":        "string",
 					"description": i18n.TParam("tr", "description"),
 				},
+				"search_query": map[string]interface{}{
+					"type":        "string",
+					"description": i18n.T("tools.params.filter_profile.search_query", nil),
+				},
 			},
 			Required: []string{"action"},
 		},
@@ -725,12 +993,17 @@ func (tr *ToolRegistry) registerUnifiedTools(s *server.MCPServer) {
 				},
 				"query": map[string]interface{}{
 					"type":        "string",
-					"description": i18n.TParam("tr", "query"),
+					"description": i18n.T("tools.params.search.query", nil),
 				},
 				"filters": map[string]interface{}{
 					"type":        "object",
 					"description": i18n.TParam("tr", "filters"),
 				},
+				"include_archived": map[string]interface{}{
+					"type":        "boolean",
+					"description": i18n.T("tools.params.search.include_archived", nil),
+					"default":     false,
+				},
 			},
 			Required: []string{"mode"},
 		},
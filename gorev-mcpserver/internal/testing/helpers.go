@@ -105,6 +105,36 @@ func SetupTestEnvironmentBasic(t *testing.T) (*gorev.IsYonetici, func()) {
 	return isYonetici, cleanup
 }
 
+// SetupMemoryTestEnvironment creates a test environment backed by gorev.MemoryVeriYonetici
+// instead of SQLite. It needs no migrations and is much faster to set up; the default
+// templates are always created. Only InitializeI18n, SeedTestData and SeederConfig of
+// config are used.
+func SetupMemoryTestEnvironment(t *testing.T, config *TestDatabaseConfig) (*gorev.IsYonetici, func()) {
+	if config == nil {
+		config = DefaultTestDatabaseConfig()
+	}
+	if config.InitializeI18n && !i18n.IsInitialized() {
+		if err := i18n.Initialize(constants.DefaultTestLanguage); err != nil {
+			t.Logf("Warning: i18n initialization failed: %v", err)
+		}
+	}
+
+	veriYonetici := gorev.NewMemoryVeriYonetici()
+	isYonetici := gorev.YeniIsYonetici(veriYonetici)
+
+	if config.SeedTestData {
+		seederConfig := config.SeederConfig
+		if seederConfig == nil {
+			seederConfig = DefaultSeederConfig()
+		}
+		seeder := NewTestDataSeeder(isYonetici, seederConfig)
+		_, err := seeder.SeedAll()
+		require.NoError(t, err, "failed to seed test data")
+	}
+
+	return isYonetici, func() { _ = veriYonetici.Kapat() }
+}
+
 // SetupTestEnvironmentWithConfig creates a test environment with custom database configuration
 func SetupTestEnvironmentWithConfig(t *testing.T, config *TestDatabaseConfig) (*gorev.IsYonetici, func()) {
 	veriYonetici, cleanup := SetupTestDatabase(t, config)
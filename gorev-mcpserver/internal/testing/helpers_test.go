@@ -189,3 +189,38 @@ func TestSetupTestDatabase_DefaultPath(t *testing.T) {
 
 	defer cleanup()
 }
+
+func TestSetupMemoryTestEnvironment(t *testing.T) {
+	config := DefaultTestDatabaseConfig()
+	config.SeedTestData = true
+
+	memIsYonetici, memCleanup := SetupMemoryTestEnvironment(t, config)
+	defer memCleanup()
+	sqlIsYonetici, sqlCleanup := SetupTestEnvironmentWithConfig(t, config)
+	defer sqlCleanup()
+
+	ctx := context.Background()
+	memTasks, err := memIsYonetici.VeriYonetici().GorevleriGetir(ctx, "", "", "")
+	if err != nil {
+		t.Fatalf("GorevleriGetir() on memory backend failed: %v", err)
+	}
+	sqlTasks, err := sqlIsYonetici.VeriYonetici().GorevleriGetir(ctx, "", "", "")
+	if err != nil {
+		t.Fatalf("GorevleriGetir() on SQLite backend failed: %v", err)
+	}
+	if len(memTasks) == 0 || len(memTasks) != len(sqlTasks) {
+		t.Errorf("Expected the seeded memory backend to hold %d tasks, got %d", len(sqlTasks), len(memTasks))
+	}
+
+	memTemplates, err := memIsYonetici.VeriYonetici().TemplateListele(ctx, "")
+	if err != nil {
+		t.Fatalf("TemplateListele() on memory backend failed: %v", err)
+	}
+	sqlTemplates, err := sqlIsYonetici.VeriYonetici().TemplateListele(ctx, "")
+	if err != nil {
+		t.Fatalf("TemplateListele() on SQLite backend failed: %v", err)
+	}
+	if len(memTemplates) != len(sqlTemplates) {
+		t.Errorf("Expected %d default templates, got %d", len(sqlTemplates), len(memTemplates))
+	}
+}
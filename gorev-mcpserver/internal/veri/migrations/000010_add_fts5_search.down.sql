@@ -6,6 +6,10 @@ DROP TRIGGER IF EXISTS gorevler_fts_tag_update;
 DROP TRIGGER IF EXISTS gorevler_fts_delete;
 DROP TRIGGER IF EXISTS gorevler_fts_update;
 DROP TRIGGER IF EXISTS gorevler_fts_insert;
+-- Sync triggers recreated by the 000011 rollback also write to gorevler_fts
+DROP TRIGGER IF EXISTS gorevler_ai;
+DROP TRIGGER IF EXISTS gorevler_ad;
+DROP TRIGGER IF EXISTS gorevler_au;
 
 -- Drop indexes
 DROP INDEX IF EXISTS idx_search_history_created;
@@ -10,13 +10,14 @@ ALTER TABLE gorevler RENAME COLUMN title TO baslik;
 ALTER TABLE gorevler RENAME COLUMN description TO aciklama;
 ALTER TABLE gorevler RENAME COLUMN status TO durum;
 ALTER TABLE gorevler RENAME COLUMN priority TO oncelik;
+ALTER TABLE gorevler RENAME COLUMN project_id TO proje_id;
 ALTER TABLE gorevler RENAME COLUMN created_at TO olusturma_tarih;
 ALTER TABLE gorevler RENAME COLUMN updated_at TO guncelleme_tarih;
 ALTER TABLE gorevler RENAME COLUMN due_date TO son_tarih;
 
 -- Restore original indexes
 DROP INDEX IF EXISTS idx_gorev_status;
-DROP INDEX IF EXISTS idx_gorev_proje;
+DROP INDEX IF EXISTS idx_gorev_project;
 DROP INDEX IF EXISTS idx_gorev_priority;
 DROP INDEX IF EXISTS idx_gorev_parent;
 CREATE INDEX idx_gorev_durum ON gorevler(durum);
@@ -37,6 +38,10 @@ ALTER TABLE projeler RENAME COLUMN updated_at TO guncelleme_tarih;
 -- ========================================
 ALTER TABLE etiketler RENAME COLUMN name TO isim;
 
+-- gorev_etiketleri junction table
+ALTER TABLE gorev_etiketleri RENAME COLUMN task_id TO gorev_id;
+ALTER TABLE gorev_etiketleri RENAME COLUMN tag_id TO etiket_id;
+
 -- ========================================
 -- 4. baglantilar table (connections/dependencies)
 -- ========================================
@@ -171,5 +176,11 @@ WITH RECURSIVE hierarchy AS (
 )
 SELECT * FROM hierarchy;
 
+-- ========================================
+-- 8. ai_interactions and aktif_proje tables
+-- ========================================
+ALTER TABLE ai_interactions RENAME COLUMN task_id TO gorev_id;
+ALTER TABLE aktif_proje RENAME COLUMN project_id TO proje_id;
+
 -- Rollback completed successfully
 -- All English field names reverted to Turkish
@@ -0,0 +1,77 @@
+-- Rollback: restore the search tables of 000010/000011
+
+DROP TRIGGER IF EXISTS projeler_fts_au;
+DROP TRIGGER IF EXISTS etiketler_fts_au;
+DROP TRIGGER IF EXISTS gorev_etiketleri_fts_ad;
+DROP TRIGGER IF EXISTS gorev_etiketleri_fts_ai;
+DROP TRIGGER IF EXISTS gorevler_fts_au;
+DROP TRIGGER IF EXISTS gorevler_fts_ad;
+DROP TRIGGER IF EXISTS gorevler_fts_ai;
+DROP TABLE IF EXISTS gorevler_fts;
+DROP TABLE IF EXISTS gorevler_fts_docs;
+
+CREATE TABLE IF NOT EXISTS gorevler_search (
+    id TEXT PRIMARY KEY,
+    baslik TEXT,
+    aciklama TEXT,
+    etiketler TEXT,
+    proje_adi TEXT,
+    search_text TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_gorevler_search_baslik ON gorevler_search(baslik);
+CREATE INDEX IF NOT EXISTS idx_gorevler_search_text ON gorevler_search(search_text);
+CREATE INDEX IF NOT EXISTS idx_gorevler_search_combined ON gorevler_search(baslik, aciklama);
+
+CREATE VIRTUAL TABLE gorevler_fts USING fts5(
+    task_id UNINDEXED,
+    title,
+    description,
+    tags
+);
+
+INSERT INTO gorevler_fts(task_id, title, description, tags)
+SELECT
+    g.id,
+    g.title,
+    COALESCE(g.description, ''),
+    COALESCE((
+        SELECT GROUP_CONCAT(e.name, ' ')
+        FROM gorev_etiketleri ge
+        JOIN etiketler e ON ge.tag_id = e.id
+        WHERE ge.task_id = g.id
+    ), '')
+FROM gorevler g;
+
+CREATE TRIGGER gorevler_ai AFTER INSERT ON gorevler BEGIN
+    INSERT INTO gorevler_fts(task_id, title, description, tags)
+    VALUES (
+        new.id,
+        new.title,
+        COALESCE(new.description, ''),
+        COALESCE((
+            SELECT GROUP_CONCAT(e.name, ' ')
+            FROM gorev_etiketleri ge
+            JOIN etiketler e ON ge.tag_id = e.id
+            WHERE ge.task_id = new.id
+        ), '')
+    );
+END;
+
+CREATE TRIGGER gorevler_ad AFTER DELETE ON gorevler BEGIN
+    DELETE FROM gorevler_fts WHERE task_id = old.id;
+END;
+
+CREATE TRIGGER gorevler_au AFTER UPDATE ON gorevler BEGIN
+    UPDATE gorevler_fts
+    SET
+        title = new.title,
+        description = COALESCE(new.description, ''),
+        tags = COALESCE((
+            SELECT GROUP_CONCAT(e.name, ' ')
+            FROM gorev_etiketleri ge
+            JOIN etiketler e ON ge.tag_id = e.id
+            WHERE ge.task_id = new.id
+        ), '')
+    WHERE task_id = old.id;
+END;
@@ -0,0 +1,9 @@
+-- Rollback: hierarchy queries fall back to recursive CTEs over parent_id
+
+DROP TRIGGER IF EXISTS gorev_kapanis_ad;
+DROP TRIGGER IF EXISTS gorev_kapanis_au_id;
+DROP TRIGGER IF EXISTS gorev_kapanis_au;
+DROP TRIGGER IF EXISTS gorev_kapanis_ai;
+DROP TRIGGER IF EXISTS gorev_kapanis_bu;
+DROP INDEX IF EXISTS idx_gorev_kapanis_descendant;
+DROP TABLE IF EXISTS gorev_kapanis;
@@ -0,0 +1,79 @@
+-- Closure table for the parent_id hierarchy: one row per ancestor/descendant pair,
+-- including a depth 0 row for every task. Subtree and ancestor queries become
+-- indexed lookups instead of recursive CTEs over gorevler.
+-- The triggers below keep it in sync in the same transaction as the write.
+
+CREATE TABLE gorev_kapanis (
+    ancestor_id TEXT NOT NULL,
+    descendant_id TEXT NOT NULL,
+    depth INTEGER NOT NULL,
+    PRIMARY KEY (ancestor_id, descendant_id)
+) WITHOUT ROWID;
+
+CREATE INDEX idx_gorev_kapanis_descendant ON gorev_kapanis(descendant_id, depth);
+
+-- Backfill existing tasks. The depth limit stops the walk on corrupted data
+-- with parent_id cycles.
+INSERT INTO gorev_kapanis(ancestor_id, descendant_id, depth)
+WITH RECURSIVE kapanis(ancestor_id, descendant_id, depth) AS (
+    SELECT id, id, 0 FROM gorevler
+    UNION ALL
+    SELECT k.ancestor_id, g.id, k.depth + 1
+    FROM kapanis k
+    JOIN gorevler g ON g.parent_id = k.descendant_id
+    WHERE k.depth < 1000
+)
+SELECT ancestor_id, descendant_id, MIN(depth) FROM kapanis GROUP BY ancestor_id, descendant_id;
+
+-- A task can not be moved below itself
+CREATE TRIGGER gorev_kapanis_bu BEFORE UPDATE OF parent_id ON gorevler
+WHEN new.parent_id IS NOT NULL AND EXISTS (
+    SELECT 1 FROM gorev_kapanis WHERE ancestor_id = new.id AND descendant_id = new.parent_id
+)
+BEGIN
+    SELECT RAISE(ABORT, 'circular parent_id');
+END;
+
+-- New tasks are linked below the ancestors of their parent. Subtasks that were
+-- inserted before their parent (imports) are linked below the new task as well.
+CREATE TRIGGER gorev_kapanis_ai AFTER INSERT ON gorevler BEGIN
+    INSERT OR IGNORE INTO gorev_kapanis(ancestor_id, descendant_id, depth) VALUES (new.id, new.id, 0);
+    INSERT OR IGNORE INTO gorev_kapanis(ancestor_id, descendant_id, depth)
+    SELECT ancestor_id, new.id, depth + 1 FROM gorev_kapanis WHERE descendant_id = new.parent_id;
+    INSERT OR IGNORE INTO gorev_kapanis(ancestor_id, descendant_id, depth)
+    SELECT a.ancestor_id, d.descendant_id, a.depth + d.depth + 1
+    FROM gorev_kapanis a
+    JOIN gorevler c ON c.parent_id = new.id AND c.id != new.id
+    JOIN gorev_kapanis d ON d.ancestor_id = c.id
+    WHERE a.descendant_id = new.id;
+END;
+
+-- Moving a task moves its whole subtree: links to the old ancestors are dropped,
+-- then every ancestor of the new parent is linked to every task of the subtree
+CREATE TRIGGER gorev_kapanis_au AFTER UPDATE OF parent_id ON gorevler
+WHEN old.parent_id IS NOT new.parent_id
+BEGIN
+    DELETE FROM gorev_kapanis
+    WHERE descendant_id IN (SELECT descendant_id FROM gorev_kapanis WHERE ancestor_id = new.id)
+      AND ancestor_id IN (SELECT ancestor_id FROM gorev_kapanis WHERE descendant_id = new.id AND depth > 0);
+    INSERT OR IGNORE INTO gorev_kapanis(ancestor_id, descendant_id, depth)
+    SELECT a.ancestor_id, d.descendant_id, a.depth + d.depth + 1
+    FROM gorev_kapanis a
+    JOIN gorev_kapanis d ON d.ancestor_id = new.id
+    WHERE a.descendant_id = new.parent_id;
+END;
+
+CREATE TRIGGER gorev_kapanis_au_id AFTER UPDATE OF id ON gorevler
+WHEN old.id != new.id
+BEGIN
+    UPDATE gorev_kapanis SET ancestor_id = new.id WHERE ancestor_id = old.id;
+    UPDATE gorev_kapanis SET descendant_id = new.id WHERE descendant_id = old.id;
+END;
+
+-- A deleted task is unlinked from its ancestors together with its subtree, the same
+-- way parent_id lookups no longer reach subtasks whose parent is gone
+CREATE TRIGGER gorev_kapanis_ad AFTER DELETE ON gorevler BEGIN
+    DELETE FROM gorev_kapanis
+    WHERE descendant_id IN (SELECT descendant_id FROM gorev_kapanis WHERE ancestor_id = old.id)
+      AND ancestor_id IN (SELECT ancestor_id FROM gorev_kapanis WHERE descendant_id = old.id);
+END;
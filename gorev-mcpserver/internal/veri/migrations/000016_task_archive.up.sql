@@ -0,0 +1,71 @@
+-- Archive for completed tasks. Tasks completed longer ago than the workspace's
+-- retention are moved here together with their tags, links, AI interactions and
+-- file paths, so that gorevler and the gorevler_fts index only hold live work.
+-- The tables mirror their live counterparts without foreign keys: archived rows
+-- may point to tasks and projects that were deleted after archiving.
+
+CREATE TABLE gorevler_arsiv (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    description TEXT,
+    status TEXT NOT NULL,
+    priority TEXT NOT NULL,
+    project_id TEXT,
+    parent_id TEXT,
+    workspace_id TEXT NOT NULL DEFAULT 'default',
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    due_date DATETIME,
+    last_ai_interaction DATETIME,
+    estimated_hours INTEGER,
+    actual_hours INTEGER,
+    archived_at DATETIME NOT NULL
+);
+
+CREATE INDEX idx_gorevler_arsiv_parent ON gorevler_arsiv(parent_id);
+CREATE INDEX idx_gorevler_arsiv_workspace ON gorevler_arsiv(workspace_id, archived_at);
+
+CREATE TABLE gorev_etiketleri_arsiv (
+    task_id TEXT NOT NULL,
+    tag_id TEXT NOT NULL,
+    PRIMARY KEY (task_id, tag_id)
+);
+
+CREATE TABLE baglantilar_arsiv (
+    id TEXT PRIMARY KEY,
+    source_id TEXT NOT NULL,
+    target_id TEXT NOT NULL,
+    connection_type TEXT NOT NULL,
+    workspace_id TEXT NOT NULL DEFAULT 'default'
+);
+
+CREATE INDEX idx_baglantilar_arsiv_source ON baglantilar_arsiv(source_id);
+CREATE INDEX idx_baglantilar_arsiv_target ON baglantilar_arsiv(target_id);
+
+CREATE TABLE ai_interactions_arsiv (
+    id INTEGER PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    action_type TEXT NOT NULL,
+    context TEXT,
+    timestamp DATETIME,
+    workspace_id TEXT NOT NULL DEFAULT 'default'
+);
+
+CREATE INDEX idx_ai_interactions_arsiv_task ON ai_interactions_arsiv(task_id);
+
+CREATE TABLE task_file_paths_arsiv (
+    id INTEGER PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    file_path TEXT NOT NULL,
+    created_at TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE INDEX idx_task_file_paths_arsiv_task ON task_file_paths_arsiv(task_id);
+
+-- Archival policy per workspace; retention_days = 0 disables archiving
+CREATE TABLE arsiv_ayarlari (
+    workspace_id TEXT PRIMARY KEY,
+    retention_days INTEGER NOT NULL DEFAULT 0 CHECK (retention_days >= 0),
+    updated_at DATETIME NOT NULL
+);
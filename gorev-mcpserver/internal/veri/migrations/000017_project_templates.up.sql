@@ -0,0 +1,15 @@
+-- Project templates: named blueprints of whole task trees. The tasks, their
+-- subtasks, dependencies and due offsets are stored as one JSON document, since
+-- a blueprint is always read and instantiated as a whole.
+
+CREATE TABLE proje_templateleri (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    alias TEXT UNIQUE,
+    definition TEXT NOT NULL DEFAULT '',
+    fields TEXT NOT NULL DEFAULT '[]',       -- JSON array of TemplateAlan
+    default_tags TEXT NOT NULL DEFAULT '[]', -- JSON array of tag names
+    tasks TEXT NOT NULL,                     -- JSON array of ProjeTemplateGorevi
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
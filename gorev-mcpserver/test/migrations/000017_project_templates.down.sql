@@ -0,0 +1,3 @@
+-- Rollback: stored project templates are lost
+
+DROP TABLE IF EXISTS proje_templateleri;
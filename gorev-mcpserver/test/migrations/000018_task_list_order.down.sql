@@ -0,0 +1,4 @@
+-- Rollback: task list pages sort the table again
+
+DROP INDEX IF EXISTS idx_gorev_workspace_created;
+DROP INDEX IF EXISTS idx_gorev_created;